@@ -7,11 +7,21 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/eventually-rs/saving-goals-go/internal/admin"
 	"github.com/eventually-rs/saving-goals-go/internal/app"
+	"github.com/eventually-rs/saving-goals-go/internal/auth"
+	"github.com/eventually-rs/saving-goals-go/internal/commandbus"
 	"github.com/eventually-rs/saving-goals-go/internal/domain/account"
-	"github.com/eventually-rs/saving-goals-go/internal/domain/interval"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/household"
 	"github.com/eventually-rs/saving-goals-go/internal/domain/monthly"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/savings"
 	"github.com/eventually-rs/saving-goals-go/internal/httpapi"
+	"github.com/eventually-rs/saving-goals-go/internal/idempotency"
+	"github.com/eventually-rs/saving-goals-go/internal/inspector"
+	"github.com/eventually-rs/saving-goals-go/internal/schema"
+	"github.com/eventually-rs/saving-goals-go/internal/shredding"
+	"github.com/eventually-rs/saving-goals-go/internal/snapshot"
+	"github.com/eventually-rs/saving-goals-go/internal/timetravel"
 	"github.com/eventually-rs/saving-goals-go/pkg/must"
 	"github.com/eventually-rs/saving-goals-go/pkg/shutdown"
 
@@ -55,30 +65,37 @@ func main() {
 		}
 	}()
 
+	keyStore, err := shredding.OpenPostgresKeyStore(ctx, config.Database.DSN())
+	must.NotFail(err)
+
+	defer func() {
+		if err := keyStore.Close(); err != nil {
+			logger.Error("Closing the key store exited with error", zap.Error(err))
+		}
+	}()
+
+	// Encrypt the events holding personal data, which are erased by forgetting their keys.
+	shreddingEventStore := shredding.WrapEventStore(postgresEventStore, keyStore, app.PersonalDataSubjects())
+	eventStore := eventstore.Store(shreddingEventStore)
+
+	// Upcast the events recorded with older versions of their types.
+	events, err := app.Events()
+	must.NotFail(err)
+
+	eventStore = schema.WrapEventStore(eventStore, events)
+
 	// Use correlated Event Store to embed additional metadata into appended events.
-	eventStore := eventstore.Store(postgresEventStore)
 	eventStore = correlation.WrapEventStore(eventStore, func() string {
 		return uuid.New().String()
 	})
 
-	must.NotFail(eventStore.Register(ctx, "month", map[string]interface{}{
-		"month_started": interval.MonthStarted{},
-	}))
-
-	must.NotFail(eventStore.Register(ctx, account.Type.Name(), map[string]interface{}{
-		"account_was_created":              account.WasCreated{},
-		"saving_goal_was_changed":          account.SavingGoalWasChanged{},
-		"saving_goal_was_disabled":         account.SavingGoalWasDisabled{},
-		"threshold_was_set":                account.ThresholdWasSet{},
-		"account_transaction_was_recorded": account.TransactionWasRecorded{},
-	}))
-
-	must.NotFail(eventStore.Register(ctx, monthly.Type.Name(), map[string]interface{}{
-		"monthly_spending_tracking_started":         monthly.SpendingTrackingStarted{},
-		"monthly_spending_transaction_was_recorded": monthly.TransactionWasRecorded{},
-		"monthly_spending_limit_was_updated":        monthly.SpendingLimitWasUpdated{},
-		"monthly_spending_threshold_was_reached":    monthly.ThresholdWasReached{},
-	}))
+	// Record the time the events are appended, to rebuild the past states of the aggregates.
+	eventStore = timetravel.WrapEventStore(eventStore, time.Now)
+
+	must.NotFail(events.Register(ctx, eventStore))
+
+	// Encrypt the events appended in clear before, so that they are erased as well.
+	must.NotFail(shredding.MigratePostgresEventStore(ctx, config.Database.DSN(), shreddingEventStore))
 
 	monthEventStore, err := eventStore.Type(ctx, "month")
 	must.NotFail(err)
@@ -89,21 +106,100 @@ func main() {
 	monthlySpendingEventStore, err := eventStore.Type(ctx, monthly.Type.Name())
 	must.NotFail(err)
 
+	savingsTransferEventStore, err := eventStore.Type(ctx, savings.Type.Name())
+	must.NotFail(err)
+
+	householdEventStore, err := eventStore.Type(ctx, household.Type.Name())
+	must.NotFail(err)
+
 	checkpointer := postgresEventStore
 	// </EventStore> ---------------------------------------------------------------------------------------------------
 
+	// <Subscriptions> -------------------------------------------------------------------------------------------------
+	positions, err := admin.OpenPostgresPositions(ctx, config.Database.DSN())
+	must.NotFail(err)
+
+	defer func() {
+		if err := positions.Close(); err != nil {
+			logger.Error("Closing the event store positions exited with error", zap.Error(err))
+		}
+	}()
+
+	// Policies and read models are started through the supervisor, to operate them from the internal endpoints.
+	supervisor := admin.NewSupervisor(checkpointer, positions, logger)
+	// </Subscriptions> ------------------------------------------------------------------------------------------------
+
 	// <Repositories> --------------------------------------------------------------------------------------------------
-	accountRepository := aggregate.NewRepository(account.Type, accountEventStore)
-	monthlySpendingRepository := aggregate.NewRepository(monthly.Type, monthlySpendingEventStore)
+	postgresSnapshotStore, err := snapshot.OpenPostgresStore(ctx, config.Database.DSN())
+	must.NotFail(err)
+
+	defer func() {
+		if err := postgresSnapshotStore.Close(); err != nil {
+			logger.Error("Closing the snapshot store exited with error", zap.Error(err))
+		}
+	}()
+
+	// Snapshots hold personal data too, so they are encrypted with the same keys of the events.
+	snapshotStore := shredding.WrapSnapshotStore(postgresSnapshotStore, keyStore, app.PersonalDataSubjects())
+
+	accountRepository := snapshot.NewRepository(account.Type, accountEventStore, snapshotStore, snapshot.Options{
+		SchemaVersion: account.SnapshotVersion,
+		Frequency:     config.Snapshot.Frequency,
+		Factory:       func() snapshot.Root { return new(account.Account) },
+	}, logger)
+
+	monthlySpendingRepository := snapshot.NewRepository(monthly.Type, monthlySpendingEventStore, snapshotStore, snapshot.Options{
+		SchemaVersion: monthly.SnapshotVersion,
+		Frequency:     config.Snapshot.Frequency,
+		Factory:       func() snapshot.Root { return new(monthly.Spending) },
+	}, logger)
+
+	savingsTransferRepository := aggregate.NewRepository(savings.Type, savingsTransferEventStore)
+	householdRepository := aggregate.NewRepository(household.Type, householdEventStore)
 	// </Repositories> -------------------------------------------------------------------------------------------------
 
 	// <Queries> -------------------------------------------------------------------------------------------------------
 	queryBus := query.NewSimpleBus()
 
-	accountsWithSavingGoals, err := buildAccountsWithSavingGoalsReadModel(ctx, accountEventStore, logger)
+	accountsWithSavingGoals, err := buildAccountsWithSavingGoalsReadModel(ctx, supervisor, accountEventStore)
+	must.NotFail(err)
+
+	accountView, err := buildAccountViewReadModel(ctx, supervisor, accountEventStore)
+	must.NotFail(err)
+
+	monthlyProgress, err := buildMonthlyProgressReadModel(ctx, supervisor, eventStore)
+	must.NotFail(err)
+
+	accountHistory, err := buildAccountHistoryReadModel(ctx, supervisor, accountEventStore)
+	must.NotFail(err)
+
+	savingsTransfers, err := buildSavingsTransfersReadModel(ctx, supervisor, eventStore)
+	must.NotFail(err)
+
+	householdView, err := buildHouseholdViewReadModel(ctx, supervisor, householdEventStore)
+	must.NotFail(err)
+
+	householdsWithSavingGoals, err := buildHouseholdsWithSavingGoalsReadModel(ctx, supervisor, householdEventStore)
 	must.NotFail(err)
 
 	queryBus.Register(accountsWithSavingGoals)
+	queryBus.Register(accountView)
+	queryBus.Register(monthlyProgress)
+	queryBus.Register(accountHistory)
+	queryBus.Register(savingsTransfers)
+	queryBus.Register(householdView)
+	queryBus.Register(householdsWithSavingGoals)
+
+	// Past states are rebuilt from the event streams, rather than from the read models.
+	queryBus.Register(timetravel.AccountHandler{EventStore: accountEventStore})
+	queryBus.Register(timetravel.ProgressHandler{EventStore: monthlySpendingEventStore})
+	queryBus.Register(timetravel.EvolutionHandler{
+		Accounts:  accountEventStore,
+		Spendings: monthlySpendingEventStore,
+		Events:    events,
+	})
+
+	queryBus.Register(inspector.StreamHandler{EventStore: eventStore, Events: events})
 	// </Queries> ------------------------------------------------------------------------------------------------------
 
 	// <Commands> ------------------------------------------------------------------------------------------------------
@@ -112,19 +208,89 @@ func main() {
 	commandBus.Register(account.CreateCommandHandler{Repository: accountRepository})
 	commandBus.Register(account.ChangeSavingGoalCommandHandler{Repository: accountRepository})
 	commandBus.Register(account.SetNewThresholdCommandHandler{Repository: accountRepository})
+	commandBus.Register(account.RemoveThresholdCommandHandler{Repository: accountRepository})
+	commandBus.Register(account.ReplaceThresholdsCommandHandler{Repository: accountRepository})
 	commandBus.Register(account.RecordTransactionCommandHandler{Repository: accountRepository})
+	commandBus.Register(account.AddCategorizationRuleCommandHandler{Repository: accountRepository})
+	commandBus.Register(account.RemoveCategorizationRuleCommandHandler{Repository: accountRepository})
+	commandBus.Register(account.RecategorizeTransactionsCommandHandler{Repository: accountRepository})
+	commandBus.Register(account.SetCategoryBudgetCommandHandler{Repository: accountRepository})
+	commandBus.Register(account.RemoveCategoryBudgetCommandHandler{Repository: accountRepository})
+	commandBus.Register(account.AddGoalCommandHandler{Repository: accountRepository})
+	commandBus.Register(account.UpdateGoalCommandHandler{Repository: accountRepository})
+	commandBus.Register(account.RemoveGoalCommandHandler{Repository: accountRepository})
+	commandBus.Register(account.RecordGoalContributionCommandHandler{Repository: accountRepository})
+	commandBus.Register(account.ChangePacingStrategyCommandHandler{Repository: accountRepository})
+	commandBus.Register(account.ConfirmRecurringSeriesCommandHandler{Repository: accountRepository})
+	commandBus.Register(account.DismissRecurringSeriesCommandHandler{Repository: accountRepository})
+	commandBus.Register(account.AddSweepRuleCommandHandler{Repository: accountRepository})
+	commandBus.Register(account.RemoveSweepRuleCommandHandler{Repository: accountRepository})
+	commandBus.Register(account.FreezeAccountCommandHandler{Repository: accountRepository})
+	commandBus.Register(account.CloseAccountCommandHandler{Repository: accountRepository})
+	commandBus.Register(account.ReopenAccountCommandHandler{Repository: accountRepository})
+	commandBus.Register(account.ForgetAccountCommandHandler{Repository: accountRepository, Keys: keyStore})
 
 	commandBus.Register(monthly.StartSpendingTrackingCommandHandler{Repository: monthlySpendingRepository})
 	commandBus.Register(monthly.RecordTransactionCommandHandler{Repository: monthlySpendingRepository})
+	commandBus.Register(monthly.CategorizeTransactionCommandHandler{Repository: monthlySpendingRepository})
+	commandBus.Register(monthly.CheckGoalForecastCommandHandler{Repository: monthlySpendingRepository})
+	commandBus.Register(monthly.CloseSpendingCommandHandler{Repository: monthlySpendingRepository})
+
+	commandBus.Register(savings.RequestTransferCommandHandler{Repository: savingsTransferRepository})
+
+	commandBus.Register(household.CreateCommandHandler{Repository: householdRepository})
+	commandBus.Register(household.InviteMemberCommandHandler{Repository: householdRepository})
+	commandBus.Register(household.AcceptInvitationCommandHandler{Repository: householdRepository})
+	commandBus.Register(household.LeaveCommandHandler{Repository: householdRepository})
+	commandBus.Register(household.ChangeSavingGoalCommandHandler{Repository: householdRepository})
+	commandBus.Register(household.NotifyMembersCommandHandler{Repository: householdRepository})
+
+	commandDispatcher := commandbus.Chain(commandBus, app.CommandMiddlewares(config.Concurrency, householdRepository, logger)...)
+
+	// Policies dispatch their Commands on behalf of the application itself.
+	systemDispatcher := commandbus.Chain(commandDispatcher, commandbus.As(commandbus.System))
 	// </Commands> -----------------------------------------------------------------------------------------------------
 
 	// <ProcessManagers> -----------------------------------------------------------------------------------------------
-	must.NotFail(startCreateSpendingStartOfTheMonthPolicy(ctx, commandBus, queryBus, eventStore, checkpointer, logger))
-	must.NotFail(startRecordTransactionPolicy(ctx, commandBus, queryBus, accountEventStore, checkpointer, logger))
+	must.NotFail(startCreateSpendingStartOfTheMonthPolicy(ctx, supervisor, systemDispatcher, queryBus, eventStore))
+	must.NotFail(startRecordTransactionPolicy(ctx, supervisor, systemDispatcher, accountEventStore, logger))
+	must.NotFail(startCloseSpendingPolicy(ctx, supervisor, systemDispatcher, accountEventStore, logger))
+	must.NotFail(startGoalForecastPolicy(ctx, supervisor, systemDispatcher, queryBus, config.Forecast, accountEventStore, logger))
+	must.NotFail(startRequestTransferPolicy(ctx, supervisor, systemDispatcher, queryBus, eventStore, logger))
+	must.NotFail(startRecordGoalContributionPolicy(ctx, supervisor, systemDispatcher, savingsTransferEventStore, logger))
+	must.NotFail(startCreateHouseholdSpendingStartOfTheMonthPolicy(ctx, supervisor, systemDispatcher, queryBus, monthEventStore, logger))
+	must.NotFail(startRecordHouseholdMemberTransactionPolicy(ctx, supervisor, systemDispatcher, queryBus, accountEventStore, logger))
+	must.NotFail(startNotifyHouseholdMembersPolicy(ctx, supervisor, systemDispatcher, monthlySpendingEventStore))
 	// </ProcessManagers> ----------------------------------------------------------------------------------------------
 
+	// <KafkaProducers> ------------------------------------------------------------------------------------------------
+	must.NotFail(startSavingsTransferRequestedProducer(ctx, config.Kafka, savingsTransferEventStore, checkpointer, logger))
+	// </KafkaProducers> -----------------------------------------------------------------------------------------------
+
 	// <HttpServer> ----------------------------------------------------------------------------------------------------
-	router := httpapi.NewRouter(commandBus, monthEventStore, logger)
+	keys, err := auth.LoadJWKSFile(config.Auth.JWKSFile)
+	must.NotFail(err)
+
+	authenticator := auth.NewAuthenticator(keys, auth.Options{
+		Issuer:   config.Auth.Issuer,
+		Audience: config.Auth.Audience,
+	})
+
+	idempotencyStore, err := idempotency.OpenPostgresStore(ctx, config.Database.DSN(), time.Now)
+	must.NotFail(err)
+
+	defer func() {
+		if err := idempotencyStore.Close(); err != nil {
+			logger.Error("Closing the idempotency keys store exited with error", zap.Error(err))
+		}
+	}()
+
+	go idempotency.PurgePeriodically(ctx, idempotencyStore, config.Idempotency.PurgeInterval, time.Now, logger)
+
+	// Write requests retried with the same Idempotency-Key get the response of the first one.
+	idempotent := idempotency.Middleware(idempotencyStore, config.Idempotency.TTL, config.Idempotency.Lease, time.Now, logger)
+
+	router := httpapi.NewRouter(commandDispatcher, queryBus, monthEventStore, supervisor, authenticator, idempotent, logger)
 
 	httpServer := &http.Server{
 		Addr:    config.Server.Addr(),
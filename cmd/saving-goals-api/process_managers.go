@@ -3,85 +3,222 @@ package main
 import (
 	"context"
 
+	"github.com/eventually-rs/saving-goals-go/internal/admin"
+	"github.com/eventually-rs/saving-goals-go/internal/app"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/household"
 	"github.com/eventually-rs/saving-goals-go/internal/domain/monthly"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/savings"
+	"github.com/eventually-rs/saving-goals-go/internal/shredding"
 
 	"github.com/eventually-rs/eventually-go/command"
 	"github.com/eventually-rs/eventually-go/eventstore"
 	"github.com/eventually-rs/eventually-go/extension/correlation"
 	"github.com/eventually-rs/eventually-go/projection"
-	"github.com/eventually-rs/eventually-go/subscription"
-	"github.com/eventually-rs/eventually-go/subscription/checkpoint"
 	"go.uber.org/zap"
 )
 
 func startCreateSpendingStartOfTheMonthPolicy(
 	ctx context.Context,
+	supervisor *admin.Supervisor,
 	commandBus command.Dispatcher,
 	queryBus monthly.QueryDispatcher,
 	eventStore eventstore.Store,
-	checkpointer checkpoint.Checkpointer,
-	logger *zap.Logger,
 ) error {
-	createSpendingStartOfTheMonthPolicy := monthly.CreateSpendingStartOfTheMonthPolicy{
+	return supervisor.StartPolicy(ctx, admin.Policy{
+		Name:              "create-spending-start-of-the-month",
+		EventStore:        eventStore,
 		CommandDispatcher: commandBus,
-		QueryDispatcher:   queryBus,
-	}
-
-	createSpendingStartOfTheMonthSubscription := subscription.CatchUp{
-		SubscriptionName: "create-spending-start-of-the-month",
-		Checkpointer:     checkpointer,
-		EventStore:       eventStore,
-	}
-
-	go func() {
-		logger.Info("monthly.CreateSpendingStartOfTheMonthPolicy projector started")
-
-		createSpendingStartOfTheMonthPolicy := correlation.WrapProjection(createSpendingStartOfTheMonthPolicy)
-		projector := projection.NewProjector(
-			createSpendingStartOfTheMonthPolicy,
-			createSpendingStartOfTheMonthSubscription,
-		)
-
-		if err := projector.Start(ctx); err != nil {
-			logger.Error("monthly.CreateSpendingStartOfTheMonthPolicy projector exited with error", zap.Error(err))
-		}
-	}()
-
-	return nil
+		New: func(commandBus command.Dispatcher) projection.Applier {
+			createSpendingStartOfTheMonthPolicy := monthly.CreateSpendingStartOfTheMonthPolicy{
+				CommandDispatcher: commandBus,
+				QueryDispatcher:   queryBus,
+			}
+
+			return correlation.WrapProjection(shredding.WrapProjection(createSpendingStartOfTheMonthPolicy))
+		},
+	})
 }
 
 func startRecordTransactionPolicy(
 	ctx context.Context,
+	supervisor *admin.Supervisor,
+	commandBus command.Dispatcher,
+	accountStore eventstore.Typed,
+	logger *zap.Logger,
+) error {
+	return supervisor.StartPolicy(ctx, admin.Policy{
+		Name:              "record-transaction",
+		EventStore:        accountStore,
+		CommandDispatcher: commandBus,
+		New: func(commandBus command.Dispatcher) projection.Applier {
+			recordTransactionPolicy := monthly.RecordTransactionPolicy{
+				CommandDispatcher: commandBus,
+				Logger:            logger,
+			}
+
+			return correlation.WrapProjection(shredding.WrapProjection(recordTransactionPolicy))
+		},
+	})
+}
+
+func startCloseSpendingPolicy(
+	ctx context.Context,
+	supervisor *admin.Supervisor,
+	commandBus command.Dispatcher,
+	accountStore eventstore.Typed,
+	logger *zap.Logger,
+) error {
+	return supervisor.StartPolicy(ctx, admin.Policy{
+		Name:              "close-spending",
+		EventStore:        accountStore,
+		CommandDispatcher: commandBus,
+		New: func(commandBus command.Dispatcher) projection.Applier {
+			closeSpendingPolicy := monthly.CloseSpendingPolicy{
+				CommandDispatcher: commandBus,
+				Logger:            logger,
+			}
+
+			return correlation.WrapProjection(shredding.WrapProjection(closeSpendingPolicy))
+		},
+	})
+}
+
+func startGoalForecastPolicy(
+	ctx context.Context,
+	supervisor *admin.Supervisor,
 	commandBus command.Dispatcher,
 	queryBus monthly.QueryDispatcher,
+	config app.Forecast,
 	accountStore eventstore.Typed,
-	checkpointer checkpoint.Checkpointer,
 	logger *zap.Logger,
 ) error {
-	recordTransactionPolicy := monthly.RecordTransactionPolicy{
+	return supervisor.StartPolicy(ctx, admin.Policy{
+		Name:              "goal-forecast",
+		EventStore:        accountStore,
+		CommandDispatcher: commandBus,
+		New: func(commandBus command.Dispatcher) projection.Applier {
+			goalForecastPolicy := monthly.GoalForecastPolicy{
+				CommandDispatcher: commandBus,
+				QueryDispatcher:   queryBus,
+				Confidence:        config.Confidence,
+				Logger:            logger,
+			}
+
+			return correlation.WrapProjection(shredding.WrapProjection(goalForecastPolicy))
+		},
+	})
+}
+
+func startRequestTransferPolicy(
+	ctx context.Context,
+	supervisor *admin.Supervisor,
+	commandBus command.Dispatcher,
+	queryBus monthly.QueryDispatcher,
+	eventStore eventstore.Store,
+	logger *zap.Logger,
+) error {
+	return supervisor.StartPolicy(ctx, admin.Policy{
+		Name:              "request-savings-transfer",
+		EventStore:        eventStore,
 		CommandDispatcher: commandBus,
-		Logger:            logger,
-	}
-
-	recordTransactionSubscription := subscription.CatchUp{
-		SubscriptionName: "record-transaction",
-		EventStore:       accountStore,
-		Checkpointer:     checkpointer,
-	}
-
-	go func() {
-		logger.Info("monthly.RecordTransactionPolicy projector started")
-
-		recordTransactionPolicy := correlation.WrapProjection(recordTransactionPolicy)
-		projector := projection.NewProjector(
-			recordTransactionPolicy,
-			recordTransactionSubscription,
-		)
-
-		if err := projector.Start(ctx); err != nil {
-			logger.Error("monthly.RecordTransactionPolicy projector exited with error", zap.Error(err))
-		}
-	}()
-
-	return nil
+		New: func(commandBus command.Dispatcher) projection.Applier {
+			requestTransferPolicy := savings.RequestTransferPolicy{
+				CommandDispatcher: commandBus,
+				QueryDispatcher:   queryBus,
+				Logger:            logger,
+			}
+
+			return correlation.WrapProjection(shredding.WrapProjection(requestTransferPolicy))
+		},
+	})
+}
+
+func startRecordGoalContributionPolicy(
+	ctx context.Context,
+	supervisor *admin.Supervisor,
+	commandBus command.Dispatcher,
+	savingsTransferStore eventstore.Typed,
+	logger *zap.Logger,
+) error {
+	return supervisor.StartPolicy(ctx, admin.Policy{
+		Name:              "record-goal-contribution",
+		EventStore:        savingsTransferStore,
+		CommandDispatcher: commandBus,
+		New: func(commandBus command.Dispatcher) projection.Applier {
+			recordGoalContributionPolicy := savings.RecordGoalContributionPolicy{
+				CommandDispatcher: commandBus,
+				Logger:            logger,
+			}
+
+			return correlation.WrapProjection(shredding.WrapProjection(recordGoalContributionPolicy))
+		},
+	})
+}
+
+func startCreateHouseholdSpendingStartOfTheMonthPolicy(
+	ctx context.Context,
+	supervisor *admin.Supervisor,
+	commandBus command.Dispatcher,
+	queryBus monthly.QueryDispatcher,
+	monthStore eventstore.Typed,
+	logger *zap.Logger,
+) error {
+	return supervisor.StartPolicy(ctx, admin.Policy{
+		Name:              "create-household-spending-start-of-the-month",
+		EventStore:        monthStore,
+		CommandDispatcher: commandBus,
+		New: func(commandBus command.Dispatcher) projection.Applier {
+			createHouseholdSpendingStartOfTheMonthPolicy := household.CreateSpendingStartOfTheMonthPolicy{
+				CommandDispatcher: commandBus,
+				QueryDispatcher:   queryBus,
+				Logger:            logger,
+			}
+
+			return correlation.WrapProjection(createHouseholdSpendingStartOfTheMonthPolicy)
+		},
+	})
+}
+
+func startRecordHouseholdMemberTransactionPolicy(
+	ctx context.Context,
+	supervisor *admin.Supervisor,
+	commandBus command.Dispatcher,
+	queryBus monthly.QueryDispatcher,
+	accountStore eventstore.Typed,
+	logger *zap.Logger,
+) error {
+	return supervisor.StartPolicy(ctx, admin.Policy{
+		Name:              "record-household-member-transaction",
+		EventStore:        accountStore,
+		CommandDispatcher: commandBus,
+		New: func(commandBus command.Dispatcher) projection.Applier {
+			recordHouseholdMemberTransactionPolicy := household.RecordMemberTransactionPolicy{
+				CommandDispatcher: commandBus,
+				QueryDispatcher:   queryBus,
+				Logger:            logger,
+			}
+
+			return correlation.WrapProjection(shredding.WrapProjection(recordHouseholdMemberTransactionPolicy))
+		},
+	})
+}
+
+func startNotifyHouseholdMembersPolicy(
+	ctx context.Context,
+	supervisor *admin.Supervisor,
+	commandBus command.Dispatcher,
+	monthlySpendingStore eventstore.Typed,
+) error {
+	return supervisor.StartPolicy(ctx, admin.Policy{
+		Name:              "notify-household-members",
+		EventStore:        monthlySpendingStore,
+		CommandDispatcher: commandBus,
+		New: func(commandBus command.Dispatcher) projection.Applier {
+			notifyHouseholdMembersPolicy := household.NotifyMembersPolicy{
+				CommandDispatcher: commandBus,
+			}
+
+			return correlation.WrapProjection(shredding.WrapProjection(notifyHouseholdMembersPolicy))
+		},
+	})
 }
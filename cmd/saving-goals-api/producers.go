@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+
+	"github.com/eventually-rs/saving-goals-go/internal/app"
+	"github.com/eventually-rs/saving-goals-go/internal/producer"
+
+	"github.com/eventually-rs/eventually-go/eventstore"
+	"github.com/eventually-rs/eventually-go/projection"
+	"github.com/eventually-rs/eventually-go/subscription"
+	"github.com/eventually-rs/eventually-go/subscription/checkpoint"
+	"go.uber.org/zap"
+)
+
+func startSavingsTransferRequestedProducer(
+	ctx context.Context,
+	config app.Kafka,
+	savingsTransferStore eventstore.Typed,
+	checkpointer checkpoint.Checkpointer,
+	logger *zap.Logger,
+) error {
+	savingsTransferRequestedProducer := producer.NewSavingsTransferRequested(config.Addr(), logger)
+
+	savingsTransferRequestedSubscription := subscription.CatchUp{
+		SubscriptionName: "savings-transfer-requested-producer",
+		EventStore:       savingsTransferStore,
+		Checkpointer:     checkpointer,
+	}
+
+	go func() {
+		logger.Info("producer.SavingsTransferRequested projector started")
+
+		defer func() {
+			if err := savingsTransferRequestedProducer.Close(); err != nil {
+				logger.Error("producer.SavingsTransferRequested failed to close", zap.Error(err))
+			}
+		}()
+
+		projector := projection.NewProjector(
+			savingsTransferRequestedProducer,
+			savingsTransferRequestedSubscription,
+		)
+
+		if err := projector.Start(ctx); err != nil {
+			logger.Error("producer.SavingsTransferRequested projector exited with error", zap.Error(err))
+		}
+	}()
+
+	return nil
+}
@@ -3,39 +3,131 @@ package main
 import (
 	"context"
 
+	"github.com/eventually-rs/saving-goals-go/internal/admin"
 	"github.com/eventually-rs/saving-goals-go/internal/domain/account"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/household"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/monthly"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/savings"
+	"github.com/eventually-rs/saving-goals-go/internal/shredding"
 
 	"github.com/eventually-rs/eventually-go/eventstore"
 	"github.com/eventually-rs/eventually-go/extension/correlation"
 	"github.com/eventually-rs/eventually-go/projection"
-	"github.com/eventually-rs/eventually-go/subscription"
-	"github.com/eventually-rs/eventually-go/subscription/checkpoint"
-	"go.uber.org/zap"
+	"github.com/eventually-rs/eventually-go/query"
 )
 
 func buildAccountsWithSavingGoalsReadModel(
 	ctx context.Context,
+	supervisor *admin.Supervisor,
 	accountEventStore eventstore.Typed,
-	logger *zap.Logger,
-) (*account.WithSavingGoalsProjection, error) {
-	accountsWithSavingGoals := account.NewWithSavingGoalsProjection()
+) (query.Handler, error) {
+	return supervisor.StartReadModel(ctx, admin.ReadModel{
+		Name:       "accounts-with-saving-goals",
+		EventStore: accountEventStore,
+		New: func() (query.Handler, projection.Applier) {
+			accountsWithSavingGoals := account.NewWithSavingGoalsProjection()
 
-	accountsWithSavingGoalsSubscription := subscription.CatchUp{
-		SubscriptionName: "accounts-with-saving-goals",
-		EventStore:       accountEventStore,
-		Checkpointer:     checkpoint.NopCheckpointer,
-	}
+			return accountsWithSavingGoals, correlation.WrapProjection(shredding.WrapProjection(accountsWithSavingGoals))
+		},
+	})
+}
+
+func buildAccountViewReadModel(
+	ctx context.Context,
+	supervisor *admin.Supervisor,
+	accountEventStore eventstore.Typed,
+) (query.Handler, error) {
+	return supervisor.StartReadModel(ctx, admin.ReadModel{
+		Name:       "account-view",
+		EventStore: accountEventStore,
+		New: func() (query.Handler, projection.Applier) {
+			accountView := account.NewViewProjection()
+
+			return accountView, correlation.WrapProjection(shredding.WrapProjection(accountView))
+		},
+	})
+}
+
+// The monthly progress is built from all the Events, as it also erases
+// the spendings of the forgotten Accounts.
+func buildMonthlyProgressReadModel(
+	ctx context.Context,
+	supervisor *admin.Supervisor,
+	eventStore eventstore.Store,
+) (query.Handler, error) {
+	return supervisor.StartReadModel(ctx, admin.ReadModel{
+		Name:       "monthly-progress",
+		EventStore: eventStore,
+		New: func() (query.Handler, projection.Applier) {
+			monthlyProgress := monthly.NewProgressProjection()
+
+			return monthlyProgress, correlation.WrapProjection(shredding.WrapProjection(monthlyProgress))
+		},
+	})
+}
+
+func buildAccountHistoryReadModel(
+	ctx context.Context,
+	supervisor *admin.Supervisor,
+	accountEventStore eventstore.Typed,
+) (query.Handler, error) {
+	return supervisor.StartReadModel(ctx, admin.ReadModel{
+		Name:       "account-history",
+		EventStore: accountEventStore,
+		New: func() (query.Handler, projection.Applier) {
+			accountHistory := account.NewHistoryProjection()
+
+			return accountHistory, correlation.WrapProjection(shredding.WrapProjection(accountHistory))
+		},
+	})
+}
 
-	go func() {
-		logger.Info("account.WithSavingGoals projector started")
+// The savings transfers are built from all the Events, as they are also
+// erased when their Account is forgotten.
+func buildSavingsTransfersReadModel(
+	ctx context.Context,
+	supervisor *admin.Supervisor,
+	eventStore eventstore.Store,
+) (query.Handler, error) {
+	return supervisor.StartReadModel(ctx, admin.ReadModel{
+		Name:       "savings-transfers",
+		EventStore: eventStore,
+		New: func() (query.Handler, projection.Applier) {
+			savingsTransfers := savings.NewTransfersProjection()
 
-		accountsWithSavingGoals := correlation.WrapProjection(accountsWithSavingGoals)
-		projector := projection.NewProjector(accountsWithSavingGoals, accountsWithSavingGoalsSubscription)
+			return savingsTransfers, correlation.WrapProjection(shredding.WrapProjection(savingsTransfers))
+		},
+	})
+}
 
-		if err := projector.Start(ctx); err != nil {
-			logger.Error("account.WithSavingGoals projector exited with error", zap.Error(err))
-		}
-	}()
+func buildHouseholdViewReadModel(
+	ctx context.Context,
+	supervisor *admin.Supervisor,
+	householdEventStore eventstore.Typed,
+) (query.Handler, error) {
+	return supervisor.StartReadModel(ctx, admin.ReadModel{
+		Name:       "household-view",
+		EventStore: householdEventStore,
+		New: func() (query.Handler, projection.Applier) {
+			householdView := household.NewViewProjection()
+
+			return householdView, correlation.WrapProjection(householdView)
+		},
+	})
+}
+
+func buildHouseholdsWithSavingGoalsReadModel(
+	ctx context.Context,
+	supervisor *admin.Supervisor,
+	householdEventStore eventstore.Typed,
+) (query.Handler, error) {
+	return supervisor.StartReadModel(ctx, admin.ReadModel{
+		Name:       "households-with-saving-goals",
+		EventStore: householdEventStore,
+		New: func() (query.Handler, projection.Applier) {
+			householdsWithSavingGoals := household.NewWithSavingGoalsProjection()
 
-	return accountsWithSavingGoals, nil
+			return householdsWithSavingGoals, correlation.WrapProjection(householdsWithSavingGoals)
+		},
+	})
 }
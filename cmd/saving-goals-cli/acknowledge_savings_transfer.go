@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/eventually-rs/saving-goals-go/internal/app"
+	"github.com/eventually-rs/saving-goals-go/resources/messages"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/segmentio/kafka-go"
+	"github.com/urfave/cli/v2"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var savingsTransferStatuses = map[string]messages.SavingsTransferStatus{
+	"confirmed": messages.SavingsTransferStatus_SAVINGS_TRANSFER_STATUS_CONFIRMED,
+	"rejected":  messages.SavingsTransferStatus_SAVINGS_TRANSFER_STATUS_REJECTED,
+}
+
+func acknowledgeSavingsTransfer(ctx *cli.Context) error {
+	config, err := app.ParseConfig()
+	if err != nil {
+		return fmt.Errorf("acknowledgeSavingsTransfer: %w", err)
+	}
+
+	kafkaWriter := kafka.NewWriter(kafka.WriterConfig{
+		Brokers: []string{config.Kafka.Addr()},
+		Topic:   "savings-transfer-acknowledgements",
+	})
+
+	transferID := ctx.String("transfer-id")
+
+	status, ok := savingsTransferStatuses[ctx.String("status")]
+	if !ok {
+		return fmt.Errorf("acknowledgeSavingsTransfer: unsupported 'status' specified: %s", ctx.String("status"))
+	}
+
+	msg, err := proto.Marshal(&messages.SavingsTransferAcknowledged{
+		TransferId:     transferID,
+		Status:         status,
+		Reason:         ctx.String("reason"),
+		AcknowledgedAt: timestamppb.Now(),
+	})
+
+	if err != nil {
+		return fmt.Errorf("acknowledgeSavingsTransfer: failed to marshal message to protobuf: %w", err)
+	}
+
+	err = kafkaWriter.WriteMessages(context.Background(), kafka.Message{
+		Key:   []byte(transferID),
+		Value: msg,
+	})
+
+	if err != nil {
+		err = fmt.Errorf("acknowledgeSavingsTransfer: failed to write message to kafka: %w", err)
+	}
+
+	return err
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/eventually-rs/saving-goals-go/internal/app"
+	"github.com/eventually-rs/saving-goals-go/pkg/client"
+
+	"github.com/urfave/cli/v2"
+)
+
+func aggregateEvolution(ctx *cli.Context) error {
+	config, err := app.ParseConfig()
+	if err != nil {
+		return fmt.Errorf("aggregateEvolution: %w", err)
+	}
+
+	c, err := newClient(config)
+	if err != nil {
+		return fmt.Errorf("aggregateEvolution: %w", err)
+	}
+
+	var options []client.ReadOption
+	if asOf := ctx.String("as-of"); asOf != "" {
+		options = append(options, client.AsOf(asOf))
+	}
+
+	steps, err := c.GetAggregateEvolution(ctx.Context, ctx.String("type"), ctx.String("id"), options...)
+	if err != nil {
+		return fmt.Errorf("aggregateEvolution: %w", err)
+	}
+
+	for _, step := range steps {
+		recordedAt := "-"
+		if step.RecordedAt != nil {
+			recordedAt = step.RecordedAt.Format(time.RFC3339)
+		}
+
+		payload, err := json.Marshal(step.Event.Payload)
+		if err != nil {
+			return fmt.Errorf("aggregateEvolution: failed to marshal event payload: %w", err)
+		}
+
+		state, err := json.MarshalIndent(step.State, "    ", "  ")
+		if err != nil {
+			return fmt.Errorf("aggregateEvolution: failed to marshal state: %w", err)
+		}
+
+		fmt.Fprintf(os.Stdout, "#%d (sequence number %d, recorded at %s) %s\n    event: %s\n    state: %s\n\n",
+			step.Version, step.SequenceNumber, recordedAt, step.Event.Type, payload, state)
+	}
+
+	return nil
+}
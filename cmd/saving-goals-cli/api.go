@@ -0,0 +1,21 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/eventually-rs/saving-goals-go/internal/app"
+	"github.com/eventually-rs/saving-goals-go/pkg/client"
+)
+
+// newClient returns a client of the API server listening on the port
+// specified in SERVER_PORT, to call its internal endpoints.
+//
+// The requests are authenticated with the bearer token specified in AUTH_TOKEN.
+func newClient(config app.Config) (*client.Client, error) {
+	c, err := client.New(fmt.Sprintf("http://localhost:%d", config.Server.Port), client.Options{Token: config.Auth.Token})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create api client: %w", err)
+	}
+
+	return c, nil
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/eventually-rs/saving-goals-go/internal/app"
+	"github.com/eventually-rs/saving-goals-go/resources/messages"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/segmentio/kafka-go"
+	"github.com/urfave/cli/v2"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func closeAccount(ctx *cli.Context) error {
+	config, err := app.ParseConfig()
+	if err != nil {
+		return fmt.Errorf("closeAccount: %w", err)
+	}
+
+	kafkaWriter := kafka.NewWriter(kafka.WriterConfig{
+		Brokers: []string{config.Kafka.Addr()},
+		Topic:   "account-closed",
+	})
+
+	accountID := ctx.String("account-id")
+
+	closedTimestamp := timestamppb.Now()
+	if closedAt := ctx.Timestamp("closed-at"); closedAt != nil {
+		closedTimestamp = timestamppb.New(*closedAt)
+	}
+
+	msg, err := proto.Marshal(&messages.AccountClosed{
+		AccountId: accountID,
+		Reason:    ctx.String("reason"),
+		ClosedAt:  closedTimestamp,
+	})
+
+	if err != nil {
+		return fmt.Errorf("closeAccount: failed to marshal message to protobuf: %w", err)
+	}
+
+	err = kafkaWriter.WriteMessages(context.Background(), kafka.Message{
+		Key:   []byte(accountID),
+		Value: msg,
+	})
+
+	if err != nil {
+		err = fmt.Errorf("closeAccount: failed to write message to kafka: %w", err)
+	}
+
+	return err
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/eventually-rs/saving-goals-go/internal/app"
+	v1 "github.com/eventually-rs/saving-goals-go/pkg/api/v1"
+	"github.com/eventually-rs/saving-goals-go/pkg/client"
+	"github.com/eventually-rs/saving-goals-go/pkg/shutdown"
+
+	"github.com/urfave/cli/v2"
+)
+
+// eventsTail prints the Events of an Event Stream as they are recorded,
+// polling the API server until interrupted.
+func eventsTail(ctx *cli.Context) error {
+	config, err := app.ParseConfig()
+	if err != nil {
+		return fmt.Errorf("eventsTail: %w", err)
+	}
+
+	c, err := newClient(config)
+	if err != nil {
+		return fmt.Errorf("eventsTail: %w", err)
+	}
+
+	page := func(from int64, limit int) (v1.StreamPage, error) {
+		return c.ListStreamEvents(ctx.Context, ctx.String("type"), ctx.String("id"), client.StreamEventsQuery{
+			Names: ctx.StringSlice("event"),
+			From:  from,
+			Limit: limit,
+		})
+	}
+
+	from := ctx.Int64("from")
+
+	// Without a starting version, only the Events recorded from now on are printed.
+	if from <= 0 {
+		response, err := page(1, 1)
+		if err != nil {
+			return fmt.Errorf("eventsTail: %w", err)
+		}
+
+		from = response.Version + 1
+	}
+
+	stop := shutdown.Gracefully()
+	ticker := time.NewTicker(ctx.Duration("interval"))
+	defer ticker.Stop()
+
+	for {
+		response, err := page(from, 0)
+		if err != nil {
+			return fmt.Errorf("eventsTail: %w", err)
+		}
+
+		for _, event := range response.Events {
+			if err := printStreamEvent(event); err != nil {
+				return fmt.Errorf("eventsTail: %w", err)
+			}
+		}
+
+		if response.Next > 0 {
+			from = response.Next
+			continue
+		}
+
+		if response.Version >= from {
+			from = response.Version + 1
+		}
+
+		select {
+		case <-stop:
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func printStreamEvent(event v1.StreamEvent) error {
+	payload, err := json.Marshal(event.Payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event payload: %w", err)
+	}
+
+	recordedAt := "-"
+	if event.RecordedAt != nil {
+		recordedAt = event.RecordedAt.Format(time.RFC3339)
+	}
+
+	fmt.Printf("#%d (sequence number %d, recorded at %s, correlation id %s) %s %s\n",
+		event.Version, event.SequenceNumber, recordedAt, event.CorrelationID, event.Name, payload)
+
+	return nil
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/eventually-rs/saving-goals-go/internal/app"
+
+	"github.com/urfave/cli/v2"
+)
+
+func listSubscriptions(ctx *cli.Context) error {
+	config, err := app.ParseConfig()
+	if err != nil {
+		return fmt.Errorf("listSubscriptions: %w", err)
+	}
+
+	c, err := newClient(config)
+	if err != nil {
+		return fmt.Errorf("listSubscriptions: %w", err)
+	}
+
+	subscriptions, err := c.ListSubscriptions(ctx.Context)
+	if err != nil {
+		return fmt.Errorf("listSubscriptions: %w", err)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tKIND\tSEQUENCE NUMBER\tRUNNING\tREBUILDING")
+
+	for _, s := range subscriptions {
+		fmt.Fprintf(w, "%s\t%s\t%d\t%t\t%t\n", s.Name, s.Kind, s.SequenceNumber, s.Running, s.Rebuilding)
+	}
+
+	return w.Flush()
+}
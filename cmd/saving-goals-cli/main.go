@@ -39,6 +39,26 @@ func main() {
 						Required: true,
 						Usage:    "transaction amount",
 					},
+					&cli.StringFlag{
+						Name:  "kind",
+						Usage: "transaction kind, one of: income, expense, internal-transfer, refund",
+					},
+					&cli.StringFlag{
+						Name:  "transaction-id",
+						Usage: "transaction identifier, a random one is used if not specified",
+					},
+					&cli.StringFlag{
+						Name:  "merchant",
+						Usage: "name of the merchant involved in the transaction",
+					},
+					&cli.StringFlag{
+						Name:  "description",
+						Usage: "transaction description",
+					},
+					&cli.StringFlag{
+						Name:  "mcc",
+						Usage: "merchant category code of the transaction",
+					},
 					&cli.TimestampFlag{
 						Name:   "recorded-at",
 						Usage:  "timestamp of when the event occurred",
@@ -46,6 +66,147 @@ func main() {
 					},
 				},
 			},
+			{
+				Name:   "acknowledge-savings-transfer",
+				Usage:  "sends a SavingsTransferAcknowledged message on the Kafka client specified in KAFKA_HOST",
+				Action: acknowledgeSavingsTransfer,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "transfer-id",
+						Required: true,
+						Usage:    "identifier of the savings transfer requested",
+					},
+					&cli.StringFlag{
+						Name:  "status",
+						Value: "confirmed",
+						Usage: "outcome of the transfer, one of: confirmed, rejected",
+					},
+					&cli.StringFlag{
+						Name:  "reason",
+						Usage: "reason of the rejection, if any",
+					},
+				},
+			},
+			{
+				Name:   "close-account",
+				Usage:  "sends a AccountClosed message on the Kafka client specified in KAFKA_HOST",
+				Action: closeAccount,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "account-id",
+						Required: true,
+						Usage:    "account identifier",
+					},
+					&cli.StringFlag{
+						Name:  "reason",
+						Usage: "reason of the account closure, if any",
+					},
+					&cli.TimestampFlag{
+						Name:   "closed-at",
+						Usage:  "timestamp of when the account was closed",
+						Layout: time.RFC3339,
+					},
+				},
+			},
+			{
+				Name:   "list-subscriptions",
+				Usage:  "lists the policies and read models running in the API server, with their checkpoints",
+				Action: listSubscriptions,
+			},
+			{
+				Name:   "reset-subscription",
+				Usage:  "moves the checkpoint of a policy running in the API server, or replays it in dry-run mode",
+				Action: resetSubscription,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "name",
+						Required: true,
+						Usage:    "name of the policy",
+					},
+					&cli.Int64Flag{
+						Name:  "sequence-number",
+						Usage: "global sequence number to reset the checkpoint to",
+					},
+					&cli.TimestampFlag{
+						Name:   "timestamp",
+						Usage:  "reset the checkpoint to the latest event recorded before this time",
+						Layout: time.RFC3339,
+					},
+					&cli.BoolFlag{
+						Name:  "dry-run",
+						Usage: "print the commands the policy would dispatch, without moving its checkpoint",
+					},
+				},
+			},
+			{
+				Name:   "rebuild-read-model",
+				Usage:  "rebuilds a read model of the API server from the beginning, replacing it once caught up",
+				Action: rebuildReadModel,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "name",
+						Required: true,
+						Usage:    "name of the read model",
+					},
+				},
+			},
+			{
+				Name:   "aggregate-evolution",
+				Usage:  "prints the state of an aggregate of the API server after each one of its events",
+				Action: aggregateEvolution,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "type",
+						Value: "account",
+						Usage: "aggregate type, one of: account, monthly-spending",
+					},
+					&cli.StringFlag{
+						Name:     "id",
+						Required: true,
+						Usage:    "aggregate identifier, e.g. account:<account-id>:month:2021-01 for monthly spendings",
+					},
+					&cli.StringFlag{
+						Name:  "as-of",
+						Usage: "stop at the specified version, timestamp (RFC 3339) or date (YYYY-MM-DD)",
+					},
+				},
+			},
+			{
+				Name:  "events",
+				Usage: "inspects the events recorded in the event store of the API server",
+				Subcommands: []*cli.Command{
+					{
+						Name:   "tail",
+						Usage:  "prints the events of an event stream as they are recorded, until interrupted",
+						Action: eventsTail,
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name:  "type",
+								Value: "account",
+								Usage: "type of the event stream, e.g. account, monthly-spending, household",
+							},
+							&cli.StringFlag{
+								Name:     "id",
+								Required: true,
+								Usage:    "identifier of the event stream",
+							},
+							&cli.Int64Flag{
+								Name:  "from",
+								Usage: "version to start from, only new events are printed if not specified",
+							},
+							&cli.StringSliceFlag{
+								Name:  "event",
+								Usage: "name of the events to print, all the events are printed if not specified",
+							},
+							&cli.DurationFlag{
+								Name:  "interval",
+								Value: time.Second,
+								Usage: "interval between the checks for new events",
+							},
+						},
+					},
+				},
+			},
 		},
 	}
 
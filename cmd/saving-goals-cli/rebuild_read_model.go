@@ -0,0 +1,29 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/eventually-rs/saving-goals-go/internal/app"
+
+	"github.com/urfave/cli/v2"
+)
+
+func rebuildReadModel(ctx *cli.Context) error {
+	config, err := app.ParseConfig()
+	if err != nil {
+		return fmt.Errorf("rebuildReadModel: %w", err)
+	}
+
+	c, err := newClient(config)
+	if err != nil {
+		return fmt.Errorf("rebuildReadModel: %w", err)
+	}
+
+	if err := c.RebuildReadModel(ctx.Context, ctx.String("name")); err != nil {
+		return fmt.Errorf("rebuildReadModel: %w", err)
+	}
+
+	fmt.Printf("read model %s rebuilt\n", ctx.String("name"))
+
+	return nil
+}
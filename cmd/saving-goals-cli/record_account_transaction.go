@@ -8,11 +8,20 @@ import (
 	"github.com/eventually-rs/saving-goals-go/resources/messages"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/google/uuid"
 	"github.com/segmentio/kafka-go"
 	"github.com/urfave/cli/v2"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+var transactionKinds = map[string]messages.TransactionKind{
+	"":                  messages.TransactionKind_TRANSACTION_KIND_UNSPECIFIED,
+	"income":            messages.TransactionKind_TRANSACTION_KIND_INCOME,
+	"expense":           messages.TransactionKind_TRANSACTION_KIND_EXPENSE,
+	"internal-transfer": messages.TransactionKind_TRANSACTION_KIND_INTERNAL_TRANSFER,
+	"refund":            messages.TransactionKind_TRANSACTION_KIND_REFUND,
+}
+
 func recordAccountTransaction(ctx *cli.Context) error {
 	config, err := app.ParseConfig()
 	if err != nil {
@@ -28,15 +37,30 @@ func recordAccountTransaction(ctx *cli.Context) error {
 	amount := ctx.Float64("amount")
 	recordedAt := ctx.Timestamp("recorded-at")
 
+	kind, ok := transactionKinds[ctx.String("kind")]
+	if !ok {
+		return fmt.Errorf("recordAccountTransaction: unsupported 'kind' specified: %s", ctx.String("kind"))
+	}
+
+	transactionID := ctx.String("transaction-id")
+	if transactionID == "" {
+		transactionID = uuid.New().String()
+	}
+
 	recordedTimestamp := timestamppb.Now()
 	if recordedAt != nil {
 		recordedTimestamp = timestamppb.New(*recordedAt)
 	}
 
 	msg, err := proto.Marshal(&messages.AccountTransactionRecorded{
-		AccountId:  accountID,
-		Amount:     float32(amount),
-		RecordedAt: recordedTimestamp,
+		AccountId:     accountID,
+		Amount:        float32(amount),
+		RecordedAt:    recordedTimestamp,
+		Kind:          kind,
+		TransactionId: transactionID,
+		Merchant:      ctx.String("merchant"),
+		Description:   ctx.String("description"),
+		Mcc:           ctx.String("mcc"),
 	})
 
 	if err != nil {
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/eventually-rs/saving-goals-go/internal/app"
+	v1 "github.com/eventually-rs/saving-goals-go/pkg/api/v1"
+
+	"github.com/urfave/cli/v2"
+)
+
+func resetSubscription(ctx *cli.Context) error {
+	config, err := app.ParseConfig()
+	if err != nil {
+		return fmt.Errorf("resetSubscription: %w", err)
+	}
+
+	request := v1.ResetSubscriptionRequest{
+		SequenceNumber: ctx.Int64("sequence-number"),
+		Timestamp:      ctx.Timestamp("timestamp"),
+		DryRun:         ctx.Bool("dry-run"),
+	}
+
+	c, err := newClient(config)
+	if err != nil {
+		return fmt.Errorf("resetSubscription: %w", err)
+	}
+
+	if request.DryRun {
+		response, err := c.DryRunSubscription(ctx.Context, ctx.String("name"), request)
+		if err != nil {
+			return fmt.Errorf("resetSubscription: %w", err)
+		}
+
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+
+		return encoder.Encode(response)
+	}
+
+	response, err := c.ResetSubscription(ctx.Context, ctx.String("name"), request)
+	if err != nil {
+		return fmt.Errorf("resetSubscription: %w", err)
+	}
+
+	fmt.Printf("checkpoint reset to sequence number %d\n", response.SequenceNumber)
+
+	return nil
+}
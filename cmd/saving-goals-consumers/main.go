@@ -2,10 +2,18 @@ package main
 
 import (
 	"context"
+	"time"
 
 	"github.com/eventually-rs/saving-goals-go/internal/app"
+	"github.com/eventually-rs/saving-goals-go/internal/commandbus"
 	"github.com/eventually-rs/saving-goals-go/internal/consumer"
 	"github.com/eventually-rs/saving-goals-go/internal/domain/account"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/household"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/savings"
+	"github.com/eventually-rs/saving-goals-go/internal/schema"
+	"github.com/eventually-rs/saving-goals-go/internal/shredding"
+	"github.com/eventually-rs/saving-goals-go/internal/snapshot"
+	"github.com/eventually-rs/saving-goals-go/internal/timetravel"
 	"github.com/eventually-rs/saving-goals-go/pkg/must"
 
 	"github.com/eventually-rs/eventually-go/aggregate"
@@ -48,26 +56,68 @@ func main() {
 		}
 	}()
 
+	keyStore, err := shredding.OpenPostgresKeyStore(ctx, config.Database.DSN())
+	must.NotFail(err)
+
+	defer func() {
+		if err := keyStore.Close(); err != nil {
+			logger.Error("Closing the key store exited with error", zap.Error(err))
+		}
+	}()
+
+	// Encrypt the events holding personal data, which are erased by forgetting their keys.
+	shreddingEventStore := shredding.WrapEventStore(postgresEventStore, keyStore, app.PersonalDataSubjects())
+	eventStore := eventstore.Store(shreddingEventStore)
+
+	// Upcast the events recorded with older versions of their types.
+	events, err := app.Events()
+	must.NotFail(err)
+
+	eventStore = schema.WrapEventStore(eventStore, events)
+
 	// Use correlated Event Store to embed additional metadata into appended events.
-	eventStore := eventstore.Store(postgresEventStore)
 	eventStore = correlation.WrapEventStore(eventStore, func() string {
 		return uuid.New().String()
 	})
 
-	must.NotFail(eventStore.Register(ctx, account.Type.Name(), map[string]interface{}{
-		"account_was_created":              account.WasCreated{},
-		"saving_goal_was_changed":          account.SavingGoalWasChanged{},
-		"saving_goal_was_disabled":         account.SavingGoalWasDisabled{},
-		"threshold_was_set":                account.ThresholdWasSet{},
-		"account_transaction_was_recorded": account.TransactionWasRecorded{},
-	}))
+	// Record the time the events are appended, to rebuild the past states of the aggregates.
+	eventStore = timetravel.WrapEventStore(eventStore, time.Now)
+
+	must.NotFail(events.Register(ctx, eventStore))
+
+	// Encrypt the events appended in clear before, so that they are erased as well.
+	must.NotFail(shredding.MigratePostgresEventStore(ctx, config.Database.DSN(), shreddingEventStore))
 
 	accountEventStore, err := eventStore.Type(ctx, account.Type.Name())
 	must.NotFail(err)
+
+	savingsTransferEventStore, err := eventStore.Type(ctx, savings.Type.Name())
+	must.NotFail(err)
+
+	householdEventStore, err := eventStore.Type(ctx, household.Type.Name())
+	must.NotFail(err)
 	// </EventStore> ---------------------------------------------------------------------------------------------------
 
 	// <Repositories> --------------------------------------------------------------------------------------------------
-	accountRepository := aggregate.NewRepository(account.Type, accountEventStore)
+	postgresSnapshotStore, err := snapshot.OpenPostgresStore(ctx, config.Database.DSN())
+	must.NotFail(err)
+
+	defer func() {
+		if err := postgresSnapshotStore.Close(); err != nil {
+			logger.Error("Closing the snapshot store exited with error", zap.Error(err))
+		}
+	}()
+
+	// Snapshots hold personal data too, so they are encrypted with the same keys of the events.
+	snapshotStore := shredding.WrapSnapshotStore(postgresSnapshotStore, keyStore, app.PersonalDataSubjects())
+
+	accountRepository := snapshot.NewRepository(account.Type, accountEventStore, snapshotStore, snapshot.Options{
+		SchemaVersion: account.SnapshotVersion,
+		Frequency:     config.Snapshot.Frequency,
+		Factory:       func() snapshot.Root { return new(account.Account) },
+	}, logger)
+	savingsTransferRepository := aggregate.NewRepository(savings.Type, savingsTransferEventStore)
+	householdRepository := aggregate.NewRepository(household.Type, householdEventStore)
 	// </Repositories> -------------------------------------------------------------------------------------------------
 
 	// <Commands> ------------------------------------------------------------------------------------------------------
@@ -75,18 +125,38 @@ func main() {
 
 	commandBus.Register(account.CreateCommandHandler{Repository: accountRepository})
 	commandBus.Register(account.RecordTransactionCommandHandler{Repository: accountRepository})
+	commandBus.Register(account.CloseAccountCommandHandler{Repository: accountRepository})
+	commandBus.Register(savings.AcknowledgeTransferCommandHandler{Repository: savingsTransferRepository})
+
+	// Consumers dispatch their Commands on behalf of the application itself.
+	commandDispatcher := commandbus.Chain(
+		commandbus.Chain(commandBus, app.CommandMiddlewares(config.Concurrency, householdRepository, logger)...),
+		commandbus.As(commandbus.System),
+	)
 	// </Commands> -----------------------------------------------------------------------------------------------------
 
 	// <KafkaConsumers> ------------------------------------------------------------------------------------------------
 	accountCreatedConsumer := consumer.NewAccountCreated(
 		config.Kafka.Addr(),
-		commandBus,
+		commandDispatcher,
 		logger,
 	)
 
 	accountTransactionRecordedConsumer := consumer.NewAccountTransactionRecorded(
 		config.Kafka.Addr(),
-		commandBus,
+		commandDispatcher,
+		logger,
+	)
+
+	savingsTransferAcknowledgedConsumer := consumer.NewSavingsTransferAcknowledged(
+		config.Kafka.Addr(),
+		commandDispatcher,
+		logger,
+	)
+
+	accountClosedConsumer := consumer.NewAccountClosed(
+		config.Kafka.Addr(),
+		commandDispatcher,
 		logger,
 	)
 
@@ -102,6 +172,18 @@ func main() {
 		}
 	}()
 
+	defer func() {
+		if err := savingsTransferAcknowledgedConsumer.Close(); err != nil {
+			logger.Error("savings-transfer-acknowledgements-consumer failed to close", zap.Error(err))
+		}
+	}()
+
+	defer func() {
+		if err := accountClosedConsumer.Close(); err != nil {
+			logger.Error("account-closed-consumer failed to close", zap.Error(err))
+		}
+	}()
+
 	group, ctx := errgroup.WithContext(ctx)
 
 	group.Go(func() error {
@@ -114,6 +196,16 @@ func main() {
 		return accountTransactionRecordedConsumer.Start(ctx)
 	})
 
+	group.Go(func() error {
+		logger.Info("savings-transfer-acknowledgements-consumer started")
+		return savingsTransferAcknowledgedConsumer.Start(ctx)
+	})
+
+	group.Go(func() error {
+		logger.Info("account-closed-consumer started")
+		return accountClosedConsumer.Start(ctx)
+	})
+
 	if err := group.Wait(); err != nil {
 		logger.Fatal("Consumers exited with error", zap.Error(err))
 	}
@@ -0,0 +1,217 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/command"
+	"github.com/eventually-rs/eventually-go/eventstore"
+	"github.com/eventually-rs/eventually-go/projection"
+	"github.com/eventually-rs/eventually-go/subscription"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+)
+
+// Policy is a subscription dispatching commands in reaction to the Events
+// of its Source, whose progress is saved in a checkpoint.
+type Policy struct {
+	Name       string
+	EventStore Source
+
+	// CommandDispatcher is the Dispatcher the commands of the policy are sent to.
+	CommandDispatcher command.Dispatcher
+
+	// New returns the projection.Applier of the policy, dispatching
+	// its commands to the specified Dispatcher.
+	New func(command.Dispatcher) projection.Applier
+}
+
+type policyRunner struct {
+	Policy
+
+	ctx     context.Context
+	running running
+}
+
+// DryRunResult contains the commands a policy would have dispatched
+// while replaying its Events.
+type DryRunResult struct {
+	// From is the global sequence number the policy has been replayed from, excluded.
+	From int64
+
+	// To is the global sequence number of the latest Event replayed.
+	To int64
+
+	// Events is the number of Events replayed.
+	Events int
+
+	// Commands are the commands that would have been dispatched.
+	Commands []eventually.Command
+}
+
+// StartPolicy starts the Policy in background, from its latest checkpoint,
+// until the context is canceled.
+func (s *Supervisor) StartPolicy(ctx context.Context, policy Policy) error {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	if err := s.register(policy.Name); err != nil {
+		return err
+	}
+
+	runner := &policyRunner{Policy: policy, ctx: ctx}
+	runner.running = s.startPolicy(runner)
+	s.policies[policy.Name] = runner
+
+	return nil
+}
+
+func (s *Supervisor) startPolicy(runner *policyRunner) running {
+	projector := projection.NewProjector(
+		runner.New(runner.CommandDispatcher),
+		subscription.CatchUp{
+			SubscriptionName: runner.Name,
+			EventStore:       runner.EventStore,
+			Checkpointer:     s.checkpointer,
+		},
+	)
+
+	return s.run(runner.ctx, runner.Name, projector)
+}
+
+// Reset stops the policy, moves its checkpoint to the specified Position and
+// restarts it: all the Events recorded after the Position are processed again.
+//
+// The global sequence number of the new checkpoint is returned.
+func (s *Supervisor) Reset(ctx context.Context, name string, to Position) (int64, error) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	runner, err := s.policy(name)
+	if err != nil {
+		return 0, err
+	}
+
+	sequenceNumber, err := s.resolve(ctx, to)
+	if err != nil {
+		return 0, err
+	}
+
+	runner.running.stop()
+
+	// The policy is restarted even if the checkpoint could not be written,
+	// to resume from where it was.
+	err = s.checkpointer.Write(ctx, name, sequenceNumber)
+	runner.running = s.startPolicy(runner)
+
+	if err != nil {
+		return 0, fmt.Errorf("admin.Supervisor: failed to write checkpoint of '%s': %w", name, err)
+	}
+
+	s.logger.Info("Subscription checkpoint reset",
+		zap.String("subscription", name),
+		zap.Int64("sequenceNumber", sequenceNumber))
+
+	return sequenceNumber, nil
+}
+
+// DryRun replays the Events recorded after the specified Position to
+// the policy, logging the commands it would dispatch instead of dispatching them.
+//
+// The checkpoint of the policy is left untouched, and the policy keeps running.
+func (s *Supervisor) DryRun(ctx context.Context, name string, from Position) (DryRunResult, error) {
+	s.mx.Lock()
+	runner, err := s.policy(name)
+	s.mx.Unlock()
+
+	if err != nil {
+		return DryRunResult{}, err
+	}
+
+	sequenceNumber, err := s.resolve(ctx, from)
+	if err != nil {
+		return DryRunResult{}, err
+	}
+
+	dispatcher := &dryRunDispatcher{subscription: name, logger: s.logger}
+	policy := runner.New(dispatcher)
+	result := DryRunResult{From: sequenceNumber, To: sequenceNumber}
+
+	ch := make(chan eventstore.Event, subscription.DefaultCatchUpBufferSize)
+	group, ctx := errgroup.WithContext(ctx)
+
+	group.Go(func() error { return runner.EventStore.Stream(ctx, ch, sequenceNumber+1) })
+
+	group.Go(func() error {
+		var err error
+
+		// The source channel is always drained, even after a failure,
+		// since the source might not be listening to context cancellation.
+		for event := range ch {
+			if err != nil {
+				continue
+			}
+
+			if err = policy.Apply(ctx, event); err != nil {
+				err = fmt.Errorf("admin.Supervisor: failed to replay event: %w", err)
+				continue
+			}
+
+			result.Events++
+
+			if sn, ok := event.GlobalSequenceNumber(); ok {
+				result.To = sn
+			}
+		}
+
+		return err
+	})
+
+	if err := group.Wait(); err != nil {
+		return DryRunResult{}, err
+	}
+
+	result.Commands = dispatcher.dispatched()
+
+	s.logger.Info("Subscription replayed in dry-run mode",
+		zap.String("subscription", name),
+		zap.Int64("from", result.From),
+		zap.Int64("to", result.To),
+		zap.Int("events", result.Events),
+		zap.Int("commands", len(result.Commands)))
+
+	return result, nil
+}
+
+// dryRunDispatcher is a command.Dispatcher that logs and keeps
+// the commands received, without dispatching them.
+type dryRunDispatcher struct {
+	subscription string
+	logger       *zap.Logger
+
+	mx       sync.Mutex
+	commands []eventually.Command
+}
+
+func (d *dryRunDispatcher) Dispatch(ctx context.Context, cmd eventually.Command) error {
+	d.logger.Info("Dry run: command not dispatched",
+		zap.String("subscription", d.subscription),
+		zap.String("command", fmt.Sprintf("%T", cmd.Payload)),
+		zap.Any("payload", cmd.Payload))
+
+	d.mx.Lock()
+	defer d.mx.Unlock()
+
+	d.commands = append(d.commands, cmd)
+
+	return nil
+}
+
+func (d *dryRunDispatcher) dispatched() []eventually.Command {
+	d.mx.Lock()
+	defer d.mx.Unlock()
+
+	return d.commands
+}
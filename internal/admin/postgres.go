@@ -0,0 +1,111 @@
+package admin
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/eventually-rs/saving-goals-go/internal/timetravel"
+
+	_ "github.com/lib/pq" // postgres driver for database/sql
+)
+
+var _ Positions = &PostgresPositions{}
+
+// appendedAtFunction returns the time an Event has been appended from its Metadata,
+// or NULL if it holds no timestamp there. Only the timestamps with a time zone
+// are read, so that the result does not depend on the session and can be indexed.
+var appendedAtFunction = fmt.Sprintf(`CREATE OR REPLACE FUNCTION event_appended_at(metadata JSONB)
+	RETURNS TIMESTAMPTZ AS $$
+	BEGIN
+		IF jsonb_typeof(metadata->'%[1]s') IS DISTINCT FROM 'string'
+			OR metadata->>'%[1]s' !~ '(Z|[+-]\d{2}:\d{2})$' THEN
+			RETURN NULL;
+		END IF;
+
+		RETURN (metadata->>'%[1]s')::TIMESTAMPTZ;
+	EXCEPTION WHEN OTHERS THEN
+		RETURN NULL;
+	END;
+	$$ LANGUAGE plpgsql IMMUTABLE`, timetravel.AppendedAtKey)
+
+// PostgresPositions resolves times into global sequence numbers using
+// the time the Events have been appended, saved in their Metadata
+// under timetravel.AppendedAtKey by timetravel.EventStoreWrapper.
+//
+// Events appended before that have none, or hold other values
+// under the same key, and are not resolved.
+type PostgresPositions struct {
+	db *sql.DB
+}
+
+// OpenPostgresPositions opens a connection with the database of the Event Store,
+// indexing the Events by the time they have been appended if they are not yet.
+//
+// The Event Store tables must exist already.
+func OpenPostgresPositions(ctx context.Context, dsn string) (*PostgresPositions, error) {
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("admin.PostgresPositions: failed to open connection with the db: %w", err)
+	}
+
+	if _, err := db.ExecContext(ctx, appendedAtFunction); err != nil {
+		return nil, fmt.Errorf("admin.PostgresPositions: failed to create appended time function: %w", err)
+	}
+
+	_, err = db.ExecContext(ctx, `CREATE INDEX IF NOT EXISTS events_appended_at
+		ON events (event_appended_at(metadata), global_sequence_number)`)
+
+	if err != nil {
+		return nil, fmt.Errorf("admin.PostgresPositions: failed to create appended time index: %w", err)
+	}
+
+	return &PostgresPositions{db: db}, nil
+}
+
+// Close closes the connection with the database.
+func (p *PostgresPositions) Close() error {
+	return p.db.Close()
+}
+
+// SequenceNumberAt returns the global sequence number of the latest Event
+// appended at or before the specified time, or zero if there is none.
+//
+// timetravel.ErrTimeNotRecorded is returned if the time is earlier than all
+// the Events with an appended time, while some Events have none.
+func (p *PostgresPositions) SequenceNumberAt(ctx context.Context, t time.Time) (int64, error) {
+	var (
+		sequenceNumber int64
+		unrecorded     bool
+	)
+
+	// Events without an appended time precede all the recorded ones,
+	// so checking the first one is enough.
+	err := p.db.QueryRowContext(
+		ctx,
+		`SELECT
+			COALESCE((
+				SELECT global_sequence_number FROM events
+				WHERE event_appended_at(metadata) <= $1
+				ORDER BY event_appended_at(metadata) DESC, global_sequence_number DESC
+				LIMIT 1
+			), 0),
+			COALESCE((
+				SELECT event_appended_at(metadata) IS NULL FROM events
+				ORDER BY global_sequence_number
+				LIMIT 1
+			), FALSE)`,
+		t,
+	).Scan(&sequenceNumber, &unrecorded)
+
+	if err != nil {
+		return 0, fmt.Errorf("admin.PostgresPositions: failed to query events: %w", err)
+	}
+
+	if sequenceNumber == 0 && unrecorded {
+		return 0, fmt.Errorf("admin.PostgresPositions: %w: %s", timetravel.ErrTimeNotRecorded, t.Format(time.RFC3339Nano))
+	}
+
+	return sequenceNumber, nil
+}
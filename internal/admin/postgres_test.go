@@ -0,0 +1,119 @@
+package admin_test
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/eventually-rs/saving-goals-go/internal/admin"
+	"github.com/eventually-rs/saving-goals-go/internal/timetravel"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// TestPostgresPositions runs against the Postgres database in DATABASE_TEST_DSN,
+// in a schema of its own, and is skipped if it is not set.
+func TestPostgresPositions(t *testing.T) {
+	dsn := os.Getenv("DATABASE_TEST_DSN")
+	if dsn == "" {
+		t.Skip("DATABASE_TEST_DSN not set")
+	}
+
+	ctx := context.Background()
+
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer db.Close()
+
+	schema := fmt.Sprintf("positions_test_%d", time.Now().UnixNano())
+
+	if _, err := db.ExecContext(ctx, "CREATE SCHEMA "+schema); err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		if _, err := db.ExecContext(ctx, "DROP SCHEMA "+schema+" CASCADE"); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	// The columns of the Event Store table used by the query.
+	_, err = db.ExecContext(ctx, "CREATE TABLE "+schema+`.events (
+		global_sequence_number BIGSERIAL PRIMARY KEY,
+		metadata               JSONB     NOT NULL
+	)`)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	day1 := time.Date(2021, time.January, 12, 10, 0, 0, 0, time.UTC)
+	day2 := day1.AddDate(0, 0, 1)
+	day3 := day2.AddDate(0, 0, 1)
+
+	// Metadata as saved by the Postgres Event Store.
+	for _, metadata := range []eventually.Metadata{
+		{},
+		{"Recorded-At": timestamppb.New(day1)}, // Set by the consumers.
+		{timetravel.AppendedAtKey: day1},
+		{timetravel.AppendedAtKey: day2, "Recorded-At": timestamppb.New(day1)},
+		{timetravel.AppendedAtKey: day3},
+		{timetravel.AppendedAtKey: "yesterday"},
+		{timetravel.AppendedAtKey: "2021-01-12T10:00:00"}, // Without time zone.
+	} {
+		data, err := json.Marshal(metadata)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := db.ExecContext(ctx, "INSERT INTO "+schema+".events (metadata) VALUES ($1)", data); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	u, err := url.Parse(dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	query := u.Query()
+	query.Set("search_path", schema)
+	u.RawQuery = query.Encode()
+
+	positions, err := admin.OpenPostgresPositions(ctx, u.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer positions.Close()
+
+	t.Run("times are resolved into the latest event appended at or before them", func(t *testing.T) {
+		sequenceNumber, err := positions.SequenceNumberAt(ctx, day1)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), sequenceNumber)
+
+		sequenceNumber, err = positions.SequenceNumberAt(ctx, day2.Add(time.Hour))
+		assert.NoError(t, err)
+		assert.Equal(t, int64(4), sequenceNumber)
+
+		sequenceNumber, err = positions.SequenceNumberAt(ctx, day3.Add(time.Hour))
+		assert.NoError(t, err)
+		assert.Equal(t, int64(5), sequenceNumber)
+	})
+
+	t.Run("times before the first event appended are not recorded", func(t *testing.T) {
+		_, err := positions.SequenceNumberAt(ctx, day1.Add(-time.Hour))
+		assert.True(t, errors.Is(err, timetravel.ErrTimeNotRecorded))
+	})
+}
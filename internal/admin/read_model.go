@@ -0,0 +1,279 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/eventually-rs/eventually-go/eventstore"
+	"github.com/eventually-rs/eventually-go/projection"
+	"github.com/eventually-rs/eventually-go/query"
+	"github.com/eventually-rs/eventually-go/subscription"
+	"github.com/eventually-rs/eventually-go/subscription/checkpoint"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+)
+
+// ReadModel is a subscription building an in-memory read model from
+// the Events of its Source, always starting from the beginning.
+type ReadModel struct {
+	Name       string
+	EventStore Source
+
+	// New returns a new, empty instance of the read model answering its queries,
+	// and the projection.Applier updating it.
+	New func() (query.Handler, projection.Applier)
+}
+
+type readModelRunner struct {
+	ReadModel
+
+	ctx        context.Context
+	handler    *readModelHandler
+	current    readModelInstance
+	rebuilding bool
+}
+
+type readModelInstance struct {
+	handler  query.Handler
+	progress *progress
+	running  running
+}
+
+// StartReadModel starts building the ReadModel in background,
+// until the context is canceled.
+//
+// The query.Handler returned always answers with the latest instance
+// of the read model, also after it has been rebuilt.
+func (s *Supervisor) StartReadModel(ctx context.Context, readModel ReadModel) (query.Handler, error) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	if err := s.register(readModel.Name); err != nil {
+		return nil, err
+	}
+
+	runner := &readModelRunner{ReadModel: readModel, ctx: ctx}
+	runner.current = s.startReadModel(runner)
+	runner.handler = &readModelHandler{current: runner.current.handler}
+	s.readModels[readModel.Name] = runner
+
+	return runner.handler, nil
+}
+
+func (s *Supervisor) startReadModel(runner *readModelRunner) readModelInstance {
+	handler, applier := runner.New()
+	progress := newProgress()
+
+	projector := projection.NewProjector(
+		progress.track(applier),
+		subscription.CatchUp{
+			SubscriptionName: runner.Name,
+			EventStore:       progress.trackSource(runner.EventStore),
+			Checkpointer:     checkpoint.NopCheckpointer,
+		},
+	)
+
+	return readModelInstance{
+		handler:  handler,
+		progress: progress,
+		running:  s.run(runner.ctx, runner.Name, projector),
+	}
+}
+
+// Rebuild builds a new instance of the read model in background, while the
+// current one keeps answering the queries. Once the new instance has caught up
+// with the Event Store, it atomically replaces the current one.
+//
+// The call blocks until the new instance is in place, or the context is canceled.
+func (s *Supervisor) Rebuild(ctx context.Context, name string) error {
+	s.mx.Lock()
+
+	runner, err := s.readModel(name)
+	if err == nil && runner.rebuilding {
+		err = fmt.Errorf("%w: '%s'", ErrRebuildInProgress, name)
+	}
+
+	if err != nil {
+		s.mx.Unlock()
+		return err
+	}
+
+	runner.rebuilding = true
+	s.mx.Unlock()
+
+	defer func() {
+		s.mx.Lock()
+		runner.rebuilding = false
+		s.mx.Unlock()
+	}()
+
+	s.logger.Info("Rebuilding read model", zap.String("subscription", name))
+
+	shadow := s.startReadModel(runner)
+
+	select {
+	case <-shadow.progress.caughtUp:
+	case <-shadow.running.done:
+		return fmt.Errorf("admin.Supervisor: read model '%s' exited while rebuilding", name)
+	case <-ctx.Done():
+		shadow.running.stop()
+		return fmt.Errorf("admin.Supervisor: context done while rebuilding '%s': %w", name, ctx.Err())
+	}
+
+	s.mx.Lock()
+	previous := runner.current
+	runner.current = shadow
+	runner.handler.swap(shadow.handler)
+	s.mx.Unlock()
+
+	previous.running.stop()
+
+	s.logger.Info("Read model rebuilt",
+		zap.String("subscription", name),
+		zap.Int64("sequenceNumber", shadow.progress.appliedSequenceNumber()))
+
+	return nil
+}
+
+// readModelHandler is a query.Handler forwarding the queries
+// to the current instance of a read model.
+type readModelHandler struct {
+	mx      sync.RWMutex
+	current query.Handler
+}
+
+func (h *readModelHandler) QueryType() query.Query {
+	h.mx.RLock()
+	defer h.mx.RUnlock()
+
+	return h.current.QueryType()
+}
+
+func (h *readModelHandler) Handle(ctx context.Context, q query.Query) (query.Answer, error) {
+	h.mx.RLock()
+	current := h.current
+	h.mx.RUnlock()
+
+	return current.Handle(ctx, q)
+}
+
+func (h *readModelHandler) swap(handler query.Handler) {
+	h.mx.Lock()
+	defer h.mx.Unlock()
+
+	h.current = handler
+}
+
+// progress tracks how far a read model is from having caught up
+// with the Events recorded when it started.
+type progress struct {
+	mx         sync.Mutex
+	applied    int64
+	streamed   int64
+	streamDone bool
+	caughtUp   chan struct{}
+	closed     bool
+}
+
+func newProgress() *progress {
+	return &progress{caughtUp: make(chan struct{})}
+}
+
+func (p *progress) appliedSequenceNumber() int64 {
+	p.mx.Lock()
+	defer p.mx.Unlock()
+
+	return p.applied
+}
+
+// check closes the caughtUp channel once all the Events of the initial
+// Event Stream have been applied. It must be called with the lock held.
+func (p *progress) check() {
+	if !p.closed && p.streamDone && p.applied >= p.streamed {
+		close(p.caughtUp)
+		p.closed = true
+	}
+}
+
+// track returns a projection.Applier keeping track of the Events applied.
+func (p *progress) track(applier projection.Applier) projection.Applier {
+	return trackedApplier{Applier: applier, progress: p}
+}
+
+type trackedApplier struct {
+	projection.Applier
+	progress *progress
+}
+
+func (ta trackedApplier) Apply(ctx context.Context, event eventstore.Event) error {
+	if err := ta.Applier.Apply(ctx, event); err != nil {
+		return err
+	}
+
+	if sn, ok := event.GlobalSequenceNumber(); ok {
+		ta.progress.mx.Lock()
+		ta.progress.applied = sn
+		ta.progress.check()
+		ta.progress.mx.Unlock()
+	}
+
+	return nil
+}
+
+// trackSource returns a Source keeping track of the Events
+// of the initial Event Stream, used to catch up with the Event Store.
+func (p *progress) trackSource(source Source) Source {
+	return trackedSource{Source: source, progress: p}
+}
+
+type trackedSource struct {
+	Source
+	progress *progress
+}
+
+func (ts trackedSource) Stream(ctx context.Context, stream eventstore.EventStream, from int64) error {
+	defer close(stream)
+
+	ch := make(chan eventstore.Event, 1)
+	group, ctx := errgroup.WithContext(ctx)
+
+	group.Go(func() error { return ts.Source.Stream(ctx, ch, from) })
+
+	group.Go(func() error {
+		var err error
+
+		// The source channel is always drained, even after a failure,
+		// since the source might not be listening to context cancellation.
+		for event := range ch {
+			if err != nil {
+				continue
+			}
+
+			if sn, ok := event.GlobalSequenceNumber(); ok {
+				ts.progress.mx.Lock()
+				ts.progress.streamed = sn
+				ts.progress.mx.Unlock()
+			}
+
+			select {
+			case stream <- event:
+			case <-ctx.Done():
+				err = ctx.Err()
+			}
+		}
+
+		return err
+	})
+
+	if err := group.Wait(); err != nil {
+		return err
+	}
+
+	ts.progress.mx.Lock()
+	ts.progress.streamDone = true
+	ts.progress.check()
+	ts.progress.mx.Unlock()
+
+	return nil
+}
@@ -0,0 +1,248 @@
+// Package admin implements the operational tooling of the subscriptions
+// running in the application.
+//
+// Policies and read models are started through a Supervisor, which allows to
+// inspect their progress, reset the checkpoint of a policy, replay a policy
+// in dry-run mode and rebuild a read model from scratch.
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/eventually-rs/eventually-go/eventstore"
+	"github.com/eventually-rs/eventually-go/projection"
+	"github.com/eventually-rs/eventually-go/subscription/checkpoint"
+	"go.uber.org/zap"
+)
+
+var (
+	// ErrUnknownSubscription is returned when no subscription
+	// has been started with the specified name.
+	ErrUnknownSubscription = errors.New("admin.Supervisor: unknown subscription")
+
+	// ErrNotPolicy is returned when resetting or replaying a read model:
+	// read models have no checkpoint, and can only be rebuilt.
+	ErrNotPolicy = errors.New("admin.Supervisor: subscription is not a policy")
+
+	// ErrNotReadModel is returned when rebuilding a policy.
+	ErrNotReadModel = errors.New("admin.Supervisor: subscription is not a read model")
+
+	// ErrRebuildInProgress is returned when rebuilding a read model
+	// that is already being rebuilt.
+	ErrRebuildInProgress = errors.New("admin.Supervisor: rebuild already in progress")
+)
+
+// Kind is the kind of a subscription.
+type Kind string
+
+const (
+	// KindPolicy is a subscription dispatching commands in reaction to Events,
+	// whose progress is saved in a checkpoint.
+	KindPolicy Kind = "policy"
+
+	// KindReadModel is a subscription building an in-memory read model,
+	// always starting from the beginning of the Event Store.
+	KindReadModel Kind = "read-model"
+)
+
+// Source is the Event Store a subscription reads its Events from.
+type Source interface {
+	eventstore.Streamer
+	eventstore.Subscriber
+}
+
+// Status is the progress of a subscription.
+type Status struct {
+	Name string
+	Kind Kind
+
+	// SequenceNumber is the global sequence number of the latest Event
+	// processed: the checkpoint for policies, the latest Event applied
+	// to the read model served for read models.
+	SequenceNumber int64
+
+	// Running is false if the subscription exited with an error.
+	Running bool
+
+	// Rebuilding is true while a read model is being rebuilt.
+	Rebuilding bool
+}
+
+// Position is a point of the Event Store.
+//
+// Either a global sequence number, or a time, pointing to the latest Event
+// recorded before it. Time takes precedence, if specified.
+type Position struct {
+	SequenceNumber int64
+	Time           time.Time
+}
+
+// Positions resolves times into global sequence numbers of the Event Store.
+type Positions interface {
+	// SequenceNumberAt returns the global sequence number of the latest Event
+	// appended at or before the specified time, or zero if there is none.
+	//
+	// timetravel.ErrTimeNotRecorded is returned if the Event Store cannot tell
+	// the Events appended before the time.
+	SequenceNumberAt(ctx context.Context, t time.Time) (int64, error)
+}
+
+// Supervisor starts the subscriptions of the application, and keeps track
+// of them to operate on them afterwards.
+//
+// Use NewSupervisor to create a new instance.
+type Supervisor struct {
+	checkpointer checkpoint.Checkpointer
+	positions    Positions
+	logger       *zap.Logger
+
+	mx         sync.Mutex
+	policies   map[string]*policyRunner
+	readModels map[string]*readModelRunner
+}
+
+// NewSupervisor returns a new Supervisor, saving the checkpoints of the policies
+// with the specified Checkpointer.
+func NewSupervisor(checkpointer checkpoint.Checkpointer, positions Positions, logger *zap.Logger) *Supervisor {
+	return &Supervisor{
+		checkpointer: checkpointer,
+		positions:    positions,
+		logger:       logger,
+		policies:     make(map[string]*policyRunner),
+		readModels:   make(map[string]*readModelRunner),
+	}
+}
+
+// List returns the Status of all the subscriptions started, sorted by name.
+func (s *Supervisor) List(ctx context.Context) ([]Status, error) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	statuses := make([]Status, 0, len(s.policies)+len(s.readModels))
+
+	for name, runner := range s.policies {
+		sequenceNumber, err := s.checkpointer.Read(ctx, name)
+		if err != nil {
+			return nil, fmt.Errorf("admin.Supervisor: failed to read checkpoint of '%s': %w", name, err)
+		}
+
+		statuses = append(statuses, Status{
+			Name:           name,
+			Kind:           KindPolicy,
+			SequenceNumber: sequenceNumber,
+			Running:        runner.running.isRunning(),
+		})
+	}
+
+	for name, runner := range s.readModels {
+		statuses = append(statuses, Status{
+			Name:           name,
+			Kind:           KindReadModel,
+			SequenceNumber: runner.current.progress.appliedSequenceNumber(),
+			Running:        runner.current.running.isRunning(),
+			Rebuilding:     runner.rebuilding,
+		})
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Name < statuses[j].Name
+	})
+
+	return statuses, nil
+}
+
+func (s *Supervisor) register(name string) error {
+	_, isPolicy := s.policies[name]
+	_, isReadModel := s.readModels[name]
+
+	if isPolicy || isReadModel {
+		return fmt.Errorf("admin.Supervisor: subscription '%s' already started", name)
+	}
+
+	return nil
+}
+
+func (s *Supervisor) policy(name string) (*policyRunner, error) {
+	if runner, ok := s.policies[name]; ok {
+		return runner, nil
+	}
+
+	if _, ok := s.readModels[name]; ok {
+		return nil, fmt.Errorf("%w: '%s'", ErrNotPolicy, name)
+	}
+
+	return nil, fmt.Errorf("%w: '%s'", ErrUnknownSubscription, name)
+}
+
+func (s *Supervisor) readModel(name string) (*readModelRunner, error) {
+	if runner, ok := s.readModels[name]; ok {
+		return runner, nil
+	}
+
+	if _, ok := s.policies[name]; ok {
+		return nil, fmt.Errorf("%w: '%s'", ErrNotReadModel, name)
+	}
+
+	return nil, fmt.Errorf("%w: '%s'", ErrUnknownSubscription, name)
+}
+
+func (s *Supervisor) resolve(ctx context.Context, position Position) (int64, error) {
+	if position.Time.IsZero() {
+		return position.SequenceNumber, nil
+	}
+
+	sequenceNumber, err := s.positions.SequenceNumberAt(ctx, position.Time)
+	if err != nil {
+		return 0, fmt.Errorf("admin.Supervisor: failed to resolve position at %s: %w", position.Time, err)
+	}
+
+	return sequenceNumber, nil
+}
+
+// running tracks a Projector running in background.
+type running struct {
+	stop func()
+	done <-chan struct{}
+}
+
+func (r running) isRunning() bool {
+	select {
+	case <-r.done:
+		return false
+	default:
+		return true
+	}
+}
+
+// run runs the Projector in background, until it exits
+// or until the returned running is stopped.
+func (s *Supervisor) run(ctx context.Context, name string, projector *projection.Projector) running {
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		s.logger.Info("Subscription started", zap.String("subscription", name))
+
+		if err := projector.Start(ctx); err != nil && ctx.Err() == nil {
+			s.logger.Error("Subscription exited with error", zap.String("subscription", name), zap.Error(err))
+			return
+		}
+
+		s.logger.Info("Subscription stopped", zap.String("subscription", name))
+	}()
+
+	return running{
+		stop: func() {
+			cancel()
+			<-done
+		},
+		done: done,
+	}
+}
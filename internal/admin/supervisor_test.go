@@ -0,0 +1,328 @@
+package admin_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/eventually-rs/saving-goals-go/internal/admin"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/command"
+	"github.com/eventually-rs/eventually-go/eventstore"
+	"github.com/eventually-rs/eventually-go/eventstore/inmemory"
+	"github.com/eventually-rs/eventually-go/projection"
+	"github.com/eventually-rs/eventually-go/query"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+const (
+	policyName    = "test-policy"
+	readModelName = "test-read-model"
+
+	waitFor = time.Second
+	tick    = 5 * time.Millisecond
+)
+
+type incremented struct{}
+
+type notify struct{ SequenceNumber int64 }
+
+type countQuery struct{}
+
+type countAnswer struct {
+	Instance int
+	Count    int
+}
+
+// notifyPolicy dispatches a notify command for each Event received.
+type notifyPolicy struct {
+	command.Dispatcher
+}
+
+func (p notifyPolicy) Apply(ctx context.Context, event eventstore.Event) error {
+	sn, _ := event.GlobalSequenceNumber()
+	return p.Dispatch(ctx, eventually.Command{Payload: notify{SequenceNumber: sn}})
+}
+
+// counter is a read model counting the Events received.
+type counter struct {
+	instance int
+
+	mx    sync.Mutex
+	count int
+}
+
+func (c *counter) QueryType() query.Query { return countQuery{} }
+
+func (c *counter) Handle(context.Context, query.Query) (query.Answer, error) {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	return countAnswer{Instance: c.instance, Count: c.count}, nil
+}
+
+func (c *counter) Apply(context.Context, eventstore.Event) error {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	c.count++
+
+	return nil
+}
+
+type recordingDispatcher struct {
+	mx       sync.Mutex
+	commands []eventually.Command
+}
+
+func (d *recordingDispatcher) Dispatch(_ context.Context, cmd eventually.Command) error {
+	d.mx.Lock()
+	defer d.mx.Unlock()
+
+	d.commands = append(d.commands, cmd)
+
+	return nil
+}
+
+func (d *recordingDispatcher) dispatched() []eventually.Command {
+	d.mx.Lock()
+	defer d.mx.Unlock()
+
+	return append([]eventually.Command(nil), d.commands...)
+}
+
+type memoryCheckpointer struct {
+	mx          sync.Mutex
+	checkpoints map[string]int64
+}
+
+func (c *memoryCheckpointer) Read(_ context.Context, name string) (int64, error) {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	return c.checkpoints[name], nil
+}
+
+func (c *memoryCheckpointer) Write(_ context.Context, name string, sequenceNumber int64) error {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	c.checkpoints[name] = sequenceNumber
+
+	return nil
+}
+
+type fixedPositions int64
+
+func (p fixedPositions) SequenceNumberAt(context.Context, time.Time) (int64, error) {
+	return int64(p), nil
+}
+
+type fixture struct {
+	store        *inmemory.EventStore
+	checkpointer *memoryCheckpointer
+	dispatcher   *recordingDispatcher
+	supervisor   *admin.Supervisor
+	handler      query.Handler
+}
+
+// newFixture starts a policy and a read model on an Event Store
+// with the specified number of Events, with the policy checkpoint at 1.
+func newFixture(t *testing.T, ctx context.Context, events int) fixture {
+	f := fixture{
+		store:        inmemory.NewEventStore(),
+		checkpointer: &memoryCheckpointer{checkpoints: map[string]int64{policyName: 1}},
+		dispatcher:   new(recordingDispatcher),
+	}
+
+	if err := f.store.Register(ctx, "test", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < events; i++ {
+		f.append(t, ctx)
+	}
+
+	f.supervisor = admin.NewSupervisor(f.checkpointer, fixedPositions(2), zap.NewNop())
+
+	err := f.supervisor.StartPolicy(ctx, admin.Policy{
+		Name:              policyName,
+		EventStore:        f.store,
+		CommandDispatcher: f.dispatcher,
+		New: func(dispatcher command.Dispatcher) projection.Applier {
+			return notifyPolicy{Dispatcher: dispatcher}
+		},
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	instances := 0
+
+	f.handler, err = f.supervisor.StartReadModel(ctx, admin.ReadModel{
+		Name:       readModelName,
+		EventStore: f.store,
+		New: func() (query.Handler, projection.Applier) {
+			instances++
+			c := &counter{instance: instances}
+			return c, c
+		},
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return f
+}
+
+func (f fixture) append(t *testing.T, ctx context.Context) {
+	typed, err := f.store.Type(ctx, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := typed.Instance("test").Append(ctx, -1, eventually.Event{Payload: incremented{}}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func (f fixture) count(ctx context.Context) countAnswer {
+	answer, _ := f.handler.Handle(ctx, countQuery{})
+	return answer.(countAnswer)
+}
+
+func sequenceNumbers(commands []eventually.Command) []int64 {
+	sequenceNumbers := make([]int64, 0, len(commands))
+	for _, cmd := range commands {
+		sequenceNumbers = append(sequenceNumbers, cmd.Payload.(notify).SequenceNumber)
+	}
+
+	return sequenceNumbers
+}
+
+func TestSupervisor(t *testing.T) {
+	t.Run("subscriptions are listed with their progress", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		f := newFixture(t, ctx, 3)
+
+		assert.Eventually(t, func() bool {
+			return len(f.dispatcher.dispatched()) == 2 && f.count(ctx).Count == 3
+		}, waitFor, tick)
+
+		assert.Equal(t, []int64{2, 3}, sequenceNumbers(f.dispatcher.dispatched()))
+
+		assert.Eventually(t, func() bool {
+			statuses, err := f.supervisor.List(ctx)
+			return err == nil && statuses[0].SequenceNumber == 3 && statuses[1].SequenceNumber == 3
+		}, waitFor, tick)
+
+		statuses, err := f.supervisor.List(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, []admin.Status{
+			{Name: policyName, Kind: admin.KindPolicy, SequenceNumber: 3, Running: true},
+			{Name: readModelName, Kind: admin.KindReadModel, SequenceNumber: 3, Running: true},
+		}, statuses)
+	})
+
+	t.Run("resetting a policy processes the events after the position again", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		f := newFixture(t, ctx, 3)
+
+		assert.Eventually(t, func() bool { return len(f.dispatcher.dispatched()) == 2 }, waitFor, tick)
+
+		sequenceNumber, err := f.supervisor.Reset(ctx, policyName, admin.Position{SequenceNumber: 0})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), sequenceNumber)
+
+		assert.Eventually(t, func() bool { return len(f.dispatcher.dispatched()) == 5 }, waitFor, tick)
+		assert.Equal(t, []int64{2, 3, 1, 2, 3}, sequenceNumbers(f.dispatcher.dispatched()))
+
+		// Times are resolved through the Positions of the Supervisor.
+		sequenceNumber, err = f.supervisor.Reset(ctx, policyName, admin.Position{Time: time.Now()})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), sequenceNumber)
+
+		assert.Eventually(t, func() bool { return len(f.dispatcher.dispatched()) == 6 }, waitFor, tick)
+		assert.Equal(t, []int64{2, 3, 1, 2, 3, 3}, sequenceNumbers(f.dispatcher.dispatched()))
+	})
+
+	t.Run("dry run collects the commands without dispatching them", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		f := newFixture(t, ctx, 3)
+
+		assert.Eventually(t, func() bool { return len(f.dispatcher.dispatched()) == 2 }, waitFor, tick)
+
+		result, err := f.supervisor.DryRun(ctx, policyName, admin.Position{SequenceNumber: 0})
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		assert.Equal(t, int64(0), result.From)
+		assert.Equal(t, int64(3), result.To)
+		assert.Equal(t, 3, result.Events)
+		assert.Equal(t, []int64{1, 2, 3}, sequenceNumbers(result.Commands))
+
+		assert.Len(t, f.dispatcher.dispatched(), 2)
+
+		checkpoint, _ := f.checkpointer.Read(ctx, policyName)
+		assert.Equal(t, int64(3), checkpoint)
+	})
+
+	t.Run("rebuilding a read model replaces it once caught up", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		f := newFixture(t, ctx, 3)
+
+		assert.Eventually(t, func() bool { return f.count(ctx).Count == 3 }, waitFor, tick)
+		assert.Equal(t, countAnswer{Instance: 1, Count: 3}, f.count(ctx))
+
+		f.append(t, ctx)
+
+		assert.NoError(t, f.supervisor.Rebuild(ctx, readModelName))
+		assert.Equal(t, countAnswer{Instance: 2, Count: 4}, f.count(ctx))
+
+		// The rebuilt read model keeps receiving the new Events.
+		f.append(t, ctx)
+
+		assert.Eventually(t, func() bool { return f.count(ctx).Count == 5 }, waitFor, tick)
+		assert.Equal(t, 2, f.count(ctx).Instance)
+	})
+
+	t.Run("operations on the wrong subscriptions fail", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		f := newFixture(t, ctx, 0)
+
+		_, err := f.supervisor.Reset(ctx, readModelName, admin.Position{})
+		assert.True(t, errors.Is(err, admin.ErrNotPolicy))
+
+		_, err = f.supervisor.DryRun(ctx, readModelName, admin.Position{})
+		assert.True(t, errors.Is(err, admin.ErrNotPolicy))
+
+		err = f.supervisor.Rebuild(ctx, policyName)
+		assert.True(t, errors.Is(err, admin.ErrNotReadModel))
+
+		_, err = f.supervisor.Reset(ctx, "unknown", admin.Position{})
+		assert.True(t, errors.Is(err, admin.ErrUnknownSubscription))
+
+		err = f.supervisor.Rebuild(ctx, "unknown")
+		assert.True(t, errors.Is(err, admin.ErrUnknownSubscription))
+
+		err = f.supervisor.StartPolicy(ctx, admin.Policy{Name: readModelName})
+		assert.Error(t, err)
+	})
+}
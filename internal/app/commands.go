@@ -0,0 +1,163 @@
+package app
+
+import (
+	"context"
+
+	"github.com/eventually-rs/saving-goals-go/internal/commandbus"
+	"github.com/eventually-rs/saving-goals-go/internal/concurrency"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/account"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/household"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/monthly"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/savings"
+
+	"github.com/eventually-rs/eventually-go/aggregate"
+	"github.com/eventually-rs/eventually-go/command"
+	"go.uber.org/zap"
+)
+
+// CommandMiddlewares returns the Middlewares run by the command bus
+// before the Command Handlers, in order: structural validation,
+// authorization, audit of the caller, logging, metrics and retry
+// after concurrency conflicts.
+//
+// The Households are loaded from the Repository to authorize their members.
+func CommandMiddlewares(config Concurrency, households *aggregate.Repository, logger *zap.Logger) []commandbus.Middleware {
+	return []commandbus.Middleware{
+		commandbus.Validation(CommandValidators()),
+		commandbus.Authorization(CommandAuthorizers(households)),
+		commandbus.Audit(),
+		commandbus.Logging(logger),
+		commandbus.Metrics(),
+		// Commands failed because of a concurrent write to the same aggregate are retried with a fresh instance.
+		concurrency.Retry(concurrency.Options{
+			MaxAttempts: config.MaxAttempts,
+			BaseDelay:   config.BaseDelay,
+			MaxDelay:    config.MaxDelay,
+		}, logger),
+	}
+}
+
+// CommandValidators returns the structural validation of the Domain Commands,
+// checked before reaching the Aggregates.
+//
+// The business rules stay in the Aggregates: the Validators only check
+// the Commands carry the identifiers needed to load them.
+func CommandValidators() *commandbus.Validators {
+	validators := commandbus.NewValidators()
+
+	declare := func(cmd command.Command, fields ...string) {
+		validators.Declare(cmd, commandbus.Required(fields...))
+	}
+
+	declare(account.CreateCommand{}, "AccountID")
+	declare(account.RecordTransaction{}, "AccountID", "TransactionID")
+	declare(account.ChangeSavingGoal{}, "AccountID")
+	declare(account.SetNewThreshold{}, "AccountID")
+	declare(account.RemoveThreshold{}, "AccountID")
+	declare(account.ReplaceThresholds{}, "AccountID")
+	declare(account.AddCategorizationRule{}, "AccountID")
+	declare(account.RemoveCategorizationRule{}, "AccountID", "RuleID")
+	declare(account.RecategorizeTransactions{}, "AccountID")
+	declare(account.SetCategoryBudget{}, "AccountID")
+	declare(account.RemoveCategoryBudget{}, "AccountID", "Category")
+	declare(account.AddGoal{}, "AccountID")
+	declare(account.UpdateGoal{}, "AccountID")
+	declare(account.RemoveGoal{}, "AccountID", "GoalID")
+	declare(account.RecordGoalContribution{}, "AccountID", "GoalID")
+	declare(account.ChangePacingStrategy{}, "AccountID")
+	declare(account.ConfirmRecurringSeries{}, "AccountID", "SeriesID")
+	declare(account.DismissRecurringSeries{}, "AccountID", "SeriesID")
+	declare(account.AddSweepRule{}, "AccountID")
+	declare(account.RemoveSweepRule{}, "AccountID", "RuleID")
+	declare(account.FreezeAccount{}, "AccountID")
+	declare(account.CloseAccount{}, "AccountID")
+	declare(account.ReopenAccount{}, "AccountID")
+	declare(account.ForgetAccount{}, "AccountID")
+
+	declare(savings.RequestTransfer{}, "TransferID", "AccountID")
+	declare(savings.AcknowledgeTransfer{}, "TransferID")
+
+	declare(household.CreateCommand{}, "HouseholdID", "FoundedBy")
+	declare(household.InviteMember{}, "HouseholdID", "AccountID", "InvitedBy")
+	declare(household.AcceptInvitation{}, "HouseholdID", "AccountID")
+	declare(household.Leave{}, "HouseholdID", "AccountID")
+	declare(household.ChangeSavingGoal{}, "HouseholdID")
+	declare(household.NotifyMembers{}, "HouseholdID")
+
+	return validators
+}
+
+// CommandAuthorizers returns the authorization rules of the Domain Commands,
+// checked against the Caller carried by the dispatching context.
+//
+// The Commands dispatched by the policies and the Kafka consumers
+// can only be dispatched by the application itself, while the others
+// can only be dispatched on behalf of the Accounts they act upon,
+// or of the members of the Households loaded from the Repository.
+//
+// The Commands not declared here are not authorized.
+func CommandAuthorizers(households *aggregate.Repository) *commandbus.Authorizers {
+	authorizers := commandbus.NewAuthorizers()
+
+	for _, cmd := range []command.Command{
+		account.ChangeSavingGoal{},
+		account.SetNewThreshold{},
+		account.RemoveThreshold{},
+		account.ReplaceThresholds{},
+		account.AddCategorizationRule{},
+		account.RemoveCategorizationRule{},
+		account.RecategorizeTransactions{},
+		account.SetCategoryBudget{},
+		account.RemoveCategoryBudget{},
+		account.AddGoal{},
+		account.UpdateGoal{},
+		account.RemoveGoal{},
+		account.RecordGoalContribution{},
+		account.ChangePacingStrategy{},
+		account.ConfirmRecurringSeries{},
+		account.DismissRecurringSeries{},
+		account.AddSweepRule{},
+		account.RemoveSweepRule{},
+		account.FreezeAccount{},
+		account.CloseAccount{},
+		account.ReopenAccount{},
+		account.ForgetAccount{},
+		household.AcceptInvitation{},
+		household.Leave{},
+	} {
+		authorizers.Declare(cmd, commandbus.RequireAccount("AccountID"))
+	}
+
+	authorizers.Declare(household.CreateCommand{}, commandbus.RequireAccount("FoundedBy"))
+	authorizers.Declare(household.InviteMember{}, commandbus.RequireAccount("InvitedBy"))
+	authorizers.Declare(household.ChangeSavingGoal{}, commandbus.RequireMember("HouseholdID", householdMembers(households)))
+
+	for _, cmd := range []command.Command{
+		account.CreateCommand{},
+		account.RecordTransaction{},
+		monthly.StartSpendingTracking{},
+		monthly.RecordTransaction{},
+		monthly.CategorizeTransaction{},
+		monthly.CheckGoalForecast{},
+		monthly.CloseSpending{},
+		savings.RequestTransfer{},
+		savings.AcknowledgeTransfer{},
+		household.NotifyMembers{},
+	} {
+		authorizers.Declare(cmd, commandbus.RequireRole(commandbus.RoleSystem))
+	}
+
+	return authorizers
+}
+
+// householdMembers returns the members of the Households loaded from the Repository.
+func householdMembers(households *aggregate.Repository) commandbus.Members {
+	return func(ctx context.Context, id string) ([]string, error) {
+		root, err := households.Get(ctx, aggregate.StringID(id))
+		if err != nil {
+			return nil, err
+		}
+
+		return root.(*household.Household).Members(), nil
+	}
+}
@@ -2,6 +2,7 @@ package app
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/kelseyhightower/envconfig"
 )
@@ -11,6 +12,12 @@ type Config struct {
 	Server   Server
 	Kafka    Kafka
 	Jaeger   Jaeger
+	Forecast Forecast
+	Snapshot Snapshot
+
+	Concurrency Concurrency
+	Auth        Auth
+	Idempotency Idempotency
 }
 
 type Kafka struct {
@@ -31,6 +38,62 @@ func (j Jaeger) Addr() string {
 	return fmt.Sprintf("%s:%d", j.Host, j.Port)
 }
 
+// Forecast contains the settings of the end-of-month forecast.
+type Forecast struct {
+	// Confidence is the minimum probability of reaching the desired balance
+	// for a Saving Goal not to be considered at risk.
+	Confidence float64 `default:"0.8"`
+}
+
+// Snapshot contains the settings of the Aggregates snapshotting.
+type Snapshot struct {
+	// Frequency is the number of Events after which a new Snapshot
+	// of an Aggregate is taken. Zero disables snapshotting.
+	Frequency int64 `default:"100"`
+}
+
+// Concurrency contains the settings of the retries of the Commands
+// failed because of an optimistic concurrency conflict.
+type Concurrency struct {
+	// MaxAttempts is the maximum number of times a Command is executed.
+	MaxAttempts int `default:"5"`
+
+	// BaseDelay is the delay before the first retry, doubled at each retry
+	// up to MaxDelay, and randomized to spread the writers in conflict.
+	BaseDelay time.Duration `default:"10ms"`
+	MaxDelay  time.Duration `default:"500ms"`
+}
+
+// Auth contains the settings of the authentication of the HTTP API callers.
+type Auth struct {
+	// JWKSFile is the path of the JSON Web Key Set
+	// with the keys used to sign the bearer tokens.
+	JWKSFile string `envconfig:"JWKS_FILE"`
+
+	// Issuer and Audience are the expected claims of the tokens, if set.
+	Issuer   string
+	Audience string
+
+	// Token is the bearer token used by the CLI to call the API.
+	Token string
+}
+
+// Idempotency contains the settings of the Idempotency-Key support
+// of the HTTP API write endpoints.
+type Idempotency struct {
+	// TTL is the time a key can be used to retry a request,
+	// since the first time it has been sent.
+	TTL time.Duration `default:"24h"`
+
+	// Lease is the time a request is in progress at most: its duplicates
+	// are rejected in the meantime, and it can be retried afterwards
+	// if it never completed, e.g. because the server stopped.
+	Lease time.Duration `default:"1m"`
+
+	// PurgeInterval is how often the expired keys are deleted.
+	PurgeInterval time.Duration `default:"1h"`
+}
+
 type Server struct {
 	Port uint16 `default:"8088"`
 }
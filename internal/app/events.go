@@ -0,0 +1,130 @@
+package app
+
+import (
+	"time"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/account"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/household"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/interval"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/monthly"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/savings"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/transaction"
+	"github.com/eventually-rs/saving-goals-go/internal/schema"
+)
+
+// Events returns the Registry of all the versions of the Domain Events
+// stored in the Event Store, by Stream type.
+//
+// When changing the shape of an Event payload, keep the previous one
+// as an older version with an Upcaster, and add fixtures for the new
+// version in testdata/events.
+func Events() (*schema.Registry, error) {
+	return schema.NewRegistry(map[string][]schema.EventType{
+		"month": {
+			{Name: "month_started", Version: 1, Event: interval.MonthStarted{}},
+		},
+
+		account.Type.Name(): {
+			{Name: "account_was_created", Version: 1, Event: account.WasCreated{}},
+			{Name: "saving_goal_was_changed", Version: 1, Event: account.SavingGoalWasChanged{}},
+			{Name: "saving_goal_was_disabled", Version: 1, Event: account.SavingGoalWasDisabled{}},
+			{Name: "threshold_was_set", Version: 1, Event: account.ThresholdWasSet{}},
+			{Name: "threshold_was_removed", Version: 1, Event: account.ThresholdWasRemoved{}},
+			{Name: "thresholds_were_replaced", Version: 1, Event: account.ThresholdsWereReplaced{}},
+			{
+				Name:    "account_transaction_was_recorded",
+				Version: 1,
+				Event:   accountTransactionWasRecordedV1{},
+				Upcast:  upcastAccountTransactionWasRecordedV1,
+			},
+			{Name: "account_transaction_was_recorded", Version: 2, Event: account.TransactionWasRecorded{}},
+			{Name: "categorization_rule_was_added", Version: 1, Event: account.CategorizationRuleWasAdded{}},
+			{Name: "categorization_rule_was_removed", Version: 1, Event: account.CategorizationRuleWasRemoved{}},
+			{Name: "transaction_was_categorized", Version: 1, Event: account.TransactionWasCategorized{}},
+			{Name: "category_budget_was_set", Version: 1, Event: account.CategoryBudgetWasSet{}},
+			{Name: "category_budget_was_removed", Version: 1, Event: account.CategoryBudgetWasRemoved{}},
+			{Name: "goal_was_added", Version: 1, Event: account.GoalWasAdded{}},
+			{Name: "goal_was_updated", Version: 1, Event: account.GoalWasUpdated{}},
+			{Name: "goal_was_removed", Version: 1, Event: account.GoalWasRemoved{}},
+			{Name: "goal_contribution_was_recorded", Version: 1, Event: account.GoalContributionWasRecorded{}},
+			{Name: "goal_was_achieved", Version: 1, Event: account.GoalWasAchieved{}},
+			{Name: "pacing_strategy_was_changed", Version: 1, Event: account.PacingStrategyWasChanged{}},
+			{Name: "recurring_series_detected", Version: 1, Event: account.RecurringSeriesDetected{}},
+			{Name: "recurring_series_confirmed", Version: 1, Event: account.RecurringSeriesConfirmed{}},
+			{Name: "recurring_series_dismissed", Version: 1, Event: account.RecurringSeriesDismissed{}},
+			{Name: "sweep_rule_was_added", Version: 1, Event: account.SweepRuleWasAdded{}},
+			{Name: "sweep_rule_was_removed", Version: 1, Event: account.SweepRuleWasRemoved{}},
+			{Name: "sweep_was_triggered", Version: 1, Event: account.SweepWasTriggered{}},
+			{Name: "account_was_frozen", Version: 1, Event: account.WasFrozen{}},
+			{Name: "account_was_closed", Version: 1, Event: account.WasClosed{}},
+			{Name: "account_was_reopened", Version: 1, Event: account.WasReopened{}},
+			{Name: "account_was_forgotten", Version: 1, Event: account.WasForgotten{}},
+		},
+
+		savings.Type.Name(): {
+			{Name: "savings_transfer_was_requested", Version: 1, Event: savings.TransferWasRequested{}},
+			{Name: "savings_transfer_was_confirmed", Version: 1, Event: savings.TransferWasConfirmed{}},
+			{Name: "savings_transfer_was_rejected", Version: 1, Event: savings.TransferWasRejected{}},
+		},
+
+		household.Type.Name(): {
+			{Name: "household_was_created", Version: 1, Event: household.WasCreated{}},
+			{Name: "household_member_was_invited", Version: 1, Event: household.MemberWasInvited{}},
+			{Name: "household_invitation_was_accepted", Version: 1, Event: household.InvitationWasAccepted{}},
+			{Name: "household_member_left", Version: 1, Event: household.MemberLeft{}},
+			{Name: "household_saving_goal_was_changed", Version: 1, Event: household.SavingGoalWasChanged{}},
+			{Name: "household_members_were_notified", Version: 1, Event: household.MembersWereNotified{}},
+		},
+
+		monthly.Type.Name(): {
+			{Name: "monthly_spending_tracking_started", Version: 1, Event: monthly.SpendingTrackingStarted{}},
+			{
+				Name:    "monthly_spending_transaction_was_recorded",
+				Version: 1,
+				Event:   monthlyTransactionWasRecordedV1{},
+				Upcast:  upcastMonthlyTransactionWasRecordedV1,
+			},
+			{Name: "monthly_spending_transaction_was_recorded", Version: 2, Event: monthly.TransactionWasRecorded{}},
+			{Name: "monthly_spending_limit_was_updated", Version: 1, Event: monthly.SpendingLimitWasUpdated{}},
+			{Name: "monthly_spending_threshold_was_reached", Version: 1, Event: monthly.ThresholdWasReached{}},
+			{Name: "monthly_spending_transaction_was_categorized", Version: 1, Event: monthly.TransactionWasCategorized{}},
+			{Name: "monthly_spending_category_threshold_was_reached", Version: 1, Event: monthly.CategoryThresholdWasReached{}},
+			{Name: "monthly_spending_pace_warning", Version: 1, Event: monthly.PaceWarning{}},
+			{Name: "monthly_spending_goal_at_risk", Version: 1, Event: monthly.GoalAtRisk{}},
+			{Name: "monthly_spending_goal_back_on_track", Version: 1, Event: monthly.GoalBackOnTrack{}},
+			{Name: "monthly_spending_was_closed", Version: 1, Event: monthly.SpendingWasClosed{}},
+		},
+	})
+}
+
+// accountTransactionWasRecordedV1 is the first version of account.TransactionWasRecorded,
+// recorded before the transactions were classified and categorized.
+type accountTransactionWasRecordedV1 struct {
+	Amount     float64
+	HappenedAt time.Time
+}
+
+func upcastAccountTransactionWasRecordedV1(event interface{}) interface{} {
+	evt := event.(accountTransactionWasRecordedV1)
+
+	return account.TransactionWasRecorded{
+		Amount:     evt.Amount,
+		Kind:       transaction.Classify(transaction.Unspecified, evt.Amount),
+		HappenedAt: evt.HappenedAt,
+	}
+}
+
+// monthlyTransactionWasRecordedV1 is the first version of monthly.TransactionWasRecorded,
+// recorded before the transactions were classified.
+type monthlyTransactionWasRecordedV1 struct {
+	Amount float64
+}
+
+func upcastMonthlyTransactionWasRecordedV1(event interface{}) interface{} {
+	evt := event.(monthlyTransactionWasRecordedV1)
+
+	return monthly.TransactionWasRecorded{
+		Amount: evt.Amount,
+		Kind:   transaction.Classify(transaction.Unspecified, evt.Amount),
+	}
+}
@@ -0,0 +1,129 @@
+package app_test
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/eventually-rs/saving-goals-go/internal/app"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/account"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/monthly"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/transaction"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var update = flag.Bool("update", false, "update the golden files of the event fixtures")
+
+// decodedEvent is the content of the golden files: the Event payload
+// after decoding and upcasting, with its Go type.
+type decodedEvent struct {
+	Type    string      `json:"type"`
+	Payload interface{} `json:"payload"`
+}
+
+// TestEvents decodes the fixtures in testdata/events/<versioned name>/*.json,
+// and compares the upcasted Events against the related .golden files.
+//
+// Run with -update to regenerate the golden files.
+func TestEvents(t *testing.T) {
+	events, err := app.Events()
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	for _, name := range events.Names() {
+		fixtures, err := filepath.Glob(filepath.Join("testdata", "events", name, "*.json"))
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		if !assert.NotEmpty(t, fixtures, "no fixtures found for event '%s'", name) {
+			continue
+		}
+
+		for _, fixture := range fixtures {
+			name, fixture := name, fixture
+
+			t.Run(name+"/"+filepath.Base(fixture), func(t *testing.T) {
+				data, err := ioutil.ReadFile(fixture)
+				if !assert.NoError(t, err) {
+					return
+				}
+
+				event, err := events.Decode(name, data)
+				if !assert.NoError(t, err) {
+					return
+				}
+
+				actual, err := json.MarshalIndent(decodedEvent{
+					Type:    fmt.Sprintf("%T", event),
+					Payload: event,
+				}, "", "  ")
+
+				if !assert.NoError(t, err) {
+					return
+				}
+
+				golden := strings.TrimSuffix(fixture, ".json") + ".golden"
+
+				if *update {
+					assert.NoError(t, ioutil.WriteFile(golden, append(actual, '\n'), 0o644))
+					return
+				}
+
+				expected, err := ioutil.ReadFile(golden)
+				if !assert.NoError(t, err) {
+					return
+				}
+
+				assert.JSONEq(t, string(expected), string(actual))
+			})
+		}
+	}
+}
+
+// TestEventsV1 decodes the payloads stored by the first version
+// of the Events, before versioning was introduced.
+func TestEventsV1(t *testing.T) {
+	events, err := app.Events()
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	testCases := []struct {
+		name     string
+		data     string
+		expected interface{}
+	}{
+		{
+			name: "account_transaction_was_recorded",
+			data: `{"Amount":-42.3,"HappenedAt":"2021-01-15T18:20:00Z"}`,
+			expected: account.TransactionWasRecorded{
+				Amount:     -42.3,
+				Kind:       transaction.Expense,
+				HappenedAt: time.Date(2021, time.January, 15, 18, 20, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:     "monthly_spending_transaction_was_recorded",
+			data:     `{"Amount":2500}`,
+			expected: monthly.TransactionWasRecorded{Amount: 2500, Kind: transaction.Income},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			event, err := events.Decode(tc.name, []byte(tc.data))
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, event)
+		})
+	}
+}
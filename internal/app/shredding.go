@@ -0,0 +1,30 @@
+package app
+
+import (
+	"github.com/eventually-rs/saving-goals-go/internal/domain/account"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/monthly"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/savings"
+	"github.com/eventually-rs/saving-goals-go/internal/shredding"
+)
+
+// PersonalDataSubjects returns the Stream types holding personal data,
+// whose Events are encrypted with the key of the Account they belong to.
+//
+// Households and their spendings are not bound to a single Account,
+// and are left in clear: the Household Events pseudonymize their members.
+func PersonalDataSubjects() map[string]shredding.SubjectFunc {
+	return map[string]shredding.SubjectFunc{
+		account.Type.Name(): shredding.WholeStream,
+		monthly.Type.Name(): monthlySpendingSubject,
+		savings.Type.Name(): savings.AccountOfTransfer,
+	}
+}
+
+func monthlySpendingSubject(streamID string) (string, bool) {
+	id, err := monthly.ParseID(streamID)
+	if err != nil || id.AccountID == "" {
+		return "", false
+	}
+
+	return id.AccountID, true
+}
@@ -0,0 +1,188 @@
+package app_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/eventually-rs/saving-goals-go/internal/app"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/account"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/household"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/interval"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/monthly"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/savings"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/transaction"
+	"github.com/eventually-rs/saving-goals-go/internal/shredding"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+	"github.com/eventually-rs/eventually-go/eventstore"
+	"github.com/eventually-rs/eventually-go/eventstore/inmemory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPersonalDataSubjects(t *testing.T) {
+	const (
+		accountID   = "5b0e5d0c-3f6a-4c1e-9d2b-7a8e4f1c6b3d"
+		memberID    = "c2f1a7e4-8b3d-4e6f-a1c9-0d5b7e2f4a8c"
+		householdID = "test-household"
+	)
+
+	ctx := context.Background()
+	march := interval.Month{Year: 2021, Month: time.March}
+
+	inner := inmemory.NewEventStore()
+	keys := shredding.NewInMemoryKeyStore()
+	store := shredding.WrapEventStore(inner, keys, app.PersonalDataSubjects())
+
+	events, err := app.Events()
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	if !assert.NoError(t, events.Register(ctx, store)) {
+		return
+	}
+
+	appendEvents := func(typ, id string, payloads ...interface{}) {
+		typed, err := store.Type(ctx, typ)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		for _, payload := range payloads {
+			_, err = typed.Instance(id).Append(ctx, -1, eventually.Event{Payload: payload})
+			assert.NoError(t, err)
+		}
+	}
+
+	transferID := savings.TransferID(accountID, "test-rule", "test-transaction").String()
+	spendingID := monthly.ID{AccountID: accountID, Month: march}
+
+	appendEvents(account.Type.Name(), accountID,
+		account.WasCreated{AccountID: accountID},
+		account.TransactionWasRecorded{
+			TransactionID: "test-transaction",
+			Amount:        -45,
+			Kind:          transaction.Expense,
+			Details:       transaction.Details{Description: "Groceries"},
+		},
+	)
+
+	appendEvents(monthly.Type.Name(), spendingID.String(),
+		monthly.SpendingTrackingStarted{ID: spendingID, StartingBalance: 1000, DesiredBalance: 1500},
+	)
+
+	appendEvents(savings.Type.Name(), transferID,
+		savings.TransferWasRequested{TransferID: transferID, AccountID: accountID, Amount: 50},
+	)
+
+	appendEvents(household.Type.Name(), householdID,
+		household.WasCreated{HouseholdID: householdID, Name: "Home", FoundedBy: accountID},
+		household.MemberWasInvited{AccountID: memberID, InvitedBy: accountID},
+	)
+
+	accountStore, err := store.Type(ctx, account.Type.Name())
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	handler := account.ForgetAccountCommandHandler{
+		Repository: aggregate.NewRepository(account.Type, accountStore),
+		Keys:       keys,
+	}
+
+	err = handler.Handle(ctx, eventually.Command{Payload: account.ForgetAccount{AccountID: accountID}})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	// Nothing stored refers to the Account forgotten.
+	raw, err := eventstore.StreamToSlice(ctx, func(ctx context.Context, es eventstore.EventStream) error {
+		return inner.Stream(ctx, es, 0)
+	})
+
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	var tombstones int
+
+	for _, event := range raw {
+		if _, ok := event.Payload.(account.WasForgotten); ok {
+			tombstones++
+		}
+
+		payload, err := json.Marshal(event.Payload)
+		assert.NoError(t, err)
+
+		assert.NotContains(t, event.StreamName, accountID)
+		assert.NotContains(t, string(payload), accountID, "%T", event.Payload)
+	}
+
+	assert.Equal(t, 1, tombstones)
+
+	// Only the tombstone refers to the Account forgotten once read,
+	// as the Account has been read before being forgotten.
+	read, err := eventstore.StreamToSlice(ctx, func(ctx context.Context, es eventstore.EventStream) error {
+		return store.Stream(ctx, es, 0)
+	})
+
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	for _, event := range read {
+		if _, ok := event.Payload.(account.WasForgotten); ok {
+			assert.Equal(t, account.WasForgotten{AccountID: accountID}, event.Payload)
+			continue
+		}
+
+		payload, err := json.Marshal(event.Payload)
+		assert.NoError(t, err)
+
+		assert.NotContains(t, event.StreamName, accountID)
+		assert.NotContains(t, string(payload), accountID, "%T", event.Payload)
+	}
+
+	var householdEvents []interface{}
+
+	for _, event := range read {
+		switch event.Payload.(type) {
+		case account.WasForgotten:
+		case household.WasCreated, household.MemberWasInvited:
+			householdEvents = append(householdEvents, event.Payload)
+		default:
+			assert.IsType(t, shredding.Unreadable{}, event.Payload)
+		}
+	}
+
+	// The other members of the Household are still readable.
+	assert.Equal(t, []interface{}{
+		household.WasCreated{HouseholdID: householdID, Name: "Home", FoundedBy: ""},
+		household.MemberWasInvited{AccountID: memberID, InvitedBy: ""},
+	}, householdEvents)
+
+	for typ, id := range map[string]string{
+		account.Type.Name(): accountID,
+		monthly.Type.Name(): spendingID.String(),
+		savings.Type.Name(): transferID,
+	} {
+		typed, err := store.Type(ctx, typ)
+		if !assert.NoError(t, err) {
+			continue
+		}
+
+		instance, err := eventstore.StreamToSlice(ctx, func(ctx context.Context, es eventstore.EventStream) error {
+			return typed.Instance(id).Stream(ctx, es, 0)
+		})
+
+		assert.NoError(t, err)
+		assert.Empty(t, instance, typ)
+
+		_, err = typed.Instance(id).Append(ctx, -1, eventually.Event{Payload: account.WasCreated{AccountID: accountID}})
+		assert.True(t, errors.Is(err, shredding.ErrSubjectForgotten), typ)
+	}
+}
@@ -0,0 +1,204 @@
+package auth_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/eventually-rs/saving-goals-go/internal/auth"
+	"github.com/eventually-rs/saving-goals-go/internal/commandbus"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/assert"
+)
+
+func encode(n *big.Int) string {
+	return base64.RawURLEncoding.EncodeToString(n.Bytes())
+}
+
+// writeJWKS writes the public keys in a JSON Web Key Set file,
+// returning its path.
+func writeJWKS(t *testing.T, rsaKey *rsa.PrivateKey, ecKey *ecdsa.PrivateKey) string {
+	dir, err := ioutil.TempDir("", "jwks")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	data, err := json.Marshal(map[string]interface{}{
+		"keys": []map[string]string{
+			{
+				"kty": "RSA",
+				"kid": "rsa-key",
+				"use": "sig",
+				"n":   encode(rsaKey.N),
+				"e":   encode(big.NewInt(int64(rsaKey.E))),
+			},
+			{
+				"kty": "EC",
+				"kid": "ec-key",
+				"crv": "P-256",
+				"x":   encode(ecKey.X),
+				"y":   encode(ecKey.Y),
+			},
+			{
+				"kty": "RSA",
+				"kid": "encryption-key",
+				"use": "enc",
+			},
+		},
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(dir, "jwks.json")
+	if err := ioutil.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func sign(t *testing.T, method jwt.SigningMethod, keyID string, key interface{}, claims auth.Claims) string {
+	token := jwt.NewWithClaims(method, claims)
+	token.Header["kid"] = keyID
+
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return signed
+}
+
+func TestAuthenticator(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	keys, err := auth.LoadJWKSFile(writeJWKS(t, rsaKey, ecKey))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	authenticator := auth.NewAuthenticator(keys, auth.Options{
+		Issuer:   "test-issuer",
+		Audience: "saving-goals",
+	})
+
+	claims := func() auth.Claims {
+		return auth.Claims{
+			StandardClaims: jwt.StandardClaims{
+				Subject:   "alice",
+				Issuer:    "test-issuer",
+				Audience:  "saving-goals",
+				ExpiresAt: time.Now().Add(time.Hour).Unix(),
+			},
+			Accounts: []string{"alice-account"},
+			Roles:    []string{auth.RoleAdmin},
+		}
+	}
+
+	t.Run("tokens signed with a known key identify the caller", func(t *testing.T) {
+		for keyID, token := range map[string]string{
+			"rsa-key": sign(t, jwt.SigningMethodRS256, "rsa-key", rsaKey, claims()),
+			"ec-key":  sign(t, jwt.SigningMethodES256, "ec-key", ecKey, claims()),
+		} {
+			caller, err := authenticator.Authenticate(context.Background(), token)
+			if !assert.NoError(t, err, keyID) {
+				return
+			}
+
+			assert.Equal(t, commandbus.Caller{
+				ID:       "alice",
+				Roles:    []string{auth.RoleAdmin},
+				Accounts: []string{"alice-account"},
+			}, caller)
+
+			assert.True(t, caller.CanAccess("alice-account"))
+			assert.False(t, caller.CanAccess("bob-account"))
+		}
+	})
+
+	t.Run("invalid tokens are rejected", func(t *testing.T) {
+		otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		expired := claims()
+		expired.ExpiresAt = time.Now().Add(-time.Minute).Unix()
+
+		noExpiration := claims()
+		noExpiration.ExpiresAt = 0
+
+		noSubject := claims()
+		noSubject.Subject = ""
+
+		otherIssuer := claims()
+		otherIssuer.Issuer = "other-issuer"
+
+		otherAudience := claims()
+		otherAudience.Audience = "other-audience"
+
+		system := claims()
+		system.Roles = []string{commandbus.RoleSystem}
+
+		testcases := map[string]string{
+			"malformed":         "not-a-token",
+			"expired":           sign(t, jwt.SigningMethodRS256, "rsa-key", rsaKey, expired),
+			"no expiration":     sign(t, jwt.SigningMethodRS256, "rsa-key", rsaKey, noExpiration),
+			"no subject":        sign(t, jwt.SigningMethodRS256, "rsa-key", rsaKey, noSubject),
+			"other issuer":      sign(t, jwt.SigningMethodRS256, "rsa-key", rsaKey, otherIssuer),
+			"other audience":    sign(t, jwt.SigningMethodRS256, "rsa-key", rsaKey, otherAudience),
+			"system role":       sign(t, jwt.SigningMethodRS256, "rsa-key", rsaKey, system),
+			"unknown key":       sign(t, jwt.SigningMethodRS256, "other-key", otherKey, claims()),
+			"wrong key":         sign(t, jwt.SigningMethodRS256, "rsa-key", otherKey, claims()),
+			"encryption key":    sign(t, jwt.SigningMethodRS256, "encryption-key", rsaKey, claims()),
+			"symmetric":         sign(t, jwt.SigningMethodHS256, "rsa-key", []byte("secret"), claims()),
+			"unsigned":          sign(t, jwt.SigningMethodNone, "rsa-key", jwt.UnsafeAllowNoneSignatureType, claims()),
+			"key type mismatch": sign(t, jwt.SigningMethodES256, "rsa-key", ecKey, claims()),
+		}
+
+		for name, token := range testcases {
+			_, err := authenticator.Authenticate(context.Background(), token)
+			assert.True(t, errors.Is(err, auth.ErrInvalidToken), name)
+		}
+	})
+}
+
+func TestParseJWKS(t *testing.T) {
+	_, err := auth.ParseJWKS([]byte(`{"keys": [{"kty": "oct", "kid": "secret", "k": "c2VjcmV0"}]}`))
+	assert.Error(t, err)
+
+	_, err = auth.ParseJWKS([]byte(`{"keys": [{"kty": "EC", "kid": "ec", "crv": "P-256", "x": "AQ", "y": "AQ"}]}`))
+	assert.Error(t, err)
+
+	keys, err := auth.ParseJWKS([]byte(`{"keys": []}`))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	_, err = keys.PublicKey(context.Background(), "missing")
+	assert.True(t, errors.Is(err, auth.ErrUnknownKey))
+}
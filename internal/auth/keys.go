@@ -0,0 +1,170 @@
+// Package auth implements the authentication of the callers of the HTTP API,
+// using JWT bearer tokens signed with the keys provided by a KeySource.
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+)
+
+// ErrUnknownKey is returned by a KeySource when no key
+// has the requested identifier.
+var ErrUnknownKey = errors.New("auth: unknown key")
+
+// KeySource provides the public keys used to verify the token signatures,
+// by key identifier (the "kid" header of the token).
+type KeySource interface {
+	PublicKey(ctx context.Context, keyID string) (crypto.PublicKey, error)
+}
+
+var _ KeySource = JWKS{}
+
+// JWKS is a KeySource holding the keys of a JSON Web Key Set, as specified
+// in RFC 7517. Only RSA and elliptic curve signing keys are supported.
+//
+// Use ParseJWKS or LoadJWKSFile to create a new instance.
+type JWKS struct {
+	keys map[string]crypto.PublicKey
+}
+
+type jsonWebKey struct {
+	KeyType string `json:"kty"`
+	KeyID   string `json:"kid"`
+	Use     string `json:"use"`
+
+	// RSA keys.
+	N string `json:"n"`
+	E string `json:"e"`
+
+	// Elliptic curve keys.
+	Curve string `json:"crv"`
+	X     string `json:"x"`
+	Y     string `json:"y"`
+}
+
+// LoadJWKSFile reads a JSON Web Key Set from the specified file.
+func LoadJWKSFile(path string) (JWKS, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return JWKS{}, fmt.Errorf("auth.LoadJWKSFile: failed to read file: %w", err)
+	}
+
+	jwks, err := ParseJWKS(data)
+	if err != nil {
+		return JWKS{}, fmt.Errorf("auth.LoadJWKSFile: %w", err)
+	}
+
+	return jwks, nil
+}
+
+// ParseJWKS parses the JSON representation of a JSON Web Key Set.
+//
+// Keys used for encryption are skipped.
+func ParseJWKS(data []byte) (JWKS, error) {
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+
+	if err := json.Unmarshal(data, &set); err != nil {
+		return JWKS{}, fmt.Errorf("auth.ParseJWKS: failed to decode key set: %w", err)
+	}
+
+	jwks := JWKS{keys: make(map[string]crypto.PublicKey, len(set.Keys))}
+
+	for _, key := range set.Keys {
+		if key.Use != "" && key.Use != "sig" {
+			continue
+		}
+
+		publicKey, err := key.publicKey()
+		if err != nil {
+			return JWKS{}, fmt.Errorf("auth.ParseJWKS: key %s: %w", key.KeyID, err)
+		}
+
+		jwks.keys[key.KeyID] = publicKey
+	}
+
+	return jwks, nil
+}
+
+// PublicKey returns the key with the specified identifier.
+func (jwks JWKS) PublicKey(_ context.Context, keyID string) (crypto.PublicKey, error) {
+	key, ok := jwks.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownKey, keyID)
+	}
+
+	return key, nil
+}
+
+func (key jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch key.KeyType {
+	case "RSA":
+		n, err := decodeBigInt(key.N)
+		if err != nil {
+			return nil, fmt.Errorf("invalid modulus: %w", err)
+		}
+
+		e, err := decodeBigInt(key.E)
+		if err != nil {
+			return nil, fmt.Errorf("invalid exponent: %w", err)
+		}
+
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+
+	case "EC":
+		var curve elliptic.Curve
+
+		switch key.Curve {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve: %s", key.Curve)
+		}
+
+		x, err := decodeBigInt(key.X)
+		if err != nil {
+			return nil, fmt.Errorf("invalid x coordinate: %w", err)
+		}
+
+		y, err := decodeBigInt(key.Y)
+		if err != nil {
+			return nil, fmt.Errorf("invalid y coordinate: %w", err)
+		}
+
+		if !curve.IsOnCurve(x, y) {
+			return nil, errors.New("point not on curve")
+		}
+
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported key type: %s", key.KeyType)
+	}
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) == 0 {
+		return nil, errors.New("empty value")
+	}
+
+	return new(big.Int).SetBytes(data), nil
+}
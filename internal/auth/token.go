@@ -0,0 +1,109 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/eventually-rs/saving-goals-go/internal/commandbus"
+
+	"github.com/golang-jwt/jwt"
+)
+
+// RoleAdmin is the role granting access to the internal endpoints
+// used to operate the application.
+const RoleAdmin = "admin"
+
+// ErrInvalidToken is returned when a token can not be used to authenticate
+// a caller, because malformed, expired or not signed with a known key.
+var ErrInvalidToken = errors.New("auth: invalid token")
+
+// Claims are the claims of the tokens accepted by the Authenticator.
+//
+// The subject identifies the caller, who can access the Accounts listed in
+// the "accounts" claim, and has been granted the roles in the "roles" claim.
+type Claims struct {
+	jwt.StandardClaims
+
+	Accounts []string `json:"accounts"`
+	Roles    []string `json:"roles"`
+}
+
+// Options are the checks of the token claims performed by the Authenticator,
+// in addition to their signature and validity time.
+type Options struct {
+	// Issuer is the expected "iss" claim, if not empty.
+	Issuer string
+
+	// Audience is the expected "aud" claim, if not empty.
+	Audience string
+}
+
+// Authenticator authenticates the callers from their JWT bearer tokens.
+//
+// Use NewAuthenticator to create a new instance.
+type Authenticator struct {
+	keys    KeySource
+	options Options
+	parser  *jwt.Parser
+}
+
+// NewAuthenticator returns an Authenticator verifying the tokens
+// with the keys of the provided KeySource.
+func NewAuthenticator(keys KeySource, options Options) Authenticator {
+	return Authenticator{
+		keys:    keys,
+		options: options,
+		parser: &jwt.Parser{
+			// Only asymmetric algorithms, so that the keys of the KeySource
+			// can not be used to forge tokens.
+			ValidMethods: []string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512"},
+		},
+	}
+}
+
+// Authenticate verifies the token and returns the identity of its caller.
+//
+// ErrInvalidToken is returned if the token is not valid.
+func (a Authenticator) Authenticate(ctx context.Context, token string) (commandbus.Caller, error) {
+	var claims Claims
+
+	_, err := a.parser.ParseWithClaims(token, &claims, func(t *jwt.Token) (interface{}, error) {
+		keyID, _ := t.Header["kid"].(string)
+		return a.keys.PublicKey(ctx, keyID)
+	})
+
+	if err != nil {
+		return commandbus.Caller{}, fmt.Errorf("%w: %s", ErrInvalidToken, err)
+	}
+
+	// The parser accepts the tokens without expiration time, which would never expire.
+	if claims.ExpiresAt == 0 {
+		return commandbus.Caller{}, fmt.Errorf("%w: no expiration time specified", ErrInvalidToken)
+	}
+
+	if claims.Subject == "" {
+		return commandbus.Caller{}, fmt.Errorf("%w: no subject specified", ErrInvalidToken)
+	}
+
+	if a.options.Issuer != "" && !claims.VerifyIssuer(a.options.Issuer, true) {
+		return commandbus.Caller{}, fmt.Errorf("%w: unexpected issuer %s", ErrInvalidToken, claims.Issuer)
+	}
+
+	if a.options.Audience != "" && !claims.VerifyAudience(a.options.Audience, true) {
+		return commandbus.Caller{}, fmt.Errorf("%w: unexpected audience %s", ErrInvalidToken, claims.Audience)
+	}
+
+	for _, role := range claims.Roles {
+		// The system role is reserved to the application itself.
+		if role == commandbus.RoleSystem {
+			return commandbus.Caller{}, fmt.Errorf("%w: role %s can not be granted", ErrInvalidToken, role)
+		}
+	}
+
+	return commandbus.Caller{
+		ID:       claims.Subject,
+		Roles:    claims.Roles,
+		Accounts: claims.Accounts,
+	}, nil
+}
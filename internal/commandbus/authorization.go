@@ -0,0 +1,143 @@
+package commandbus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/command"
+)
+
+// ErrUnauthorized is returned when the Caller is not allowed to dispatch
+// a Command by the Authorizers declared for its type.
+var ErrUnauthorized = errors.New("commandbus: caller not authorized")
+
+// Authorizer checks whether the Caller is allowed to dispatch the Command,
+// returning an error describing why it is not.
+//
+// The Caller is the zero value if the context carries none.
+type Authorizer func(ctx context.Context, caller Caller, cmd command.Command) error
+
+// Authorizers contains the Authorizers declared for each Command type.
+//
+// Use NewAuthorizers to create a new instance.
+type Authorizers struct {
+	byType map[reflect.Type][]Authorizer
+}
+
+// NewAuthorizers returns an empty Authorizers instance.
+func NewAuthorizers() *Authorizers {
+	return &Authorizers{byType: make(map[reflect.Type][]Authorizer)}
+}
+
+// Declare adds the Authorizers to the ones of the type of the specified Command.
+//
+// Declaring no Authorizers allows the Command to any Caller.
+func (a *Authorizers) Declare(cmd command.Command, authorizers ...Authorizer) {
+	t := reflect.TypeOf(cmd)
+	a.byType[t] = append(a.byType[t], authorizers...)
+}
+
+// Authorize runs the Authorizers declared for the type of the Command,
+// in order, returning an ErrUnauthorized error from the first failed one.
+//
+// Commands with no Authorizers declared are not allowed to any Caller.
+func (a *Authorizers) Authorize(ctx context.Context, cmd command.Command) error {
+	caller, _ := CallerFrom(ctx)
+
+	authorizers, ok := a.byType[reflect.TypeOf(cmd)]
+	if !ok {
+		return fmt.Errorf("%w: %T: no authorizers declared", ErrUnauthorized, cmd)
+	}
+
+	for _, authorizer := range authorizers {
+		if err := authorizer(ctx, caller, cmd); err != nil {
+			return fmt.Errorf("%w: %T: %s", ErrUnauthorized, cmd, err)
+		}
+	}
+
+	return nil
+}
+
+// Authorization returns a Middleware stopping the Commands the Caller
+// carried by the context is not allowed to dispatch.
+func Authorization(authorizers *Authorizers) Middleware {
+	return func(next command.Dispatcher) command.Dispatcher {
+		return DispatcherFunc(func(ctx context.Context, cmd eventually.Command) error {
+			if err := authorizers.Authorize(ctx, cmd.Payload); err != nil {
+				return err
+			}
+
+			return next.Dispatch(ctx, cmd)
+		})
+	}
+}
+
+// RequireRole returns an Authorizer allowing only the Callers
+// granted the specified role.
+func RequireRole(role string) Authorizer {
+	return func(_ context.Context, caller Caller, _ command.Command) error {
+		if !caller.HasRole(role) {
+			return fmt.Errorf("role %s required", role)
+		}
+
+		return nil
+	}
+}
+
+// RequireAccount returns an Authorizer allowing only the Callers able to access
+// the Account identified by the specified field of the Command payload.
+//
+// It panics if the payload has no string field with the specified name.
+func RequireAccount(field string) Authorizer {
+	return func(_ context.Context, caller Caller, cmd command.Command) error {
+		value := reflect.ValueOf(cmd).FieldByName(field)
+		if !value.IsValid() || value.Kind() != reflect.String {
+			panic(fmt.Sprintf("commandbus.RequireAccount: %T has no string field named %s", cmd, field))
+		}
+
+		if !caller.CanAccess(value.String()) {
+			return fmt.Errorf("account %s not accessible by %s", value.String(), caller.ID)
+		}
+
+		return nil
+	}
+}
+
+// Members returns the identifiers of the Accounts members of the group,
+// like a Household, with the specified identifier.
+type Members func(ctx context.Context, id string) ([]string, error)
+
+// RequireMember returns an Authorizer allowing only the Callers able to access
+// one of the member Accounts of the group identified by the specified field
+// of the Command payload, as returned by Members.
+//
+// It panics if the payload has no string field with the specified name.
+func RequireMember(field string, members Members) Authorizer {
+	return func(ctx context.Context, caller Caller, cmd command.Command) error {
+		value := reflect.ValueOf(cmd).FieldByName(field)
+		if !value.IsValid() || value.Kind() != reflect.String {
+			panic(fmt.Sprintf("commandbus.RequireMember: %T has no string field named %s", cmd, field))
+		}
+
+		// The application itself can access all the groups.
+		if caller.HasRole(RoleSystem) {
+			return nil
+		}
+
+		accountIDs, err := members(ctx, value.String())
+		if err != nil {
+			return fmt.Errorf("members of %s not found: %s", value.String(), err)
+		}
+
+		for _, accountID := range accountIDs {
+			if caller.CanAccess(accountID) {
+				return nil
+			}
+		}
+
+		return fmt.Errorf("%s not a member of %s", caller.ID, value.String())
+	}
+}
@@ -0,0 +1,97 @@
+package commandbus
+
+import (
+	"context"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/command"
+)
+
+// RoleSystem is the role of the application itself, dispatching Commands
+// from its policies and consumers rather than on behalf of a user.
+const RoleSystem = "system"
+
+// System is the Caller used by the application itself.
+var System = Caller{ID: "system", Roles: []string{RoleSystem}}
+
+// Caller is the identity on behalf of which a Command is dispatched.
+type Caller struct {
+	ID    string
+	Roles []string
+
+	// Accounts are the identifiers of the Accounts the Caller is allowed to access.
+	Accounts []string
+}
+
+// HasRole reports whether the Caller has been granted the specified role.
+func (c Caller) HasRole(role string) bool {
+	for _, r := range c.Roles {
+		if r == role {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CanAccess reports whether the Caller is allowed to access the specified Account.
+//
+// The application itself can access all the Accounts.
+func (c Caller) CanAccess(accountID string) bool {
+	if c.HasRole(RoleSystem) {
+		return true
+	}
+
+	for _, id := range c.Accounts {
+		if id == accountID {
+			return true
+		}
+	}
+
+	return false
+}
+
+type callerKey struct{}
+
+// WithCaller returns a new context carrying the specified Caller.
+func WithCaller(ctx context.Context, caller Caller) context.Context {
+	return context.WithValue(ctx, callerKey{}, caller)
+}
+
+// CallerFrom returns the Caller carried by the context, if any.
+func CallerFrom(ctx context.Context) (Caller, bool) {
+	caller, ok := ctx.Value(callerKey{}).(Caller)
+	return caller, ok
+}
+
+// CallerIDKey is the Command metadata key holding the identifier
+// of the Caller, recorded for audit purposes.
+const CallerIDKey = "Caller-Id"
+
+// Audit returns a Middleware recording the identifier of the Caller
+// carried by the context in the Command metadata.
+func Audit() Middleware {
+	return func(next command.Dispatcher) command.Dispatcher {
+		return DispatcherFunc(func(ctx context.Context, cmd eventually.Command) error {
+			if caller, ok := CallerFrom(ctx); ok {
+				cmd.Metadata = cmd.Metadata.With(CallerIDKey, caller.ID)
+			}
+
+			return next.Dispatch(ctx, cmd)
+		})
+	}
+}
+
+// As returns a Middleware dispatching the Commands on behalf of the specified
+// Caller, unless the context already carries one.
+func As(caller Caller) Middleware {
+	return func(next command.Dispatcher) command.Dispatcher {
+		return DispatcherFunc(func(ctx context.Context, cmd eventually.Command) error {
+			if _, ok := CallerFrom(ctx); !ok {
+				ctx = WithCaller(ctx, caller)
+			}
+
+			return next.Dispatch(ctx, cmd)
+		})
+	}
+}
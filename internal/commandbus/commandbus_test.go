@@ -0,0 +1,271 @@
+package commandbus_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/eventually-rs/saving-goals-go/internal/commandbus"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/account"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/household"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+	"github.com/eventually-rs/eventually-go/command"
+	"github.com/eventually-rs/eventually-go/eventstore"
+	"github.com/eventually-rs/eventually-go/scenario"
+	"github.com/stretchr/testify/assert"
+)
+
+var created = eventstore.Event{
+	StreamType: account.Type.Name(),
+	StreamName: "test-account",
+	Version:    1,
+	Event: eventually.Event{
+		Payload: account.WasCreated{AccountID: "test-account"},
+	},
+}
+
+func TestChain(t *testing.T) {
+	var calls []string
+
+	record := func(name string) commandbus.Middleware {
+		return func(next command.Dispatcher) command.Dispatcher {
+			return commandbus.DispatcherFunc(func(ctx context.Context, cmd eventually.Command) error {
+				calls = append(calls, name)
+				return next.Dispatch(ctx, cmd)
+			})
+		}
+	}
+
+	dispatcher := commandbus.Chain(
+		commandbus.DispatcherFunc(func(context.Context, eventually.Command) error {
+			calls = append(calls, "handler")
+			return nil
+		}),
+		record("validation"),
+		record("authorization"),
+		record("logging"),
+	)
+
+	err := dispatcher.Dispatch(context.Background(), eventually.Command{Payload: account.CreateCommand{}})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, []string{"validation", "authorization", "logging", "handler"}, calls)
+}
+
+func TestValidation(t *testing.T) {
+	validators := commandbus.NewValidators()
+	validators.Declare(account.CreateCommand{}, commandbus.Required("AccountID"))
+
+	handler := func(r *aggregate.Repository) command.Handler {
+		return commandbus.WrapHandler(
+			account.CreateCommandHandler{Repository: r},
+			commandbus.Validation(validators),
+		)
+	}
+
+	t.Run("valid commands reach the handler", func(t *testing.T) {
+		scenario.
+			CommandHandler().
+			When(eventually.Command{Payload: account.CreateCommand{AccountID: "test-account"}}).
+			Then(created).
+			Using(t, account.Type, handler)
+	})
+
+	t.Run("commands missing required fields are rejected", func(t *testing.T) {
+		scenario.
+			CommandHandler().
+			When(eventually.Command{Payload: account.CreateCommand{}}).
+			ThenError(commandbus.ErrInvalidCommand).
+			Using(t, account.Type, handler)
+	})
+
+	t.Run("validation errors report the invalid fields", func(t *testing.T) {
+		err := validators.Validate(account.CreateCommand{})
+
+		var validationErr *commandbus.ValidationError
+		if !assert.True(t, errors.As(err, &validationErr)) {
+			return
+		}
+
+		assert.Equal(t, commandbus.FieldErrors{
+			{Field: "AccountID", Reason: "should be specified"},
+		}, validationErr.Fields)
+	})
+
+	t.Run("commands with no validators are valid", func(t *testing.T) {
+		err := validators.Validate(account.ForgetAccount{})
+		assert.NoError(t, err)
+	})
+
+	t.Run("required fields must exist in the command", func(t *testing.T) {
+		assert.Panics(t, func() {
+			_ = commandbus.Required("Name")(account.CreateCommand{})
+		})
+	})
+}
+
+func TestAuthorization(t *testing.T) {
+	authorizers := commandbus.NewAuthorizers()
+	authorizers.Declare(account.CreateCommand{}, commandbus.RequireRole(commandbus.RoleSystem))
+
+	when := eventually.Command{Payload: account.CreateCommand{AccountID: "test-account"}}
+
+	t.Run("callers with the required role are authorized", func(t *testing.T) {
+		scenario.
+			CommandHandler().
+			When(when).
+			Then(created).
+			Using(t, account.Type, func(r *aggregate.Repository) command.Handler {
+				return commandbus.WrapHandler(
+					account.CreateCommandHandler{Repository: r},
+					commandbus.As(commandbus.System),
+					commandbus.Authorization(authorizers),
+				)
+			})
+	})
+
+	t.Run("callers without the required role are not authorized", func(t *testing.T) {
+		scenario.
+			CommandHandler().
+			When(when).
+			ThenError(commandbus.ErrUnauthorized).
+			Using(t, account.Type, func(r *aggregate.Repository) command.Handler {
+				return commandbus.WrapHandler(
+					account.CreateCommandHandler{Repository: r},
+					commandbus.As(commandbus.Caller{ID: "test-user"}),
+					commandbus.Authorization(authorizers),
+				)
+			})
+	})
+
+	t.Run("commands with no caller are not authorized", func(t *testing.T) {
+		scenario.
+			CommandHandler().
+			When(when).
+			ThenError(commandbus.ErrUnauthorized).
+			Using(t, account.Type, func(r *aggregate.Repository) command.Handler {
+				return commandbus.WrapHandler(
+					account.CreateCommandHandler{Repository: r},
+					commandbus.Authorization(authorizers),
+				)
+			})
+	})
+
+	t.Run("the caller in the context is not replaced", func(t *testing.T) {
+		ctx := commandbus.WithCaller(context.Background(), commandbus.Caller{ID: "test-user"})
+
+		dispatcher := commandbus.Chain(
+			commandbus.DispatcherFunc(func(context.Context, eventually.Command) error { return nil }),
+			commandbus.As(commandbus.System),
+			commandbus.Authorization(authorizers),
+		)
+
+		err := dispatcher.Dispatch(ctx, when)
+		assert.True(t, errors.Is(err, commandbus.ErrUnauthorized))
+	})
+
+	t.Run("commands with no authorizers declared are not authorized", func(t *testing.T) {
+		err := authorizers.Authorize(commandbus.WithCaller(context.Background(), commandbus.System), account.FreezeAccount{})
+		assert.True(t, errors.Is(err, commandbus.ErrUnauthorized))
+	})
+}
+
+func TestRequireAccount(t *testing.T) {
+	authorizers := commandbus.NewAuthorizers()
+	authorizers.Declare(account.FreezeAccount{}, commandbus.RequireAccount("AccountID"))
+
+	cmd := account.FreezeAccount{AccountID: "test-account"}
+
+	testcases := map[string]struct {
+		caller     commandbus.Caller
+		authorized bool
+	}{
+		"owner":         {caller: commandbus.Caller{ID: "alice", Accounts: []string{"test-account"}}, authorized: true},
+		"system":        {caller: commandbus.System, authorized: true},
+		"other account": {caller: commandbus.Caller{ID: "bob", Accounts: []string{"other-account"}}},
+		"anonymous":     {},
+	}
+
+	for name, tc := range testcases {
+		err := authorizers.Authorize(commandbus.WithCaller(context.Background(), tc.caller), cmd)
+
+		if tc.authorized {
+			assert.NoError(t, err, name)
+		} else {
+			assert.True(t, errors.Is(err, commandbus.ErrUnauthorized), name)
+		}
+	}
+}
+
+func TestRequireMember(t *testing.T) {
+	var lookups int
+
+	members := func(_ context.Context, id string) ([]string, error) {
+		lookups++
+
+		if id != "test-household" {
+			return nil, household.ErrNotFound
+		}
+
+		return []string{"test-account", "other-account"}, nil
+	}
+
+	authorizers := commandbus.NewAuthorizers()
+	authorizers.Declare(household.ChangeSavingGoal{}, commandbus.RequireMember("HouseholdID", members))
+
+	testcases := map[string]struct {
+		caller      commandbus.Caller
+		householdID string
+		authorized  bool
+	}{
+		"member":            {caller: commandbus.Caller{ID: "alice", Accounts: []string{"test-account"}}, authorized: true},
+		"system":            {caller: commandbus.System, householdID: "unknown-household", authorized: true},
+		"not member":        {caller: commandbus.Caller{ID: "bob", Accounts: []string{"third-account"}}},
+		"unknown household": {caller: commandbus.Caller{ID: "alice", Accounts: []string{"test-account"}}, householdID: "unknown-household"},
+		"anonymous":         {},
+	}
+
+	for name, tc := range testcases {
+		householdID := tc.householdID
+		if householdID == "" {
+			householdID = "test-household"
+		}
+
+		cmd := household.ChangeSavingGoal{HouseholdID: aggregate.StringID(householdID)}
+		err := authorizers.Authorize(commandbus.WithCaller(context.Background(), tc.caller), cmd)
+
+		if tc.authorized {
+			assert.NoError(t, err, name)
+		} else {
+			assert.True(t, errors.Is(err, commandbus.ErrUnauthorized), name)
+		}
+	}
+
+	// The application itself is authorized without looking the members up.
+	assert.Equal(t, len(testcases)-1, lookups)
+}
+
+func TestAudit(t *testing.T) {
+	var metadata eventually.Metadata
+
+	dispatcher := commandbus.Chain(
+		commandbus.DispatcherFunc(func(_ context.Context, cmd eventually.Command) error {
+			metadata = cmd.Metadata
+			return nil
+		}),
+		commandbus.Audit(),
+	)
+
+	ctx := commandbus.WithCaller(context.Background(), commandbus.Caller{ID: "alice"})
+
+	err := dispatcher.Dispatch(ctx, eventually.Command{Payload: account.CreateCommand{}})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, "alice", metadata[commandbus.CallerIDKey])
+}
@@ -0,0 +1,56 @@
+// Package commandbus contains the middlewares used to dispatch Domain Commands
+// through a chain of checks before reaching their Command Handler,
+// like structural validation, authorization, logging and metrics.
+package commandbus
+
+import (
+	"context"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/command"
+)
+
+// Middleware decorates a command.Dispatcher, running before the Commands
+// reach the decorated one, and optionally stopping them.
+type Middleware func(command.Dispatcher) command.Dispatcher
+
+// DispatcherFunc is a function implementing command.Dispatcher.
+type DispatcherFunc func(context.Context, eventually.Command) error
+
+// Dispatch calls the function itself.
+func (fn DispatcherFunc) Dispatch(ctx context.Context, cmd eventually.Command) error {
+	return fn(ctx, cmd)
+}
+
+// Chain returns a command.Dispatcher running the Middlewares in the specified
+// order, the first one being the first to receive the Commands, before
+// dispatching them to the provided command.Dispatcher.
+func Chain(dispatcher command.Dispatcher, middlewares ...Middleware) command.Dispatcher {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		dispatcher = middlewares[i](dispatcher)
+	}
+
+	return dispatcher
+}
+
+var _ command.Handler = handler{}
+
+type handler struct {
+	command.Handler
+	dispatcher command.Dispatcher
+}
+
+// WrapHandler returns a command.Handler running the Middlewares before
+// the provided command.Handler.
+//
+// Useful to test the Middlewares of a Command type using the scenario package.
+func WrapHandler(h command.Handler, middlewares ...Middleware) command.Handler {
+	return handler{
+		Handler:    h,
+		dispatcher: Chain(DispatcherFunc(h.Handle), middlewares...),
+	}
+}
+
+func (h handler) Handle(ctx context.Context, cmd eventually.Command) error {
+	return h.dispatcher.Dispatch(ctx, cmd)
+}
@@ -0,0 +1,69 @@
+package commandbus
+
+import (
+	"context"
+	"expvar"
+	"fmt"
+	"time"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/command"
+	"go.uber.org/zap"
+)
+
+// Metrics of the dispatched Commands, by Command type, published with expvar.
+var (
+	dispatched = expvar.NewMap("commands_dispatched")
+	failed     = expvar.NewMap("commands_failed")
+	duration   = expvar.NewMap("commands_duration_seconds")
+)
+
+// Logging returns a Middleware logging the outcome of the dispatched Commands.
+func Logging(logger *zap.Logger) Middleware {
+	return func(next command.Dispatcher) command.Dispatcher {
+		return DispatcherFunc(func(ctx context.Context, cmd eventually.Command) error {
+			start := time.Now()
+			err := next.Dispatch(ctx, cmd)
+
+			fields := []zap.Field{
+				zap.String("command", fmt.Sprintf("%T", cmd.Payload)),
+				zap.Duration("duration", time.Since(start)),
+			}
+
+			if caller, ok := CallerFrom(ctx); ok {
+				fields = append(fields, zap.String("caller", caller.ID))
+			}
+
+			if err != nil {
+				logger.Warn("Command failed", append(fields, zap.Error(err))...)
+				return err
+			}
+
+			logger.Debug("Command dispatched", fields...)
+
+			return nil
+		})
+	}
+}
+
+// Metrics returns a Middleware counting the dispatched and failed Commands,
+// and the total time spent dispatching them, by Command type.
+func Metrics() Middleware {
+	return func(next command.Dispatcher) command.Dispatcher {
+		return DispatcherFunc(func(ctx context.Context, cmd eventually.Command) error {
+			commandType := fmt.Sprintf("%T", cmd.Payload)
+
+			start := time.Now()
+			err := next.Dispatch(ctx, cmd)
+
+			dispatched.Add(commandType, 1)
+			duration.AddFloat(commandType, time.Since(start).Seconds())
+
+			if err != nil {
+				failed.Add(commandType, 1)
+			}
+
+			return err
+		})
+	}
+}
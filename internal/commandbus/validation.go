@@ -0,0 +1,162 @@
+package commandbus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/command"
+)
+
+// ErrInvalidCommand is returned when a Command fails the structural validation
+// declared for its type, before reaching its Command Handler.
+var ErrInvalidCommand = errors.New("commandbus: invalid command")
+
+// Validator checks the structure of a Command payload,
+// returning an error describing what is wrong with it.
+type Validator func(command.Command) error
+
+// Validators contains the Validators declared for each Command type.
+//
+// Use NewValidators to create a new instance.
+type Validators struct {
+	byType map[reflect.Type][]Validator
+}
+
+// NewValidators returns an empty Validators instance.
+func NewValidators() *Validators {
+	return &Validators{byType: make(map[reflect.Type][]Validator)}
+}
+
+// Declare adds the Validators to the ones of the type of the specified Command.
+func (v *Validators) Declare(cmd command.Command, validators ...Validator) {
+	t := reflect.TypeOf(cmd)
+	v.byType[t] = append(v.byType[t], validators...)
+}
+
+// FieldError describes why a field of a Command payload is not valid.
+//
+// Field is empty for the errors not caused by a specific field.
+type FieldError struct {
+	Field  string
+	Reason string
+}
+
+func (e FieldError) Error() string {
+	if e.Field == "" {
+		return e.Reason
+	}
+
+	return e.Field + ": " + e.Reason
+}
+
+// FieldErrors is returned by the Validators reporting more than one field.
+type FieldErrors []FieldError
+
+func (errs FieldErrors) Error() string {
+	messages := make([]string, 0, len(errs))
+	for _, err := range errs {
+		messages = append(messages, err.Error())
+	}
+
+	return strings.Join(messages, ", ")
+}
+
+// ValidationError is returned when a Command fails the Validators
+// declared for its type, listing the invalid fields.
+//
+// It wraps ErrInvalidCommand.
+type ValidationError struct {
+	Command command.Command
+	Fields  FieldErrors
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%s: %T: %s", ErrInvalidCommand, e.Command, e.Fields)
+}
+
+func (e *ValidationError) Unwrap() error {
+	return ErrInvalidCommand
+}
+
+// Validate runs all the Validators declared for the type of the Command,
+// joining the errors of the failed ones in a *ValidationError.
+//
+// Commands with no Validators declared are always valid.
+func (v *Validators) Validate(cmd command.Command) error {
+	var failures FieldErrors
+
+	for _, validator := range v.byType[reflect.TypeOf(cmd)] {
+		err := validator(cmd)
+		if err == nil {
+			continue
+		}
+
+		var (
+			fieldErrs FieldErrors
+			fieldErr  FieldError
+		)
+
+		switch {
+		case errors.As(err, &fieldErrs):
+			failures = append(failures, fieldErrs...)
+		case errors.As(err, &fieldErr):
+			failures = append(failures, fieldErr)
+		default:
+			failures = append(failures, FieldError{Reason: err.Error()})
+		}
+	}
+
+	if len(failures) > 0 {
+		return &ValidationError{Command: cmd, Fields: failures}
+	}
+
+	return nil
+}
+
+// Validation returns a Middleware stopping the Commands
+// failing the Validators declared for their type.
+func Validation(validators *Validators) Middleware {
+	return func(next command.Dispatcher) command.Dispatcher {
+		return DispatcherFunc(func(ctx context.Context, cmd eventually.Command) error {
+			if err := validators.Validate(cmd.Payload); err != nil {
+				return err
+			}
+
+			return next.Dispatch(ctx, cmd)
+		})
+	}
+}
+
+// Required returns a Validator checking the specified fields
+// of the Command payload are not set to their zero value,
+// reporting the missing ones as FieldErrors.
+//
+// It panics if the payload has no field with one of the specified names.
+func Required(fields ...string) Validator {
+	return func(cmd command.Command) error {
+		value := reflect.ValueOf(cmd)
+
+		var missing FieldErrors
+
+		for _, name := range fields {
+			field := value.FieldByName(name)
+			if !field.IsValid() {
+				panic(fmt.Sprintf("commandbus.Required: %T has no field named %s", cmd, name))
+			}
+
+			if field.IsZero() {
+				missing = append(missing, FieldError{Field: name, Reason: "should be specified"})
+			}
+		}
+
+		if len(missing) > 0 {
+			return missing
+		}
+
+		return nil
+	}
+}
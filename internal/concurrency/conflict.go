@@ -0,0 +1,53 @@
+// Package concurrency retries the Domain Commands failed because of
+// an optimistic concurrency conflict on the Event Streams they append to.
+//
+// Command handlers load an Aggregate, mutate it and append the new Events
+// at the version they have loaded: when another writer appended to the same
+// Event Stream in the meantime, the append fails. Executing the handler again
+// loads the Aggregate with the other writer's Events, and usually succeeds.
+package concurrency
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+const (
+	// postgresVersionCheck is the prefix of the exception raised by the Postgres
+	// Event Store when the version check of an append fails.
+	postgresVersionCheck = "invalid stream version provided"
+
+	// postgresEventsKey is the primary key of the Postgres Event Store events,
+	// violated when two concurrent appends compute the same stream version.
+	postgresEventsKey = "events_pkey"
+
+	// postgresStreamsKey is the primary key of the Postgres Event Store streams,
+	// violated when two concurrent appends create the same stream.
+	postgresStreamsKey = "streams_pkey"
+
+	// inMemoryVersionCheck is the error message returned by the in-memory
+	// Event Store when the version check of an append fails.
+	inMemoryVersionCheck = "inmemory: invalid version check"
+
+	pqUniqueViolation pq.ErrorCode = "23505"
+)
+
+// IsConflict returns true if the error has been caused by an optimistic
+// concurrency conflict while appending Events to the Event Store.
+func IsConflict(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return strings.HasPrefix(pqErr.Message, postgresVersionCheck) ||
+			(pqErr.Code == pqUniqueViolation &&
+				(pqErr.Constraint == postgresEventsKey || pqErr.Constraint == postgresStreamsKey))
+	}
+
+	// The in-memory Event Store does not wrap a typed error.
+	return strings.Contains(err.Error(), inMemoryVersionCheck)
+}
@@ -0,0 +1,111 @@
+package concurrency
+
+import (
+	"context"
+	"expvar"
+	"fmt"
+	"time"
+
+	"github.com/eventually-rs/saving-goals-go/internal/commandbus"
+	"github.com/eventually-rs/saving-goals-go/pkg/backoff"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/command"
+	"go.uber.org/zap"
+)
+
+// Metrics of the concurrency conflicts, by Command type, published with expvar.
+var (
+	conflicts = expvar.NewMap("command_conflicts")
+	retries   = expvar.NewMap("command_conflict_retries")
+	exhausted = expvar.NewMap("command_conflict_retries_exhausted")
+)
+
+// Options configures how many times, and how often,
+// a Command is retried after a concurrency conflict.
+type Options struct {
+	// MaxAttempts is the maximum number of times a Command is dispatched,
+	// including the first one. Values lower than one disable retries.
+	MaxAttempts int
+
+	// BaseDelay is the delay before the first retry, doubled at each retry.
+	BaseDelay time.Duration
+
+	// MaxDelay caps the delay between two retries. Zero leaves it uncapped.
+	MaxDelay time.Duration
+}
+
+var _ command.Dispatcher = DispatcherWrapper{}
+
+// DispatcherWrapper is a command.Dispatcher decorator that dispatches
+// a Command again when it fails because of a concurrency conflict,
+// so that its handler loads a fresh Aggregate.
+//
+// Use WrapDispatcher to create a new instance.
+type DispatcherWrapper struct {
+	dispatcher command.Dispatcher
+	options    Options
+	logger     *zap.Logger
+}
+
+// WrapDispatcher wraps the provided command.Dispatcher, retrying the Commands
+// failed because of concurrency conflicts as specified in the Options.
+func WrapDispatcher(dispatcher command.Dispatcher, options Options, logger *zap.Logger) DispatcherWrapper {
+	return DispatcherWrapper{
+		dispatcher: dispatcher,
+		options:    options,
+		logger:     logger,
+	}
+}
+
+// Retry returns a commandbus.Middleware retrying the Commands failed
+// because of concurrency conflicts as specified in the Options.
+func Retry(options Options, logger *zap.Logger) commandbus.Middleware {
+	return func(dispatcher command.Dispatcher) command.Dispatcher {
+		return WrapDispatcher(dispatcher, options, logger)
+	}
+}
+
+// Dispatch dispatches the Command, retrying it after a concurrency conflict
+// until it succeeds, it fails with a different error, or the attempts run out.
+//
+// The error of the last attempt is returned.
+func (dw DispatcherWrapper) Dispatch(ctx context.Context, cmd eventually.Command) error {
+	commandType := fmt.Sprintf("%T", cmd.Payload)
+
+	for attempt := 1; ; attempt++ {
+		err := dw.dispatcher.Dispatch(ctx, cmd)
+		if !IsConflict(err) {
+			return err
+		}
+
+		conflicts.Add(commandType, 1)
+
+		if attempt >= dw.options.MaxAttempts {
+			exhausted.Add(commandType, 1)
+
+			dw.logger.Warn("Command failed after concurrency conflicts",
+				zap.String("command", commandType),
+				zap.Int("attempts", attempt),
+				zap.Error(err))
+
+			return err
+		}
+
+		delay := backoff.Exponential{Base: dw.options.BaseDelay, Max: dw.options.MaxDelay}.Delay(attempt)
+
+		dw.logger.Debug("Concurrency conflict, retrying command",
+			zap.String("command", commandType),
+			zap.Int("attempt", attempt),
+			zap.Duration("delay", delay),
+			zap.Error(err))
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return fmt.Errorf("concurrency.DispatcherWrapper: context done while retrying: %w", err)
+		}
+
+		retries.Add(commandType, 1)
+	}
+}
@@ -0,0 +1,255 @@
+package concurrency_test
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/eventually-rs/saving-goals-go/internal/concurrency"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+	"github.com/eventually-rs/eventually-go/command"
+	"github.com/eventually-rs/eventually-go/eventstore/inmemory"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+const counterID = "test-counter"
+
+var counterType = aggregate.NewType("counter", func() aggregate.Root { return new(counter) })
+
+type counter struct {
+	aggregate.BaseRoot
+
+	id    aggregate.StringID
+	value int
+}
+
+type counterCreated struct{ ID string }
+
+type counterIncremented struct{}
+
+func (c counter) AggregateID() aggregate.ID { return c.id }
+
+func (c *counter) Apply(event eventually.Event) error {
+	switch evt := event.Payload.(type) {
+	case counterCreated:
+		c.id = aggregate.StringID(evt.ID)
+	case counterIncremented:
+		c.value++
+	default:
+		return fmt.Errorf("counter: unsupported event received")
+	}
+
+	return nil
+}
+
+type increment struct{}
+
+// incrementHandler increments the counter, while another writer increments
+// the same counter concurrently during the first conflicts attempts.
+type incrementHandler struct {
+	repository *aggregate.Repository
+	conflicts  int
+	attempts   *int
+}
+
+func (incrementHandler) CommandType() command.Command { return increment{} }
+
+func (h incrementHandler) Handle(ctx context.Context, cmd eventually.Command) error {
+	c, err := h.repository.Get(ctx, aggregate.StringID(counterID))
+	if err != nil {
+		return err
+	}
+
+	*h.attempts++
+
+	if *h.attempts <= h.conflicts {
+		if err := incrementCounter(ctx, h.repository); err != nil {
+			return err
+		}
+	}
+
+	if err := aggregate.RecordThat(c, eventually.Event{Payload: counterIncremented{}}); err != nil {
+		return err
+	}
+
+	if err := h.repository.Add(ctx, c); err != nil {
+		return fmt.Errorf("incrementHandler: failed to save counter: %w", err)
+	}
+
+	return nil
+}
+
+func incrementCounter(ctx context.Context, repository *aggregate.Repository) error {
+	c, err := repository.Get(ctx, aggregate.StringID(counterID))
+	if err != nil {
+		return err
+	}
+
+	if err := aggregate.RecordThat(c, eventually.Event{Payload: counterIncremented{}}); err != nil {
+		return err
+	}
+
+	return repository.Add(ctx, c)
+}
+
+func newRepository(t *testing.T) *aggregate.Repository {
+	ctx := context.Background()
+	store := inmemory.NewEventStore()
+
+	if err := store.Register(ctx, counterType.Name(), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	typed, err := store.Type(ctx, counterType.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	repository := aggregate.NewRepository(counterType, typed)
+
+	c := new(counter)
+	if err := aggregate.RecordThat(c, eventually.Event{Payload: counterCreated{ID: counterID}}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := repository.Add(ctx, c); err != nil {
+		t.Fatal(err)
+	}
+
+	return repository
+}
+
+func metric(name string) int64 {
+	if v, ok := expvar.Get(name).(*expvar.Map).Get(fmt.Sprintf("%T", increment{})).(*expvar.Int); ok {
+		return v.Value()
+	}
+
+	return 0
+}
+
+func TestDispatcherWrapper(t *testing.T) {
+	ctx := context.Background()
+	options := concurrency.Options{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond}
+
+	dispatch := func(t *testing.T, conflicts int) (*aggregate.Repository, int, error) {
+		repository := newRepository(t)
+		attempts := 0
+
+		bus := command.NewSimpleBus()
+		bus.Register(incrementHandler{repository: repository, conflicts: conflicts, attempts: &attempts})
+
+		err := concurrency.WrapDispatcher(bus, options, zap.NewNop()).
+			Dispatch(ctx, eventually.Command{Payload: increment{}})
+
+		return repository, attempts, err
+	}
+
+	t.Run("commands are retried with a fresh aggregate after a conflict", func(t *testing.T) {
+		conflicts, retries := metric("command_conflicts"), metric("command_conflict_retries")
+
+		repository, attempts, err := dispatch(t, 2)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		assert.Equal(t, 3, attempts)
+
+		c, err := repository.Get(ctx, aggregate.StringID(counterID))
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		// Both the concurrent increments and the retried one have been saved.
+		assert.Equal(t, 3, c.(*counter).value)
+
+		assert.Equal(t, conflicts+2, metric("command_conflicts"))
+		assert.Equal(t, retries+2, metric("command_conflict_retries"))
+	})
+
+	t.Run("commands fail after the maximum number of attempts", func(t *testing.T) {
+		exhausted := metric("command_conflict_retries_exhausted")
+
+		_, attempts, err := dispatch(t, 5)
+
+		assert.True(t, concurrency.IsConflict(err))
+		assert.Equal(t, options.MaxAttempts, attempts)
+		assert.Equal(t, exhausted+1, metric("command_conflict_retries_exhausted"))
+	})
+
+	t.Run("commands without conflicts are dispatched once", func(t *testing.T) {
+		_, attempts, err := dispatch(t, 0)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, attempts)
+	})
+
+	t.Run("other errors are not retried", func(t *testing.T) {
+		attempts := 0
+		failure := errors.New("failure")
+
+		err := concurrency.WrapDispatcher(dispatcherFunc(func(context.Context, eventually.Command) error {
+			attempts++
+			return failure
+		}), options, zap.NewNop()).Dispatch(ctx, eventually.Command{Payload: increment{}})
+
+		assert.True(t, errors.Is(err, failure))
+		assert.Equal(t, 1, attempts)
+	})
+}
+
+type dispatcherFunc func(context.Context, eventually.Command) error
+
+func (fn dispatcherFunc) Dispatch(ctx context.Context, cmd eventually.Command) error {
+	return fn(ctx, cmd)
+}
+
+func TestIsConflict(t *testing.T) {
+	testcases := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{name: "no error", err: nil, expected: false},
+		{name: "other error", err: errors.New("failure"), expected: false},
+		{
+			name:     "postgres version check",
+			err:      fmt.Errorf("failed to append event: %w", &pq.Error{Code: "P0001", Message: "invalid stream version provided: 3, expected: 2"}),
+			expected: true,
+		},
+		{
+			name:     "postgres concurrent append",
+			err:      fmt.Errorf("failed to append event: %w", &pq.Error{Code: "23505", Constraint: "events_pkey"}),
+			expected: true,
+		},
+		{
+			name:     "postgres concurrent stream creation",
+			err:      fmt.Errorf("failed to append event: %w", &pq.Error{Code: "23505", Constraint: "streams_pkey"}),
+			expected: true,
+		},
+		{
+			name:     "postgres other unique violation",
+			err:      fmt.Errorf("failed to save key: %w", &pq.Error{Code: "23505", Constraint: "keys_pkey"}),
+			expected: false,
+		},
+		{
+			name:     "in-memory version check",
+			err:      fmt.Errorf("failed to commit: %w", errors.New("inmemory: invalid version check, expected 2")),
+			expected: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, concurrency.IsConflict(tc.err))
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package consumer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/account"
+	"github.com/eventually-rs/saving-goals-go/resources/messages"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+	"github.com/eventually-rs/eventually-go/command"
+	"github.com/segmentio/kafka-go"
+	"go.uber.org/zap"
+)
+
+type AccountClosed struct {
+	kafkaReader *kafka.Reader
+	deadLetter  *kafka.Writer
+	commandBus  command.Dispatcher
+	logger      *zap.Logger
+}
+
+func NewAccountClosed(
+	kafkaURL string,
+	commandBus command.Dispatcher,
+	logger *zap.Logger,
+) AccountClosed {
+	kafkaReader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers: []string{kafkaURL},
+		GroupID: "account-closed-consumer",
+		Topic:   "account-closed",
+	})
+
+	deadLetterWriter := kafka.NewWriter(kafka.WriterConfig{
+		Brokers: []string{kafkaURL},
+		Topic:   "saving-goals.account-closed.dead",
+	})
+
+	return AccountClosed{
+		kafkaReader: kafkaReader,
+		deadLetter:  deadLetterWriter,
+		commandBus:  commandBus,
+		logger:      logger,
+	}
+}
+
+func (c AccountClosed) Close() error { return c.kafkaReader.Close() }
+
+func (c AccountClosed) Start(ctx context.Context) error {
+	for {
+		msg, err := c.kafkaReader.FetchMessage(ctx)
+
+		if errors.Is(err, io.EOF) {
+			c.logger.Info("EOF received, closing consumer")
+			return nil
+		}
+
+		if err != nil {
+			return fmt.Errorf("consumer.AccountClosed: failed to read message from kafka: %w", err)
+		}
+
+		c.logger.Debug("Message received",
+			zap.Binary("key", msg.Key),
+			zap.Binary("value", msg.Value))
+
+		if err := c.handle(ctx, msg); err != nil {
+			c.logger.Warn("Failed to handle message", zap.Error(err))
+
+			err = c.deadLetter.WriteMessages(ctx, kafka.Message{
+				Key:   msg.Key,
+				Value: msg.Value,
+			})
+
+			if err != nil {
+				c.logger.Error("Failed to deadletter message", zap.Error(err))
+				continue
+			}
+		}
+
+		if err := c.kafkaReader.CommitMessages(ctx, msg); err != nil {
+			c.logger.Error("Failed to commit message", zap.Error(err))
+		}
+	}
+}
+
+func (c AccountClosed) handle(ctx context.Context, msg kafka.Message) error {
+	var message messages.AccountClosed
+
+	if err := proto.Unmarshal(msg.Value, &message); err != nil {
+		return fmt.Errorf("consumer.AccountClosed: failed to unmarshal message: %w", err)
+	}
+
+	err := c.commandBus.Dispatch(ctx, eventually.Command{
+		Payload: account.CloseAccount{
+			AccountID: aggregate.StringID(message.AccountId),
+			Reason:    message.Reason,
+			ClosedAt:  message.ClosedAt.AsTime(),
+		},
+	})
+
+	if err != nil {
+		return fmt.Errorf("consumer.AccountClosed: failed to dispatch command: %w", err)
+	}
+
+	return nil
+}
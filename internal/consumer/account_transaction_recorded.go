@@ -7,6 +7,7 @@ import (
 	"io"
 
 	"github.com/eventually-rs/saving-goals-go/internal/domain/account"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/transaction"
 	"github.com/eventually-rs/saving-goals-go/resources/messages"
 	"google.golang.org/protobuf/proto"
 
@@ -98,8 +99,15 @@ func (c AccountTransactionRecorded) handle(ctx context.Context, msg kafka.Messag
 
 	err := c.commandBus.Dispatch(ctx, eventually.Command{
 		Payload: account.RecordTransaction{
-			AccountID:  aggregate.StringID(message.AccountId),
-			Amount:     float64(message.Amount),
+			AccountID:     aggregate.StringID(message.AccountId),
+			TransactionID: message.TransactionId,
+			Amount:        float64(message.Amount),
+			Kind:          transactionKind(message.Kind),
+			Details: transaction.Details{
+				Merchant:    message.Merchant,
+				Description: message.Description,
+				MCC:         message.Mcc,
+			},
 			RecordedAt: message.RecordedAt.AsTime(),
 		},
 	})
@@ -110,3 +118,18 @@ func (c AccountTransactionRecorded) handle(ctx context.Context, msg kafka.Messag
 
 	return nil
 }
+
+func transactionKind(kind messages.TransactionKind) transaction.Kind {
+	switch kind {
+	case messages.TransactionKind_TRANSACTION_KIND_INCOME:
+		return transaction.Income
+	case messages.TransactionKind_TRANSACTION_KIND_EXPENSE:
+		return transaction.Expense
+	case messages.TransactionKind_TRANSACTION_KIND_INTERNAL_TRANSFER:
+		return transaction.InternalTransfer
+	case messages.TransactionKind_TRANSACTION_KIND_REFUND:
+		return transaction.Refund
+	default:
+		return transaction.Unspecified
+	}
+}
@@ -0,0 +1,125 @@
+package consumer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/savings"
+	"github.com/eventually-rs/saving-goals-go/resources/messages"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+	"github.com/eventually-rs/eventually-go/command"
+	"github.com/segmentio/kafka-go"
+	"go.uber.org/zap"
+)
+
+// ErrUnknownTransferStatus is returned when a SavingsTransferAcknowledged
+// message does not specify whether the transfer has been executed.
+var ErrUnknownTransferStatus = fmt.Errorf("consumer.SavingsTransferAcknowledged: unknown transfer status")
+
+type SavingsTransferAcknowledged struct {
+	kafkaReader *kafka.Reader
+	deadLetter  *kafka.Writer
+	commandBus  command.Dispatcher
+	logger      *zap.Logger
+}
+
+func NewSavingsTransferAcknowledged(
+	kafkaURL string,
+	commandBus command.Dispatcher,
+	logger *zap.Logger,
+) SavingsTransferAcknowledged {
+	kafkaReader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers: []string{kafkaURL},
+		GroupID: "savings-transfer-acknowledgements-consumer",
+		Topic:   "savings-transfer-acknowledgements",
+	})
+
+	deadLetterWriter := kafka.NewWriter(kafka.WriterConfig{
+		Brokers: []string{kafkaURL},
+		Topic:   "saving-goals.savings-transfer-acknowledgements.dead",
+	})
+
+	return SavingsTransferAcknowledged{
+		kafkaReader: kafkaReader,
+		deadLetter:  deadLetterWriter,
+		commandBus:  commandBus,
+		logger:      logger,
+	}
+}
+
+func (c SavingsTransferAcknowledged) Close() error { return c.kafkaReader.Close() }
+
+func (c SavingsTransferAcknowledged) Start(ctx context.Context) error {
+	for {
+		msg, err := c.kafkaReader.FetchMessage(ctx)
+
+		if errors.Is(err, io.EOF) {
+			c.logger.Info("EOF received, closing consumer")
+			return nil
+		}
+
+		if err != nil {
+			return fmt.Errorf("consumer.SavingsTransferAcknowledged: failed to read message from kafka: %w", err)
+		}
+
+		c.logger.Debug("Message received",
+			zap.Binary("key", msg.Key),
+			zap.Binary("value", msg.Value))
+
+		if err := c.handle(ctx, msg); err != nil {
+			c.logger.Warn("Failed to handle message", zap.Error(err))
+
+			err = c.deadLetter.WriteMessages(ctx, kafka.Message{
+				Key:   msg.Key,
+				Value: msg.Value,
+			})
+
+			if err != nil {
+				c.logger.Error("Failed to deadletter message", zap.Error(err))
+				continue
+			}
+		}
+
+		if err := c.kafkaReader.CommitMessages(ctx, msg); err != nil {
+			c.logger.Error("Failed to commit message", zap.Error(err))
+		}
+	}
+}
+
+func (c SavingsTransferAcknowledged) handle(ctx context.Context, msg kafka.Message) error {
+	var message messages.SavingsTransferAcknowledged
+
+	if err := proto.Unmarshal(msg.Value, &message); err != nil {
+		return fmt.Errorf("consumer.SavingsTransferAcknowledged: failed to unmarshal message: %w", err)
+	}
+
+	var confirmed bool
+
+	switch message.Status {
+	case messages.SavingsTransferStatus_SAVINGS_TRANSFER_STATUS_CONFIRMED:
+		confirmed = true
+	case messages.SavingsTransferStatus_SAVINGS_TRANSFER_STATUS_REJECTED:
+		confirmed = false
+	default:
+		return fmt.Errorf("%w: %s", ErrUnknownTransferStatus, message.Status)
+	}
+
+	err := c.commandBus.Dispatch(ctx, eventually.Command{
+		Payload: savings.AcknowledgeTransfer{
+			TransferID: aggregate.StringID(message.TransferId),
+			Confirmed:  confirmed,
+			Reason:     message.Reason,
+		},
+	})
+
+	if err != nil {
+		return fmt.Errorf("consumer.SavingsTransferAcknowledged: failed to dispatch command: %w", err)
+	}
+
+	return nil
+}
@@ -2,12 +2,19 @@ package account
 
 import (
 	"context"
+	"sort"
 	"sync"
 
 	"github.com/eventually-rs/eventually-go/eventstore"
 	"github.com/eventually-rs/eventually-go/projection"
 	"github.com/eventually-rs/eventually-go/query"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/category"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/goal"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/interval"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/pacing"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/recurring"
 	"github.com/eventually-rs/saving-goals-go/internal/domain/saving"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/sweep"
 )
 
 var _ projection.Projection = &WithSavingGoalsProjection{}
@@ -26,11 +33,44 @@ type WithSavingGoalsQuery struct {
 type WithSavingGoalsAnswer <-chan WithSavingGoal
 
 // WithSavingGoal represents a single Account projection,
-// containing its current Balance and its Saving Goal amount.
+// containing its current Balance, its Saving Goal amount,
+// its active named Goals, the Budgets set for its spending Categories,
+// the pacing Strategy of its monthly spending, the confirmed recurring
+// outflows already committed for the month and its sweep rules.
+//
+// SavingGoal is zero-valued if the Account has only named Goals:
+// use MonthlySavingGoal to get the amount to save in a specific month.
 type WithSavingGoal struct {
 	AccountID      string
 	CurrentBalance float64
 	SavingGoal     saving.Goal
+	Goals          []goal.Goal
+	Budgets        []saving.Budget
+	Pacing         pacing.Strategy
+	Commitments    []recurring.Series
+	SweepRules     []sweep.Rule
+}
+
+// MonthlySavingGoal returns the Saving Goal to track in the specified month,
+// where the amount to save is the sum of the Saving Goal amount and of the
+// monthly contributions required by all the active Goals.
+//
+// saving.DefaultThresholds are used if the Account has no Saving Goal set.
+func (a WithSavingGoal) MonthlySavingGoal(month interval.Month) saving.Goal {
+	result := saving.Goal{
+		Amount:     a.SavingGoal.Amount,
+		Thresholds: a.SavingGoal.Thresholds,
+	}
+
+	if len(result.Thresholds) == 0 {
+		result.Thresholds = saving.DefaultThresholds()
+	}
+
+	for _, g := range a.Goals {
+		result.Amount += g.RequiredMonthlyContribution(month)
+	}
+
+	return result
 }
 
 // WithSavingGoalsProjection listens to Account Domain Events to build
@@ -43,6 +83,12 @@ type WithSavingGoalsProjection struct {
 type withSavingGoalEntry struct {
 	balance    float64
 	savingGoal *saving.Goal
+	budgets    map[category.Category]saving.Budget
+	goals      map[string]goal.Goal
+	pacing     pacing.Strategy
+	series     map[string]recurring.Series
+	sweepRules []sweep.Rule
+	closed     bool
 }
 
 // NewWithSavingGoalsProjection returns a new instance of WithSavingGoalsProjection type.
@@ -62,7 +108,12 @@ func (p *WithSavingGoalsProjection) Apply(ctx context.Context, event eventstore.
 
 	switch evt := event.Payload.(type) {
 	case WasCreated:
-		p.accounts[evt.AccountID] = withSavingGoalEntry{}
+		p.accounts[evt.AccountID] = withSavingGoalEntry{
+			budgets: make(map[category.Category]saving.Budget),
+			goals:   make(map[string]goal.Goal),
+			pacing:  pacing.Linear,
+			series:  make(map[string]recurring.Series),
+		}
 
 	case SavingGoalWasChanged:
 		entry := p.accounts[event.StreamName]
@@ -74,6 +125,16 @@ func (p *WithSavingGoalsProjection) Apply(ctx context.Context, event eventstore.
 		entry.savingGoal.Thresholds = append(entry.savingGoal.Thresholds, evt.Threshold)
 		p.accounts[event.StreamName] = entry
 
+	case ThresholdWasRemoved:
+		entry := p.accounts[event.StreamName]
+		entry.savingGoal.Thresholds = withoutThreshold(entry.savingGoal.Thresholds, evt.Threshold)
+		p.accounts[event.StreamName] = entry
+
+	case ThresholdsWereReplaced:
+		entry := p.accounts[event.StreamName]
+		entry.savingGoal.Thresholds = append([]saving.Threshold{}, evt.Thresholds...)
+		p.accounts[event.StreamName] = entry
+
 	case SavingGoalWasDisabled:
 		entry := p.accounts[event.StreamName]
 		entry.savingGoal = nil
@@ -83,13 +144,54 @@ func (p *WithSavingGoalsProjection) Apply(ctx context.Context, event eventstore.
 		entry := p.accounts[event.StreamName]
 		entry.balance += evt.Amount
 		p.accounts[event.StreamName] = entry
+
+		applyRecurringSeriesEvent(entry.series, evt)
+
+	case RecurringSeriesDetected, RecurringSeriesConfirmed, RecurringSeriesDismissed:
+		if entry, ok := p.accounts[event.StreamName]; ok {
+			applyRecurringSeriesEvent(entry.series, evt)
+		}
+
+	case CategoryBudgetWasSet:
+		p.accounts[event.StreamName].budgets[evt.Budget.Category] = evt.Budget
+
+	case CategoryBudgetWasRemoved:
+		delete(p.accounts[event.StreamName].budgets, evt.Category)
+
+	case GoalWasAdded, GoalWasUpdated, GoalWasRemoved, GoalContributionWasRecorded, GoalWasAchieved:
+		if entry, ok := p.accounts[event.StreamName]; ok {
+			applyGoalEvent(entry.goals, evt)
+		}
+
+	case PacingStrategyWasChanged:
+		entry := p.accounts[event.StreamName]
+		entry.pacing = evt.Strategy
+		p.accounts[event.StreamName] = entry
+
+	case SweepRuleWasAdded, SweepRuleWasRemoved:
+		entry := p.accounts[event.StreamName]
+		entry.sweepRules = applySweepRuleEvent(entry.sweepRules, evt)
+		p.accounts[event.StreamName] = entry
+
+	case WasClosed:
+		entry := p.accounts[event.StreamName]
+		entry.closed = true
+		p.accounts[event.StreamName] = entry
+
+	case WasReopened:
+		entry := p.accounts[event.StreamName]
+		entry.closed = false
+		p.accounts[event.StreamName] = entry
+
+	case WasForgotten:
+		delete(p.accounts, evt.AccountID)
 	}
 
 	return nil
 }
 
 // Handle returns a channel containing the list of all Accounts that have set
-// a Saving Goal, if any.
+// a Saving Goal, if any, excluding the closed ones.
 //
 // The channel created is buffered following the input provided in
 // WithSavingGoalsQuery.
@@ -106,14 +208,28 @@ func (p *WithSavingGoalsProjection) Handle(ctx context.Context, query query.Quer
 		defer close(ch)
 
 		for id, entry := range p.accounts {
-			if entry.savingGoal == nil {
+			// Closed Accounts are not tracked anymore, until they are reopened.
+			if entry.closed {
+				continue
+			}
+
+			goals := activeGoals(entry.goals)
+			if entry.savingGoal == nil && len(goals) == 0 {
 				continue
 			}
 
 			item := WithSavingGoal{
 				AccountID:      id,
 				CurrentBalance: entry.balance,
-				SavingGoal:     *entry.savingGoal,
+				Goals:          goals,
+				Budgets:        sortedBudgets(entry.budgets),
+				Pacing:         entry.pacing,
+				Commitments:    committedOutflows(entry.series),
+				SweepRules:     entry.sweepRules,
+			}
+
+			if entry.savingGoal != nil {
+				item.SavingGoal = *entry.savingGoal
 			}
 
 			select {
@@ -130,3 +246,36 @@ func (p *WithSavingGoalsProjection) Handle(ctx context.Context, query query.Quer
 
 	return WithSavingGoalsAnswer(ch), nil
 }
+
+// sortedBudgets returns the Budgets in the map sorted by Category,
+// to have a predictable order.
+func sortedBudgets(budgets map[category.Category]saving.Budget) []saving.Budget {
+	if len(budgets) == 0 {
+		return nil
+	}
+
+	result := make([]saving.Budget, 0, len(budgets))
+	for _, budget := range budgets {
+		budget.Thresholds = append([]saving.Threshold{}, budget.Thresholds...)
+		result = append(result, budget)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Category < result[j].Category
+	})
+
+	return result
+}
+
+// activeGoals returns the active Goals in the map, sorted by priority.
+func activeGoals(goals map[string]goal.Goal) []goal.Goal {
+	var result []goal.Goal
+
+	for _, g := range sortedGoals(goals) {
+		if g.Status == goal.Active {
+			result = append(result, g)
+		}
+	}
+
+	return result
+}
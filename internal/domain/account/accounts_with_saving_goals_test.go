@@ -1,6 +1,172 @@
 package account_test
 
-import "testing"
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/account"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/goal"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/interval"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/pacing"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/saving"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/eventstore"
+	"github.com/stretchr/testify/assert"
+)
 
 func TestAccountsWithSavingGoals(t *testing.T) {
+	ctx := context.Background()
+	projection := account.NewWithSavingGoalsProjection()
+
+	events := []interface{}{
+		account.WasCreated{AccountID: "test-account"},
+		account.SavingGoalWasChanged{
+			SavingGoal: saving.Goal{
+				Amount:     500,
+				Thresholds: []saving.Threshold{saving.Percentage(0.5)},
+			},
+		},
+		account.ThresholdWasSet{Threshold: saving.Percentage(1)},
+		account.ThresholdWasRemoved{Threshold: saving.Percentage(0.5)},
+		account.ThresholdWasSet{Threshold: saving.SpentAbove(300)},
+		account.TransactionWasRecorded{Amount: 1000},
+		account.PacingStrategyWasChanged{Strategy: pacing.WeekdayWeighted},
+	}
+
+	for i, payload := range events {
+		err := projection.Apply(ctx, eventstore.Event{
+			StreamType: account.Type.Name(),
+			StreamName: "test-account",
+			Version:    int64(i) + 1,
+			Event:      eventually.Event{Payload: payload},
+		})
+
+		assert.NoError(t, err)
+	}
+
+	// Accounts without a Saving Goal are not returned.
+	assert.NoError(t, projection.Apply(ctx, eventstore.Event{
+		StreamType: account.Type.Name(),
+		StreamName: "other-account",
+		Version:    1,
+		Event:      eventually.Event{Payload: account.WasCreated{AccountID: "other-account"}},
+	}))
+
+	answer, err := projection.Handle(ctx, account.WithSavingGoalsQuery{})
+	assert.NoError(t, err)
+
+	var accounts []account.WithSavingGoal
+	for item := range answer.(account.WithSavingGoalsAnswer) {
+		accounts = append(accounts, item)
+	}
+
+	assert.Equal(t, []account.WithSavingGoal{
+		{
+			AccountID:      "test-account",
+			CurrentBalance: 1000,
+			SavingGoal: saving.Goal{
+				Amount:     500,
+				Thresholds: []saving.Threshold{saving.Percentage(1), saving.SpentAbove(300)},
+			},
+			Pacing: pacing.WeekdayWeighted,
+		},
+	}, accounts)
+}
+
+func TestAccountsWithSavingGoalsExcludesClosedAccounts(t *testing.T) {
+	ctx := context.Background()
+	projection := account.NewWithSavingGoalsProjection()
+
+	events := []interface{}{
+		account.WasCreated{AccountID: "test-account"},
+		account.SavingGoalWasChanged{
+			SavingGoal: saving.Goal{
+				Amount:     500,
+				Thresholds: []saving.Threshold{saving.Percentage(1)},
+			},
+		},
+		account.WasClosed{Reason: "requested by the owner"},
+	}
+
+	for i, payload := range events {
+		err := projection.Apply(ctx, eventstore.Event{
+			StreamType: account.Type.Name(),
+			StreamName: "test-account",
+			Version:    int64(i) + 1,
+			Event:      eventually.Event{Payload: payload},
+		})
+
+		assert.NoError(t, err)
+	}
+
+	answer, err := projection.Handle(ctx, account.WithSavingGoalsQuery{})
+	assert.NoError(t, err)
+	assert.Empty(t, collect(answer.(account.WithSavingGoalsAnswer)))
+
+	// Reopened Accounts are tracked again.
+	assert.NoError(t, projection.Apply(ctx, eventstore.Event{
+		StreamType: account.Type.Name(),
+		StreamName: "test-account",
+		Version:    int64(len(events)) + 1,
+		Event:      eventually.Event{Payload: account.WasReopened{}},
+	}))
+
+	answer, err = projection.Handle(ctx, account.WithSavingGoalsQuery{})
+	assert.NoError(t, err)
+	assert.Len(t, collect(answer.(account.WithSavingGoalsAnswer)), 1)
+}
+
+func collect(answer account.WithSavingGoalsAnswer) []account.WithSavingGoal {
+	var accounts []account.WithSavingGoal
+	for item := range answer {
+		accounts = append(accounts, item)
+	}
+
+	return accounts
+}
+
+func TestWithSavingGoalMonthlySavingGoal(t *testing.T) {
+	month := interval.Month{Year: 2021, Month: time.January}
+
+	holiday := goal.Goal{
+		ID:           "holiday",
+		Name:         "Holiday",
+		TargetAmount: 1200,
+		Deadline:     time.Date(2021, time.June, 30, 0, 0, 0, 0, time.UTC),
+		Status:       goal.Active,
+	}
+
+	emergencyFund := goal.Goal{
+		ID:                  "emergency-fund",
+		Name:                "Emergency fund",
+		TargetAmount:        10000,
+		MonthlyContribution: 300,
+		Status:              goal.Active,
+	}
+
+	t.Run("saving goal amount is summed to the goals contributions", func(t *testing.T) {
+		account := account.WithSavingGoal{
+			SavingGoal: saving.Goal{
+				Amount:     100,
+				Thresholds: []saving.Threshold{saving.Percentage(1)},
+			},
+			Goals: []goal.Goal{holiday, emergencyFund},
+		}
+
+		assert.Equal(t, saving.Goal{
+			Amount:     600,
+			Thresholds: []saving.Threshold{saving.Percentage(1)},
+		}, account.MonthlySavingGoal(month))
+	})
+
+	t.Run("default thresholds are used without a saving goal", func(t *testing.T) {
+		account := account.WithSavingGoal{Goals: []goal.Goal{holiday}}
+
+		assert.Equal(t, saving.Goal{
+			Amount:     200,
+			Thresholds: saving.DefaultThresholds(),
+		}, account.MonthlySavingGoal(month))
+	})
 }
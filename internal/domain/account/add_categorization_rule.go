@@ -0,0 +1,49 @@
+package account
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/category"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+	"github.com/eventually-rs/eventually-go/command"
+)
+
+// AddCategorizationRule is the Domain Command used to add a new rule
+// to categorize the transactions of an Account.
+type AddCategorizationRule struct {
+	AccountID aggregate.StringID
+	Rule      category.Rule
+}
+
+// AddCategorizationRuleCommandHandler is the Command Handler for AddCategorizationRule commands.
+type AddCategorizationRuleCommandHandler struct {
+	Repository *aggregate.Repository
+}
+
+// CommandType returns an AddCategorizationRule instance to bind to this Handler.
+func (AddCategorizationRuleCommandHandler) CommandType() command.Command {
+	return AddCategorizationRule{}
+}
+
+// Handle adds the categorization rule specified in the Command to the Account.
+func (h AddCategorizationRuleCommandHandler) Handle(ctx context.Context, cmd eventually.Command) error {
+	command := cmd.Payload.(AddCategorizationRule)
+
+	account, err := h.Repository.Get(ctx, command.AccountID)
+	if err != nil {
+		return fmt.Errorf("account.AddCategorizationRuleCommandHandler: failed to get account: %w", err)
+	}
+
+	if err := account.(*Account).AddCategorizationRule(command.Rule); err != nil {
+		return fmt.Errorf("account.AddCategorizationRuleCommandHandler: failed to add rule: %w", err)
+	}
+
+	if err := h.Repository.Add(ctx, account); err != nil {
+		return fmt.Errorf("account.AddCategorizationRuleCommandHandler: failed to save new account state: %w", err)
+	}
+
+	return nil
+}
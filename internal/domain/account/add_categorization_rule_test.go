@@ -0,0 +1,111 @@
+package account_test
+
+import (
+	"testing"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/account"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/category"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+	"github.com/eventually-rs/eventually-go/command"
+	"github.com/eventually-rs/eventually-go/eventstore"
+	"github.com/eventually-rs/eventually-go/scenario"
+)
+
+func TestAddCategorizationRule(t *testing.T) {
+	groceries := category.Rule{
+		ID:       "groceries",
+		Category: "groceries",
+		MCC:      "5411",
+	}
+
+	accountCreated := eventstore.Event{
+		StreamType: account.Type.Name(),
+		StreamName: "test-account",
+		Version:    1,
+		Event: eventually.Event{
+			Payload: account.WasCreated{AccountID: "test-account"},
+		},
+	}
+
+	t.Run("command fails when the account specified in the command does not exist", func(t *testing.T) {
+		scenario.
+			CommandHandler().
+			When(eventually.Command{
+				Payload: account.AddCategorizationRule{
+					AccountID: "test-account",
+					Rule:      groceries,
+				},
+			}).
+			ThenFails().
+			Using(t, account.Type, func(r *aggregate.Repository) command.Handler {
+				return account.AddCategorizationRuleCommandHandler{Repository: r}
+			})
+	})
+
+	t.Run("command fails when the rule has no matching criteria", func(t *testing.T) {
+		scenario.
+			CommandHandler().
+			Given(accountCreated).
+			When(eventually.Command{
+				Payload: account.AddCategorizationRule{
+					AccountID: "test-account",
+					Rule: category.Rule{
+						ID:       "everything",
+						Category: "groceries",
+					},
+				},
+			}).
+			ThenError(category.ErrNoCriteria).
+			Using(t, account.Type, func(r *aggregate.Repository) command.Handler {
+				return account.AddCategorizationRuleCommandHandler{Repository: r}
+			})
+	})
+
+	t.Run("command fails when a rule with the same id already exists", func(t *testing.T) {
+		scenario.
+			CommandHandler().
+			Given(accountCreated, eventstore.Event{
+				StreamType: account.Type.Name(),
+				StreamName: "test-account",
+				Version:    2,
+				Event: eventually.Event{
+					Payload: account.CategorizationRuleWasAdded{Rule: groceries},
+				},
+			}).
+			When(eventually.Command{
+				Payload: account.AddCategorizationRule{
+					AccountID: "test-account",
+					Rule:      groceries,
+				},
+			}).
+			ThenError(account.ErrRuleAlreadyExists).
+			Using(t, account.Type, func(r *aggregate.Repository) command.Handler {
+				return account.AddCategorizationRuleCommandHandler{Repository: r}
+			})
+	})
+
+	t.Run("new rule is added to an existing account", func(t *testing.T) {
+		scenario.
+			CommandHandler().
+			Given(accountCreated).
+			When(eventually.Command{
+				Payload: account.AddCategorizationRule{
+					AccountID: "test-account",
+					Rule:      groceries,
+				},
+			}).
+			Then(eventstore.Event{
+				StreamType: account.Type.Name(),
+				StreamName: "test-account",
+				Version:    2,
+				Event: eventually.Event{
+					Payload: account.CategorizationRuleWasAdded{Rule: groceries},
+				},
+			}).
+			Using(t, account.Type, func(r *aggregate.Repository) command.Handler {
+				return account.AddCategorizationRuleCommandHandler{Repository: r}
+			})
+	})
+}
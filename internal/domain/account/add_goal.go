@@ -0,0 +1,46 @@
+package account
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/goal"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+	"github.com/eventually-rs/eventually-go/command"
+)
+
+// AddGoal is the Domain Command used to add a new named Goal to an Account.
+type AddGoal struct {
+	AccountID aggregate.StringID
+	Goal      goal.Goal
+}
+
+// AddGoalCommandHandler is the Command Handler for AddGoal commands.
+type AddGoalCommandHandler struct {
+	Repository *aggregate.Repository
+}
+
+// CommandType returns a AddGoal instance to bind to this Handler.
+func (AddGoalCommandHandler) CommandType() command.Command { return AddGoal{} }
+
+// Handle adds the Goal specified in the Command to the Account.
+func (h AddGoalCommandHandler) Handle(ctx context.Context, cmd eventually.Command) error {
+	command := cmd.Payload.(AddGoal)
+
+	account, err := h.Repository.Get(ctx, command.AccountID)
+	if err != nil {
+		return fmt.Errorf("account.AddGoalCommandHandler: failed to get account: %w", err)
+	}
+
+	if err := account.(*Account).AddGoal(command.Goal); err != nil {
+		return fmt.Errorf("account.AddGoalCommandHandler: failed to add goal: %w", err)
+	}
+
+	if err := h.Repository.Add(ctx, account); err != nil {
+		return fmt.Errorf("account.AddGoalCommandHandler: failed to save new account state: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,114 @@
+package account_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/account"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/goal"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+	"github.com/eventually-rs/eventually-go/command"
+	"github.com/eventually-rs/eventually-go/eventstore"
+	"github.com/eventually-rs/eventually-go/scenario"
+)
+
+func TestAddGoal(t *testing.T) {
+	holiday := goal.Goal{
+		ID:           "holiday",
+		Name:         "Holiday",
+		TargetAmount: 2000,
+		Deadline:     time.Date(2021, time.June, 30, 0, 0, 0, 0, time.UTC),
+	}
+
+	accountWasCreated := eventstore.Event{
+		StreamType: account.Type.Name(),
+		StreamName: "test-account",
+		Version:    1,
+		Event: eventually.Event{
+			Payload: account.WasCreated{AccountID: "test-account"},
+		},
+	}
+
+	t.Run("command fails when the goal has no identifier", func(t *testing.T) {
+		scenario.
+			CommandHandler().
+			Given(accountWasCreated).
+			When(eventually.Command{
+				Payload: account.AddGoal{
+					AccountID: "test-account",
+					Goal:      goal.Goal{Name: "Holiday", TargetAmount: 2000, Deadline: holiday.Deadline},
+				},
+			}).
+			ThenError(account.ErrNoGoalID).
+			Using(t, account.Type, func(r *aggregate.Repository) command.Handler {
+				return account.AddGoalCommandHandler{Repository: r}
+			})
+	})
+
+	t.Run("command fails when the goal is not valid", func(t *testing.T) {
+		scenario.
+			CommandHandler().
+			Given(accountWasCreated).
+			When(eventually.Command{
+				Payload: account.AddGoal{
+					AccountID: "test-account",
+					Goal:      goal.Goal{ID: "emergency-fund", Name: "Emergency fund", TargetAmount: 10000},
+				},
+			}).
+			ThenError(goal.ErrNoContribution).
+			Using(t, account.Type, func(r *aggregate.Repository) command.Handler {
+				return account.AddGoalCommandHandler{Repository: r}
+			})
+	})
+
+	t.Run("command fails when the goal already exists", func(t *testing.T) {
+		scenario.
+			CommandHandler().
+			Given(accountWasCreated, eventstore.Event{
+				StreamType: account.Type.Name(),
+				StreamName: "test-account",
+				Version:    2,
+				Event: eventually.Event{
+					Payload: account.GoalWasAdded{Goal: holiday},
+				},
+			}).
+			When(eventually.Command{
+				Payload: account.AddGoal{
+					AccountID: "test-account",
+					Goal:      holiday,
+				},
+			}).
+			ThenError(account.ErrGoalAlreadyExists).
+			Using(t, account.Type, func(r *aggregate.Repository) command.Handler {
+				return account.AddGoalCommandHandler{Repository: r}
+			})
+	})
+
+	t.Run("new goal is added as active", func(t *testing.T) {
+		expected := holiday
+		expected.Status = goal.Active
+
+		scenario.
+			CommandHandler().
+			Given(accountWasCreated).
+			When(eventually.Command{
+				Payload: account.AddGoal{
+					AccountID: "test-account",
+					Goal:      holiday,
+				},
+			}).
+			Then(eventstore.Event{
+				StreamType: account.Type.Name(),
+				StreamName: "test-account",
+				Version:    2,
+				Event: eventually.Event{
+					Payload: account.GoalWasAdded{Goal: expected},
+				},
+			}).
+			Using(t, account.Type, func(r *aggregate.Repository) command.Handler {
+				return account.AddGoalCommandHandler{Repository: r}
+			})
+	})
+}
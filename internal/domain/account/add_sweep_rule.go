@@ -0,0 +1,49 @@
+package account
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/sweep"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+	"github.com/eventually-rs/eventually-go/command"
+)
+
+// AddSweepRule is the Domain Command used to add a new rule
+// to automatically save money for one of the Account's Goals.
+type AddSweepRule struct {
+	AccountID aggregate.StringID
+	Rule      sweep.Rule
+}
+
+// AddSweepRuleCommandHandler is the Command Handler for AddSweepRule commands.
+type AddSweepRuleCommandHandler struct {
+	Repository *aggregate.Repository
+}
+
+// CommandType returns an AddSweepRule instance to bind to this Handler.
+func (AddSweepRuleCommandHandler) CommandType() command.Command {
+	return AddSweepRule{}
+}
+
+// Handle adds the sweep rule specified in the Command to the Account.
+func (h AddSweepRuleCommandHandler) Handle(ctx context.Context, cmd eventually.Command) error {
+	command := cmd.Payload.(AddSweepRule)
+
+	account, err := h.Repository.Get(ctx, command.AccountID)
+	if err != nil {
+		return fmt.Errorf("account.AddSweepRuleCommandHandler: failed to get account: %w", err)
+	}
+
+	if err := account.(*Account).AddSweepRule(command.Rule); err != nil {
+		return fmt.Errorf("account.AddSweepRuleCommandHandler: failed to add rule: %w", err)
+	}
+
+	if err := h.Repository.Add(ctx, account); err != nil {
+		return fmt.Errorf("account.AddSweepRuleCommandHandler: failed to save new account state: %w", err)
+	}
+
+	return nil
+}
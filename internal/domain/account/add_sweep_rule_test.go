@@ -0,0 +1,147 @@
+package account_test
+
+import (
+	"testing"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/account"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/goal"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/sweep"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+	"github.com/eventually-rs/eventually-go/command"
+	"github.com/eventually-rs/eventually-go/eventstore"
+	"github.com/eventually-rs/eventually-go/scenario"
+)
+
+func TestAddSweepRule(t *testing.T) {
+	roundUp := sweep.Rule{
+		ID:     "round-up",
+		Kind:   sweep.RoundUp,
+		GoalID: "holiday",
+		Unit:   1,
+	}
+
+	accountCreated := eventstore.Event{
+		StreamType: account.Type.Name(),
+		StreamName: "test-account",
+		Version:    1,
+		Event: eventually.Event{
+			Payload: account.WasCreated{AccountID: "test-account"},
+		},
+	}
+
+	goalAdded := eventstore.Event{
+		StreamType: account.Type.Name(),
+		StreamName: "test-account",
+		Version:    2,
+		Event: eventually.Event{
+			Payload: account.GoalWasAdded{
+				Goal: goal.Goal{
+					ID:                  "holiday",
+					Name:                "Holiday",
+					TargetAmount:        1200,
+					MonthlyContribution: 100,
+					Status:              goal.Active,
+				},
+			},
+		},
+	}
+
+	t.Run("command fails when the account specified in the command does not exist", func(t *testing.T) {
+		scenario.
+			CommandHandler().
+			When(eventually.Command{
+				Payload: account.AddSweepRule{
+					AccountID: "test-account",
+					Rule:      roundUp,
+				},
+			}).
+			ThenFails().
+			Using(t, account.Type, func(r *aggregate.Repository) command.Handler {
+				return account.AddSweepRuleCommandHandler{Repository: r}
+			})
+	})
+
+	t.Run("command fails when the rule is not valid", func(t *testing.T) {
+		scenario.
+			CommandHandler().
+			Given(accountCreated, goalAdded).
+			When(eventually.Command{
+				Payload: account.AddSweepRule{
+					AccountID: "test-account",
+					Rule: sweep.Rule{
+						ID:     "round-up",
+						Kind:   sweep.RoundUp,
+						GoalID: "holiday",
+					},
+				},
+			}).
+			ThenError(sweep.ErrInvalidUnit).
+			Using(t, account.Type, func(r *aggregate.Repository) command.Handler {
+				return account.AddSweepRuleCommandHandler{Repository: r}
+			})
+	})
+
+	t.Run("command fails when the goal does not exist", func(t *testing.T) {
+		scenario.
+			CommandHandler().
+			Given(accountCreated).
+			When(eventually.Command{
+				Payload: account.AddSweepRule{
+					AccountID: "test-account",
+					Rule:      roundUp,
+				},
+			}).
+			ThenError(account.ErrGoalNotFound).
+			Using(t, account.Type, func(r *aggregate.Repository) command.Handler {
+				return account.AddSweepRuleCommandHandler{Repository: r}
+			})
+	})
+
+	t.Run("command fails when a rule with the same id already exists", func(t *testing.T) {
+		scenario.
+			CommandHandler().
+			Given(accountCreated, goalAdded, eventstore.Event{
+				StreamType: account.Type.Name(),
+				StreamName: "test-account",
+				Version:    3,
+				Event: eventually.Event{
+					Payload: account.SweepRuleWasAdded{Rule: roundUp},
+				},
+			}).
+			When(eventually.Command{
+				Payload: account.AddSweepRule{
+					AccountID: "test-account",
+					Rule:      roundUp,
+				},
+			}).
+			ThenError(account.ErrSweepRuleAlreadyExists).
+			Using(t, account.Type, func(r *aggregate.Repository) command.Handler {
+				return account.AddSweepRuleCommandHandler{Repository: r}
+			})
+	})
+
+	t.Run("new rule is added to an existing account", func(t *testing.T) {
+		scenario.
+			CommandHandler().
+			Given(accountCreated, goalAdded).
+			When(eventually.Command{
+				Payload: account.AddSweepRule{
+					AccountID: "test-account",
+					Rule:      roundUp,
+				},
+			}).
+			Then(eventstore.Event{
+				StreamType: account.Type.Name(),
+				StreamName: "test-account",
+				Version:    3,
+				Event: eventually.Event{
+					Payload: account.SweepRuleWasAdded{Rule: roundUp},
+				},
+			}).
+			Using(t, account.Type, func(r *aggregate.Repository) command.Handler {
+				return account.AddSweepRuleCommandHandler{Repository: r}
+			})
+	})
+}
@@ -4,7 +4,13 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/eventually-rs/saving-goals-go/internal/domain/category"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/goal"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/pacing"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/recurring"
 	"github.com/eventually-rs/saving-goals-go/internal/domain/saving"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/sweep"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/transaction"
 
 	"github.com/eventually-rs/eventually-go"
 	"github.com/eventually-rs/eventually-go/aggregate"
@@ -39,9 +45,33 @@ var Type = aggregate.NewType("account", func() aggregate.Root {
 type Account struct {
 	aggregate.BaseRoot
 
-	accountID  aggregate.StringID
-	balance    float64
-	savingGoal *saving.Goal
+	accountID           aggregate.StringID
+	balance             float64
+	savingGoal          *saving.Goal
+	categorizationRules []category.CompiledRule
+	transactions        map[string]recordedTransaction
+	budgets             map[category.Category]saving.Budget
+	goals               map[string]goal.Goal
+	pacing              pacing.Strategy
+	series              map[string]recurring.Series
+	sweepRules          []sweep.Rule
+	status              Status
+	forgotten           bool
+}
+
+// transactionsRetention is how long the transactions are kept in the Account's
+// state after newer ones have been recorded: long enough to detect monthly
+// recurring series, and to recategorize the transactions of the last months.
+const transactionsRetention = 93 * 24 * time.Hour
+
+// recordedTransaction is a transaction recorded with an identifier,
+// which can be categorized again later on, until older than transactionsRetention.
+type recordedTransaction struct {
+	amount     float64
+	kind       transaction.Kind
+	details    transaction.Details
+	happenedAt time.Time
+	category   category.Category
 }
 
 // AggregateID returns the accountId of the Account Aggregate.
@@ -66,15 +96,29 @@ type SavingGoalWasDisabled struct{}
 // ThresholdWasSet is the Domain Event triggered by the Aggregate
 // when setting a new Threshold for the Account's Saving Goal.
 type ThresholdWasSet struct {
-	Threshold float64
+	Threshold saving.Threshold
 }
 
 // TransactionWasRecorded is the Domain Event triggered by the Aggregate
 // when a new transaction involving the Account has taken place, modifying
 // the Account's Balance.
+//
+// Kind is always specified since the second version of the Event:
+// the Events recorded before the classification was introduced
+// are classified with transaction.Classify when read.
+//
+// TransactionID might be empty for transactions recorded before
+// the categorization was introduced.
+//
+// SeriesID is the identifier of the recurring series the transaction
+// belongs to, if any.
 type TransactionWasRecorded struct {
-	Amount     float64
-	HappenedAt time.Time
+	TransactionID string
+	Amount        float64
+	Kind          transaction.Kind
+	Details       transaction.Details
+	HappenedAt    time.Time
+	SeriesID      string
 }
 
 // Apply applies the Domain Event received onto the Aggregate Root
@@ -85,6 +129,13 @@ func (a *Account) Apply(event eventually.Event) error {
 		a.accountID = aggregate.StringID(evt.AccountID)
 		a.balance = 0
 		a.savingGoal = nil
+		a.transactions = make(map[string]recordedTransaction)
+		a.budgets = make(map[category.Category]saving.Budget)
+		a.goals = make(map[string]goal.Goal)
+		a.pacing = pacing.Linear
+		a.series = make(map[string]recurring.Series)
+		a.sweepRules = nil
+		a.status = Open
 
 	case SavingGoalWasChanged:
 		a.savingGoal = &evt.SavingGoal
@@ -92,9 +143,80 @@ func (a *Account) Apply(event eventually.Event) error {
 	case ThresholdWasSet:
 		a.savingGoal.Thresholds = append(a.savingGoal.Thresholds, evt.Threshold)
 
+	case ThresholdWasRemoved:
+		a.savingGoal.Thresholds = withoutThreshold(a.savingGoal.Thresholds, evt.Threshold)
+
+	case ThresholdsWereReplaced:
+		a.savingGoal.Thresholds = append([]saving.Threshold{}, evt.Thresholds...)
+
 	case TransactionWasRecorded:
 		a.balance += evt.Amount
 
+		if evt.TransactionID != "" {
+			a.transactions[evt.TransactionID] = recordedTransaction{
+				amount:     evt.Amount,
+				kind:       transaction.Classify(evt.Kind, evt.Amount),
+				details:    evt.Details,
+				happenedAt: evt.HappenedAt,
+			}
+		}
+
+		a.pruneTransactions(evt.HappenedAt)
+		applyRecurringSeriesEvent(a.series, evt)
+
+	case CategorizationRuleWasAdded:
+		a.categorizationRules = append(a.categorizationRules, evt.Rule.Compile())
+
+	case CategorizationRuleWasRemoved:
+		rules := make([]category.CompiledRule, 0, len(a.categorizationRules))
+		for _, rule := range a.categorizationRules {
+			if rule.ID != evt.RuleID {
+				rules = append(rules, rule)
+			}
+		}
+
+		a.categorizationRules = rules
+
+	case TransactionWasCategorized:
+		tx := a.transactions[evt.TransactionID]
+		tx.category = evt.Category
+		a.transactions[evt.TransactionID] = tx
+
+	case CategoryBudgetWasSet:
+		a.budgets[evt.Budget.Category] = evt.Budget
+
+	case CategoryBudgetWasRemoved:
+		delete(a.budgets, evt.Category)
+
+	case GoalWasAdded, GoalWasUpdated, GoalWasRemoved, GoalContributionWasRecorded, GoalWasAchieved:
+		applyGoalEvent(a.goals, evt)
+
+	case PacingStrategyWasChanged:
+		a.pacing = evt.Strategy
+
+	case RecurringSeriesDetected, RecurringSeriesConfirmed, RecurringSeriesDismissed:
+		applyRecurringSeriesEvent(a.series, evt)
+
+	case SweepRuleWasAdded, SweepRuleWasRemoved:
+		a.sweepRules = applySweepRuleEvent(a.sweepRules, evt)
+
+	case SweepWasTriggered:
+
+	case WasFrozen:
+		a.status = Frozen
+
+	case WasClosed:
+		a.status = Closed
+
+	case WasReopened:
+		a.status = Open
+
+	case WasForgotten:
+		a.forgotten = true
+
+	case Snapshot:
+		a.restore(evt)
+
 	default:
 		return fmt.Errorf("account: unsupported event received")
 	}
@@ -121,9 +243,14 @@ func Create(accountID string) (*Account, error) {
 
 // ChangeSavingGoal changes the Account's Saving Goal with the specified one.
 //
-// An error is returned if no thresholds have been specified in the
-// new Saving goal, or if the Saving Goal target amount is zero.
+// An error is returned if the Account is not open, if no thresholds have been
+// specified in the new Saving goal, if any of the thresholds is not valid,
+// or if the Saving Goal target amount is zero.
 func (a *Account) ChangeSavingGoal(goal saving.Goal) error {
+	if err := a.ensureGoalsCanChange(); err != nil {
+		return fmt.Errorf("account.ChangeSavingGoal: %w", err)
+	}
+
 	if len(goal.Thresholds) < 1 {
 		return ErrAtLeastOneThreshold
 	}
@@ -132,6 +259,12 @@ func (a *Account) ChangeSavingGoal(goal saving.Goal) error {
 		return ErrGoalIsZero
 	}
 
+	for _, threshold := range goal.Thresholds {
+		if err := threshold.Validate(); err != nil {
+			return fmt.Errorf("account.ChangeSavingGoal: %w", err)
+		}
+	}
+
 	err := aggregate.RecordThat(a, eventually.Event{
 		Payload: SavingGoalWasChanged{SavingGoal: goal},
 	})
@@ -146,7 +279,13 @@ func (a *Account) ChangeSavingGoal(goal saving.Goal) error {
 // DisableSavingGoal disabled the Account's Saving Goal previously set.
 //
 // ErrNoSavingGoal is returned if no Saving Goal was previously set on the Account.
+//
+// ErrAccountClosed or ErrAccountFrozen is returned if the Account is not open.
 func (a *Account) DisableSavingGoal(goal saving.Goal) error {
+	if err := a.ensureGoalsCanChange(); err != nil {
+		return fmt.Errorf("account.DisableSavingGoal: %w", err)
+	}
+
 	if a.savingGoal == nil {
 		return fmt.Errorf("account.DisableSavingGoal: %w", ErrNoSavingGoal)
 	}
@@ -168,11 +307,23 @@ func (a *Account) DisableSavingGoal(goal saving.Goal) error {
 //
 // ErrThresholdAlreadyExists is returned if the Account's Saving Goal already
 // has the very same threshold set.
-func (a *Account) SetNewThreshold(threshold float64) error {
+//
+// saving.ErrInvalidThreshold is returned if the threshold is not valid.
+//
+// ErrAccountClosed or ErrAccountFrozen is returned if the Account is not open.
+func (a *Account) SetNewThreshold(threshold saving.Threshold) error {
+	if err := a.ensureGoalsCanChange(); err != nil {
+		return fmt.Errorf("account.SetNewThreshold: %w", err)
+	}
+
 	if a.savingGoal == nil {
 		return ErrNoSavingGoal
 	}
 
+	if err := threshold.Validate(); err != nil {
+		return fmt.Errorf("account.SetNewThreshold: %w", err)
+	}
+
 	for _, th := range a.savingGoal.Thresholds {
 		if th == threshold {
 			return ErrThresholdAlreadyExists
@@ -190,13 +341,63 @@ func (a *Account) SetNewThreshold(threshold float64) error {
 	return nil
 }
 
-// RecordTransaction records a new transaction of the specified amount,
+// pruneTransactions forgets the transactions that happened more than
+// transactionsRetention before the specified time, so that the Account's
+// state does not grow with every transaction ever recorded.
+//
+// Transactions recorded with no time are forgotten too, as their age is unknown.
+func (a *Account) pruneTransactions(latest time.Time) {
+	if latest.IsZero() {
+		return
+	}
+
+	cutoff := latest.Add(-transactionsRetention)
+
+	for id, tx := range a.transactions {
+		if tx.happenedAt.Before(cutoff) {
+			delete(a.transactions, id)
+		}
+	}
+}
+
+// RecordTransaction records a new transaction of the specified amount and kind,
 // updating the Account's balance accordingly.
-func (a *Account) RecordTransaction(amount float64, happenedAt time.Time) error {
+//
+// If no kind is specified, the transaction is classified using the sign of the amount.
+//
+// Transactions recorded with an identifier are also categorized using
+// the Account's categorization rules, if any matches.
+//
+// Transactions are matched with the recurring series already detected: if they do
+// not belong to any, they are used to detect new recurring series.
+//
+// Finally, the Account's sweep rules are applied to the transactions
+// recorded with an identifier, see SweepWasTriggered.
+//
+// ErrAccountClosed is returned if the Account has been closed, while
+// transactions of frozen Accounts are still recorded.
+func (a *Account) RecordTransaction(
+	transactionID string,
+	amount float64,
+	kind transaction.Kind,
+	details transaction.Details,
+	happenedAt time.Time,
+) error {
+	if a.status == Closed {
+		return fmt.Errorf("account.RecordTransaction: %w", ErrAccountClosed)
+	}
+
+	kind = transaction.Classify(kind, amount)
+	seriesID := a.matchingSeries(amount, kind, details)
+
 	err := aggregate.RecordThat(a, eventually.Event{
 		Payload: TransactionWasRecorded{
-			Amount:     amount,
-			HappenedAt: happenedAt,
+			TransactionID: transactionID,
+			Amount:        amount,
+			Kind:          kind,
+			Details:       details,
+			HappenedAt:    happenedAt,
+			SeriesID:      seriesID,
 		},
 	})
 
@@ -204,5 +405,23 @@ func (a *Account) RecordTransaction(amount float64, happenedAt time.Time) error
 		return fmt.Errorf("account.RecordTransaction: failed to record domain even: %w", err)
 	}
 
+	if transactionID == "" {
+		return nil
+	}
+
+	if _, err := a.categorize(transactionID); err != nil {
+		return fmt.Errorf("account.RecordTransaction: %w", err)
+	}
+
+	if seriesID == "" {
+		if err := a.detectRecurringSeries(transactionID); err != nil {
+			return fmt.Errorf("account.RecordTransaction: %w", err)
+		}
+	}
+
+	if err := a.sweep(transactionID); err != nil {
+		return fmt.Errorf("account.RecordTransaction: %w", err)
+	}
+
 	return nil
 }
@@ -0,0 +1,95 @@
+package account
+
+import (
+	"fmt"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/category"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/saving"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+)
+
+var (
+	// ErrBudgetWithoutCategory is returned when setting a Category Budget
+	// without specifying the Category it refers to.
+	ErrBudgetWithoutCategory = fmt.Errorf("account.SetCategoryBudget: budget category should be specified")
+
+	// ErrBudgetIsZero is returned when setting a Category Budget with
+	// an amount that is not more than zero.
+	ErrBudgetIsZero = fmt.Errorf("account.SetCategoryBudget: budget amount should be more than zero")
+
+	// ErrBudgetNotFound is returned when removing a Category Budget
+	// that was not set before.
+	ErrBudgetNotFound = fmt.Errorf("account.RemoveCategoryBudget: budget not found")
+)
+
+// CategoryBudgetWasSet is the Domain Event triggered by the Aggregate
+// when a Budget for a specific Category has been set, or changed.
+type CategoryBudgetWasSet struct {
+	Budget saving.Budget
+}
+
+// CategoryBudgetWasRemoved is the Domain Event triggered by the Aggregate
+// when the Budget for a specific Category has been removed.
+type CategoryBudgetWasRemoved struct {
+	Category category.Category
+}
+
+// SetCategoryBudget sets the Budget for a specific Category, replacing
+// the previous Budget for the same Category, if any.
+//
+// Category Budgets are tracked in the monthly spending alongside the
+// Saving Goal, starting from the next month.
+//
+// An error is returned if no Category or thresholds have been specified,
+// if any of the thresholds is not valid, or if the Budget amount
+// is not more than zero.
+func (a *Account) SetCategoryBudget(budget saving.Budget) error {
+	if budget.Category == category.Uncategorized {
+		return ErrBudgetWithoutCategory
+	}
+
+	if budget.Amount <= 0 {
+		return ErrBudgetIsZero
+	}
+
+	if len(budget.Thresholds) < 1 {
+		return fmt.Errorf("account.SetCategoryBudget: %w", ErrAtLeastOneThreshold)
+	}
+
+	for _, threshold := range budget.Thresholds {
+		if err := threshold.Validate(); err != nil {
+			return fmt.Errorf("account.SetCategoryBudget: %w", err)
+		}
+	}
+
+	err := aggregate.RecordThat(a, eventually.Event{
+		Payload: CategoryBudgetWasSet{Budget: budget},
+	})
+
+	if err != nil {
+		return fmt.Errorf("account.SetCategoryBudget: failed to record domain event: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveCategoryBudget removes the Budget previously set for the specified Category.
+//
+// ErrBudgetNotFound is returned if no Budget was set for the Category.
+func (a *Account) RemoveCategoryBudget(c category.Category) error {
+	if _, ok := a.budgets[c]; !ok {
+		return ErrBudgetNotFound
+	}
+
+	err := aggregate.RecordThat(a, eventually.Event{
+		Payload: CategoryBudgetWasRemoved{Category: c},
+	})
+
+	if err != nil {
+		return fmt.Errorf("account.RemoveCategoryBudget: failed to record domain event: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,208 @@
+package account
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/category"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/transaction"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+)
+
+var (
+	// ErrNoRuleID is returned when adding a new categorization rule
+	// without specifying its identifier.
+	ErrNoRuleID = fmt.Errorf("account.AddCategorizationRule: rule id should be specified")
+
+	// ErrRuleAlreadyExists is returned when adding a new categorization rule
+	// with the same identifier of an existing one.
+	ErrRuleAlreadyExists = fmt.Errorf("account.AddCategorizationRule: rule already exists")
+
+	// ErrRuleNotFound is returned when removing a categorization rule
+	// that does not exist.
+	ErrRuleNotFound = fmt.Errorf("account.RemoveCategorizationRule: rule not found")
+
+	// ErrTransactionNotFound is returned when recategorizing a transaction
+	// that was not recorded on the Account, or that was recorded without
+	// an identifier.
+	ErrTransactionNotFound = fmt.Errorf("account.RecategorizeTransactions: transaction not found")
+)
+
+// CategorizationRuleWasAdded is the Domain Event triggered by the Aggregate
+// when a new categorization rule has been added to the Account.
+type CategorizationRuleWasAdded struct {
+	Rule category.Rule
+}
+
+// CategorizationRuleWasRemoved is the Domain Event triggered by the Aggregate
+// when a categorization rule has been removed from the Account.
+type CategorizationRuleWasRemoved struct {
+	RuleID string
+}
+
+// TransactionWasCategorized is the Domain Event triggered by the Aggregate
+// when a category has been assigned to a recorded transaction,
+// or when the category of a transaction has changed.
+//
+// The Event also carries the transaction amount, kind and time, so that
+// the spending of the transaction can be moved between categories
+// without having to look up the original transaction.
+type TransactionWasCategorized struct {
+	TransactionID    string
+	Category         category.Category
+	PreviousCategory category.Category
+
+	// RuleID is the identifier of the rule that matched the transaction,
+	// empty if the transaction is now uncategorized.
+	RuleID string
+
+	Amount     float64
+	Kind       transaction.Kind
+	HappenedAt time.Time
+}
+
+// AddCategorizationRule adds a new rule to categorize the Account's transactions.
+//
+// Rules are only applied to new transactions: use RecategorizeTransactions
+// to apply them to transactions already recorded.
+//
+// An error is returned if the rule is not valid, or if a rule with
+// the same identifier already exists.
+func (a *Account) AddCategorizationRule(rule category.Rule) error {
+	if rule.ID == "" {
+		return ErrNoRuleID
+	}
+
+	if err := rule.Validate(); err != nil {
+		return fmt.Errorf("account.AddCategorizationRule: invalid rule: %w", err)
+	}
+
+	for _, r := range a.categorizationRules {
+		if r.ID == rule.ID {
+			return ErrRuleAlreadyExists
+		}
+	}
+
+	err := aggregate.RecordThat(a, eventually.Event{
+		Payload: CategorizationRuleWasAdded{Rule: rule},
+	})
+
+	if err != nil {
+		return fmt.Errorf("account.AddCategorizationRule: failed to record domain event: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveCategorizationRule removes the rule with the specified identifier.
+//
+// ErrRuleNotFound is returned if no such rule exists.
+func (a *Account) RemoveCategorizationRule(ruleID string) error {
+	found := false
+
+	for _, r := range a.categorizationRules {
+		if r.ID == ruleID {
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		return ErrRuleNotFound
+	}
+
+	err := aggregate.RecordThat(a, eventually.Event{
+		Payload: CategorizationRuleWasRemoved{RuleID: ruleID},
+	})
+
+	if err != nil {
+		return fmt.Errorf("account.RemoveCategorizationRule: failed to record domain event: %w", err)
+	}
+
+	return nil
+}
+
+// RecategorizeTransactions applies the current categorization rules
+// to the transaction specified, or to all the recorded transactions
+// if no transaction identifier is specified.
+//
+// Only the transactions of the last three months, see transactionsRetention,
+// are kept by the Account and can be recategorized.
+//
+// The number of transactions that changed category is returned.
+//
+// ErrTransactionNotFound is returned if the specified transaction does not exist,
+// or happened before the last three months.
+func (a *Account) RecategorizeTransactions(transactionID string) (int, error) {
+	ids := make([]string, 0, len(a.transactions))
+
+	if transactionID != "" {
+		if _, ok := a.transactions[transactionID]; !ok {
+			return 0, ErrTransactionNotFound
+		}
+
+		ids = append(ids, transactionID)
+	} else {
+		for id := range a.transactions {
+			ids = append(ids, id)
+		}
+	}
+
+	// Process the transactions in chronological order, to have
+	// a predictable sequence of Domain Events.
+	sort.Slice(ids, func(i, j int) bool {
+		ti, tj := a.transactions[ids[i]], a.transactions[ids[j]]
+		if ti.happenedAt.Equal(tj.happenedAt) {
+			return ids[i] < ids[j]
+		}
+
+		return ti.happenedAt.Before(tj.happenedAt)
+	})
+
+	recategorized := 0
+
+	for _, id := range ids {
+		changed, err := a.categorize(id)
+		if err != nil {
+			return recategorized, fmt.Errorf("account.RecategorizeTransactions: %w", err)
+		}
+
+		if changed {
+			recategorized++
+		}
+	}
+
+	return recategorized, nil
+}
+
+// categorize assigns a category to the specified transaction, using the
+// current categorization rules, but only if the category has changed.
+func (a *Account) categorize(transactionID string) (bool, error) {
+	tx := a.transactions[transactionID]
+	rule, _ := category.Categorize(a.categorizationRules, tx.amount, tx.details)
+
+	if rule.Category == tx.category {
+		return false, nil
+	}
+
+	err := aggregate.RecordThat(a, eventually.Event{
+		Payload: TransactionWasCategorized{
+			TransactionID:    transactionID,
+			Category:         rule.Category,
+			PreviousCategory: tx.category,
+			RuleID:           rule.ID,
+			Amount:           tx.amount,
+			Kind:             tx.kind,
+			HappenedAt:       tx.happenedAt,
+		},
+	})
+
+	if err != nil {
+		return false, fmt.Errorf("failed to record domain event: %w", err)
+	}
+
+	return true, nil
+}
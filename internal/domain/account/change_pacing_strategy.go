@@ -0,0 +1,49 @@
+package account
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/pacing"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+	"github.com/eventually-rs/eventually-go/command"
+)
+
+// ChangePacingStrategy is the Domain Command used to change the pacing Strategy
+// of the Account's monthly spending.
+type ChangePacingStrategy struct {
+	AccountID aggregate.StringID
+	Strategy  pacing.Strategy
+}
+
+// ChangePacingStrategyCommandHandler is the Command Handler for ChangePacingStrategy commands.
+type ChangePacingStrategyCommandHandler struct {
+	Repository *aggregate.Repository
+}
+
+// CommandType returns a ChangePacingStrategy instance to bind to this Handler.
+func (ChangePacingStrategyCommandHandler) CommandType() command.Command {
+	return ChangePacingStrategy{}
+}
+
+// Handle changes the pacing Strategy of the Account specified in the Command.
+func (h ChangePacingStrategyCommandHandler) Handle(ctx context.Context, cmd eventually.Command) error {
+	command := cmd.Payload.(ChangePacingStrategy)
+
+	account, err := h.Repository.Get(ctx, command.AccountID)
+	if err != nil {
+		return fmt.Errorf("account.ChangePacingStrategyCommandHandler: failed to get account: %w", err)
+	}
+
+	if err := account.(*Account).ChangePacingStrategy(command.Strategy); err != nil {
+		return fmt.Errorf("account.ChangePacingStrategyCommandHandler: failed to change pacing strategy: %w", err)
+	}
+
+	if err := h.Repository.Add(ctx, account); err != nil {
+		return fmt.Errorf("account.ChangePacingStrategyCommandHandler: failed to save new account state: %w", err)
+	}
+
+	return nil
+}
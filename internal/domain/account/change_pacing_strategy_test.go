@@ -0,0 +1,99 @@
+package account_test
+
+import (
+	"testing"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/account"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/pacing"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+	"github.com/eventually-rs/eventually-go/command"
+	"github.com/eventually-rs/eventually-go/eventstore"
+	"github.com/eventually-rs/eventually-go/scenario"
+)
+
+func TestChangePacingStrategy(t *testing.T) {
+	accountWasCreated := eventstore.Event{
+		StreamType: account.Type.Name(),
+		StreamName: "test-account",
+		Version:    1,
+		Event: eventually.Event{
+			Payload: account.WasCreated{AccountID: "test-account"},
+		},
+	}
+
+	t.Run("command fails when the account specified in the command does not exist", func(t *testing.T) {
+		scenario.
+			CommandHandler().
+			When(eventually.Command{
+				Payload: account.ChangePacingStrategy{
+					AccountID: "test-account",
+					Strategy:  pacing.FrontLoaded,
+				},
+			}).
+			ThenFails().
+			Using(t, account.Type, func(r *aggregate.Repository) command.Handler {
+				return account.ChangePacingStrategyCommandHandler{Repository: r}
+			})
+	})
+
+	t.Run("command fails when the strategy is not supported", func(t *testing.T) {
+		scenario.
+			CommandHandler().
+			Given(accountWasCreated).
+			When(eventually.Command{
+				Payload: account.ChangePacingStrategy{
+					AccountID: "test-account",
+					Strategy:  "back-loaded",
+				},
+			}).
+			ThenError(pacing.ErrUnknownStrategy).
+			Using(t, account.Type, func(r *aggregate.Repository) command.Handler {
+				return account.ChangePacingStrategyCommandHandler{Repository: r}
+			})
+	})
+
+	t.Run("unspecified strategy is changed to linear", func(t *testing.T) {
+		scenario.
+			CommandHandler().
+			Given(accountWasCreated).
+			When(eventually.Command{
+				Payload: account.ChangePacingStrategy{AccountID: "test-account"},
+			}).
+			Then(eventstore.Event{
+				StreamType: account.Type.Name(),
+				StreamName: "test-account",
+				Version:    2,
+				Event: eventually.Event{
+					Payload: account.PacingStrategyWasChanged{Strategy: pacing.Linear},
+				},
+			}).
+			Using(t, account.Type, func(r *aggregate.Repository) command.Handler {
+				return account.ChangePacingStrategyCommandHandler{Repository: r}
+			})
+	})
+
+	t.Run("new strategy is set", func(t *testing.T) {
+		scenario.
+			CommandHandler().
+			Given(accountWasCreated).
+			When(eventually.Command{
+				Payload: account.ChangePacingStrategy{
+					AccountID: "test-account",
+					Strategy:  pacing.WeekdayWeighted,
+				},
+			}).
+			Then(eventstore.Event{
+				StreamType: account.Type.Name(),
+				StreamName: "test-account",
+				Version:    2,
+				Event: eventually.Event{
+					Payload: account.PacingStrategyWasChanged{Strategy: pacing.WeekdayWeighted},
+				},
+			}).
+			Using(t, account.Type, func(r *aggregate.Repository) command.Handler {
+				return account.ChangePacingStrategyCommandHandler{Repository: r}
+			})
+	})
+}
@@ -21,8 +21,13 @@ func TestChangeSavingGoal(t *testing.T) {
 				Payload: account.ChangeSavingGoal{
 					AccountID: "test-account",
 					SavingGoal: saving.Goal{
-						Amount:     500,
-						Thresholds: []float64{0.25, 0.5, 0.75, 1},
+						Amount: 500,
+						Thresholds: []saving.Threshold{
+							saving.Percentage(0.25),
+							saving.Percentage(0.5),
+							saving.Percentage(0.75),
+							saving.Percentage(1),
+						},
 					},
 				},
 			}).
@@ -74,8 +79,13 @@ func TestChangeSavingGoal(t *testing.T) {
 				Payload: account.ChangeSavingGoal{
 					AccountID: "test-account",
 					SavingGoal: saving.Goal{
-						Amount:     0,
-						Thresholds: []float64{0.25, 0.5, 0.75, 1},
+						Amount: 0,
+						Thresholds: []saving.Threshold{
+							saving.Percentage(0.25),
+							saving.Percentage(0.5),
+							saving.Percentage(0.75),
+							saving.Percentage(1),
+						},
 					},
 				},
 			}).
@@ -85,11 +95,85 @@ func TestChangeSavingGoal(t *testing.T) {
 			})
 	})
 
+	t.Run("command fails when a threshold is not valid", func(t *testing.T) {
+		scenario.
+			CommandHandler().
+			Given(eventstore.Event{
+				StreamType: account.Type.Name(),
+				StreamName: "test-account",
+				Version:    1,
+				Event: eventually.Event{
+					Payload: account.WasCreated{
+						AccountID: "test-account",
+					},
+				},
+			}).
+			When(eventually.Command{
+				Payload: account.ChangeSavingGoal{
+					AccountID: "test-account",
+					SavingGoal: saving.Goal{
+						Amount:     500,
+						Thresholds: []saving.Threshold{saving.Percentage(0.5), saving.Percentage(-1)},
+					},
+				},
+			}).
+			ThenError(saving.ErrInvalidThreshold).
+			Using(t, account.Type, func(r *aggregate.Repository) command.Handler {
+				return account.ChangeSavingGoalCommandHandler{Repository: r}
+			})
+	})
+
+	t.Run("command fails when the account is not open", func(t *testing.T) {
+		testCases := []struct {
+			payload interface{}
+			err     error
+		}{
+			{payload: account.WasFrozen{Reason: "suspicious activity"}, err: account.ErrAccountFrozen},
+			{payload: account.WasClosed{Reason: "requested by the owner"}, err: account.ErrAccountClosed},
+		}
+
+		for _, tc := range testCases {
+			scenario.
+				CommandHandler().
+				Given(eventstore.Event{
+					StreamType: account.Type.Name(),
+					StreamName: "test-account",
+					Version:    1,
+					Event: eventually.Event{
+						Payload: account.WasCreated{AccountID: "test-account"},
+					},
+				}, eventstore.Event{
+					StreamType: account.Type.Name(),
+					StreamName: "test-account",
+					Version:    2,
+					Event:      eventually.Event{Payload: tc.payload},
+				}).
+				When(eventually.Command{
+					Payload: account.ChangeSavingGoal{
+						AccountID: "test-account",
+						SavingGoal: saving.Goal{
+							Amount:     500,
+							Thresholds: []saving.Threshold{saving.Percentage(1)},
+						},
+					},
+				}).
+				ThenError(tc.err).
+				Using(t, account.Type, func(r *aggregate.Repository) command.Handler {
+					return account.ChangeSavingGoalCommandHandler{Repository: r}
+				})
+		}
+	})
+
 	t.Run("new saving goal with at least one threshold is saved for an existing account", func(t *testing.T) {
 		accountID := "test-account"
 		newSavingGoal := saving.Goal{
-			Amount:     500,
-			Thresholds: []float64{0.25, 0.5, 0.75, 1},
+			Amount: 500,
+			Thresholds: []saving.Threshold{
+				saving.Percentage(0.25),
+				saving.Percentage(0.5),
+				saving.Percentage(0.75),
+				saving.Percentage(1),
+			},
 		}
 
 		scenario.
@@ -0,0 +1,54 @@
+package account
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+	"github.com/eventually-rs/eventually-go/command"
+)
+
+// CloseAccount is the Domain Command used to close an Account,
+// e.g. when it has been closed at the bank.
+type CloseAccount struct {
+	AccountID aggregate.StringID
+	Reason    string
+	ClosedAt  time.Time
+}
+
+// CloseAccountCommandHandler is the Command Handler for CloseAccount commands.
+type CloseAccountCommandHandler struct {
+	Repository *aggregate.Repository
+}
+
+// CommandType returns a CloseAccount instance to bind to this Handler.
+func (CloseAccountCommandHandler) CommandType() command.Command { return CloseAccount{} }
+
+// Handle closes the Account specified in the Command dispatched.
+//
+// Closing an already closed Account has no effect.
+func (h CloseAccountCommandHandler) Handle(ctx context.Context, cmd eventually.Command) error {
+	command := cmd.Payload.(CloseAccount)
+
+	account, err := h.Repository.Get(ctx, command.AccountID)
+	if err != nil {
+		return fmt.Errorf("account.CloseAccountCommandHandler: failed to get account: %w", err)
+	}
+
+	changed, err := account.(*Account).Close(command.Reason, command.ClosedAt)
+	if err != nil {
+		return fmt.Errorf("account.CloseAccountCommandHandler: failed to close account: %w", err)
+	}
+
+	if !changed {
+		return nil
+	}
+
+	if err := h.Repository.Add(ctx, account); err != nil {
+		return fmt.Errorf("account.CloseAccountCommandHandler: failed to save new account state: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,101 @@
+package account_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/account"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+	"github.com/eventually-rs/eventually-go/command"
+	"github.com/eventually-rs/eventually-go/eventstore"
+	"github.com/eventually-rs/eventually-go/scenario"
+)
+
+func TestCloseAccount(t *testing.T) {
+	closedAt := time.Date(2021, time.March, 15, 10, 0, 0, 0, time.UTC)
+
+	created := eventstore.Event{
+		StreamType: account.Type.Name(),
+		StreamName: "test-account",
+		Version:    1,
+		Event: eventually.Event{
+			Payload: account.WasCreated{AccountID: "test-account"},
+		},
+	}
+
+	closed := eventstore.Event{
+		StreamType: account.Type.Name(),
+		StreamName: "test-account",
+		Version:    2,
+		Event: eventually.Event{
+			Payload: account.WasClosed{Reason: "requested by the owner", ClosedAt: closedAt},
+		},
+	}
+
+	t.Run("open account is closed", func(t *testing.T) {
+		scenario.
+			CommandHandler().
+			Given(created).
+			When(eventually.Command{
+				Payload: account.CloseAccount{
+					AccountID: "test-account",
+					Reason:    "requested by the owner",
+					ClosedAt:  closedAt,
+				},
+			}).
+			Then(closed).
+			Using(t, account.Type, func(r *aggregate.Repository) command.Handler {
+				return account.CloseAccountCommandHandler{Repository: r}
+			})
+	})
+
+	t.Run("frozen account is closed", func(t *testing.T) {
+		frozen := eventstore.Event{
+			StreamType: account.Type.Name(),
+			StreamName: "test-account",
+			Version:    2,
+			Event: eventually.Event{
+				Payload: account.WasFrozen{Reason: "suspicious activity", FrozenAt: closedAt.Add(-time.Hour)},
+			},
+		}
+
+		closed := closed
+		closed.Version = 3
+
+		scenario.
+			CommandHandler().
+			Given(created, frozen).
+			When(eventually.Command{
+				Payload: account.CloseAccount{
+					AccountID: "test-account",
+					Reason:    "requested by the owner",
+					ClosedAt:  closedAt,
+				},
+			}).
+			Then(closed).
+			Using(t, account.Type, func(r *aggregate.Repository) command.Handler {
+				return account.CloseAccountCommandHandler{Repository: r}
+			})
+	})
+
+	t.Run("closing a closed account has no effect", func(t *testing.T) {
+		var then []eventstore.Event
+
+		scenario.
+			CommandHandler().
+			Given(created, closed).
+			When(eventually.Command{
+				Payload: account.CloseAccount{
+					AccountID: "test-account",
+					Reason:    "duplicated message",
+					ClosedAt:  closedAt.Add(time.Minute),
+				},
+			}).
+			Then(then...).
+			Using(t, account.Type, func(r *aggregate.Repository) command.Handler {
+				return account.CloseAccountCommandHandler{Repository: r}
+			})
+	})
+}
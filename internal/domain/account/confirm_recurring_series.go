@@ -0,0 +1,47 @@
+package account
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+	"github.com/eventually-rs/eventually-go/command"
+)
+
+// ConfirmRecurringSeries is the Domain Command used to confirm a recurring series
+// detected in the Account's transactions.
+type ConfirmRecurringSeries struct {
+	AccountID aggregate.StringID
+	SeriesID  string
+}
+
+// ConfirmRecurringSeriesCommandHandler is the Command Handler for ConfirmRecurringSeries commands.
+type ConfirmRecurringSeriesCommandHandler struct {
+	Repository *aggregate.Repository
+}
+
+// CommandType returns a ConfirmRecurringSeries instance to bind to this Handler.
+func (ConfirmRecurringSeriesCommandHandler) CommandType() command.Command {
+	return ConfirmRecurringSeries{}
+}
+
+// Handle confirms the recurring series specified in the Command.
+func (h ConfirmRecurringSeriesCommandHandler) Handle(ctx context.Context, cmd eventually.Command) error {
+	command := cmd.Payload.(ConfirmRecurringSeries)
+
+	account, err := h.Repository.Get(ctx, command.AccountID)
+	if err != nil {
+		return fmt.Errorf("account.ConfirmRecurringSeriesCommandHandler: failed to get account: %w", err)
+	}
+
+	if err := account.(*Account).ConfirmRecurringSeries(command.SeriesID); err != nil {
+		return fmt.Errorf("account.ConfirmRecurringSeriesCommandHandler: failed to confirm recurring series: %w", err)
+	}
+
+	if err := h.Repository.Add(ctx, account); err != nil {
+		return fmt.Errorf("account.ConfirmRecurringSeriesCommandHandler: failed to save new account state: %w", err)
+	}
+
+	return nil
+}
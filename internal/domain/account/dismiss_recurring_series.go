@@ -0,0 +1,47 @@
+package account
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+	"github.com/eventually-rs/eventually-go/command"
+)
+
+// DismissRecurringSeries is the Domain Command used to dismiss a recurring series
+// detected in the Account's transactions.
+type DismissRecurringSeries struct {
+	AccountID aggregate.StringID
+	SeriesID  string
+}
+
+// DismissRecurringSeriesCommandHandler is the Command Handler for DismissRecurringSeries commands.
+type DismissRecurringSeriesCommandHandler struct {
+	Repository *aggregate.Repository
+}
+
+// CommandType returns a DismissRecurringSeries instance to bind to this Handler.
+func (DismissRecurringSeriesCommandHandler) CommandType() command.Command {
+	return DismissRecurringSeries{}
+}
+
+// Handle dismisses the recurring series specified in the Command.
+func (h DismissRecurringSeriesCommandHandler) Handle(ctx context.Context, cmd eventually.Command) error {
+	command := cmd.Payload.(DismissRecurringSeries)
+
+	account, err := h.Repository.Get(ctx, command.AccountID)
+	if err != nil {
+		return fmt.Errorf("account.DismissRecurringSeriesCommandHandler: failed to get account: %w", err)
+	}
+
+	if err := account.(*Account).DismissRecurringSeries(command.SeriesID); err != nil {
+		return fmt.Errorf("account.DismissRecurringSeriesCommandHandler: failed to dismiss recurring series: %w", err)
+	}
+
+	if err := h.Repository.Add(ctx, account); err != nil {
+		return fmt.Errorf("account.DismissRecurringSeriesCommandHandler: failed to save new account state: %w", err)
+	}
+
+	return nil
+}
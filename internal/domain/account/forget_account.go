@@ -0,0 +1,102 @@
+package account
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+	"github.com/eventually-rs/eventually-go/command"
+)
+
+// KeyEraser erases the encryption key of a personal data subject,
+// making all the Events encrypted with it unrecoverable.
+type KeyEraser interface {
+	Forget(ctx context.Context, subjectID string) error
+}
+
+// WasForgotten is the Domain Event triggered by the Aggregate when the
+// Account has been forgotten, used by the read models to erase its data.
+//
+// The Event is stored unencrypted, with the pseudonym of the Account in place
+// of its identifier, and it is the only one of the Account still readable
+// once its key has been erased.
+type WasForgotten struct {
+	AccountID string
+}
+
+// ForgottenSubject returns the identifier of the Account forgotten.
+func (e WasForgotten) ForgottenSubject() string { return e.AccountID }
+
+// MapSubjects returns a copy of the Event with the Account mapped.
+func (e WasForgotten) MapSubjects(f func(string) string) interface{} {
+	e.AccountID = f(e.AccountID)
+	return e
+}
+
+// Forget records that the Account is going to be forgotten.
+//
+// false is returned if the Account has already been forgotten,
+// and nothing changed.
+func (a *Account) Forget() (bool, error) {
+	if a.forgotten {
+		return false, nil
+	}
+
+	err := aggregate.RecordThat(a, eventually.Event{
+		Payload: WasForgotten{AccountID: a.accountID.String()},
+	})
+
+	if err != nil {
+		return false, fmt.Errorf("account.Forget: failed to record domain event: %w", err)
+	}
+
+	return true, nil
+}
+
+// ForgetAccount is the Domain Command used to erase all the data
+// of an Account, e.g. when its owner exercises the right to erasure.
+type ForgetAccount struct {
+	AccountID aggregate.StringID
+}
+
+// ForgetAccountCommandHandler is the Command Handler for ForgetAccount commands.
+type ForgetAccountCommandHandler struct {
+	Repository *aggregate.Repository
+	Keys       KeyEraser
+}
+
+// CommandType returns a ForgetAccount instance to bind to this Handler.
+func (ForgetAccountCommandHandler) CommandType() command.Command { return ForgetAccount{} }
+
+// Handle forgets the Account specified in the Command dispatched,
+// by recording a WasForgotten Domain Event for the read models,
+// and then erasing the key its Events have been encrypted with.
+//
+// Once forgotten, the Account is not found anymore.
+func (h ForgetAccountCommandHandler) Handle(ctx context.Context, cmd eventually.Command) error {
+	command := cmd.Payload.(ForgetAccount)
+
+	account, err := h.Repository.Get(ctx, command.AccountID)
+	if err != nil {
+		return fmt.Errorf("account.ForgetAccountCommandHandler: failed to get account: %w", err)
+	}
+
+	changed, err := account.(*Account).Forget()
+	if err != nil {
+		return fmt.Errorf("account.ForgetAccountCommandHandler: failed to forget account: %w", err)
+	}
+
+	// The key might have not been erased after a previous attempt.
+	if changed {
+		if err := h.Repository.Add(ctx, account); err != nil {
+			return fmt.Errorf("account.ForgetAccountCommandHandler: failed to save new account state: %w", err)
+		}
+	}
+
+	if err := h.Keys.Forget(ctx, command.AccountID.String()); err != nil {
+		return fmt.Errorf("account.ForgetAccountCommandHandler: failed to erase account key: %w", err)
+	}
+
+	return nil
+}
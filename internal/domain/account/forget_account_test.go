@@ -0,0 +1,148 @@
+package account_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/account"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/saving"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+	"github.com/eventually-rs/eventually-go/command"
+	"github.com/eventually-rs/eventually-go/eventstore"
+	"github.com/eventually-rs/eventually-go/scenario"
+	"github.com/stretchr/testify/assert"
+)
+
+type keyEraser struct {
+	forgotten []string
+}
+
+func (k *keyEraser) Forget(ctx context.Context, subjectID string) error {
+	k.forgotten = append(k.forgotten, subjectID)
+	return nil
+}
+
+func TestForgetAccount(t *testing.T) {
+	created := eventstore.Event{
+		StreamType: account.Type.Name(),
+		StreamName: "test-account",
+		Version:    1,
+		Event: eventually.Event{
+			Payload: account.WasCreated{AccountID: "test-account"},
+		},
+	}
+
+	forgotten := eventstore.Event{
+		StreamType: account.Type.Name(),
+		StreamName: "test-account",
+		Version:    2,
+		Event: eventually.Event{
+			Payload: account.WasForgotten{AccountID: "test-account"},
+		},
+	}
+
+	t.Run("account is forgotten and its key is erased", func(t *testing.T) {
+		keys := new(keyEraser)
+
+		scenario.
+			CommandHandler().
+			Given(created).
+			When(eventually.Command{
+				Payload: account.ForgetAccount{AccountID: "test-account"},
+			}).
+			Then(forgotten).
+			Using(t, account.Type, func(r *aggregate.Repository) command.Handler {
+				return account.ForgetAccountCommandHandler{Repository: r, Keys: keys}
+			})
+
+		assert.Equal(t, []string{"test-account"}, keys.forgotten)
+	})
+
+	t.Run("account key is erased again if the account has already been forgotten", func(t *testing.T) {
+		var then []eventstore.Event
+		keys := new(keyEraser)
+
+		scenario.
+			CommandHandler().
+			Given(created, forgotten).
+			When(eventually.Command{
+				Payload: account.ForgetAccount{AccountID: "test-account"},
+			}).
+			Then(then...).
+			Using(t, account.Type, func(r *aggregate.Repository) command.Handler {
+				return account.ForgetAccountCommandHandler{Repository: r, Keys: keys}
+			})
+
+		assert.Equal(t, []string{"test-account"}, keys.forgotten)
+	})
+
+	t.Run("unknown account fails", func(t *testing.T) {
+		keys := new(keyEraser)
+
+		scenario.
+			CommandHandler().
+			When(eventually.Command{
+				Payload: account.ForgetAccount{AccountID: "test-account"},
+			}).
+			ThenError(aggregate.ErrRootNotFound).
+			Using(t, account.Type, func(r *aggregate.Repository) command.Handler {
+				return account.ForgetAccountCommandHandler{Repository: r, Keys: keys}
+			})
+
+		assert.Empty(t, keys.forgotten)
+	})
+}
+
+func TestForgottenAccountsAreErased(t *testing.T) {
+	ctx := context.Background()
+
+	view := account.NewViewProjection()
+	history := account.NewHistoryProjection()
+	withSavingGoals := account.NewWithSavingGoalsProjection()
+
+	events := []interface{}{
+		account.WasCreated{AccountID: "test-account"},
+		account.SavingGoalWasChanged{
+			SavingGoal: saving.Goal{
+				Amount:     500,
+				Thresholds: []saving.Threshold{saving.Percentage(1)},
+			},
+		},
+		account.TransactionWasRecorded{Amount: 1000, HappenedAt: time.Date(2021, time.March, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	apply := func(version int, streamName string, payload interface{}) {
+		event := eventstore.Event{
+			StreamType: account.Type.Name(),
+			StreamName: streamName,
+			Version:    int64(version),
+			Event:      eventually.Event{Payload: payload},
+		}
+
+		assert.NoError(t, view.Apply(ctx, event))
+		assert.NoError(t, history.Apply(ctx, event))
+		assert.NoError(t, withSavingGoals.Apply(ctx, event))
+	}
+
+	for i, payload := range events {
+		apply(i+1, "test-account", payload)
+	}
+
+	// The Stream name of the tombstone is pseudonymized.
+	apply(len(events)+1, "pseudonym", account.WasForgotten{AccountID: "test-account"})
+
+	_, err := view.Handle(ctx, account.ViewQuery{AccountID: "test-account"})
+	assert.True(t, errors.Is(err, account.ErrNotFound))
+
+	answer, err := history.Handle(ctx, account.HistoryQuery{AccountID: "test-account"})
+	assert.NoError(t, err)
+	assert.Empty(t, answer)
+
+	answer, err = withSavingGoals.Handle(ctx, account.WithSavingGoalsQuery{})
+	assert.NoError(t, err)
+	assert.Empty(t, collect(answer.(account.WithSavingGoalsAnswer)))
+}
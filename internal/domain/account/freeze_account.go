@@ -0,0 +1,53 @@
+package account
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+	"github.com/eventually-rs/eventually-go/command"
+)
+
+// FreezeAccount is the Domain Command used to temporarily suspend an Account.
+type FreezeAccount struct {
+	AccountID aggregate.StringID
+	Reason    string
+	FrozenAt  time.Time
+}
+
+// FreezeAccountCommandHandler is the Command Handler for FreezeAccount commands.
+type FreezeAccountCommandHandler struct {
+	Repository *aggregate.Repository
+}
+
+// CommandType returns a FreezeAccount instance to bind to this Handler.
+func (FreezeAccountCommandHandler) CommandType() command.Command { return FreezeAccount{} }
+
+// Handle freezes the Account specified in the Command dispatched.
+//
+// Freezing an already frozen Account has no effect.
+func (h FreezeAccountCommandHandler) Handle(ctx context.Context, cmd eventually.Command) error {
+	command := cmd.Payload.(FreezeAccount)
+
+	account, err := h.Repository.Get(ctx, command.AccountID)
+	if err != nil {
+		return fmt.Errorf("account.FreezeAccountCommandHandler: failed to get account: %w", err)
+	}
+
+	changed, err := account.(*Account).Freeze(command.Reason, command.FrozenAt)
+	if err != nil {
+		return fmt.Errorf("account.FreezeAccountCommandHandler: failed to freeze account: %w", err)
+	}
+
+	if !changed {
+		return nil
+	}
+
+	if err := h.Repository.Add(ctx, account); err != nil {
+		return fmt.Errorf("account.FreezeAccountCommandHandler: failed to save new account state: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+package account_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/account"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+	"github.com/eventually-rs/eventually-go/command"
+	"github.com/eventually-rs/eventually-go/eventstore"
+	"github.com/eventually-rs/eventually-go/scenario"
+)
+
+func TestFreezeAccount(t *testing.T) {
+	frozenAt := time.Date(2021, time.March, 15, 10, 0, 0, 0, time.UTC)
+
+	created := eventstore.Event{
+		StreamType: account.Type.Name(),
+		StreamName: "test-account",
+		Version:    1,
+		Event: eventually.Event{
+			Payload: account.WasCreated{AccountID: "test-account"},
+		},
+	}
+
+	frozen := eventstore.Event{
+		StreamType: account.Type.Name(),
+		StreamName: "test-account",
+		Version:    2,
+		Event: eventually.Event{
+			Payload: account.WasFrozen{Reason: "suspicious activity", FrozenAt: frozenAt},
+		},
+	}
+
+	t.Run("open account is frozen", func(t *testing.T) {
+		scenario.
+			CommandHandler().
+			Given(created).
+			When(eventually.Command{
+				Payload: account.FreezeAccount{
+					AccountID: "test-account",
+					Reason:    "suspicious activity",
+					FrozenAt:  frozenAt,
+				},
+			}).
+			Then(frozen).
+			Using(t, account.Type, func(r *aggregate.Repository) command.Handler {
+				return account.FreezeAccountCommandHandler{Repository: r}
+			})
+	})
+
+	t.Run("freezing a frozen account has no effect", func(t *testing.T) {
+		var then []eventstore.Event
+
+		scenario.
+			CommandHandler().
+			Given(created, frozen).
+			When(eventually.Command{
+				Payload: account.FreezeAccount{
+					AccountID: "test-account",
+					Reason:    "suspicious activity",
+					FrozenAt:  frozenAt,
+				},
+			}).
+			Then(then...).
+			Using(t, account.Type, func(r *aggregate.Repository) command.Handler {
+				return account.FreezeAccountCommandHandler{Repository: r}
+			})
+	})
+
+	t.Run("closed account cannot be frozen", func(t *testing.T) {
+		scenario.
+			CommandHandler().
+			Given(created, eventstore.Event{
+				StreamType: account.Type.Name(),
+				StreamName: "test-account",
+				Version:    2,
+				Event: eventually.Event{
+					Payload: account.WasClosed{ClosedAt: frozenAt.Add(-time.Hour)},
+				},
+			}).
+			When(eventually.Command{
+				Payload: account.FreezeAccount{
+					AccountID: "test-account",
+					Reason:    "suspicious activity",
+					FrozenAt:  frozenAt,
+				},
+			}).
+			ThenError(account.ErrAccountClosed).
+			Using(t, account.Type, func(r *aggregate.Repository) command.Handler {
+				return account.FreezeAccountCommandHandler{Repository: r}
+			})
+	})
+}
@@ -0,0 +1,266 @@
+package account
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/goal"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+)
+
+var (
+	// ErrNoGoalID is returned when adding a new Goal without specifying its identifier.
+	ErrNoGoalID = fmt.Errorf("account.AddGoal: goal id should be specified")
+
+	// ErrGoalAlreadyExists is returned when adding a new Goal with
+	// the same identifier of an existing one.
+	ErrGoalAlreadyExists = fmt.Errorf("account.AddGoal: goal already exists")
+
+	// ErrGoalNotFound is returned when updating, removing or contributing
+	// to a Goal that does not exist.
+	ErrGoalNotFound = fmt.Errorf("account.Goal: goal not found")
+
+	// ErrContributionIsZero is returned when recording a zero-valued
+	// contribution to a Goal.
+	ErrContributionIsZero = fmt.Errorf("account.RecordGoalContribution: contribution amount should not be zero")
+)
+
+// GoalWasAdded is the Domain Event triggered by the Aggregate
+// when a new named Goal has been added to the Account.
+type GoalWasAdded struct {
+	Goal goal.Goal
+}
+
+// GoalWasUpdated is the Domain Event triggered by the Aggregate
+// when the settings of a Goal have changed.
+type GoalWasUpdated struct {
+	Goal goal.Goal
+}
+
+// GoalWasRemoved is the Domain Event triggered by the Aggregate
+// when a Goal has been removed from the Account.
+type GoalWasRemoved struct {
+	GoalID string
+}
+
+// GoalContributionWasRecorded is the Domain Event triggered by the Aggregate
+// when some money has been saved for a Goal, or withdrawn from it
+// if the amount is negative.
+type GoalContributionWasRecorded struct {
+	GoalID string
+	Amount float64
+}
+
+// GoalWasAchieved is the Domain Event triggered by the Aggregate
+// when the amount saved for a Goal has reached its target.
+type GoalWasAchieved struct {
+	GoalID string
+}
+
+// AddGoal adds a new named Goal to the Account.
+//
+// An error is returned if the Goal is not valid, or if a Goal with
+// the same identifier already exists.
+//
+// ErrAccountClosed or ErrAccountFrozen is returned if the Account is not open.
+func (a *Account) AddGoal(g goal.Goal) error {
+	if err := a.ensureGoalsCanChange(); err != nil {
+		return fmt.Errorf("account.AddGoal: %w", err)
+	}
+
+	if g.ID == "" {
+		return ErrNoGoalID
+	}
+
+	if err := g.Validate(); err != nil {
+		return fmt.Errorf("account.AddGoal: invalid goal: %w", err)
+	}
+
+	if _, ok := a.goals[g.ID]; ok {
+		return ErrGoalAlreadyExists
+	}
+
+	g.SavedAmount = 0
+	g.Status = goal.Active
+
+	err := aggregate.RecordThat(a, eventually.Event{
+		Payload: GoalWasAdded{Goal: g},
+	})
+
+	if err != nil {
+		return fmt.Errorf("account.AddGoal: failed to record domain event: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateGoal changes the settings of an existing Goal, keeping
+// the amount already saved for it.
+//
+// An error is returned if the Goal does not exist or is not valid.
+//
+// ErrAccountClosed or ErrAccountFrozen is returned if the Account is not open.
+func (a *Account) UpdateGoal(g goal.Goal) error {
+	if err := a.ensureGoalsCanChange(); err != nil {
+		return fmt.Errorf("account.UpdateGoal: %w", err)
+	}
+
+	current, ok := a.goals[g.ID]
+	if !ok {
+		return fmt.Errorf("account.UpdateGoal: %w", ErrGoalNotFound)
+	}
+
+	if err := g.Validate(); err != nil {
+		return fmt.Errorf("account.UpdateGoal: invalid goal: %w", err)
+	}
+
+	g.SavedAmount = current.SavedAmount
+	g.Status = current.Status
+
+	if g.RemainingAmount() > 0 {
+		// A raised target makes an achieved Goal active again.
+		g.Status = goal.Active
+	}
+
+	err := aggregate.RecordThat(a, eventually.Event{
+		Payload: GoalWasUpdated{Goal: g},
+	})
+
+	if err != nil {
+		return fmt.Errorf("account.UpdateGoal: failed to record domain event: %w", err)
+	}
+
+	return a.recordGoalAchievedIfAny(g.ID)
+}
+
+// RemoveGoal removes a Goal from the Account.
+//
+// ErrGoalNotFound is returned if the Goal does not exist.
+//
+// ErrAccountClosed or ErrAccountFrozen is returned if the Account is not open.
+func (a *Account) RemoveGoal(goalID string) error {
+	if err := a.ensureGoalsCanChange(); err != nil {
+		return fmt.Errorf("account.RemoveGoal: %w", err)
+	}
+
+	if _, ok := a.goals[goalID]; !ok {
+		return fmt.Errorf("account.RemoveGoal: %w", ErrGoalNotFound)
+	}
+
+	err := aggregate.RecordThat(a, eventually.Event{
+		Payload: GoalWasRemoved{GoalID: goalID},
+	})
+
+	if err != nil {
+		return fmt.Errorf("account.RemoveGoal: failed to record domain event: %w", err)
+	}
+
+	return nil
+}
+
+// RecordGoalContribution records some money saved for the specified Goal,
+// or withdrawn from it if the amount is negative.
+//
+// GoalWasAchieved is recorded as well when the contribution
+// makes the Goal reach its target.
+func (a *Account) RecordGoalContribution(goalID string, amount float64) error {
+	if _, ok := a.goals[goalID]; !ok {
+		return fmt.Errorf("account.RecordGoalContribution: %w", ErrGoalNotFound)
+	}
+
+	if amount == 0 {
+		return ErrContributionIsZero
+	}
+
+	err := aggregate.RecordThat(a, eventually.Event{
+		Payload: GoalContributionWasRecorded{GoalID: goalID, Amount: amount},
+	})
+
+	if err != nil {
+		return fmt.Errorf("account.RecordGoalContribution: failed to record domain event: %w", err)
+	}
+
+	return a.recordGoalAchievedIfAny(goalID)
+}
+
+func (a *Account) recordGoalAchievedIfAny(goalID string) error {
+	g := a.goals[goalID]
+	if g.Status != goal.Active || g.RemainingAmount() > 0 {
+		return nil
+	}
+
+	err := aggregate.RecordThat(a, eventually.Event{
+		Payload: GoalWasAchieved{GoalID: goalID},
+	})
+
+	if err != nil {
+		return fmt.Errorf("account.Goal: failed to record domain event: %w", err)
+	}
+
+	return nil
+}
+
+// applyGoalEvent applies the Goal-related Domain Events to the goals specified.
+//
+// It is shared by the Aggregate and the read models, to keep
+// the Goals state transitions in a single place.
+func applyGoalEvent(goals map[string]goal.Goal, payload interface{}) {
+	switch evt := payload.(type) {
+	case GoalWasAdded:
+		goals[evt.Goal.ID] = evt.Goal
+
+	case GoalWasUpdated:
+		goals[evt.Goal.ID] = evt.Goal
+
+	case GoalWasRemoved:
+		delete(goals, evt.GoalID)
+
+	case GoalContributionWasRecorded:
+		g := goals[evt.GoalID]
+		g.SavedAmount += evt.Amount
+
+		if g.SavedAmount < 0 {
+			g.SavedAmount = 0
+		}
+
+		if g.Status == goal.Achieved && g.RemainingAmount() > 0 {
+			g.Status = goal.Active
+		}
+
+		goals[evt.GoalID] = g
+
+	case GoalWasAchieved:
+		g := goals[evt.GoalID]
+		g.Status = goal.Achieved
+		goals[evt.GoalID] = g
+	}
+}
+
+// sortedGoals returns the Goals sorted by priority, from the highest,
+// and then by name.
+func sortedGoals(goals map[string]goal.Goal) []goal.Goal {
+	if len(goals) == 0 {
+		return nil
+	}
+
+	result := make([]goal.Goal, 0, len(goals))
+	for _, g := range goals {
+		result = append(result, g)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Priority != result[j].Priority {
+			return result[i].Priority > result[j].Priority
+		}
+
+		if result[i].Name != result[j].Name {
+			return result[i].Name < result[j].Name
+		}
+
+		return result[i].ID < result[j].ID
+	})
+
+	return result
+}
@@ -0,0 +1,106 @@
+package account
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/forecast"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/transaction"
+
+	"github.com/eventually-rs/eventually-go/eventstore"
+	"github.com/eventually-rs/eventually-go/projection"
+	"github.com/eventually-rs/eventually-go/query"
+)
+
+var _ projection.Projection = &HistoryProjection{}
+
+// HistoryQuery is the Domain Query used to fetch the transactions
+// of an Account that happened since the specified time.
+type HistoryQuery struct {
+	AccountID string
+	Since     time.Time
+}
+
+// History is the Domain Answer returned from a HistoryQuery, containing
+// the Account's transactions sorted by the time they happened.
+//
+// Unknown Accounts have an empty History.
+type History []forecast.Transaction
+
+// HistoryProjection listens to Account Domain Events to build the
+// transaction history of all the Accounts.
+//
+// Transactions recorded without the time they happened are not included.
+type HistoryProjection struct {
+	mx       sync.RWMutex
+	accounts map[string]History
+}
+
+// NewHistoryProjection returns a new instance of HistoryProjection type.
+func NewHistoryProjection() *HistoryProjection {
+	return &HistoryProjection{
+		accounts: make(map[string]History),
+	}
+}
+
+// QueryType binds the HistoryQuery type to the projection.
+func (*HistoryProjection) QueryType() query.Query { return HistoryQuery{} }
+
+// Apply updates the state of the projection using the incoming event.
+func (p *HistoryProjection) Apply(ctx context.Context, event eventstore.Event) error {
+	if evt, ok := event.Payload.(WasForgotten); ok {
+		p.mx.Lock()
+		delete(p.accounts, evt.AccountID)
+		p.mx.Unlock()
+
+		return nil
+	}
+
+	evt, ok := event.Payload.(TransactionWasRecorded)
+	if !ok || evt.HappenedAt.IsZero() {
+		return nil
+	}
+
+	p.mx.Lock()
+	defer p.mx.Unlock()
+
+	history := p.accounts[event.StreamName]
+	tx := forecast.Transaction{
+		Amount:     evt.Amount,
+		Kind:       transaction.Classify(evt.Kind, evt.Amount),
+		Details:    evt.Details,
+		HappenedAt: evt.HappenedAt,
+	}
+
+	// Transactions are usually recorded in order, so insertion starts from the end.
+	i := len(history)
+	for i > 0 && history[i-1].HappenedAt.After(tx.HappenedAt) {
+		i--
+	}
+
+	history = append(history, forecast.Transaction{})
+	copy(history[i+1:], history[i:])
+	history[i] = tx
+
+	p.accounts[event.StreamName] = history
+
+	return nil
+}
+
+// Handle returns a copy of the transactions of the Account requested
+// that happened since the time specified in the query.
+func (p *HistoryProjection) Handle(ctx context.Context, q query.Query) (query.Answer, error) {
+	p.mx.RLock()
+	defer p.mx.RUnlock()
+
+	query := q.(HistoryQuery)
+	history := p.accounts[query.AccountID]
+
+	i := len(history)
+	for i > 0 && !history[i-1].HappenedAt.Before(query.Since) {
+		i--
+	}
+
+	return append(History{}, history[i:]...), nil
+}
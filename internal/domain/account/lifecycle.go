@@ -0,0 +1,130 @@
+package account
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+)
+
+var (
+	// ErrAccountClosed is returned when changing the goals of a closed Account,
+	// or recording new transactions for it.
+	ErrAccountClosed = fmt.Errorf("account.Account: account is closed")
+
+	// ErrAccountFrozen is returned when changing the goals of a frozen Account.
+	ErrAccountFrozen = fmt.Errorf("account.Account: account is frozen")
+)
+
+// Status is the lifecycle status of an Account at the bank.
+type Status string
+
+const (
+	// Open accounts are fully operational.
+	Open Status = "open"
+
+	// Frozen accounts are temporarily suspended by the bank: their transactions
+	// are still recorded, but their goals cannot be changed.
+	Frozen Status = "frozen"
+
+	// Closed accounts do not accept new transactions or goal changes,
+	// and their spending is no longer tracked.
+	Closed Status = "closed"
+)
+
+// WasFrozen is the Domain Event triggered by the Aggregate
+// when the Account has been temporarily suspended by the bank.
+type WasFrozen struct {
+	Reason   string
+	FrozenAt time.Time
+}
+
+// WasClosed is the Domain Event triggered by the Aggregate
+// when the Account has been closed at the bank.
+type WasClosed struct {
+	Reason   string
+	ClosedAt time.Time
+}
+
+// WasReopened is the Domain Event triggered by the Aggregate
+// when a frozen or closed Account is operational again.
+type WasReopened struct {
+	ReopenedAt time.Time
+}
+
+// Freeze temporarily suspends the Account.
+//
+// false is returned if the Account is already frozen, and nothing changed.
+//
+// ErrAccountClosed is returned if the Account has been closed:
+// closed Accounts should be reopened instead.
+func (a *Account) Freeze(reason string, frozenAt time.Time) (bool, error) {
+	switch a.status {
+	case Frozen:
+		return false, nil
+	case Closed:
+		return false, fmt.Errorf("account.Freeze: %w", ErrAccountClosed)
+	}
+
+	err := aggregate.RecordThat(a, eventually.Event{
+		Payload: WasFrozen{Reason: reason, FrozenAt: frozenAt},
+	})
+
+	if err != nil {
+		return false, fmt.Errorf("account.Freeze: failed to record domain event: %w", err)
+	}
+
+	return true, nil
+}
+
+// Close closes the Account, either open or frozen.
+//
+// false is returned if the Account is already closed, and nothing changed.
+func (a *Account) Close(reason string, closedAt time.Time) (bool, error) {
+	if a.status == Closed {
+		return false, nil
+	}
+
+	err := aggregate.RecordThat(a, eventually.Event{
+		Payload: WasClosed{Reason: reason, ClosedAt: closedAt},
+	})
+
+	if err != nil {
+		return false, fmt.Errorf("account.Close: failed to record domain event: %w", err)
+	}
+
+	return true, nil
+}
+
+// Reopen makes a frozen or closed Account operational again.
+//
+// false is returned if the Account is already open, and nothing changed.
+func (a *Account) Reopen(reopenedAt time.Time) (bool, error) {
+	if a.status == Open {
+		return false, nil
+	}
+
+	err := aggregate.RecordThat(a, eventually.Event{
+		Payload: WasReopened{ReopenedAt: reopenedAt},
+	})
+
+	if err != nil {
+		return false, fmt.Errorf("account.Reopen: failed to record domain event: %w", err)
+	}
+
+	return true, nil
+}
+
+// ensureGoalsCanChange returns ErrAccountClosed or ErrAccountFrozen
+// if the Account is not open.
+func (a *Account) ensureGoalsCanChange() error {
+	switch a.status {
+	case Closed:
+		return ErrAccountClosed
+	case Frozen:
+		return ErrAccountFrozen
+	default:
+		return nil
+	}
+}
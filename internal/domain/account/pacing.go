@@ -0,0 +1,36 @@
+package account
+
+import (
+	"fmt"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/pacing"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+)
+
+// PacingStrategyWasChanged is the Domain Event triggered by the Aggregate
+// when the Account's Owner chooses how the monthly spending should be paced.
+type PacingStrategyWasChanged struct {
+	Strategy pacing.Strategy
+}
+
+// ChangePacingStrategy changes the pacing Strategy used to track the Account's
+// monthly spending, starting from the next month.
+//
+// pacing.ErrUnknownStrategy is returned if the Strategy is not supported.
+func (a *Account) ChangePacingStrategy(strategy pacing.Strategy) error {
+	if err := strategy.Validate(); err != nil {
+		return fmt.Errorf("account.ChangePacingStrategy: %w", err)
+	}
+
+	err := aggregate.RecordThat(a, eventually.Event{
+		Payload: PacingStrategyWasChanged{Strategy: strategy.OrDefault()},
+	})
+
+	if err != nil {
+		return fmt.Errorf("account.ChangePacingStrategy: failed to record domain event: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package account
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+	"github.com/eventually-rs/eventually-go/command"
+)
+
+// RecategorizeTransactions is the Domain Command used to apply the current
+// categorization rules of an Account to the transactions already recorded.
+type RecategorizeTransactions struct {
+	AccountID aggregate.StringID
+
+	// TransactionID limits the recategorization to a single transaction.
+	// All the Account's transactions are recategorized if empty.
+	TransactionID string
+}
+
+// RecategorizeTransactionsCommandHandler is the Command Handler for RecategorizeTransactions commands.
+type RecategorizeTransactionsCommandHandler struct {
+	Repository *aggregate.Repository
+}
+
+// CommandType returns a RecategorizeTransactions instance to bind to this Handler.
+func (RecategorizeTransactionsCommandHandler) CommandType() command.Command {
+	return RecategorizeTransactions{}
+}
+
+// Handle recategorizes the Account's transactions specified in the Command.
+func (h RecategorizeTransactionsCommandHandler) Handle(ctx context.Context, cmd eventually.Command) error {
+	command := cmd.Payload.(RecategorizeTransactions)
+
+	account, err := h.Repository.Get(ctx, command.AccountID)
+	if err != nil {
+		return fmt.Errorf("account.RecategorizeTransactionsCommandHandler: failed to get account: %w", err)
+	}
+
+	recategorized, err := account.(*Account).RecategorizeTransactions(command.TransactionID)
+	if err != nil {
+		return fmt.Errorf("account.RecategorizeTransactionsCommandHandler: failed to recategorize transactions: %w", err)
+	}
+
+	if recategorized == 0 {
+		// Nothing has changed, no need to save the account.
+		return nil
+	}
+
+	if err := h.Repository.Add(ctx, account); err != nil {
+		return fmt.Errorf("account.RecategorizeTransactionsCommandHandler: failed to save new account state: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,196 @@
+package account_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/account"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/category"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/transaction"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+	"github.com/eventually-rs/eventually-go/command"
+	"github.com/eventually-rs/eventually-go/eventstore"
+	"github.com/eventually-rs/eventually-go/scenario"
+)
+
+func TestRecategorizeTransactions(t *testing.T) {
+	now := time.Now()
+
+	given := []eventstore.Event{
+		{
+			StreamType: account.Type.Name(),
+			StreamName: "test-account",
+			Version:    1,
+			Event: eventually.Event{
+				Payload: account.WasCreated{AccountID: "test-account"},
+			},
+		},
+		{
+			StreamType: account.Type.Name(),
+			StreamName: "test-account",
+			Version:    2,
+			Event: eventually.Event{
+				Payload: account.TransactionWasRecorded{
+					TransactionID: "tx-1",
+					Amount:        -45,
+					Kind:          transaction.Expense,
+					Details:       transaction.Details{Merchant: "Supermarket", MCC: "5411"},
+					HappenedAt:    now.Add(-time.Hour),
+				},
+			},
+		},
+		{
+			StreamType: account.Type.Name(),
+			StreamName: "test-account",
+			Version:    3,
+			Event: eventually.Event{
+				Payload: account.TransactionWasRecorded{
+					TransactionID: "tx-2",
+					Amount:        -12,
+					Kind:          transaction.Expense,
+					Details:       transaction.Details{Merchant: "Cinema", Description: "Movie tickets"},
+					HappenedAt:    now,
+				},
+			},
+		},
+		{
+			StreamType: account.Type.Name(),
+			StreamName: "test-account",
+			Version:    4,
+			Event: eventually.Event{
+				Payload: account.CategorizationRuleWasAdded{
+					Rule: category.Rule{
+						ID:       "groceries",
+						Category: "groceries",
+						MCC:      "5411",
+					},
+				},
+			},
+		},
+	}
+
+	t.Run("command fails when the transaction does not exist", func(t *testing.T) {
+		scenario.
+			CommandHandler().
+			Given(given...).
+			When(eventually.Command{
+				Payload: account.RecategorizeTransactions{
+					AccountID:     "test-account",
+					TransactionID: "tx-3",
+				},
+			}).
+			ThenError(account.ErrTransactionNotFound).
+			Using(t, account.Type, func(r *aggregate.Repository) command.Handler {
+				return account.RecategorizeTransactionsCommandHandler{Repository: r}
+			})
+	})
+
+	t.Run("command fails when the transaction is older than the last months", func(t *testing.T) {
+		scenario.
+			CommandHandler().
+			Given(append(given[:len(given):len(given)], eventstore.Event{
+				StreamType: account.Type.Name(),
+				StreamName: "test-account",
+				Version:    5,
+				Event: eventually.Event{
+					Payload: account.TransactionWasRecorded{
+						TransactionID: "tx-3",
+						Amount:        -30,
+						Kind:          transaction.Expense,
+						Details:       transaction.Details{Merchant: "Supermarket", MCC: "5411"},
+						HappenedAt:    now.AddDate(0, 4, 0),
+					},
+				},
+			})...).
+			When(eventually.Command{
+				Payload: account.RecategorizeTransactions{
+					AccountID:     "test-account",
+					TransactionID: "tx-1",
+				},
+			}).
+			ThenError(account.ErrTransactionNotFound).
+			Using(t, account.Type, func(r *aggregate.Repository) command.Handler {
+				return account.RecategorizeTransactionsCommandHandler{Repository: r}
+			})
+	})
+
+	t.Run("only transactions matching a new rule are recategorized", func(t *testing.T) {
+		scenario.
+			CommandHandler().
+			Given(given...).
+			When(eventually.Command{
+				Payload: account.RecategorizeTransactions{AccountID: "test-account"},
+			}).
+			Then(eventstore.Event{
+				StreamType: account.Type.Name(),
+				StreamName: "test-account",
+				Version:    5,
+				Event: eventually.Event{
+					Payload: account.TransactionWasCategorized{
+						TransactionID: "tx-1",
+						Category:      "groceries",
+						RuleID:        "groceries",
+						Amount:        -45,
+						Kind:          transaction.Expense,
+						HappenedAt:    now.Add(-time.Hour),
+					},
+				},
+			}).
+			Using(t, account.Type, func(r *aggregate.Repository) command.Handler {
+				return account.RecategorizeTransactionsCommandHandler{Repository: r}
+			})
+	})
+
+	t.Run("transactions no longer matching a removed rule become uncategorized", func(t *testing.T) {
+		scenario.
+			CommandHandler().
+			Given(append(given[:len(given):len(given)], eventstore.Event{
+				StreamType: account.Type.Name(),
+				StreamName: "test-account",
+				Version:    5,
+				Event: eventually.Event{
+					Payload: account.TransactionWasCategorized{
+						TransactionID: "tx-1",
+						Category:      "groceries",
+						RuleID:        "groceries",
+						Amount:        -45,
+						Kind:          transaction.Expense,
+						HappenedAt:    now.Add(-time.Hour),
+					},
+				},
+			}, eventstore.Event{
+				StreamType: account.Type.Name(),
+				StreamName: "test-account",
+				Version:    6,
+				Event: eventually.Event{
+					Payload: account.CategorizationRuleWasRemoved{RuleID: "groceries"},
+				},
+			})...).
+			When(eventually.Command{
+				Payload: account.RecategorizeTransactions{
+					AccountID:     "test-account",
+					TransactionID: "tx-1",
+				},
+			}).
+			Then(eventstore.Event{
+				StreamType: account.Type.Name(),
+				StreamName: "test-account",
+				Version:    7,
+				Event: eventually.Event{
+					Payload: account.TransactionWasCategorized{
+						TransactionID:    "tx-1",
+						Category:         category.Uncategorized,
+						PreviousCategory: "groceries",
+						Amount:           -45,
+						Kind:             transaction.Expense,
+						HappenedAt:       now.Add(-time.Hour),
+					},
+				},
+			}).
+			Using(t, account.Type, func(r *aggregate.Repository) command.Handler {
+				return account.RecategorizeTransactionsCommandHandler{Repository: r}
+			})
+	})
+}
@@ -0,0 +1,48 @@
+package account
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+	"github.com/eventually-rs/eventually-go/command"
+)
+
+// RecordGoalContribution is the Domain Command used to record some money
+// saved for, or withdrawn from, an Account's Goal.
+type RecordGoalContribution struct {
+	AccountID aggregate.StringID
+	GoalID    string
+	Amount    float64
+}
+
+// RecordGoalContributionCommandHandler is the Command Handler for RecordGoalContribution commands.
+type RecordGoalContributionCommandHandler struct {
+	Repository *aggregate.Repository
+}
+
+// CommandType returns a RecordGoalContribution instance to bind to this Handler.
+func (RecordGoalContributionCommandHandler) CommandType() command.Command {
+	return RecordGoalContribution{}
+}
+
+// Handle records the contribution specified in the Command to the Account's Goal.
+func (h RecordGoalContributionCommandHandler) Handle(ctx context.Context, cmd eventually.Command) error {
+	command := cmd.Payload.(RecordGoalContribution)
+
+	account, err := h.Repository.Get(ctx, command.AccountID)
+	if err != nil {
+		return fmt.Errorf("account.RecordGoalContributionCommandHandler: failed to get account: %w", err)
+	}
+
+	if err := account.(*Account).RecordGoalContribution(command.GoalID, command.Amount); err != nil {
+		return fmt.Errorf("account.RecordGoalContributionCommandHandler: failed to record goal contribution: %w", err)
+	}
+
+	if err := h.Repository.Add(ctx, account); err != nil {
+		return fmt.Errorf("account.RecordGoalContributionCommandHandler: failed to save new account state: %w", err)
+	}
+
+	return nil
+}
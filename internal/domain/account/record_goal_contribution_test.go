@@ -0,0 +1,139 @@
+package account_test
+
+import (
+	"testing"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/account"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/goal"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+	"github.com/eventually-rs/eventually-go/command"
+	"github.com/eventually-rs/eventually-go/eventstore"
+	"github.com/eventually-rs/eventually-go/scenario"
+)
+
+func TestRecordGoalContribution(t *testing.T) {
+	given := []eventstore.Event{
+		{
+			StreamType: account.Type.Name(),
+			StreamName: "test-account",
+			Version:    1,
+			Event: eventually.Event{
+				Payload: account.WasCreated{AccountID: "test-account"},
+			},
+		},
+		{
+			StreamType: account.Type.Name(),
+			StreamName: "test-account",
+			Version:    2,
+			Event: eventually.Event{
+				Payload: account.GoalWasAdded{
+					Goal: goal.Goal{
+						ID:                  "emergency-fund",
+						Name:                "Emergency fund",
+						TargetAmount:        1000,
+						MonthlyContribution: 200,
+						Status:              goal.Active,
+					},
+				},
+			},
+		},
+		{
+			StreamType: account.Type.Name(),
+			StreamName: "test-account",
+			Version:    3,
+			Event: eventually.Event{
+				Payload: account.GoalContributionWasRecorded{GoalID: "emergency-fund", Amount: 800},
+			},
+		},
+	}
+
+	t.Run("command fails when the goal does not exist", func(t *testing.T) {
+		scenario.
+			CommandHandler().
+			Given(given...).
+			When(eventually.Command{
+				Payload: account.RecordGoalContribution{
+					AccountID: "test-account",
+					GoalID:    "holiday",
+					Amount:    100,
+				},
+			}).
+			ThenError(account.ErrGoalNotFound).
+			Using(t, account.Type, func(r *aggregate.Repository) command.Handler {
+				return account.RecordGoalContributionCommandHandler{Repository: r}
+			})
+	})
+
+	t.Run("command fails when the contribution is zero", func(t *testing.T) {
+		scenario.
+			CommandHandler().
+			Given(given...).
+			When(eventually.Command{
+				Payload: account.RecordGoalContribution{
+					AccountID: "test-account",
+					GoalID:    "emergency-fund",
+				},
+			}).
+			ThenError(account.ErrContributionIsZero).
+			Using(t, account.Type, func(r *aggregate.Repository) command.Handler {
+				return account.RecordGoalContributionCommandHandler{Repository: r}
+			})
+	})
+
+	t.Run("contribution below the target is recorded", func(t *testing.T) {
+		scenario.
+			CommandHandler().
+			Given(given...).
+			When(eventually.Command{
+				Payload: account.RecordGoalContribution{
+					AccountID: "test-account",
+					GoalID:    "emergency-fund",
+					Amount:    100,
+				},
+			}).
+			Then(eventstore.Event{
+				StreamType: account.Type.Name(),
+				StreamName: "test-account",
+				Version:    4,
+				Event: eventually.Event{
+					Payload: account.GoalContributionWasRecorded{GoalID: "emergency-fund", Amount: 100},
+				},
+			}).
+			Using(t, account.Type, func(r *aggregate.Repository) command.Handler {
+				return account.RecordGoalContributionCommandHandler{Repository: r}
+			})
+	})
+
+	t.Run("contribution reaching the target achieves the goal", func(t *testing.T) {
+		scenario.
+			CommandHandler().
+			Given(given...).
+			When(eventually.Command{
+				Payload: account.RecordGoalContribution{
+					AccountID: "test-account",
+					GoalID:    "emergency-fund",
+					Amount:    200,
+				},
+			}).
+			Then(eventstore.Event{
+				StreamType: account.Type.Name(),
+				StreamName: "test-account",
+				Version:    4,
+				Event: eventually.Event{
+					Payload: account.GoalContributionWasRecorded{GoalID: "emergency-fund", Amount: 200},
+				},
+			}, eventstore.Event{
+				StreamType: account.Type.Name(),
+				StreamName: "test-account",
+				Version:    5,
+				Event: eventually.Event{
+					Payload: account.GoalWasAchieved{GoalID: "emergency-fund"},
+				},
+			}).
+			Using(t, account.Type, func(r *aggregate.Repository) command.Handler {
+				return account.RecordGoalContributionCommandHandler{Repository: r}
+			})
+	})
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/eventually-rs/saving-goals-go/internal/domain/transaction"
+
 	"github.com/eventually-rs/eventually-go"
 	"github.com/eventually-rs/eventually-go/aggregate"
 	"github.com/eventually-rs/eventually-go/command"
@@ -13,9 +15,12 @@ import (
 // RecordTransaction is the Domain Command used to record a new Transaction
 // involving the specified Account.
 type RecordTransaction struct {
-	AccountID  aggregate.StringID
-	Amount     float64
-	RecordedAt time.Time
+	AccountID     aggregate.StringID
+	TransactionID string
+	Amount        float64
+	Kind          transaction.Kind
+	Details       transaction.Details
+	RecordedAt    time.Time
 }
 
 // RecordTransactionCommandHandler is the Command Handler for RecordTransaction commands.
@@ -35,7 +40,15 @@ func (h RecordTransactionCommandHandler) Handle(ctx context.Context, cmd eventua
 		return fmt.Errorf("account.RecordTransaction: failed to get account: %w", err)
 	}
 
-	if err := account.(*Account).RecordTransaction(command.Amount, command.RecordedAt); err != nil {
+	err = account.(*Account).RecordTransaction(
+		command.TransactionID,
+		command.Amount,
+		command.Kind,
+		command.Details,
+		command.RecordedAt,
+	)
+
+	if err != nil {
 		return fmt.Errorf("account.RecordTransaction: failed to record transaction: %w", err)
 	}
 
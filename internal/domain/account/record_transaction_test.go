@@ -2,8 +2,13 @@ package account_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/eventually-rs/saving-goals-go/internal/domain/account"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/category"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/goal"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/sweep"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/transaction"
 
 	"github.com/eventually-rs/eventually-go"
 	"github.com/eventually-rs/eventually-go/aggregate"
@@ -59,11 +64,312 @@ func TestRecordTransaction(t *testing.T) {
 				StreamName: accountID,
 				Version:    3,
 				Event: eventually.Event{
-					Payload: account.TransactionWasRecorded{Amount: -200},
+					Payload: account.TransactionWasRecorded{
+						Amount: -200,
+						Kind:   transaction.Expense,
+					},
 				},
 			}).
 			Using(t, account.Type, func(r *aggregate.Repository) command.Handler {
 				return account.RecordTransactionCommandHandler{Repository: r}
 			})
 	})
+
+	t.Run("command fails when the account is closed", func(t *testing.T) {
+		scenario.
+			CommandHandler().
+			Given(eventstore.Event{
+				StreamType: account.Type.Name(),
+				StreamName: "test-account",
+				Version:    1,
+				Event: eventually.Event{
+					Payload: account.WasCreated{AccountID: "test-account"},
+				},
+			}, eventstore.Event{
+				StreamType: account.Type.Name(),
+				StreamName: "test-account",
+				Version:    2,
+				Event: eventually.Event{
+					Payload: account.WasClosed{Reason: "requested by the owner"},
+				},
+			}).
+			When(eventually.Command{
+				Payload: account.RecordTransaction{
+					AccountID: "test-account",
+					Amount:    -200,
+				},
+			}).
+			ThenError(account.ErrAccountClosed).
+			Using(t, account.Type, func(r *aggregate.Repository) command.Handler {
+				return account.RecordTransactionCommandHandler{Repository: r}
+			})
+	})
+
+	t.Run("transactions of frozen accounts are still recorded", func(t *testing.T) {
+		scenario.
+			CommandHandler().
+			Given(eventstore.Event{
+				StreamType: account.Type.Name(),
+				StreamName: "test-account",
+				Version:    1,
+				Event: eventually.Event{
+					Payload: account.WasCreated{AccountID: "test-account"},
+				},
+			}, eventstore.Event{
+				StreamType: account.Type.Name(),
+				StreamName: "test-account",
+				Version:    2,
+				Event: eventually.Event{
+					Payload: account.WasFrozen{Reason: "suspicious activity"},
+				},
+			}).
+			When(eventually.Command{
+				Payload: account.RecordTransaction{
+					AccountID: "test-account",
+					Amount:    -200,
+				},
+			}).
+			Then(eventstore.Event{
+				StreamType: account.Type.Name(),
+				StreamName: "test-account",
+				Version:    3,
+				Event: eventually.Event{
+					Payload: account.TransactionWasRecorded{
+						Amount: -200,
+						Kind:   transaction.Expense,
+					},
+				},
+			}).
+			Using(t, account.Type, func(r *aggregate.Repository) command.Handler {
+				return account.RecordTransactionCommandHandler{Repository: r}
+			})
+	})
+
+	t.Run("transaction kind specified in the command is preserved", func(t *testing.T) {
+		accountID := "test-account"
+
+		scenario.
+			CommandHandler().
+			Given(eventstore.Event{
+				StreamType: account.Type.Name(),
+				StreamName: accountID,
+				Version:    1,
+				Event: eventually.Event{
+					Payload: account.WasCreated{AccountID: accountID},
+				},
+			}).
+			When(eventually.Command{
+				Payload: account.RecordTransaction{
+					AccountID: aggregate.StringID(accountID),
+					Amount:    300,
+					Kind:      transaction.InternalTransfer,
+				},
+			}).
+			Then(eventstore.Event{
+				StreamType: account.Type.Name(),
+				StreamName: accountID,
+				Version:    2,
+				Event: eventually.Event{
+					Payload: account.TransactionWasRecorded{
+						Amount: 300,
+						Kind:   transaction.InternalTransfer,
+					},
+				},
+			}).
+			Using(t, account.Type, func(r *aggregate.Repository) command.Handler {
+				return account.RecordTransactionCommandHandler{Repository: r}
+			})
+	})
+
+	t.Run("transaction with an identifier is categorized using the account rules", func(t *testing.T) {
+		accountID := "test-account"
+		details := transaction.Details{
+			Merchant:    "Landlord Ltd",
+			Description: "Rent for April",
+		}
+
+		scenario.
+			CommandHandler().
+			Given(eventstore.Event{
+				StreamType: account.Type.Name(),
+				StreamName: accountID,
+				Version:    1,
+				Event: eventually.Event{
+					Payload: account.WasCreated{AccountID: accountID},
+				},
+			}, eventstore.Event{
+				StreamType: account.Type.Name(),
+				StreamName: accountID,
+				Version:    2,
+				Event: eventually.Event{
+					Payload: account.CategorizationRuleWasAdded{
+						Rule: category.Rule{
+							ID:                 "rent",
+							Category:           "rent",
+							DescriptionPattern: "(?i)^rent",
+						},
+					},
+				},
+			}).
+			When(eventually.Command{
+				Payload: account.RecordTransaction{
+					AccountID:     aggregate.StringID(accountID),
+					TransactionID: "tx-1",
+					Amount:        -800,
+					Details:       details,
+				},
+			}).
+			Then(eventstore.Event{
+				StreamType: account.Type.Name(),
+				StreamName: accountID,
+				Version:    3,
+				Event: eventually.Event{
+					Payload: account.TransactionWasRecorded{
+						TransactionID: "tx-1",
+						Amount:        -800,
+						Kind:          transaction.Expense,
+						Details:       details,
+					},
+				},
+			}, eventstore.Event{
+				StreamType: account.Type.Name(),
+				StreamName: accountID,
+				Version:    4,
+				Event: eventually.Event{
+					Payload: account.TransactionWasCategorized{
+						TransactionID: "tx-1",
+						Category:      "rent",
+						RuleID:        "rent",
+						Amount:        -800,
+						Kind:          transaction.Expense,
+					},
+				},
+			}).
+			Using(t, account.Type, func(r *aggregate.Repository) command.Handler {
+				return account.RecordTransactionCommandHandler{Repository: r}
+			})
+	})
+}
+
+func TestRecordTransactionSweeps(t *testing.T) {
+	const accountID = "test-account"
+
+	happenedAt := time.Date(2021, time.March, 12, 18, 30, 0, 0, time.UTC)
+
+	event := func(version int64, payload interface{}) eventstore.Event {
+		return eventstore.Event{
+			StreamType: account.Type.Name(),
+			StreamName: accountID,
+			Version:    version,
+			Event:      eventually.Event{Payload: payload},
+		}
+	}
+
+	holiday := goal.Goal{
+		ID:                  "holiday",
+		Name:                "Holiday",
+		TargetAmount:        1200,
+		MonthlyContribution: 100,
+		Status:              goal.Active,
+	}
+
+	given := []eventstore.Event{
+		event(1, account.WasCreated{AccountID: accountID}),
+		event(2, account.GoalWasAdded{Goal: holiday}),
+		event(3, account.SweepRuleWasAdded{
+			Rule: sweep.Rule{ID: "round-up", Kind: sweep.RoundUp, GoalID: "holiday", Unit: 1},
+		}),
+		event(4, account.SweepRuleWasAdded{
+			Rule: sweep.Rule{ID: "salary", Kind: sweep.IncomePercentage, GoalID: "holiday", Percentage: 0.1},
+		}),
+	}
+
+	t.Run("expense is rounded up", func(t *testing.T) {
+		scenario.
+			CommandHandler().
+			Given(given...).
+			When(eventually.Command{
+				Payload: account.RecordTransaction{
+					AccountID:     accountID,
+					TransactionID: "tx-1",
+					Amount:        -12.3,
+					RecordedAt:    happenedAt,
+				},
+			}).
+			Then(
+				event(5, account.TransactionWasRecorded{
+					TransactionID: "tx-1",
+					Amount:        -12.3,
+					Kind:          transaction.Expense,
+					HappenedAt:    happenedAt,
+				}),
+				event(6, account.SweepWasTriggered{
+					RuleID:        "round-up",
+					Kind:          sweep.RoundUp,
+					GoalID:        "holiday",
+					TransactionID: "tx-1",
+					Amount:        0.7,
+					HappenedAt:    happenedAt,
+				}),
+			).
+			Using(t, account.Type, func(r *aggregate.Repository) command.Handler {
+				return account.RecordTransactionCommandHandler{Repository: r}
+			})
+	})
+
+	t.Run("percentage of the income is swept", func(t *testing.T) {
+		scenario.
+			CommandHandler().
+			Given(given...).
+			When(eventually.Command{
+				Payload: account.RecordTransaction{
+					AccountID:     accountID,
+					TransactionID: "tx-2",
+					Amount:        2500,
+					RecordedAt:    happenedAt,
+				},
+			}).
+			Then(
+				event(5, account.TransactionWasRecorded{
+					TransactionID: "tx-2",
+					Amount:        2500,
+					Kind:          transaction.Income,
+					HappenedAt:    happenedAt,
+				}),
+				event(6, account.SweepWasTriggered{
+					RuleID:        "salary",
+					Kind:          sweep.IncomePercentage,
+					GoalID:        "holiday",
+					TransactionID: "tx-2",
+					Amount:        250,
+					HappenedAt:    happenedAt,
+				}),
+			).
+			Using(t, account.Type, func(r *aggregate.Repository) command.Handler {
+				return account.RecordTransactionCommandHandler{Repository: r}
+			})
+	})
+
+	t.Run("rules of achieved goals are not triggered", func(t *testing.T) {
+		scenario.
+			CommandHandler().
+			Given(append(given, event(5, account.GoalWasAchieved{GoalID: "holiday"}))...).
+			When(eventually.Command{
+				Payload: account.RecordTransaction{
+					AccountID:     accountID,
+					TransactionID: "tx-3",
+					Amount:        -12.3,
+					RecordedAt:    happenedAt,
+				},
+			}).
+			Then(event(6, account.TransactionWasRecorded{
+				TransactionID: "tx-3",
+				Amount:        -12.3,
+				Kind:          transaction.Expense,
+				HappenedAt:    happenedAt,
+			})).
+			Using(t, account.Type, func(r *aggregate.Repository) command.Handler {
+				return account.RecordTransactionCommandHandler{Repository: r}
+			})
+	})
 }
@@ -0,0 +1,220 @@
+package account
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/recurring"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/transaction"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+)
+
+// ErrSeriesNotFound is returned when confirming or dismissing
+// a recurring series that has not been detected.
+var ErrSeriesNotFound = fmt.Errorf("account.RecurringSeries: series not found")
+
+// RecurringSeriesDetected is the Domain Event triggered by the Aggregate
+// when a new series of recurring transactions has been found in the
+// Account's transactions.
+type RecurringSeriesDetected struct {
+	Series recurring.Series
+}
+
+// RecurringSeriesConfirmed is the Domain Event triggered by the Aggregate
+// when the Account's Owner confirms a recurring series is expected to happen again.
+type RecurringSeriesConfirmed struct {
+	SeriesID string
+}
+
+// RecurringSeriesDismissed is the Domain Event triggered by the Aggregate
+// when the Account's Owner dismisses a recurring series.
+type RecurringSeriesDismissed struct {
+	SeriesID string
+}
+
+// ConfirmRecurringSeries confirms the specified recurring series, so that
+// its outflows are considered as committed in the monthly spending,
+// starting from the next month.
+//
+// ErrSeriesNotFound is returned if the series has not been detected.
+func (a *Account) ConfirmRecurringSeries(seriesID string) error {
+	if _, ok := a.series[seriesID]; !ok {
+		return fmt.Errorf("account.ConfirmRecurringSeries: %w", ErrSeriesNotFound)
+	}
+
+	err := aggregate.RecordThat(a, eventually.Event{
+		Payload: RecurringSeriesConfirmed{SeriesID: seriesID},
+	})
+
+	if err != nil {
+		return fmt.Errorf("account.ConfirmRecurringSeries: failed to record domain event: %w", err)
+	}
+
+	return nil
+}
+
+// DismissRecurringSeries dismisses the specified recurring series, which is
+// not going to be detected again, nor matched with new transactions.
+//
+// ErrSeriesNotFound is returned if the series has not been detected.
+func (a *Account) DismissRecurringSeries(seriesID string) error {
+	if _, ok := a.series[seriesID]; !ok {
+		return fmt.Errorf("account.DismissRecurringSeries: %w", ErrSeriesNotFound)
+	}
+
+	err := aggregate.RecordThat(a, eventually.Event{
+		Payload: RecurringSeriesDismissed{SeriesID: seriesID},
+	})
+
+	if err != nil {
+		return fmt.Errorf("account.DismissRecurringSeries: failed to record domain event: %w", err)
+	}
+
+	return nil
+}
+
+// matchingSeries returns the identifier of the recurring series a new transaction
+// belongs to, or an empty string if it does not belong to any series,
+// or if the series has been dismissed.
+func (a *Account) matchingSeries(amount float64, kind transaction.Kind, details transaction.Details) string {
+	series, ok := a.series[recurring.SeriesID(details)]
+	if !ok || series.Status == recurring.Dismissed {
+		return ""
+	}
+
+	if series.Kind != kind || !recurring.Similar(series.Amount, amount) {
+		return ""
+	}
+
+	return series.ID
+}
+
+// detectRecurringSeries looks for a new recurring series formed by the
+// specified transaction and the previous ones with the same merchant,
+// recording a RecurringSeriesDetected event if found.
+//
+// Series already detected, including dismissed ones, are not detected again.
+func (a *Account) detectRecurringSeries(transactionID string) error {
+	tx := a.transactions[transactionID]
+	seriesID := recurring.SeriesID(tx.details)
+
+	if seriesID == "" || tx.happenedAt.IsZero() || (tx.kind != transaction.Income && tx.kind != transaction.Expense) {
+		return nil
+	}
+
+	if _, ok := a.series[seriesID]; ok {
+		return nil
+	}
+
+	var occurrences []recurring.Occurrence
+
+	for _, other := range a.transactions {
+		if other.kind == tx.kind && !other.happenedAt.IsZero() && recurring.SeriesID(other.details) == seriesID {
+			occurrences = append(occurrences, recurring.Occurrence{
+				Amount:     other.amount,
+				HappenedAt: other.happenedAt,
+			})
+		}
+	}
+
+	sort.Slice(occurrences, func(i, j int) bool {
+		return occurrences[i].HappenedAt.Before(occurrences[j].HappenedAt)
+	})
+
+	cadence, ok := recurring.Detect(occurrences)
+	if !ok {
+		return nil
+	}
+
+	latest := occurrences[len(occurrences)-1]
+
+	err := aggregate.RecordThat(a, eventually.Event{
+		Payload: RecurringSeriesDetected{
+			Series: recurring.Series{
+				ID:         seriesID,
+				Name:       recurring.SeriesName(tx.details),
+				Kind:       tx.kind,
+				Amount:     latest.Amount,
+				Cadence:    cadence,
+				LastSeenAt: latest.HappenedAt,
+				Status:     recurring.Detected,
+			},
+		},
+	})
+
+	if err != nil {
+		return fmt.Errorf("account.detectRecurringSeries: failed to record domain event: %w", err)
+	}
+
+	return nil
+}
+
+// applyRecurringSeriesEvent applies the recurring series-related Domain Events
+// to the series specified, including new transactions matching a series.
+//
+// It is shared by the Aggregate and the read models, to keep
+// the series state transitions in a single place.
+func applyRecurringSeriesEvent(series map[string]recurring.Series, payload interface{}) {
+	switch evt := payload.(type) {
+	case RecurringSeriesDetected:
+		series[evt.Series.ID] = evt.Series
+
+	case RecurringSeriesConfirmed:
+		s := series[evt.SeriesID]
+		s.Status = recurring.Confirmed
+		series[evt.SeriesID] = s
+
+	case RecurringSeriesDismissed:
+		s := series[evt.SeriesID]
+		s.Status = recurring.Dismissed
+		series[evt.SeriesID] = s
+
+	case TransactionWasRecorded:
+		s, ok := series[evt.SeriesID]
+		if !ok {
+			return
+		}
+
+		s.Amount = evt.Amount
+		s.LastSeenAt = evt.HappenedAt
+		series[evt.SeriesID] = s
+	}
+}
+
+// sortedSeries returns the recurring series sorted by name.
+func sortedSeries(series map[string]recurring.Series) []recurring.Series {
+	if len(series) == 0 {
+		return nil
+	}
+
+	result := make([]recurring.Series, 0, len(series))
+	for _, s := range series {
+		result = append(result, s)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Name != result[j].Name {
+			return result[i].Name < result[j].Name
+		}
+
+		return result[i].ID < result[j].ID
+	})
+
+	return result
+}
+
+// committedOutflows returns the confirmed recurring series of outgoing
+// transactions, sorted by name.
+func committedOutflows(series map[string]recurring.Series) []recurring.Series {
+	var result []recurring.Series
+
+	for _, s := range sortedSeries(series) {
+		if s.Status == recurring.Confirmed && s.Kind == transaction.Expense {
+			result = append(result, s)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,205 @@
+package account_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/account"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/recurring"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/transaction"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+	"github.com/eventually-rs/eventually-go/command"
+	"github.com/eventually-rs/eventually-go/eventstore"
+	"github.com/eventually-rs/eventually-go/scenario"
+)
+
+func TestRecurringSeries(t *testing.T) {
+	const accountID = "test-account"
+
+	streaming := transaction.Details{Merchant: "Streamflix"}
+
+	event := func(version int64, payload interface{}) eventstore.Event {
+		return eventstore.Event{
+			StreamType: account.Type.Name(),
+			StreamName: accountID,
+			Version:    version,
+			Event:      eventually.Event{Payload: payload},
+		}
+	}
+
+	subscription := func(version int64, id string, month time.Month) eventstore.Event {
+		return event(version, account.TransactionWasRecorded{
+			TransactionID: id,
+			Amount:        -12.99,
+			Kind:          transaction.Expense,
+			Details:       streaming,
+			HappenedAt:    time.Date(2021, month, 5, 9, 0, 0, 0, time.UTC),
+		})
+	}
+
+	detected := recurring.Series{
+		ID:         "streamflix",
+		Name:       "Streamflix",
+		Kind:       transaction.Expense,
+		Amount:     -12.99,
+		Cadence:    recurring.Monthly,
+		LastSeenAt: time.Date(2021, time.March, 5, 9, 0, 0, 0, time.UTC),
+		Status:     recurring.Detected,
+	}
+
+	t.Run("third monthly transaction with the same merchant detects a recurring series", func(t *testing.T) {
+		scenario.
+			CommandHandler().
+			Given(
+				event(1, account.WasCreated{AccountID: accountID}),
+				subscription(2, "tx-1", time.January),
+				subscription(3, "tx-2", time.February),
+			).
+			When(eventually.Command{
+				Payload: account.RecordTransaction{
+					AccountID:     accountID,
+					TransactionID: "tx-3",
+					Amount:        -12.99,
+					Details:       streaming,
+					RecordedAt:    time.Date(2021, time.March, 5, 9, 0, 0, 0, time.UTC),
+				},
+			}).
+			Then(
+				subscription(4, "tx-3", time.March),
+				event(5, account.RecurringSeriesDetected{Series: detected}),
+			).
+			Using(t, account.Type, func(r *aggregate.Repository) command.Handler {
+				return account.RecordTransactionCommandHandler{Repository: r}
+			})
+	})
+
+	t.Run("transaction matching a recurring series is linked to it", func(t *testing.T) {
+		scenario.
+			CommandHandler().
+			Given(
+				event(1, account.WasCreated{AccountID: accountID}),
+				event(2, account.RecurringSeriesDetected{Series: detected}),
+			).
+			When(eventually.Command{
+				Payload: account.RecordTransaction{
+					AccountID:     accountID,
+					TransactionID: "tx-4",
+					Amount:        -13.49,
+					Details:       streaming,
+					RecordedAt:    time.Date(2021, time.April, 5, 9, 0, 0, 0, time.UTC),
+				},
+			}).
+			Then(event(3, account.TransactionWasRecorded{
+				TransactionID: "tx-4",
+				Amount:        -13.49,
+				Kind:          transaction.Expense,
+				Details:       streaming,
+				HappenedAt:    time.Date(2021, time.April, 5, 9, 0, 0, 0, time.UTC),
+				SeriesID:      "streamflix",
+			})).
+			Using(t, account.Type, func(r *aggregate.Repository) command.Handler {
+				return account.RecordTransactionCommandHandler{Repository: r}
+			})
+	})
+
+	t.Run("transaction is not linked to a dismissed recurring series", func(t *testing.T) {
+		scenario.
+			CommandHandler().
+			Given(
+				event(1, account.WasCreated{AccountID: accountID}),
+				event(2, account.RecurringSeriesDetected{Series: detected}),
+				event(3, account.RecurringSeriesDismissed{SeriesID: "streamflix"}),
+			).
+			When(eventually.Command{
+				Payload: account.RecordTransaction{
+					AccountID:     accountID,
+					TransactionID: "tx-4",
+					Amount:        -12.99,
+					Details:       streaming,
+					RecordedAt:    time.Date(2021, time.April, 5, 9, 0, 0, 0, time.UTC),
+				},
+			}).
+			Then(event(4, account.TransactionWasRecorded{
+				TransactionID: "tx-4",
+				Amount:        -12.99,
+				Kind:          transaction.Expense,
+				Details:       streaming,
+				HappenedAt:    time.Date(2021, time.April, 5, 9, 0, 0, 0, time.UTC),
+			})).
+			Using(t, account.Type, func(r *aggregate.Repository) command.Handler {
+				return account.RecordTransactionCommandHandler{Repository: r}
+			})
+	})
+
+	t.Run("confirming a series not detected fails", func(t *testing.T) {
+		scenario.
+			CommandHandler().
+			Given(event(1, account.WasCreated{AccountID: accountID})).
+			When(eventually.Command{
+				Payload: account.ConfirmRecurringSeries{
+					AccountID: accountID,
+					SeriesID:  "streamflix",
+				},
+			}).
+			ThenError(account.ErrSeriesNotFound).
+			Using(t, account.Type, func(r *aggregate.Repository) command.Handler {
+				return account.ConfirmRecurringSeriesCommandHandler{Repository: r}
+			})
+	})
+
+	t.Run("detected series is confirmed", func(t *testing.T) {
+		scenario.
+			CommandHandler().
+			Given(
+				event(1, account.WasCreated{AccountID: accountID}),
+				event(2, account.RecurringSeriesDetected{Series: detected}),
+			).
+			When(eventually.Command{
+				Payload: account.ConfirmRecurringSeries{
+					AccountID: accountID,
+					SeriesID:  "streamflix",
+				},
+			}).
+			Then(event(3, account.RecurringSeriesConfirmed{SeriesID: "streamflix"})).
+			Using(t, account.Type, func(r *aggregate.Repository) command.Handler {
+				return account.ConfirmRecurringSeriesCommandHandler{Repository: r}
+			})
+	})
+
+	t.Run("dismissing a series not detected fails", func(t *testing.T) {
+		scenario.
+			CommandHandler().
+			Given(event(1, account.WasCreated{AccountID: accountID})).
+			When(eventually.Command{
+				Payload: account.DismissRecurringSeries{
+					AccountID: accountID,
+					SeriesID:  "streamflix",
+				},
+			}).
+			ThenError(account.ErrSeriesNotFound).
+			Using(t, account.Type, func(r *aggregate.Repository) command.Handler {
+				return account.DismissRecurringSeriesCommandHandler{Repository: r}
+			})
+	})
+
+	t.Run("detected series is dismissed", func(t *testing.T) {
+		scenario.
+			CommandHandler().
+			Given(
+				event(1, account.WasCreated{AccountID: accountID}),
+				event(2, account.RecurringSeriesDetected{Series: detected}),
+			).
+			When(eventually.Command{
+				Payload: account.DismissRecurringSeries{
+					AccountID: accountID,
+					SeriesID:  "streamflix",
+				},
+			}).
+			Then(event(3, account.RecurringSeriesDismissed{SeriesID: "streamflix"})).
+			Using(t, account.Type, func(r *aggregate.Repository) command.Handler {
+				return account.DismissRecurringSeriesCommandHandler{Repository: r}
+			})
+	})
+}
@@ -0,0 +1,47 @@
+package account
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+	"github.com/eventually-rs/eventually-go/command"
+)
+
+// RemoveCategorizationRule is the Domain Command used to remove
+// an existing categorization rule from an Account.
+type RemoveCategorizationRule struct {
+	AccountID aggregate.StringID
+	RuleID    string
+}
+
+// RemoveCategorizationRuleCommandHandler is the Command Handler for RemoveCategorizationRule commands.
+type RemoveCategorizationRuleCommandHandler struct {
+	Repository *aggregate.Repository
+}
+
+// CommandType returns a RemoveCategorizationRule instance to bind to this Handler.
+func (RemoveCategorizationRuleCommandHandler) CommandType() command.Command {
+	return RemoveCategorizationRule{}
+}
+
+// Handle removes the categorization rule specified in the Command from the Account.
+func (h RemoveCategorizationRuleCommandHandler) Handle(ctx context.Context, cmd eventually.Command) error {
+	command := cmd.Payload.(RemoveCategorizationRule)
+
+	account, err := h.Repository.Get(ctx, command.AccountID)
+	if err != nil {
+		return fmt.Errorf("account.RemoveCategorizationRuleCommandHandler: failed to get account: %w", err)
+	}
+
+	if err := account.(*Account).RemoveCategorizationRule(command.RuleID); err != nil {
+		return fmt.Errorf("account.RemoveCategorizationRuleCommandHandler: failed to remove rule: %w", err)
+	}
+
+	if err := h.Repository.Add(ctx, account); err != nil {
+		return fmt.Errorf("account.RemoveCategorizationRuleCommandHandler: failed to save new account state: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,80 @@
+package account_test
+
+import (
+	"testing"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/account"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/category"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+	"github.com/eventually-rs/eventually-go/command"
+	"github.com/eventually-rs/eventually-go/eventstore"
+	"github.com/eventually-rs/eventually-go/scenario"
+)
+
+func TestRemoveCategorizationRule(t *testing.T) {
+	given := []eventstore.Event{
+		{
+			StreamType: account.Type.Name(),
+			StreamName: "test-account",
+			Version:    1,
+			Event: eventually.Event{
+				Payload: account.WasCreated{AccountID: "test-account"},
+			},
+		},
+		{
+			StreamType: account.Type.Name(),
+			StreamName: "test-account",
+			Version:    2,
+			Event: eventually.Event{
+				Payload: account.CategorizationRuleWasAdded{
+					Rule: category.Rule{
+						ID:       "rent",
+						Category: "rent",
+						Merchant: "Landlord Ltd",
+					},
+				},
+			},
+		},
+	}
+
+	t.Run("command fails when the rule does not exist", func(t *testing.T) {
+		scenario.
+			CommandHandler().
+			Given(given...).
+			When(eventually.Command{
+				Payload: account.RemoveCategorizationRule{
+					AccountID: "test-account",
+					RuleID:    "groceries",
+				},
+			}).
+			ThenError(account.ErrRuleNotFound).
+			Using(t, account.Type, func(r *aggregate.Repository) command.Handler {
+				return account.RemoveCategorizationRuleCommandHandler{Repository: r}
+			})
+	})
+
+	t.Run("existing rule is removed", func(t *testing.T) {
+		scenario.
+			CommandHandler().
+			Given(given...).
+			When(eventually.Command{
+				Payload: account.RemoveCategorizationRule{
+					AccountID: "test-account",
+					RuleID:    "rent",
+				},
+			}).
+			Then(eventstore.Event{
+				StreamType: account.Type.Name(),
+				StreamName: "test-account",
+				Version:    3,
+				Event: eventually.Event{
+					Payload: account.CategorizationRuleWasRemoved{RuleID: "rent"},
+				},
+			}).
+			Using(t, account.Type, func(r *aggregate.Repository) command.Handler {
+				return account.RemoveCategorizationRuleCommandHandler{Repository: r}
+			})
+	})
+}
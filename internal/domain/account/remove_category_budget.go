@@ -0,0 +1,49 @@
+package account
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/category"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+	"github.com/eventually-rs/eventually-go/command"
+)
+
+// RemoveCategoryBudget is the Domain Command used to remove the Budget
+// of a specific spending Category from an Account.
+type RemoveCategoryBudget struct {
+	AccountID aggregate.StringID
+	Category  category.Category
+}
+
+// RemoveCategoryBudgetCommandHandler is the Command Handler for RemoveCategoryBudget commands.
+type RemoveCategoryBudgetCommandHandler struct {
+	Repository *aggregate.Repository
+}
+
+// CommandType returns a RemoveCategoryBudget instance to bind to this Handler.
+func (RemoveCategoryBudgetCommandHandler) CommandType() command.Command {
+	return RemoveCategoryBudget{}
+}
+
+// Handle removes the Category Budget specified in the Command from the Account.
+func (h RemoveCategoryBudgetCommandHandler) Handle(ctx context.Context, cmd eventually.Command) error {
+	command := cmd.Payload.(RemoveCategoryBudget)
+
+	account, err := h.Repository.Get(ctx, command.AccountID)
+	if err != nil {
+		return fmt.Errorf("account.RemoveCategoryBudgetCommandHandler: failed to get account: %w", err)
+	}
+
+	if err := account.(*Account).RemoveCategoryBudget(command.Category); err != nil {
+		return fmt.Errorf("account.RemoveCategoryBudgetCommandHandler: failed to remove budget: %w", err)
+	}
+
+	if err := h.Repository.Add(ctx, account); err != nil {
+		return fmt.Errorf("account.RemoveCategoryBudgetCommandHandler: failed to save new account state: %w", err)
+	}
+
+	return nil
+}
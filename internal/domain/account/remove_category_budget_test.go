@@ -0,0 +1,80 @@
+package account_test
+
+import (
+	"testing"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/account"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/saving"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+	"github.com/eventually-rs/eventually-go/command"
+	"github.com/eventually-rs/eventually-go/eventstore"
+	"github.com/eventually-rs/eventually-go/scenario"
+)
+
+func TestRemoveCategoryBudget(t *testing.T) {
+	given := []eventstore.Event{
+		{
+			StreamType: account.Type.Name(),
+			StreamName: "test-account",
+			Version:    1,
+			Event: eventually.Event{
+				Payload: account.WasCreated{AccountID: "test-account"},
+			},
+		},
+		{
+			StreamType: account.Type.Name(),
+			StreamName: "test-account",
+			Version:    2,
+			Event: eventually.Event{
+				Payload: account.CategoryBudgetWasSet{
+					Budget: saving.Budget{
+						Category:   "dining",
+						Amount:     300,
+						Thresholds: []saving.Threshold{saving.Percentage(0.5), saving.Percentage(1)},
+					},
+				},
+			},
+		},
+	}
+
+	t.Run("command fails when the budget does not exist", func(t *testing.T) {
+		scenario.
+			CommandHandler().
+			Given(given...).
+			When(eventually.Command{
+				Payload: account.RemoveCategoryBudget{
+					AccountID: "test-account",
+					Category:  "groceries",
+				},
+			}).
+			ThenError(account.ErrBudgetNotFound).
+			Using(t, account.Type, func(r *aggregate.Repository) command.Handler {
+				return account.RemoveCategoryBudgetCommandHandler{Repository: r}
+			})
+	})
+
+	t.Run("existing budget is removed", func(t *testing.T) {
+		scenario.
+			CommandHandler().
+			Given(given...).
+			When(eventually.Command{
+				Payload: account.RemoveCategoryBudget{
+					AccountID: "test-account",
+					Category:  "dining",
+				},
+			}).
+			Then(eventstore.Event{
+				StreamType: account.Type.Name(),
+				StreamName: "test-account",
+				Version:    3,
+				Event: eventually.Event{
+					Payload: account.CategoryBudgetWasRemoved{Category: "dining"},
+				},
+			}).
+			Using(t, account.Type, func(r *aggregate.Repository) command.Handler {
+				return account.RemoveCategoryBudgetCommandHandler{Repository: r}
+			})
+	})
+}
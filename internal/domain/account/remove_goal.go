@@ -0,0 +1,44 @@
+package account
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+	"github.com/eventually-rs/eventually-go/command"
+)
+
+// RemoveGoal is the Domain Command used to remove a Goal from an Account.
+type RemoveGoal struct {
+	AccountID aggregate.StringID
+	GoalID    string
+}
+
+// RemoveGoalCommandHandler is the Command Handler for RemoveGoal commands.
+type RemoveGoalCommandHandler struct {
+	Repository *aggregate.Repository
+}
+
+// CommandType returns a RemoveGoal instance to bind to this Handler.
+func (RemoveGoalCommandHandler) CommandType() command.Command { return RemoveGoal{} }
+
+// Handle removes the Goal specified in the Command from the Account.
+func (h RemoveGoalCommandHandler) Handle(ctx context.Context, cmd eventually.Command) error {
+	command := cmd.Payload.(RemoveGoal)
+
+	account, err := h.Repository.Get(ctx, command.AccountID)
+	if err != nil {
+		return fmt.Errorf("account.RemoveGoalCommandHandler: failed to get account: %w", err)
+	}
+
+	if err := account.(*Account).RemoveGoal(command.GoalID); err != nil {
+		return fmt.Errorf("account.RemoveGoalCommandHandler: failed to remove goal: %w", err)
+	}
+
+	if err := h.Repository.Add(ctx, account); err != nil {
+		return fmt.Errorf("account.RemoveGoalCommandHandler: failed to save new account state: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package account_test
+
+import (
+	"testing"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/account"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/goal"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+	"github.com/eventually-rs/eventually-go/command"
+	"github.com/eventually-rs/eventually-go/eventstore"
+	"github.com/eventually-rs/eventually-go/scenario"
+)
+
+func TestRemoveGoal(t *testing.T) {
+	given := []eventstore.Event{
+		{
+			StreamType: account.Type.Name(),
+			StreamName: "test-account",
+			Version:    1,
+			Event: eventually.Event{
+				Payload: account.WasCreated{AccountID: "test-account"},
+			},
+		},
+		{
+			StreamType: account.Type.Name(),
+			StreamName: "test-account",
+			Version:    2,
+			Event: eventually.Event{
+				Payload: account.GoalWasAdded{
+					Goal: goal.Goal{
+						ID:                  "emergency-fund",
+						Name:                "Emergency fund",
+						TargetAmount:        1000,
+						MonthlyContribution: 200,
+						Status:              goal.Active,
+					},
+				},
+			},
+		},
+	}
+
+	t.Run("command fails when the goal does not exist", func(t *testing.T) {
+		scenario.
+			CommandHandler().
+			Given(given...).
+			When(eventually.Command{
+				Payload: account.RemoveGoal{
+					AccountID: "test-account",
+					GoalID:    "holiday",
+				},
+			}).
+			ThenError(account.ErrGoalNotFound).
+			Using(t, account.Type, func(r *aggregate.Repository) command.Handler {
+				return account.RemoveGoalCommandHandler{Repository: r}
+			})
+	})
+
+	t.Run("existing goal is removed", func(t *testing.T) {
+		scenario.
+			CommandHandler().
+			Given(given...).
+			When(eventually.Command{
+				Payload: account.RemoveGoal{
+					AccountID: "test-account",
+					GoalID:    "emergency-fund",
+				},
+			}).
+			Then(eventstore.Event{
+				StreamType: account.Type.Name(),
+				StreamName: "test-account",
+				Version:    3,
+				Event: eventually.Event{
+					Payload: account.GoalWasRemoved{GoalID: "emergency-fund"},
+				},
+			}).
+			Using(t, account.Type, func(r *aggregate.Repository) command.Handler {
+				return account.RemoveGoalCommandHandler{Repository: r}
+			})
+	})
+}
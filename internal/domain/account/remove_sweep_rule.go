@@ -0,0 +1,47 @@
+package account
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+	"github.com/eventually-rs/eventually-go/command"
+)
+
+// RemoveSweepRule is the Domain Command used to remove
+// an existing sweep rule from an Account.
+type RemoveSweepRule struct {
+	AccountID aggregate.StringID
+	RuleID    string
+}
+
+// RemoveSweepRuleCommandHandler is the Command Handler for RemoveSweepRule commands.
+type RemoveSweepRuleCommandHandler struct {
+	Repository *aggregate.Repository
+}
+
+// CommandType returns a RemoveSweepRule instance to bind to this Handler.
+func (RemoveSweepRuleCommandHandler) CommandType() command.Command {
+	return RemoveSweepRule{}
+}
+
+// Handle removes the sweep rule specified in the Command from the Account.
+func (h RemoveSweepRuleCommandHandler) Handle(ctx context.Context, cmd eventually.Command) error {
+	command := cmd.Payload.(RemoveSweepRule)
+
+	account, err := h.Repository.Get(ctx, command.AccountID)
+	if err != nil {
+		return fmt.Errorf("account.RemoveSweepRuleCommandHandler: failed to get account: %w", err)
+	}
+
+	if err := account.(*Account).RemoveSweepRule(command.RuleID); err != nil {
+		return fmt.Errorf("account.RemoveSweepRuleCommandHandler: failed to remove rule: %w", err)
+	}
+
+	if err := h.Repository.Add(ctx, account); err != nil {
+		return fmt.Errorf("account.RemoveSweepRuleCommandHandler: failed to save new account state: %w", err)
+	}
+
+	return nil
+}
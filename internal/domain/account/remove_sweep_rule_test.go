@@ -0,0 +1,97 @@
+package account_test
+
+import (
+	"testing"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/account"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/goal"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/sweep"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+	"github.com/eventually-rs/eventually-go/command"
+	"github.com/eventually-rs/eventually-go/eventstore"
+	"github.com/eventually-rs/eventually-go/scenario"
+)
+
+func TestRemoveSweepRule(t *testing.T) {
+	given := []eventstore.Event{
+		{
+			StreamType: account.Type.Name(),
+			StreamName: "test-account",
+			Version:    1,
+			Event: eventually.Event{
+				Payload: account.WasCreated{AccountID: "test-account"},
+			},
+		},
+		{
+			StreamType: account.Type.Name(),
+			StreamName: "test-account",
+			Version:    2,
+			Event: eventually.Event{
+				Payload: account.GoalWasAdded{
+					Goal: goal.Goal{
+						ID:           "emergency-fund",
+						Name:         "Emergency fund",
+						TargetAmount: 10000,
+						Status:       goal.Active,
+					},
+				},
+			},
+		},
+		{
+			StreamType: account.Type.Name(),
+			StreamName: "test-account",
+			Version:    3,
+			Event: eventually.Event{
+				Payload: account.SweepRuleWasAdded{
+					Rule: sweep.Rule{
+						ID:         "salary",
+						Kind:       sweep.IncomePercentage,
+						GoalID:     "emergency-fund",
+						Percentage: 0.1,
+					},
+				},
+			},
+		},
+	}
+
+	t.Run("command fails when the rule does not exist", func(t *testing.T) {
+		scenario.
+			CommandHandler().
+			Given(given...).
+			When(eventually.Command{
+				Payload: account.RemoveSweepRule{
+					AccountID: "test-account",
+					RuleID:    "round-up",
+				},
+			}).
+			ThenError(account.ErrSweepRuleNotFound).
+			Using(t, account.Type, func(r *aggregate.Repository) command.Handler {
+				return account.RemoveSweepRuleCommandHandler{Repository: r}
+			})
+	})
+
+	t.Run("existing rule is removed", func(t *testing.T) {
+		scenario.
+			CommandHandler().
+			Given(given...).
+			When(eventually.Command{
+				Payload: account.RemoveSweepRule{
+					AccountID: "test-account",
+					RuleID:    "salary",
+				},
+			}).
+			Then(eventstore.Event{
+				StreamType: account.Type.Name(),
+				StreamName: "test-account",
+				Version:    4,
+				Event: eventually.Event{
+					Payload: account.SweepRuleWasRemoved{RuleID: "salary"},
+				},
+			}).
+			Using(t, account.Type, func(r *aggregate.Repository) command.Handler {
+				return account.RemoveSweepRuleCommandHandler{Repository: r}
+			})
+	})
+}
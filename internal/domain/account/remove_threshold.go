@@ -0,0 +1,47 @@
+package account
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/saving"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+	"github.com/eventually-rs/eventually-go/command"
+)
+
+// RemoveThreshold is the Domain Command used to remove a Threshold
+// from an Account's Saving Goal.
+type RemoveThreshold struct {
+	AccountID aggregate.StringID
+	Threshold saving.Threshold
+}
+
+// RemoveThresholdCommandHandler is the Command Handler for RemoveThreshold commands.
+type RemoveThresholdCommandHandler struct {
+	Repository *aggregate.Repository
+}
+
+// CommandType returns a RemoveThreshold instance to bind to this Handler.
+func (RemoveThresholdCommandHandler) CommandType() command.Command { return RemoveThreshold{} }
+
+// Handle removes the Threshold specified in the Command from the Account's Saving Goal.
+func (h RemoveThresholdCommandHandler) Handle(ctx context.Context, cmd eventually.Command) error {
+	command := cmd.Payload.(RemoveThreshold)
+
+	account, err := h.Repository.Get(ctx, command.AccountID)
+	if err != nil {
+		return fmt.Errorf("account.RemoveThresholdCommandHandler: failed to get account: %w", err)
+	}
+
+	if err := account.(*Account).RemoveThreshold(command.Threshold); err != nil {
+		return fmt.Errorf("account.RemoveThresholdCommandHandler: failed to remove threshold: %w", err)
+	}
+
+	if err := h.Repository.Add(ctx, account); err != nil {
+		return fmt.Errorf("account.RemoveThresholdCommandHandler: failed to save new account state: %w", err)
+	}
+
+	return nil
+}
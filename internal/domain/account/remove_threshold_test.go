@@ -0,0 +1,109 @@
+package account_test
+
+import (
+	"testing"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/account"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/saving"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+	"github.com/eventually-rs/eventually-go/command"
+	"github.com/eventually-rs/eventually-go/eventstore"
+	"github.com/eventually-rs/eventually-go/scenario"
+)
+
+func TestRemoveThreshold(t *testing.T) {
+	accountWasCreated := eventstore.Event{
+		StreamType: account.Type.Name(),
+		StreamName: "test-account",
+		Version:    1,
+		Event: eventually.Event{
+			Payload: account.WasCreated{AccountID: "test-account"},
+		},
+	}
+
+	savingGoalWasChanged := func(thresholds ...saving.Threshold) eventstore.Event {
+		return eventstore.Event{
+			StreamType: account.Type.Name(),
+			StreamName: "test-account",
+			Version:    2,
+			Event: eventually.Event{
+				Payload: account.SavingGoalWasChanged{
+					SavingGoal: saving.Goal{Amount: 500, Thresholds: thresholds},
+				},
+			},
+		}
+	}
+
+	t.Run("command fails when the account has no saving goal set", func(t *testing.T) {
+		scenario.
+			CommandHandler().
+			Given(accountWasCreated).
+			When(eventually.Command{
+				Payload: account.RemoveThreshold{
+					AccountID: "test-account",
+					Threshold: saving.Percentage(0.5),
+				},
+			}).
+			ThenError(account.ErrNoSavingGoal).
+			Using(t, account.Type, func(r *aggregate.Repository) command.Handler {
+				return account.RemoveThresholdCommandHandler{Repository: r}
+			})
+	})
+
+	t.Run("command fails when the threshold is not set", func(t *testing.T) {
+		scenario.
+			CommandHandler().
+			Given(accountWasCreated, savingGoalWasChanged(saving.Percentage(0.5), saving.Percentage(1))).
+			When(eventually.Command{
+				Payload: account.RemoveThreshold{
+					AccountID: "test-account",
+					Threshold: saving.SpentAbove(0.5),
+				},
+			}).
+			ThenError(account.ErrThresholdNotFound).
+			Using(t, account.Type, func(r *aggregate.Repository) command.Handler {
+				return account.RemoveThresholdCommandHandler{Repository: r}
+			})
+	})
+
+	t.Run("command fails when removing the last threshold", func(t *testing.T) {
+		scenario.
+			CommandHandler().
+			Given(accountWasCreated, savingGoalWasChanged(saving.Percentage(0.5))).
+			When(eventually.Command{
+				Payload: account.RemoveThreshold{
+					AccountID: "test-account",
+					Threshold: saving.Percentage(0.5),
+				},
+			}).
+			ThenError(account.ErrAtLeastOneThreshold).
+			Using(t, account.Type, func(r *aggregate.Repository) command.Handler {
+				return account.RemoveThresholdCommandHandler{Repository: r}
+			})
+	})
+
+	t.Run("existing threshold is removed", func(t *testing.T) {
+		scenario.
+			CommandHandler().
+			Given(accountWasCreated, savingGoalWasChanged(saving.Percentage(0.5), saving.Percentage(1))).
+			When(eventually.Command{
+				Payload: account.RemoveThreshold{
+					AccountID: "test-account",
+					Threshold: saving.Percentage(0.5),
+				},
+			}).
+			Then(eventstore.Event{
+				StreamType: account.Type.Name(),
+				StreamName: "test-account",
+				Version:    3,
+				Event: eventually.Event{
+					Payload: account.ThresholdWasRemoved{Threshold: saving.Percentage(0.5)},
+				},
+			}).
+			Using(t, account.Type, func(r *aggregate.Repository) command.Handler {
+				return account.RemoveThresholdCommandHandler{Repository: r}
+			})
+	})
+}
@@ -0,0 +1,53 @@
+package account
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+	"github.com/eventually-rs/eventually-go/command"
+)
+
+// ReopenAccount is the Domain Command used to make a frozen
+// or closed Account operational again.
+type ReopenAccount struct {
+	AccountID  aggregate.StringID
+	ReopenedAt time.Time
+}
+
+// ReopenAccountCommandHandler is the Command Handler for ReopenAccount commands.
+type ReopenAccountCommandHandler struct {
+	Repository *aggregate.Repository
+}
+
+// CommandType returns a ReopenAccount instance to bind to this Handler.
+func (ReopenAccountCommandHandler) CommandType() command.Command { return ReopenAccount{} }
+
+// Handle reopens the Account specified in the Command dispatched.
+//
+// Reopening an already open Account has no effect.
+func (h ReopenAccountCommandHandler) Handle(ctx context.Context, cmd eventually.Command) error {
+	command := cmd.Payload.(ReopenAccount)
+
+	account, err := h.Repository.Get(ctx, command.AccountID)
+	if err != nil {
+		return fmt.Errorf("account.ReopenAccountCommandHandler: failed to get account: %w", err)
+	}
+
+	changed, err := account.(*Account).Reopen(command.ReopenedAt)
+	if err != nil {
+		return fmt.Errorf("account.ReopenAccountCommandHandler: failed to reopen account: %w", err)
+	}
+
+	if !changed {
+		return nil
+	}
+
+	if err := h.Repository.Add(ctx, account); err != nil {
+		return fmt.Errorf("account.ReopenAccountCommandHandler: failed to save new account state: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package account_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/account"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+	"github.com/eventually-rs/eventually-go/command"
+	"github.com/eventually-rs/eventually-go/eventstore"
+	"github.com/eventually-rs/eventually-go/scenario"
+)
+
+func TestReopenAccount(t *testing.T) {
+	reopenedAt := time.Date(2021, time.March, 20, 10, 0, 0, 0, time.UTC)
+
+	created := eventstore.Event{
+		StreamType: account.Type.Name(),
+		StreamName: "test-account",
+		Version:    1,
+		Event: eventually.Event{
+			Payload: account.WasCreated{AccountID: "test-account"},
+		},
+	}
+
+	testCases := []struct {
+		name    string
+		payload interface{}
+	}{
+		{name: "frozen account is reopened", payload: account.WasFrozen{FrozenAt: reopenedAt.AddDate(0, 0, -5)}},
+		{name: "closed account is reopened", payload: account.WasClosed{ClosedAt: reopenedAt.AddDate(0, 0, -5)}},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			scenario.
+				CommandHandler().
+				Given(created, eventstore.Event{
+					StreamType: account.Type.Name(),
+					StreamName: "test-account",
+					Version:    2,
+					Event:      eventually.Event{Payload: tc.payload},
+				}).
+				When(eventually.Command{
+					Payload: account.ReopenAccount{AccountID: "test-account", ReopenedAt: reopenedAt},
+				}).
+				Then(eventstore.Event{
+					StreamType: account.Type.Name(),
+					StreamName: "test-account",
+					Version:    3,
+					Event: eventually.Event{
+						Payload: account.WasReopened{ReopenedAt: reopenedAt},
+					},
+				}).
+				Using(t, account.Type, func(r *aggregate.Repository) command.Handler {
+					return account.ReopenAccountCommandHandler{Repository: r}
+				})
+		})
+	}
+
+	t.Run("reopening an open account has no effect", func(t *testing.T) {
+		var then []eventstore.Event
+
+		scenario.
+			CommandHandler().
+			Given(created).
+			When(eventually.Command{
+				Payload: account.ReopenAccount{AccountID: "test-account", ReopenedAt: reopenedAt},
+			}).
+			Then(then...).
+			Using(t, account.Type, func(r *aggregate.Repository) command.Handler {
+				return account.ReopenAccountCommandHandler{Repository: r}
+			})
+	})
+}
@@ -0,0 +1,48 @@
+package account
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/saving"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+	"github.com/eventually-rs/eventually-go/command"
+)
+
+// ReplaceThresholds is the Domain Command used to replace all the Thresholds
+// of an Account's Saving Goal.
+type ReplaceThresholds struct {
+	AccountID  aggregate.StringID
+	Thresholds []saving.Threshold
+}
+
+// ReplaceThresholdsCommandHandler is the Command Handler for ReplaceThresholds commands.
+type ReplaceThresholdsCommandHandler struct {
+	Repository *aggregate.Repository
+}
+
+// CommandType returns a ReplaceThresholds instance to bind to this Handler.
+func (ReplaceThresholdsCommandHandler) CommandType() command.Command { return ReplaceThresholds{} }
+
+// Handle replaces the Thresholds of the Account's Saving Goal with the ones
+// specified in the Command.
+func (h ReplaceThresholdsCommandHandler) Handle(ctx context.Context, cmd eventually.Command) error {
+	command := cmd.Payload.(ReplaceThresholds)
+
+	account, err := h.Repository.Get(ctx, command.AccountID)
+	if err != nil {
+		return fmt.Errorf("account.ReplaceThresholdsCommandHandler: failed to get account: %w", err)
+	}
+
+	if err := account.(*Account).ReplaceThresholds(command.Thresholds); err != nil {
+		return fmt.Errorf("account.ReplaceThresholdsCommandHandler: failed to replace thresholds: %w", err)
+	}
+
+	if err := h.Repository.Add(ctx, account); err != nil {
+		return fmt.Errorf("account.ReplaceThresholdsCommandHandler: failed to save new account state: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,122 @@
+package account_test
+
+import (
+	"testing"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/account"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/saving"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+	"github.com/eventually-rs/eventually-go/command"
+	"github.com/eventually-rs/eventually-go/eventstore"
+	"github.com/eventually-rs/eventually-go/scenario"
+)
+
+func TestReplaceThresholds(t *testing.T) {
+	given := []eventstore.Event{
+		{
+			StreamType: account.Type.Name(),
+			StreamName: "test-account",
+			Version:    1,
+			Event: eventually.Event{
+				Payload: account.WasCreated{AccountID: "test-account"},
+			},
+		},
+		{
+			StreamType: account.Type.Name(),
+			StreamName: "test-account",
+			Version:    2,
+			Event: eventually.Event{
+				Payload: account.SavingGoalWasChanged{
+					SavingGoal: saving.Goal{
+						Amount:     500,
+						Thresholds: []saving.Threshold{saving.Percentage(0.5)},
+					},
+				},
+			},
+		},
+	}
+
+	testCases := []struct {
+		name       string
+		thresholds []saving.Threshold
+		err        error
+	}{
+		{
+			name: "command fails when no thresholds are specified",
+			err:  account.ErrAtLeastOneThreshold,
+		},
+		{
+			name:       "command fails when a threshold is not valid",
+			thresholds: []saving.Threshold{saving.Percentage(0.5), saving.Percentage(50)},
+			err:        saving.ErrInvalidThreshold,
+		},
+		{
+			name:       "command fails when the same threshold is specified twice",
+			thresholds: []saving.Threshold{saving.SpentAbove(100), saving.SpentAbove(100)},
+			err:        account.ErrThresholdAlreadyExists,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			scenario.
+				CommandHandler().
+				Given(given...).
+				When(eventually.Command{
+					Payload: account.ReplaceThresholds{
+						AccountID:  "test-account",
+						Thresholds: tc.thresholds,
+					},
+				}).
+				ThenError(tc.err).
+				Using(t, account.Type, func(r *aggregate.Repository) command.Handler {
+					return account.ReplaceThresholdsCommandHandler{Repository: r}
+				})
+		})
+	}
+
+	t.Run("command fails when the account has no saving goal set", func(t *testing.T) {
+		scenario.
+			CommandHandler().
+			Given(given[0]).
+			When(eventually.Command{
+				Payload: account.ReplaceThresholds{
+					AccountID:  "test-account",
+					Thresholds: []saving.Threshold{saving.Percentage(0.5)},
+				},
+			}).
+			ThenError(account.ErrNoSavingGoal).
+			Using(t, account.Type, func(r *aggregate.Repository) command.Handler {
+				return account.ReplaceThresholdsCommandHandler{Repository: r}
+			})
+	})
+
+	t.Run("thresholds are replaced", func(t *testing.T) {
+		thresholds := []saving.Threshold{saving.Percentage(0.8), saving.RemainingBelow(50)}
+
+		scenario.
+			CommandHandler().
+			Given(given...).
+			When(eventually.Command{
+				Payload: account.ReplaceThresholds{
+					AccountID:  "test-account",
+					Thresholds: thresholds,
+				},
+			}).
+			Then(eventstore.Event{
+				StreamType: account.Type.Name(),
+				StreamName: "test-account",
+				Version:    3,
+				Event: eventually.Event{
+					Payload: account.ThresholdsWereReplaced{Thresholds: thresholds},
+				},
+			}).
+			Using(t, account.Type, func(r *aggregate.Repository) command.Handler {
+				return account.ReplaceThresholdsCommandHandler{Repository: r}
+			})
+	})
+}
@@ -0,0 +1,47 @@
+package account
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/saving"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+	"github.com/eventually-rs/eventually-go/command"
+)
+
+// SetCategoryBudget is the Domain Command used to set the Budget
+// of a specific spending Category for an Account.
+type SetCategoryBudget struct {
+	AccountID aggregate.StringID
+	Budget    saving.Budget
+}
+
+// SetCategoryBudgetCommandHandler is the Command Handler for SetCategoryBudget commands.
+type SetCategoryBudgetCommandHandler struct {
+	Repository *aggregate.Repository
+}
+
+// CommandType returns a SetCategoryBudget instance to bind to this Handler.
+func (SetCategoryBudgetCommandHandler) CommandType() command.Command { return SetCategoryBudget{} }
+
+// Handle sets the Category Budget specified in the Command on the Account.
+func (h SetCategoryBudgetCommandHandler) Handle(ctx context.Context, cmd eventually.Command) error {
+	command := cmd.Payload.(SetCategoryBudget)
+
+	account, err := h.Repository.Get(ctx, command.AccountID)
+	if err != nil {
+		return fmt.Errorf("account.SetCategoryBudgetCommandHandler: failed to get account: %w", err)
+	}
+
+	if err := account.(*Account).SetCategoryBudget(command.Budget); err != nil {
+		return fmt.Errorf("account.SetCategoryBudgetCommandHandler: failed to set budget: %w", err)
+	}
+
+	if err := h.Repository.Add(ctx, account); err != nil {
+		return fmt.Errorf("account.SetCategoryBudgetCommandHandler: failed to save new account state: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+package account_test
+
+import (
+	"testing"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/account"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/saving"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+	"github.com/eventually-rs/eventually-go/command"
+	"github.com/eventually-rs/eventually-go/eventstore"
+	"github.com/eventually-rs/eventually-go/scenario"
+)
+
+func TestSetCategoryBudget(t *testing.T) {
+	accountWasCreated := eventstore.Event{
+		StreamType: account.Type.Name(),
+		StreamName: "test-account",
+		Version:    1,
+		Event: eventually.Event{
+			Payload: account.WasCreated{AccountID: "test-account"},
+		},
+	}
+
+	testCases := []struct {
+		name   string
+		budget saving.Budget
+		err    error
+	}{
+		{
+			name:   "command fails when no category is specified",
+			budget: saving.Budget{Amount: 300, Thresholds: []saving.Threshold{saving.Percentage(0.5)}},
+			err:    account.ErrBudgetWithoutCategory,
+		},
+		{
+			name:   "command fails when the budget amount is zero",
+			budget: saving.Budget{Category: "dining", Thresholds: []saving.Threshold{saving.Percentage(0.5)}},
+			err:    account.ErrBudgetIsZero,
+		},
+		{
+			name:   "command fails when no thresholds are specified",
+			budget: saving.Budget{Category: "dining", Amount: 300},
+			err:    account.ErrAtLeastOneThreshold,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			scenario.
+				CommandHandler().
+				Given(accountWasCreated).
+				When(eventually.Command{
+					Payload: account.SetCategoryBudget{
+						AccountID: "test-account",
+						Budget:    tc.budget,
+					},
+				}).
+				ThenError(tc.err).
+				Using(t, account.Type, func(r *aggregate.Repository) command.Handler {
+					return account.SetCategoryBudgetCommandHandler{Repository: r}
+				})
+		})
+	}
+
+	t.Run("valid budget is set", func(t *testing.T) {
+		budget := saving.Budget{
+			Category:   "dining",
+			Amount:     300,
+			Thresholds: []saving.Threshold{saving.Percentage(0.5), saving.Percentage(1)},
+		}
+
+		scenario.
+			CommandHandler().
+			Given(accountWasCreated).
+			When(eventually.Command{
+				Payload: account.SetCategoryBudget{
+					AccountID: "test-account",
+					Budget:    budget,
+				},
+			}).
+			Then(eventstore.Event{
+				StreamType: account.Type.Name(),
+				StreamName: "test-account",
+				Version:    2,
+				Event: eventually.Event{
+					Payload: account.CategoryBudgetWasSet{Budget: budget},
+				},
+			}).
+			Using(t, account.Type, func(r *aggregate.Repository) command.Handler {
+				return account.SetCategoryBudgetCommandHandler{Repository: r}
+			})
+	})
+}
@@ -20,7 +20,7 @@ func TestSetNewThreshold(t *testing.T) {
 			When(eventually.Command{
 				Payload: account.SetNewThreshold{
 					AccountID: "test-account",
-					Value:     0.35,
+					Value:     saving.Percentage(0.35),
 				},
 			}).
 			ThenFails().
@@ -45,7 +45,7 @@ func TestSetNewThreshold(t *testing.T) {
 			When(eventually.Command{
 				Payload: account.SetNewThreshold{
 					AccountID: "test-account",
-					Value:     0.35,
+					Value:     saving.Percentage(0.35),
 				},
 			}).
 			ThenError(account.ErrNoSavingGoal).
@@ -74,7 +74,7 @@ func TestSetNewThreshold(t *testing.T) {
 					Payload: account.SavingGoalWasChanged{
 						SavingGoal: saving.Goal{
 							Amount:     500,
-							Thresholds: []float64{0.25, 0.5},
+							Thresholds: []saving.Threshold{saving.Percentage(0.25), saving.Percentage(0.5)},
 						},
 					},
 				},
@@ -82,7 +82,7 @@ func TestSetNewThreshold(t *testing.T) {
 			When(eventually.Command{
 				Payload: account.SetNewThreshold{
 					AccountID: "test-account",
-					Value:     0.5,
+					Value:     saving.Percentage(0.5),
 				},
 			}).
 			ThenError(account.ErrThresholdAlreadyExists).
@@ -91,6 +91,89 @@ func TestSetNewThreshold(t *testing.T) {
 			})
 	})
 
+	t.Run("command fails when the threshold is not valid", func(t *testing.T) {
+		scenario.
+			CommandHandler().
+			Given(eventstore.Event{
+				StreamType: account.Type.Name(),
+				StreamName: "test-account",
+				Version:    1,
+				Event: eventually.Event{
+					Payload: account.WasCreated{
+						AccountID: "test-account",
+					},
+				},
+			}, eventstore.Event{
+				StreamType: account.Type.Name(),
+				StreamName: "test-account",
+				Version:    2,
+				Event: eventually.Event{
+					Payload: account.SavingGoalWasChanged{
+						SavingGoal: saving.Goal{
+							Amount:     500,
+							Thresholds: []saving.Threshold{saving.Percentage(0.25), saving.Percentage(0.5)},
+						},
+					},
+				},
+			}).
+			When(eventually.Command{
+				Payload: account.SetNewThreshold{
+					AccountID: "test-account",
+					Value:     saving.Percentage(50),
+				},
+			}).
+			ThenError(saving.ErrInvalidThreshold).
+			Using(t, account.Type, func(r *aggregate.Repository) command.Handler {
+				return account.SetNewThresholdCommandHandler{Repository: r}
+			})
+	})
+
+	t.Run("new threshold of a different kind is set", func(t *testing.T) {
+		scenario.
+			CommandHandler().
+			Given(eventstore.Event{
+				StreamType: account.Type.Name(),
+				StreamName: "test-account",
+				Version:    1,
+				Event: eventually.Event{
+					Payload: account.WasCreated{
+						AccountID: "test-account",
+					},
+				},
+			}, eventstore.Event{
+				StreamType: account.Type.Name(),
+				StreamName: "test-account",
+				Version:    2,
+				Event: eventually.Event{
+					Payload: account.SavingGoalWasChanged{
+						SavingGoal: saving.Goal{
+							Amount:     500,
+							Thresholds: []saving.Threshold{saving.Percentage(0.25), saving.Percentage(0.5)},
+						},
+					},
+				},
+			}).
+			When(eventually.Command{
+				Payload: account.SetNewThreshold{
+					AccountID: "test-account",
+					Value:     saving.RemainingBelow(100),
+				},
+			}).
+			Then(eventstore.Event{
+				StreamType: account.Type.Name(),
+				StreamName: "test-account",
+				Version:    3,
+				Event: eventually.Event{
+					Payload: account.ThresholdWasSet{
+						Threshold: saving.RemainingBelow(100),
+					},
+				},
+			}).
+			Using(t, account.Type, func(r *aggregate.Repository) command.Handler {
+				return account.SetNewThresholdCommandHandler{Repository: r}
+			})
+	})
+
 	t.Run("new threshold is set if was not set before in the account saving goal", func(t *testing.T) {
 		scenario.
 			CommandHandler().
@@ -111,7 +194,7 @@ func TestSetNewThreshold(t *testing.T) {
 					Payload: account.SavingGoalWasChanged{
 						SavingGoal: saving.Goal{
 							Amount:     500,
-							Thresholds: []float64{0.25, 0.5},
+							Thresholds: []saving.Threshold{saving.Percentage(0.25), saving.Percentage(0.5)},
 						},
 					},
 				},
@@ -119,7 +202,7 @@ func TestSetNewThreshold(t *testing.T) {
 			When(eventually.Command{
 				Payload: account.SetNewThreshold{
 					AccountID: "test-account",
-					Value:     0.75,
+					Value:     saving.Percentage(0.75),
 				},
 			}).
 			Then(eventstore.Event{
@@ -128,7 +211,7 @@ func TestSetNewThreshold(t *testing.T) {
 				Version:    3,
 				Event: eventually.Event{
 					Payload: account.ThresholdWasSet{
-						Threshold: 0.75,
+						Threshold: saving.Percentage(0.75),
 					},
 				},
 			}).
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/eventually-rs/saving-goals-go/internal/domain/saving"
+
 	"github.com/eventually-rs/eventually-go"
 	"github.com/eventually-rs/eventually-go/aggregate"
 	"github.com/eventually-rs/eventually-go/command"
@@ -13,7 +15,7 @@ import (
 // in an Account's Saving Goal.
 type SetNewThreshold struct {
 	AccountID aggregate.StringID
-	Value     float64
+	Value     saving.Threshold
 }
 
 // SetNewThresholdCommandHandler is the Command Handler for SetNewThreshold commands.
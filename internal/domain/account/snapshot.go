@@ -0,0 +1,118 @@
+package account
+
+import (
+	"time"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/category"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/goal"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/pacing"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/recurring"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/saving"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/sweep"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/transaction"
+
+	"github.com/eventually-rs/eventually-go/aggregate"
+)
+
+// SnapshotVersion is the schema version of the Account Snapshot.
+//
+// Increase it whenever the Snapshot type or the meaning of its fields change:
+// Snapshots with a different version are discarded, and the Account
+// is loaded by replaying all of its Domain Events.
+const SnapshotVersion = 2
+
+// Snapshot is the state of an Account at a certain version, used to load it
+// without replaying all of its Domain Events.
+//
+// A Snapshot is applied to the Account as any other Domain Event,
+// replacing its whole state.
+type Snapshot struct {
+	AccountID           string
+	Balance             float64
+	SavingGoal          *saving.Goal
+	CategorizationRules []category.Rule
+	Transactions        map[string]TransactionSnapshot
+	Budgets             map[category.Category]saving.Budget
+	Goals               map[string]goal.Goal
+	Pacing              pacing.Strategy
+	Series              map[string]recurring.Series
+	SweepRules          []sweep.Rule
+	Status              Status
+	Forgotten           bool
+}
+
+// TransactionSnapshot is the state of a transaction recorded
+// with an identifier, part of the Account Snapshot.
+type TransactionSnapshot struct {
+	Amount     float64
+	Kind       transaction.Kind
+	Details    transaction.Details
+	HappenedAt time.Time
+	Category   category.Category
+}
+
+// Snapshot returns the current state of the Account.
+func (a *Account) Snapshot() interface{} {
+	transactions := make(map[string]TransactionSnapshot, len(a.transactions))
+	for id, tx := range a.transactions {
+		transactions[id] = TransactionSnapshot{
+			Amount:     tx.amount,
+			Kind:       tx.kind,
+			Details:    tx.details,
+			HappenedAt: tx.happenedAt,
+			Category:   tx.category,
+		}
+	}
+
+	return Snapshot{
+		AccountID:           a.accountID.String(),
+		Balance:             a.balance,
+		SavingGoal:          a.savingGoal,
+		CategorizationRules: category.Rules(a.categorizationRules),
+		Transactions:        transactions,
+		Budgets:             a.budgets,
+		Goals:               a.goals,
+		Pacing:              a.pacing,
+		Series:              a.series,
+		SweepRules:          a.sweepRules,
+		Status:              a.status,
+		Forgotten:           a.forgotten,
+	}
+}
+
+func (a *Account) restore(snapshot Snapshot) {
+	a.accountID = aggregate.StringID(snapshot.AccountID)
+	a.balance = snapshot.Balance
+	a.savingGoal = snapshot.SavingGoal
+	a.categorizationRules = category.CompileAll(snapshot.CategorizationRules)
+	a.transactions = make(map[string]recordedTransaction, len(snapshot.Transactions))
+	a.budgets = make(map[category.Category]saving.Budget, len(snapshot.Budgets))
+	a.goals = make(map[string]goal.Goal, len(snapshot.Goals))
+	a.pacing = snapshot.Pacing
+	a.series = make(map[string]recurring.Series, len(snapshot.Series))
+	a.sweepRules = snapshot.SweepRules
+	a.status = snapshot.Status
+	a.forgotten = snapshot.Forgotten
+
+	for id, tx := range snapshot.Transactions {
+		a.transactions[id] = recordedTransaction{
+			amount:     tx.Amount,
+			kind:       tx.Kind,
+			details:    tx.Details,
+			happenedAt: tx.HappenedAt,
+			category:   tx.Category,
+		}
+	}
+
+	for c, budget := range snapshot.Budgets {
+		a.budgets[c] = budget
+	}
+
+	for id, g := range snapshot.Goals {
+		a.goals[id] = g
+	}
+
+	for id, series := range snapshot.Series {
+		a.series[id] = series
+	}
+}
@@ -0,0 +1,50 @@
+package account_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/account"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/transaction"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnapshot(t *testing.T) {
+	t.Run("forgotten accounts are restored as forgotten", func(t *testing.T) {
+		acc, err := account.Create("test-account")
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		happenedAt := time.Date(2021, time.March, 12, 10, 0, 0, 0, time.UTC)
+		assert.NoError(t, acc.RecordTransaction("test-transaction", -10, transaction.Expense, transaction.Details{}, happenedAt))
+
+		changed, err := acc.Forget()
+		assert.NoError(t, err)
+		assert.True(t, changed)
+
+		// Snapshots are saved as JSON.
+		state, err := json.Marshal(acc.Snapshot())
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		var snapshot account.Snapshot
+		if !assert.NoError(t, json.Unmarshal(state, &snapshot)) {
+			return
+		}
+
+		assert.True(t, snapshot.Forgotten)
+
+		restored := new(account.Account)
+		assert.NoError(t, restored.Apply(eventually.Event{Payload: snapshot}))
+		assert.Equal(t, acc.Snapshot(), restored.Snapshot())
+
+		changed, err = restored.Forget()
+		assert.NoError(t, err)
+		assert.False(t, changed)
+	})
+}
@@ -0,0 +1,170 @@
+package account
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/goal"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/sweep"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+)
+
+var (
+	// ErrNoSweepRuleID is returned when adding a new sweep rule
+	// without specifying its identifier.
+	ErrNoSweepRuleID = fmt.Errorf("account.AddSweepRule: rule id should be specified")
+
+	// ErrSweepRuleAlreadyExists is returned when adding a new sweep rule
+	// with the same identifier of an existing one.
+	ErrSweepRuleAlreadyExists = fmt.Errorf("account.AddSweepRule: rule already exists")
+
+	// ErrSweepRuleNotFound is returned when removing a sweep rule
+	// that does not exist.
+	ErrSweepRuleNotFound = fmt.Errorf("account.RemoveSweepRule: rule not found")
+)
+
+// SweepRuleWasAdded is the Domain Event triggered by the Aggregate
+// when a new rule to automatically save money for a Goal has been added
+// to the Account.
+type SweepRuleWasAdded struct {
+	Rule sweep.Rule
+}
+
+// SweepRuleWasRemoved is the Domain Event triggered by the Aggregate
+// when a sweep rule has been removed from the Account.
+type SweepRuleWasRemoved struct {
+	RuleID string
+}
+
+// SweepWasTriggered is the Domain Event triggered by the Aggregate
+// when a recorded transaction matches one of the Account's sweep rules,
+// and some money should be moved to one of its Goals.
+type SweepWasTriggered struct {
+	RuleID        string
+	Kind          sweep.Kind
+	GoalID        string
+	TransactionID string
+	Amount        float64
+	HappenedAt    time.Time
+}
+
+// AddSweepRule adds a new rule to automatically save money for one of the Account's Goals.
+//
+// An error is returned if the rule is not valid, if its Goal does not exist,
+// or if a rule with the same identifier already exists.
+func (a *Account) AddSweepRule(rule sweep.Rule) error {
+	if rule.ID == "" {
+		return ErrNoSweepRuleID
+	}
+
+	if err := rule.Validate(); err != nil {
+		return fmt.Errorf("account.AddSweepRule: invalid rule: %w", err)
+	}
+
+	if _, ok := a.goals[rule.GoalID]; !ok {
+		return fmt.Errorf("account.AddSweepRule: %w", ErrGoalNotFound)
+	}
+
+	for _, r := range a.sweepRules {
+		if r.ID == rule.ID {
+			return ErrSweepRuleAlreadyExists
+		}
+	}
+
+	err := aggregate.RecordThat(a, eventually.Event{
+		Payload: SweepRuleWasAdded{Rule: rule},
+	})
+
+	if err != nil {
+		return fmt.Errorf("account.AddSweepRule: failed to record domain event: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveSweepRule removes the sweep rule with the specified identifier.
+//
+// ErrSweepRuleNotFound is returned if no such rule exists.
+func (a *Account) RemoveSweepRule(ruleID string) error {
+	found := false
+
+	for _, r := range a.sweepRules {
+		if r.ID == ruleID {
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		return ErrSweepRuleNotFound
+	}
+
+	err := aggregate.RecordThat(a, eventually.Event{
+		Payload: SweepRuleWasRemoved{RuleID: ruleID},
+	})
+
+	if err != nil {
+		return fmt.Errorf("account.RemoveSweepRule: failed to record domain event: %w", err)
+	}
+
+	return nil
+}
+
+// sweep applies the Account's sweep rules to the specified transaction,
+// recording a SweepWasTriggered event for each rule with some money to save.
+//
+// Rules saving for Goals that have been removed or already achieved are skipped.
+func (a *Account) sweep(transactionID string) error {
+	tx := a.transactions[transactionID]
+
+	for _, rule := range a.sweepRules {
+		if g, ok := a.goals[rule.GoalID]; !ok || g.Status != goal.Active {
+			continue
+		}
+
+		amount := rule.AmountFor(tx.amount, tx.kind)
+		if amount <= 0 {
+			continue
+		}
+
+		err := aggregate.RecordThat(a, eventually.Event{
+			Payload: SweepWasTriggered{
+				RuleID:        rule.ID,
+				Kind:          rule.Kind,
+				GoalID:        rule.GoalID,
+				TransactionID: transactionID,
+				Amount:        amount,
+				HappenedAt:    tx.happenedAt,
+			},
+		})
+
+		if err != nil {
+			return fmt.Errorf("account.sweep: failed to record domain event: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// applySweepRuleEvent applies the sweep rule-related Domain Events
+// to the rules specified, returning the updated rules.
+func applySweepRuleEvent(rules []sweep.Rule, payload interface{}) []sweep.Rule {
+	switch evt := payload.(type) {
+	case SweepRuleWasAdded:
+		return append(rules, evt.Rule)
+
+	case SweepRuleWasRemoved:
+		result := make([]sweep.Rule, 0, len(rules))
+		for _, rule := range rules {
+			if rule.ID != evt.RuleID {
+				result = append(result, rule)
+			}
+		}
+
+		return result
+	}
+
+	return rules
+}
@@ -0,0 +1,133 @@
+package account
+
+import (
+	"fmt"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/saving"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+)
+
+// ErrThresholdNotFound is returned when trying to remove a threshold
+// that is not set in the Account's Saving Goal.
+var ErrThresholdNotFound = fmt.Errorf("account.RemoveThreshold: threshold not found")
+
+// ThresholdWasRemoved is the Domain Event triggered by the Aggregate
+// when a Threshold has been removed from the Account's Saving Goal.
+type ThresholdWasRemoved struct {
+	Threshold saving.Threshold
+}
+
+// ThresholdsWereReplaced is the Domain Event triggered by the Aggregate
+// when all the Thresholds of the Account's Saving Goal have been replaced.
+type ThresholdsWereReplaced struct {
+	Thresholds []saving.Threshold
+}
+
+// RemoveThreshold removes a threshold from the Account's Saving Goal.
+//
+// ErrNoSavingGoal is returned if the Account has no Saving Goal set.
+//
+// ErrThresholdNotFound is returned if the threshold is not set.
+//
+// ErrAtLeastOneThreshold is returned if the threshold is the last one
+// of the Saving Goal: use DisableSavingGoal instead.
+//
+// ErrAccountClosed or ErrAccountFrozen is returned if the Account is not open.
+func (a *Account) RemoveThreshold(threshold saving.Threshold) error {
+	if err := a.ensureGoalsCanChange(); err != nil {
+		return fmt.Errorf("account.RemoveThreshold: %w", err)
+	}
+
+	if a.savingGoal == nil {
+		return fmt.Errorf("account.RemoveThreshold: %w", ErrNoSavingGoal)
+	}
+
+	found := false
+
+	for _, th := range a.savingGoal.Thresholds {
+		if th == threshold {
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		return ErrThresholdNotFound
+	}
+
+	if len(a.savingGoal.Thresholds) == 1 {
+		return fmt.Errorf("account.RemoveThreshold: %w", ErrAtLeastOneThreshold)
+	}
+
+	err := aggregate.RecordThat(a, eventually.Event{
+		Payload: ThresholdWasRemoved{Threshold: threshold},
+	})
+
+	if err != nil {
+		return fmt.Errorf("account.RemoveThreshold: failed to record domain event: %w", err)
+	}
+
+	return nil
+}
+
+// ReplaceThresholds replaces all the thresholds of the Account's Saving Goal
+// with the specified ones.
+//
+// ErrNoSavingGoal is returned if the Account has no Saving Goal set.
+//
+// An error is returned if no thresholds have been specified, if any of
+// the thresholds is not valid, or if the same threshold is specified twice.
+//
+// ErrAccountClosed or ErrAccountFrozen is returned if the Account is not open.
+func (a *Account) ReplaceThresholds(thresholds []saving.Threshold) error {
+	if err := a.ensureGoalsCanChange(); err != nil {
+		return fmt.Errorf("account.ReplaceThresholds: %w", err)
+	}
+
+	if a.savingGoal == nil {
+		return fmt.Errorf("account.ReplaceThresholds: %w", ErrNoSavingGoal)
+	}
+
+	if len(thresholds) < 1 {
+		return fmt.Errorf("account.ReplaceThresholds: %w", ErrAtLeastOneThreshold)
+	}
+
+	seen := make(map[saving.Threshold]struct{}, len(thresholds))
+
+	for _, threshold := range thresholds {
+		if err := threshold.Validate(); err != nil {
+			return fmt.Errorf("account.ReplaceThresholds: %w", err)
+		}
+
+		if _, ok := seen[threshold]; ok {
+			return fmt.Errorf("account.ReplaceThresholds: %w: %s", ErrThresholdAlreadyExists, threshold)
+		}
+
+		seen[threshold] = struct{}{}
+	}
+
+	err := aggregate.RecordThat(a, eventually.Event{
+		Payload: ThresholdsWereReplaced{Thresholds: thresholds},
+	})
+
+	if err != nil {
+		return fmt.Errorf("account.ReplaceThresholds: failed to record domain event: %w", err)
+	}
+
+	return nil
+}
+
+// withoutThreshold returns a copy of the thresholds without the specified one.
+func withoutThreshold(thresholds []saving.Threshold, threshold saving.Threshold) []saving.Threshold {
+	result := make([]saving.Threshold, 0, len(thresholds))
+
+	for _, th := range thresholds {
+		if th != threshold {
+			result = append(result, th)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,46 @@
+package account
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/goal"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+	"github.com/eventually-rs/eventually-go/command"
+)
+
+// UpdateGoal is the Domain Command used to change the settings of an Account's Goal.
+type UpdateGoal struct {
+	AccountID aggregate.StringID
+	Goal      goal.Goal
+}
+
+// UpdateGoalCommandHandler is the Command Handler for UpdateGoal commands.
+type UpdateGoalCommandHandler struct {
+	Repository *aggregate.Repository
+}
+
+// CommandType returns a UpdateGoal instance to bind to this Handler.
+func (UpdateGoalCommandHandler) CommandType() command.Command { return UpdateGoal{} }
+
+// Handle updates the Goal specified in the Command, using its identifier.
+func (h UpdateGoalCommandHandler) Handle(ctx context.Context, cmd eventually.Command) error {
+	command := cmd.Payload.(UpdateGoal)
+
+	account, err := h.Repository.Get(ctx, command.AccountID)
+	if err != nil {
+		return fmt.Errorf("account.UpdateGoalCommandHandler: failed to get account: %w", err)
+	}
+
+	if err := account.(*Account).UpdateGoal(command.Goal); err != nil {
+		return fmt.Errorf("account.UpdateGoalCommandHandler: failed to update goal: %w", err)
+	}
+
+	if err := h.Repository.Add(ctx, account); err != nil {
+		return fmt.Errorf("account.UpdateGoalCommandHandler: failed to save new account state: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,150 @@
+package account_test
+
+import (
+	"testing"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/account"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/goal"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+	"github.com/eventually-rs/eventually-go/command"
+	"github.com/eventually-rs/eventually-go/eventstore"
+	"github.com/eventually-rs/eventually-go/scenario"
+)
+
+func TestUpdateGoal(t *testing.T) {
+	given := []eventstore.Event{
+		{
+			StreamType: account.Type.Name(),
+			StreamName: "test-account",
+			Version:    1,
+			Event: eventually.Event{
+				Payload: account.WasCreated{AccountID: "test-account"},
+			},
+		},
+		{
+			StreamType: account.Type.Name(),
+			StreamName: "test-account",
+			Version:    2,
+			Event: eventually.Event{
+				Payload: account.GoalWasAdded{
+					Goal: goal.Goal{
+						ID:                  "emergency-fund",
+						Name:                "Emergency fund",
+						TargetAmount:        1000,
+						MonthlyContribution: 200,
+						Status:              goal.Active,
+					},
+				},
+			},
+		},
+		{
+			StreamType: account.Type.Name(),
+			StreamName: "test-account",
+			Version:    3,
+			Event: eventually.Event{
+				Payload: account.GoalContributionWasRecorded{GoalID: "emergency-fund", Amount: 600},
+			},
+		},
+	}
+
+	t.Run("command fails when the goal does not exist", func(t *testing.T) {
+		scenario.
+			CommandHandler().
+			Given(given...).
+			When(eventually.Command{
+				Payload: account.UpdateGoal{
+					AccountID: "test-account",
+					Goal:      goal.Goal{ID: "holiday", Name: "Holiday", TargetAmount: 100, MonthlyContribution: 10},
+				},
+			}).
+			ThenError(account.ErrGoalNotFound).
+			Using(t, account.Type, func(r *aggregate.Repository) command.Handler {
+				return account.UpdateGoalCommandHandler{Repository: r}
+			})
+	})
+
+	t.Run("updated goal keeps the amount saved", func(t *testing.T) {
+		scenario.
+			CommandHandler().
+			Given(given...).
+			When(eventually.Command{
+				Payload: account.UpdateGoal{
+					AccountID: "test-account",
+					Goal: goal.Goal{
+						ID:                  "emergency-fund",
+						Name:                "Rainy days",
+						TargetAmount:        5000,
+						MonthlyContribution: 300,
+						Priority:            1,
+					},
+				},
+			}).
+			Then(eventstore.Event{
+				StreamType: account.Type.Name(),
+				StreamName: "test-account",
+				Version:    4,
+				Event: eventually.Event{
+					Payload: account.GoalWasUpdated{
+						Goal: goal.Goal{
+							ID:                  "emergency-fund",
+							Name:                "Rainy days",
+							TargetAmount:        5000,
+							MonthlyContribution: 300,
+							Priority:            1,
+							SavedAmount:         600,
+							Status:              goal.Active,
+						},
+					},
+				},
+			}).
+			Using(t, account.Type, func(r *aggregate.Repository) command.Handler {
+				return account.UpdateGoalCommandHandler{Repository: r}
+			})
+	})
+
+	t.Run("lowering the target below the amount saved achieves the goal", func(t *testing.T) {
+		scenario.
+			CommandHandler().
+			Given(given...).
+			When(eventually.Command{
+				Payload: account.UpdateGoal{
+					AccountID: "test-account",
+					Goal: goal.Goal{
+						ID:                  "emergency-fund",
+						Name:                "Emergency fund",
+						TargetAmount:        500,
+						MonthlyContribution: 200,
+					},
+				},
+			}).
+			Then(eventstore.Event{
+				StreamType: account.Type.Name(),
+				StreamName: "test-account",
+				Version:    4,
+				Event: eventually.Event{
+					Payload: account.GoalWasUpdated{
+						Goal: goal.Goal{
+							ID:                  "emergency-fund",
+							Name:                "Emergency fund",
+							TargetAmount:        500,
+							MonthlyContribution: 200,
+							SavedAmount:         600,
+							Status:              goal.Active,
+						},
+					},
+				},
+			}, eventstore.Event{
+				StreamType: account.Type.Name(),
+				StreamName: "test-account",
+				Version:    5,
+				Event: eventually.Event{
+					Payload: account.GoalWasAchieved{GoalID: "emergency-fund"},
+				},
+			}).
+			Using(t, account.Type, func(r *aggregate.Repository) command.Handler {
+				return account.UpdateGoalCommandHandler{Repository: r}
+			})
+	})
+}
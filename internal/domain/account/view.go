@@ -0,0 +1,224 @@
+package account
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/category"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/goal"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/pacing"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/recurring"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/saving"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/sweep"
+
+	"github.com/eventually-rs/eventually-go/eventstore"
+	"github.com/eventually-rs/eventually-go/projection"
+	"github.com/eventually-rs/eventually-go/query"
+)
+
+var _ projection.Projection = &ViewProjection{}
+
+// ErrNotFound is returned by ViewProjection when the Account requested
+// does not exist.
+var ErrNotFound = fmt.Errorf("account.View: account not found")
+
+// ViewQuery is the Domain Query used to fetch the current state
+// of a single Account.
+type ViewQuery struct {
+	AccountID string
+}
+
+// View is the Domain Answer returned from a ViewQuery, containing
+// the current state of the requested Account.
+type View struct {
+	AccountID           string
+	Balance             float64
+	SavingGoal          *saving.Goal
+	CategorizationRules []category.Rule
+	Budgets             []saving.Budget
+	Goals               []goal.Goal
+	Pacing              pacing.Strategy
+	RecurringSeries     []recurring.Series
+	SweepRules          []sweep.Rule
+	Status              Status
+}
+
+// ViewProjection listens to Account Domain Events to build the
+// current state of all the Accounts.
+type ViewProjection struct {
+	mx       sync.RWMutex
+	accounts map[string]View
+	budgets  map[string]map[category.Category]saving.Budget
+	goals    map[string]map[string]goal.Goal
+	series   map[string]map[string]recurring.Series
+}
+
+// NewViewProjection returns a new instance of ViewProjection type.
+func NewViewProjection() *ViewProjection {
+	return &ViewProjection{
+		accounts: make(map[string]View),
+		budgets:  make(map[string]map[category.Category]saving.Budget),
+		goals:    make(map[string]map[string]goal.Goal),
+		series:   make(map[string]map[string]recurring.Series),
+	}
+}
+
+// QueryType binds the ViewQuery type to the projection.
+func (*ViewProjection) QueryType() query.Query { return ViewQuery{} }
+
+// Apply updates the state of the projection using the incoming event.
+func (p *ViewProjection) Apply(ctx context.Context, event eventstore.Event) error {
+	p.mx.Lock()
+	defer p.mx.Unlock()
+
+	if evt, ok := event.Payload.(WasCreated); ok {
+		p.accounts[evt.AccountID] = View{AccountID: evt.AccountID, Pacing: pacing.Linear, Status: Open}
+		p.budgets[evt.AccountID] = make(map[category.Category]saving.Budget)
+		p.goals[evt.AccountID] = make(map[string]goal.Goal)
+		p.series[evt.AccountID] = make(map[string]recurring.Series)
+
+		return nil
+	}
+
+	// The Stream name of forgotten Accounts is pseudonymized.
+	if evt, ok := event.Payload.(WasForgotten); ok {
+		delete(p.accounts, evt.AccountID)
+		delete(p.budgets, evt.AccountID)
+		delete(p.goals, evt.AccountID)
+		delete(p.series, evt.AccountID)
+
+		return nil
+	}
+
+	view, ok := p.accounts[event.StreamName]
+	if !ok {
+		return nil
+	}
+
+	switch evt := event.Payload.(type) {
+	case SavingGoalWasChanged:
+		goal := evt.SavingGoal
+		goal.Thresholds = append([]saving.Threshold{}, evt.SavingGoal.Thresholds...)
+		view.SavingGoal = &goal
+
+	case ThresholdWasSet:
+		if view.SavingGoal != nil {
+			view.SavingGoal.Thresholds = append(view.SavingGoal.Thresholds, evt.Threshold)
+		}
+
+	case ThresholdWasRemoved:
+		if view.SavingGoal != nil {
+			view.SavingGoal.Thresholds = withoutThreshold(view.SavingGoal.Thresholds, evt.Threshold)
+		}
+
+	case ThresholdsWereReplaced:
+		if view.SavingGoal != nil {
+			view.SavingGoal.Thresholds = append([]saving.Threshold{}, evt.Thresholds...)
+		}
+
+	case SavingGoalWasDisabled:
+		view.SavingGoal = nil
+
+	case TransactionWasRecorded:
+		view.Balance += evt.Amount
+		applyRecurringSeriesEvent(p.series[event.StreamName], evt)
+
+	case RecurringSeriesDetected, RecurringSeriesConfirmed, RecurringSeriesDismissed:
+		applyRecurringSeriesEvent(p.series[event.StreamName], evt)
+
+	case CategorizationRuleWasAdded:
+		view.CategorizationRules = append(view.CategorizationRules, evt.Rule)
+
+	case CategorizationRuleWasRemoved:
+		rules := make([]category.Rule, 0, len(view.CategorizationRules))
+		for _, rule := range view.CategorizationRules {
+			if rule.ID != evt.RuleID {
+				rules = append(rules, rule)
+			}
+		}
+
+		view.CategorizationRules = rules
+
+	case CategoryBudgetWasSet:
+		p.budgets[event.StreamName][evt.Budget.Category] = evt.Budget
+
+	case CategoryBudgetWasRemoved:
+		delete(p.budgets[event.StreamName], evt.Category)
+
+	case GoalWasAdded, GoalWasUpdated, GoalWasRemoved, GoalContributionWasRecorded, GoalWasAchieved:
+		applyGoalEvent(p.goals[event.StreamName], evt)
+
+	case PacingStrategyWasChanged:
+		view.Pacing = evt.Strategy
+
+	case SweepRuleWasAdded, SweepRuleWasRemoved:
+		view.SweepRules = applySweepRuleEvent(view.SweepRules, evt)
+
+	case WasFrozen:
+		view.Status = Frozen
+
+	case WasClosed:
+		view.Status = Closed
+
+	case WasReopened:
+		view.Status = Open
+	}
+
+	p.accounts[event.StreamName] = view
+
+	return nil
+}
+
+// Handle returns a copy of the current state of the Account requested.
+//
+// ErrNotFound is returned if the Account does not exist.
+func (p *ViewProjection) Handle(ctx context.Context, q query.Query) (query.Answer, error) {
+	p.mx.RLock()
+	defer p.mx.RUnlock()
+
+	accountID := q.(ViewQuery).AccountID
+
+	view, ok := p.accounts[accountID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, accountID)
+	}
+
+	if view.SavingGoal != nil {
+		goal := *view.SavingGoal
+		goal.Thresholds = append([]saving.Threshold{}, view.SavingGoal.Thresholds...)
+		view.SavingGoal = &goal
+	}
+
+	view.CategorizationRules = append([]category.Rule{}, view.CategorizationRules...)
+	view.SweepRules = append([]sweep.Rule{}, view.SweepRules...)
+	view.Budgets = sortedBudgets(p.budgets[accountID])
+	view.Goals = sortedGoals(p.goals[accountID])
+	view.RecurringSeries = sortedSeries(p.series[accountID])
+
+	return view, nil
+}
+
+// View returns the state of the Account as a View, the same returned
+// by the ViewProjection for the same Events.
+func (a Account) View() View {
+	view := View{
+		AccountID:           a.accountID.String(),
+		Balance:             a.balance,
+		CategorizationRules: category.Rules(a.categorizationRules),
+		Budgets:             sortedBudgets(a.budgets),
+		Goals:               sortedGoals(a.goals),
+		Pacing:              a.pacing,
+		RecurringSeries:     sortedSeries(a.series),
+		SweepRules:          append([]sweep.Rule{}, a.sweepRules...),
+		Status:              a.status,
+	}
+
+	if a.savingGoal != nil {
+		goal := *a.savingGoal
+		goal.Thresholds = append([]saving.Threshold{}, a.savingGoal.Thresholds...)
+		view.SavingGoal = &goal
+	}
+
+	return view
+}
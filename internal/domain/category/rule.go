@@ -0,0 +1,182 @@
+package category
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/transaction"
+)
+
+var (
+	// ErrNoCategory is returned when validating a Rule that does not
+	// specify the Category to assign.
+	ErrNoCategory = fmt.Errorf("category.Rule: category should be specified")
+
+	// ErrNoCriteria is returned when validating a Rule that does not
+	// specify any matching criteria.
+	ErrNoCriteria = fmt.Errorf("category.Rule: at least one matching criteria should be specified")
+
+	// ErrInvalidDescriptionPattern is returned when validating a Rule
+	// with a description pattern that is not a valid regular expression.
+	ErrInvalidDescriptionPattern = fmt.Errorf("category.Rule: description pattern is not a valid regular expression")
+
+	// ErrInvalidAmountRange is returned when validating a Rule with
+	// a minimum amount higher than its maximum amount.
+	ErrInvalidAmountRange = fmt.Errorf("category.Rule: minimum amount should not be higher than maximum amount")
+)
+
+// Category is the spending category assigned to a transaction,
+// e.g. "groceries", "rent" or "entertainment".
+type Category string
+
+// Uncategorized is the Category of transactions that did not match any Rule.
+const Uncategorized Category = ""
+
+// Rule is a user-defined rule to assign a Category to a transaction.
+//
+// A Rule matches a transaction only if all the criteria specified match:
+// unspecified criteria are ignored.
+type Rule struct {
+	ID       string
+	Category Category
+
+	// Priority is used to choose between multiple matching Rules:
+	// Rules with higher priority win. Rules with the same priority
+	// are evaluated in the order they have been added.
+	Priority int
+
+	// Merchant matches the transaction merchant, ignoring the case.
+	Merchant string
+
+	// DescriptionPattern is a regular expression matching the transaction description.
+	DescriptionPattern string
+
+	// MCC matches the transaction Merchant Category Code.
+	MCC string
+
+	// MinAmount and MaxAmount match the absolute transaction amount,
+	// both bounds included.
+	MinAmount *float64
+	MaxAmount *float64
+}
+
+// Validate checks the Rule is well-formed and can be used to categorize transactions.
+func (r Rule) Validate() error {
+	if r.Category == Uncategorized {
+		return ErrNoCategory
+	}
+
+	if r.Merchant == "" && r.DescriptionPattern == "" && r.MCC == "" && r.MinAmount == nil && r.MaxAmount == nil {
+		return ErrNoCriteria
+	}
+
+	if _, err := regexp.Compile(r.DescriptionPattern); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidDescriptionPattern, err)
+	}
+
+	if r.MinAmount != nil && r.MaxAmount != nil && *r.MinAmount > *r.MaxAmount {
+		return ErrInvalidAmountRange
+	}
+
+	return nil
+}
+
+// CompiledRule is a Rule ready to match transactions, with its
+// description pattern compiled only once.
+//
+// Use Rule.Compile to create a new instance.
+type CompiledRule struct {
+	Rule
+
+	description *regexp.Regexp
+	invalid     bool
+}
+
+// Compile returns the CompiledRule to match transactions with the Rule.
+//
+// Rules with an invalid description pattern never match any transaction:
+// use Validate to check the Rule beforehand.
+func (r Rule) Compile() CompiledRule {
+	compiled := CompiledRule{Rule: r}
+
+	if r.DescriptionPattern != "" {
+		pattern, err := regexp.Compile(r.DescriptionPattern)
+		compiled.description = pattern
+		compiled.invalid = err != nil
+	}
+
+	return compiled
+}
+
+// Matches returns true if the transaction specified satisfies all the Rule criteria.
+func (r CompiledRule) Matches(amount float64, details transaction.Details) bool {
+	if r.invalid {
+		return false
+	}
+
+	if r.Merchant != "" && !strings.EqualFold(r.Merchant, details.Merchant) {
+		return false
+	}
+
+	if r.MCC != "" && r.MCC != details.MCC {
+		return false
+	}
+
+	amount = math.Abs(amount)
+
+	if r.MinAmount != nil && amount < *r.MinAmount {
+		return false
+	}
+
+	if r.MaxAmount != nil && amount > *r.MaxAmount {
+		return false
+	}
+
+	if r.description != nil && !r.description.MatchString(details.Description) {
+		return false
+	}
+
+	return true
+}
+
+// CompileAll returns the CompiledRules of the specified Rules, in the same order.
+func CompileAll(rules []Rule) []CompiledRule {
+	compiled := make([]CompiledRule, 0, len(rules))
+	for _, rule := range rules {
+		compiled = append(compiled, rule.Compile())
+	}
+
+	return compiled
+}
+
+// Rules returns the Rules of the specified CompiledRules, in the same order.
+func Rules(compiled []CompiledRule) []Rule {
+	rules := make([]Rule, 0, len(compiled))
+	for _, rule := range compiled {
+		rules = append(rules, rule.Rule)
+	}
+
+	return rules
+}
+
+// Categorize returns the Rule with the highest priority matching the
+// specified transaction, if any.
+func Categorize(rules []CompiledRule, amount float64, details transaction.Details) (Rule, bool) {
+	sorted := make([]CompiledRule, len(rules))
+	copy(sorted, rules)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Priority > sorted[j].Priority
+	})
+
+	for _, rule := range sorted {
+		if rule.Matches(amount, details) {
+			return rule.Rule, true
+		}
+	}
+
+	return Rule{}, false
+}
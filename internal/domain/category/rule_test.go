@@ -0,0 +1,124 @@
+package category_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/category"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/transaction"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func amount(v float64) *float64 { return &v }
+
+func TestRuleValidate(t *testing.T) {
+	testCases := []struct {
+		name string
+		rule category.Rule
+		err  error
+	}{
+		{
+			name: "rule without category",
+			rule: category.Rule{Merchant: "Supermarket"},
+			err:  category.ErrNoCategory,
+		},
+		{
+			name: "rule without criteria",
+			rule: category.Rule{Category: "groceries"},
+			err:  category.ErrNoCriteria,
+		},
+		{
+			name: "rule with invalid description pattern",
+			rule: category.Rule{Category: "groceries", DescriptionPattern: "(unclosed"},
+			err:  category.ErrInvalidDescriptionPattern,
+		},
+		{
+			name: "rule with invalid amount range",
+			rule: category.Rule{Category: "groceries", MinAmount: amount(100), MaxAmount: amount(10)},
+			err:  category.ErrInvalidAmountRange,
+		},
+		{
+			name: "valid rule",
+			rule: category.Rule{Category: "groceries", MCC: "5411", MaxAmount: amount(200)},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.rule.Validate()
+
+			if tc.err == nil {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.True(t, errors.Is(err, tc.err), "unexpected error: %v", err)
+		})
+	}
+}
+
+func TestCategorize(t *testing.T) {
+	rules := []category.Rule{
+		{ID: "groceries", Category: "groceries", MCC: "5411"},
+		{ID: "small-groceries", Category: "snacks", MCC: "5411", MaxAmount: amount(5), Priority: 1},
+		{ID: "rent", Category: "rent", Merchant: "landlord ltd", MinAmount: amount(500)},
+		{ID: "cinema", Category: "entertainment", DescriptionPattern: "(?i)movie|cinema"},
+		{ID: "invalid", Category: "broken", DescriptionPattern: "(movie", Priority: 2},
+	}
+
+	testCases := []struct {
+		name     string
+		amount   float64
+		details  transaction.Details
+		category category.Category
+	}{
+		{
+			name:     "mcc match",
+			amount:   -45,
+			details:  transaction.Details{MCC: "5411"},
+			category: "groceries",
+		},
+		{
+			name:     "higher priority rule wins",
+			amount:   -3,
+			details:  transaction.Details{MCC: "5411"},
+			category: "snacks",
+		},
+		{
+			name:     "merchant match is case insensitive",
+			amount:   -800,
+			details:  transaction.Details{Merchant: "Landlord Ltd"},
+			category: "rent",
+		},
+		{
+			name:     "all criteria should match",
+			amount:   -50,
+			details:  transaction.Details{Merchant: "Landlord Ltd"},
+			category: category.Uncategorized,
+		},
+		{
+			name:     "description pattern match",
+			amount:   -12,
+			details:  transaction.Details{Description: "Movie tickets"},
+			category: "entertainment",
+		},
+		{
+			name:     "rule with an invalid pattern never matches",
+			amount:   -12,
+			details:  transaction.Details{Description: "(movie"},
+			category: "entertainment",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			rule, _ := category.Categorize(category.CompileAll(rules), tc.amount, tc.details)
+			assert.Equal(t, tc.category, rule.Category)
+		})
+	}
+}
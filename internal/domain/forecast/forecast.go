@@ -0,0 +1,307 @@
+package forecast
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/interval"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/recurring"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/transaction"
+)
+
+const (
+	// historyWindow is the number of days before the forecast date used
+	// to compute the average daily spending.
+	historyWindow = 90
+
+	// recurringMonths is the number of months before the forecast month
+	// inspected to find recurring payments.
+	recurringMonths = 3
+
+	// recurringOccurrences is the minimum number of months, among the ones
+	// inspected, a payment should appear in to be considered recurring.
+	recurringOccurrences = 2
+)
+
+// Transaction is a transaction of the Account's history used to forecast
+// the spending of the month.
+type Transaction struct {
+	Amount     float64
+	Kind       transaction.Kind
+	Details    transaction.Details
+	HappenedAt time.Time
+}
+
+// key returns the identifier used to recognize the same payment across
+// different months, or an empty string if the transaction has no details.
+func (t Transaction) key() string {
+	return recurring.SeriesID(t.Details)
+}
+
+// RecurringPayment is a payment, either incoming or outgoing, expected
+// to happen every month on the same day with a similar amount,
+// like rent, subscriptions or the salary.
+type RecurringPayment struct {
+	Key        string
+	DayOfMonth int
+	Amount     float64
+}
+
+// Forecast is the prediction of the Account's balance at the end of the month.
+type Forecast struct {
+	Date                 time.Time
+	DaysLeft             int
+	AverageDailySpending float64
+	ExpectedSpending     float64
+	RecurringPayments    float64
+	ExpectedBalance      float64
+	DesiredBalance       float64
+
+	// Probability is the probability of the balance at the end of the month
+	// being at least the desired one, between 0 and 1.
+	Probability float64
+}
+
+// HistorySince returns the time of the oldest transaction of the history
+// used to forecast the month of the specified date.
+func HistorySince(date time.Time) time.Time {
+	year, month, _ := date.Date()
+	since := time.Date(year, month-recurringMonths, 1, 0, 0, 0, 0, date.Location())
+
+	if window := date.AddDate(0, 0, -historyWindow-1); window.Before(since) {
+		return window
+	}
+
+	return since
+}
+
+// Compute forecasts the balance at the end of the month of the specified date,
+// starting from the current balance, using the Account's transaction history.
+//
+// The forecast is the sum of:
+//
+//   - the recurring payments still expected in the month, see DetectRecurring,
+//   - the spending expected in the days left, using the moving average of the
+//     daily spending in the last 90 days, adjusted for each day with the
+//     average spending of the same day of the month.
+//
+// The probability of reaching the desired balance assumes the daily spending
+// to be normally distributed, with the variance observed in the history.
+func Compute(history []Transaction, date time.Time, currentBalance, desiredBalance float64) Forecast {
+	month := interval.MonthFromTime(date)
+	payments := DetectRecurring(history, date)
+
+	recurringKeys := make(map[string]bool, len(payments))
+	for _, payment := range payments {
+		recurringKeys[payment.Key] = true
+	}
+
+	stats := newDailyStatistics(history, date, recurringKeys)
+
+	year, m, day := date.Date()
+	lastDay := time.Date(year, m+1, 0, 0, 0, 0, 0, date.Location()).Day()
+
+	forecast := Forecast{
+		Date:                 date,
+		DaysLeft:             lastDay - day,
+		AverageDailySpending: stats.mean,
+		DesiredBalance:       desiredBalance,
+	}
+
+	for d := day + 1; d <= lastDay; d++ {
+		forecast.ExpectedSpending += stats.expectedOn(d)
+	}
+
+	paidThisMonth := make(map[string]bool)
+	for _, tx := range history {
+		if interval.MonthFromTime(tx.HappenedAt) == month && !tx.HappenedAt.After(date) {
+			paidThisMonth[tx.key()] = true
+		}
+	}
+
+	for _, payment := range payments {
+		if !paidThisMonth[payment.Key] && payment.DayOfMonth > day {
+			forecast.RecurringPayments += payment.Amount
+		}
+	}
+
+	forecast.ExpectedBalance = currentBalance + forecast.RecurringPayments - forecast.ExpectedSpending
+
+	deviation := stats.deviation * math.Sqrt(float64(forecast.DaysLeft))
+	forecast.Probability = probabilityAtLeast(forecast.ExpectedBalance, deviation, desiredBalance)
+
+	return forecast
+}
+
+// DetectRecurring returns the payments found once a month, with a similar amount,
+// in at least two of the three months before the month of the specified date,
+// sorted by key.
+//
+// Payments are recognized using their merchant, or their description
+// if no merchant is available. Internal transfers and refunds are ignored.
+func DetectRecurring(history []Transaction, date time.Time) []RecurringPayment {
+	month := interval.MonthFromTime(date)
+	occurrences := make(map[string]map[int][]Transaction)
+
+	for _, tx := range history {
+		kind := transaction.Classify(tx.Kind, tx.Amount)
+		if kind != transaction.Income && kind != transaction.Expense {
+			continue
+		}
+
+		key := tx.key()
+		monthsAgo := interval.MonthFromTime(tx.HappenedAt).MonthsUntil(month)
+
+		if key == "" || monthsAgo < 1 || monthsAgo > recurringMonths {
+			continue
+		}
+
+		if occurrences[key] == nil {
+			occurrences[key] = make(map[int][]Transaction)
+		}
+
+		occurrences[key][monthsAgo] = append(occurrences[key][monthsAgo], tx)
+	}
+
+	var result []RecurringPayment
+
+	for key, byMonth := range occurrences {
+		if len(byMonth) < recurringOccurrences {
+			continue
+		}
+
+		var latest Transaction
+		for monthsAgo := recurringMonths; monthsAgo >= 1; monthsAgo-- {
+			if txs, ok := byMonth[monthsAgo]; ok {
+				latest = txs[0]
+			}
+		}
+
+		isRecurring := true
+		for _, txs := range byMonth {
+			// Payments happening more than once a month are part of the daily spending.
+			if len(txs) > 1 || !recurring.Similar(latest.Amount, txs[0].Amount) {
+				isRecurring = false
+				break
+			}
+		}
+
+		if isRecurring {
+			result = append(result, RecurringPayment{
+				Key:        key,
+				DayOfMonth: latest.HappenedAt.Day(),
+				Amount:     latest.Amount,
+			})
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Key < result[j].Key
+	})
+
+	return result
+}
+
+// dailyStatistics contains the statistics of the daily spending
+// in the history window, excluding the recurring payments.
+type dailyStatistics struct {
+	mean       float64
+	deviation  float64
+	byDayTotal map[int]float64
+	byDayCount map[int]int
+}
+
+func newDailyStatistics(history []Transaction, date time.Time, recurringKeys map[string]bool) dailyStatistics {
+	year, month, day := date.Date()
+	end := time.Date(year, month, day, 0, 0, 0, 0, date.Location())
+	start := end.AddDate(0, 0, -historyWindow)
+
+	// The window starts from the first transaction available, to avoid
+	// underestimating the spending of Accounts with a short history.
+	first := end
+	for _, tx := range history {
+		if !tx.HappenedAt.Before(start) && tx.HappenedAt.Before(first) {
+			first = tx.HappenedAt
+		}
+	}
+
+	fy, fm, fd := first.Date()
+	start = time.Date(fy, fm, fd, 0, 0, 0, 0, date.Location())
+
+	totals := make(map[string]float64)
+	for _, tx := range history {
+		if tx.HappenedAt.Before(start) || !tx.HappenedAt.Before(end) || recurringKeys[tx.key()] {
+			continue
+		}
+
+		dayKey := tx.HappenedAt.In(date.Location()).Format("2006-01-02")
+
+		switch transaction.Classify(tx.Kind, tx.Amount) {
+		case transaction.Expense:
+			totals[dayKey] += math.Abs(tx.Amount)
+		case transaction.Refund:
+			totals[dayKey] -= math.Abs(tx.Amount)
+		}
+	}
+
+	stats := dailyStatistics{
+		byDayTotal: make(map[int]float64),
+		byDayCount: make(map[int]int),
+	}
+
+	var days []float64
+	for d := start; d.Before(end); d = d.AddDate(0, 0, 1) {
+		total := math.Max(0, totals[d.Format("2006-01-02")])
+
+		days = append(days, total)
+		stats.byDayTotal[d.Day()] += total
+		stats.byDayCount[d.Day()]++
+	}
+
+	if len(days) == 0 {
+		return stats
+	}
+
+	for _, total := range days {
+		stats.mean += total
+	}
+
+	stats.mean /= float64(len(days))
+
+	var variance float64
+	for _, total := range days {
+		variance += (total - stats.mean) * (total - stats.mean)
+	}
+
+	stats.deviation = math.Sqrt(variance / float64(len(days)))
+
+	return stats
+}
+
+// expectedOn returns the spending expected on the specified day of the month,
+// as the average of the daily mean and the mean of the same day in the history.
+func (s dailyStatistics) expectedOn(dayOfMonth int) float64 {
+	count := s.byDayCount[dayOfMonth]
+	if count == 0 {
+		return s.mean
+	}
+
+	return (s.mean + s.byDayTotal[dayOfMonth]/float64(count)) / 2
+}
+
+// probabilityAtLeast returns the probability of a normally-distributed value,
+// with the specified mean and standard deviation, being at least the threshold.
+func probabilityAtLeast(mean, deviation, threshold float64) float64 {
+	if deviation == 0 {
+		if mean >= threshold {
+			return 1
+		}
+
+		return 0
+	}
+
+	z := (mean - threshold) / deviation
+
+	return 0.5 * (1 + math.Erf(z/math.Sqrt2))
+}
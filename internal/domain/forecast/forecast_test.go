@@ -0,0 +1,139 @@
+package forecast_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/forecast"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/transaction"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func day(month time.Month, d int) time.Time {
+	return time.Date(2021, month, d, 12, 0, 0, 0, time.UTC)
+}
+
+func payment(merchant string, amount float64, happenedAt time.Time) forecast.Transaction {
+	return forecast.Transaction{
+		Amount:     amount,
+		Kind:       transaction.Classify(transaction.Unspecified, amount),
+		Details:    transaction.Details{Merchant: merchant},
+		HappenedAt: happenedAt,
+	}
+}
+
+// history returns the rent paid at the start of each month, the salary received
+// at the end of each month, and the daily groceries since the start of the year.
+func history(groceries func(time.Time) float64) []forecast.Transaction {
+	result := []forecast.Transaction{
+		payment("Landlord", -800, day(time.January, 1)),
+		payment("Acme Corp", 2000, day(time.January, 28)),
+		payment("Landlord", -800, day(time.February, 1)),
+		payment("Acme Corp", 2050, day(time.February, 26)),
+		payment("Landlord", -800, day(time.March, 1)),
+	}
+
+	for d := day(time.January, 1); d.Before(day(time.March, 15)); d = d.AddDate(0, 0, 1) {
+		result = append(result, payment("Grocery Store", groceries(d), d))
+	}
+
+	return result
+}
+
+func TestDetectRecurring(t *testing.T) {
+	payments := []forecast.Transaction{
+		payment("Landlord", -800, day(time.January, 1)),
+		payment("Landlord", -800, day(time.February, 1)),
+		payment("Acme Corp", 2000, day(time.January, 28)),
+		payment("ACME Corp ", 2050, day(time.February, 26)),
+		payment("Gym", -50, day(time.February, 3)),
+		payment("Electricity", -60, day(time.January, 10)),
+		payment("Electricity", -100, day(time.February, 10)),
+		payment("Streaming", -10, day(time.November, 10).AddDate(-1, 0, 0)),
+		payment("Streaming", -10, day(time.December, 10).AddDate(-1, 0, 0)),
+		{
+			Amount:     -500,
+			Kind:       transaction.InternalTransfer,
+			Details:    transaction.Details{Description: "Savings"},
+			HappenedAt: day(time.January, 5),
+		},
+		{
+			Amount:     -500,
+			Kind:       transaction.InternalTransfer,
+			Details:    transaction.Details{Description: "Savings"},
+			HappenedAt: day(time.February, 5),
+		},
+	}
+
+	assert.Equal(t, []forecast.RecurringPayment{
+		{Key: "acme-corp", DayOfMonth: 26, Amount: 2050},
+		{Key: "landlord", DayOfMonth: 1, Amount: -800},
+	}, forecast.DetectRecurring(payments, day(time.March, 15)))
+}
+
+func TestCompute(t *testing.T) {
+	t.Run("recurring payments and daily spending are used to forecast the balance", func(t *testing.T) {
+		history := history(func(time.Time) float64 { return -30 })
+
+		f := forecast.Compute(history, day(time.March, 15), 1000, 2500)
+
+		assert.Equal(t, 16, f.DaysLeft)
+		assert.InDelta(t, 30, f.AverageDailySpending, 1e-9)
+		assert.InDelta(t, 480, f.ExpectedSpending, 1e-9)
+		assert.Equal(t, 2050.0, f.RecurringPayments)
+		assert.InDelta(t, 2570, f.ExpectedBalance, 1e-9)
+		assert.Equal(t, 1.0, f.Probability)
+	})
+
+	t.Run("desired balance above a certain forecast is never reached", func(t *testing.T) {
+		history := history(func(time.Time) float64 { return -30 })
+
+		f := forecast.Compute(history, day(time.March, 15), 1000, 2600)
+
+		assert.Equal(t, 0.0, f.Probability)
+	})
+
+	t.Run("same day of the month spending is taken into account", func(t *testing.T) {
+		history := history(func(d time.Time) float64 {
+			if d.Day() == 20 {
+				return -90
+			}
+
+			return -30
+		})
+
+		f := forecast.Compute(history, day(time.March, 15), 1000, 2500)
+
+		// Each day is expected to cost the average between the daily mean
+		// and the mean of the same day of the month, which is higher on the 20th.
+		mean := f.AverageDailySpending
+		assert.InDelta(t, 15*(mean+30)/2+(mean+90)/2, f.ExpectedSpending, 1e-9)
+	})
+
+	t.Run("probability reflects the variability of the daily spending", func(t *testing.T) {
+		history := history(func(d time.Time) float64 {
+			if d.Day()%2 == 0 {
+				return -10
+			}
+
+			return -50
+		})
+
+		f := forecast.Compute(history, day(time.March, 15), 1000, 0)
+		assert.Greater(t, f.Probability, 0.99)
+
+		f = forecast.Compute(history, day(time.March, 15), 1000, f.ExpectedBalance)
+		assert.InDelta(t, 0.5, f.Probability, 1e-9)
+
+		f = forecast.Compute(history, day(time.March, 15), 1000, f.ExpectedBalance+100)
+		assert.Less(t, f.Probability, 0.5)
+	})
+
+	t.Run("account without history is forecast to keep its balance", func(t *testing.T) {
+		f := forecast.Compute(nil, day(time.March, 15), 1000, 1000)
+
+		assert.Equal(t, 1000.0, f.ExpectedBalance)
+		assert.Equal(t, 1.0, f.Probability)
+	})
+}
@@ -0,0 +1,123 @@
+// Package goal contains the named Saving Goals an Account's Owner
+// can save money for, e.g. "holiday: 2,000 by June".
+package goal
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/interval"
+)
+
+var (
+	// ErrNoName is returned when a Goal has no name.
+	ErrNoName = fmt.Errorf("goal.Validate: goal name should be specified")
+
+	// ErrTargetIsZero is returned when a Goal has a target amount
+	// that is not more than zero.
+	ErrTargetIsZero = fmt.Errorf("goal.Validate: goal target amount should be more than zero")
+
+	// ErrNegativeContribution is returned when a Goal has a negative
+	// monthly contribution.
+	ErrNegativeContribution = fmt.Errorf("goal.Validate: goal monthly contribution should not be negative")
+
+	// ErrNoContribution is returned when a Goal has neither a deadline
+	// nor a monthly contribution, so it would never be reached.
+	ErrNoContribution = fmt.Errorf("goal.Validate: goal should have either a deadline or a monthly contribution")
+)
+
+// Status is the lifecycle status of a Goal.
+type Status string
+
+const (
+	// Active Goals are still being saved for, and contribute
+	// to the monthly spending limit.
+	Active Status = "active"
+
+	// Achieved Goals have reached their target amount.
+	Achieved Status = "achieved"
+)
+
+// Goal is a named amount of money the Account's Owner wants to save,
+// optionally by a deadline.
+type Goal struct {
+	ID           string
+	Name         string
+	TargetAmount float64
+
+	// Deadline is the day by which the TargetAmount should be saved,
+	// zero-valued if the Goal has no deadline.
+	Deadline time.Time
+
+	// MonthlyContribution is the amount to save every month for Goals
+	// without a deadline. For Goals with a deadline, it is the minimum
+	// amount to save every month.
+	MonthlyContribution float64
+
+	// Priority is used to sort the Goals, where higher values come first.
+	Priority int
+
+	SavedAmount float64
+	Status      Status
+}
+
+// Validate returns an error if the Goal is not valid.
+func (g Goal) Validate() error {
+	if g.Name == "" {
+		return ErrNoName
+	}
+
+	if g.TargetAmount <= 0 {
+		return ErrTargetIsZero
+	}
+
+	if g.MonthlyContribution < 0 {
+		return ErrNegativeContribution
+	}
+
+	if g.Deadline.IsZero() && g.MonthlyContribution == 0 {
+		return ErrNoContribution
+	}
+
+	return nil
+}
+
+// RemainingAmount returns the amount still to save to reach the Goal's target.
+func (g Goal) RemainingAmount() float64 {
+	return math.Max(0, g.TargetAmount-g.SavedAmount)
+}
+
+// Progress returns the fraction of the target amount already saved.
+func (g Goal) Progress() float64 {
+	return math.Min(1, g.SavedAmount/g.TargetAmount)
+}
+
+// RequiredMonthlyContribution returns the amount that should be saved
+// in the specified month to reach the Goal's target.
+//
+// Goals with a deadline split the remaining amount evenly across the months
+// left, including the specified one and the deadline's month, unless the
+// MonthlyContribution is higher. Once the deadline has passed, the whole
+// remaining amount is required.
+//
+// Goals that are not active require no contribution.
+func (g Goal) RequiredMonthlyContribution(month interval.Month) float64 {
+	remaining := g.RemainingAmount()
+	if g.Status != Active || remaining == 0 {
+		return 0
+	}
+
+	contribution := g.MonthlyContribution
+
+	if !g.Deadline.IsZero() {
+		monthsLeft := month.MonthsUntil(interval.MonthFromTime(g.Deadline)) + 1
+		if monthsLeft < 1 {
+			monthsLeft = 1
+		}
+
+		contribution = math.Max(contribution, remaining/float64(monthsLeft))
+	}
+
+	return math.Min(contribution, remaining)
+}
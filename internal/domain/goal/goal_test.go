@@ -0,0 +1,127 @@
+package goal_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/goal"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/interval"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGoalValidate(t *testing.T) {
+	deadline := time.Date(2021, time.June, 30, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name string
+		goal goal.Goal
+		err  error
+	}{
+		{
+			name: "goal without name",
+			goal: goal.Goal{TargetAmount: 2000, Deadline: deadline},
+			err:  goal.ErrNoName,
+		},
+		{
+			name: "goal without target amount",
+			goal: goal.Goal{Name: "holiday", Deadline: deadline},
+			err:  goal.ErrTargetIsZero,
+		},
+		{
+			name: "goal with negative monthly contribution",
+			goal: goal.Goal{Name: "holiday", TargetAmount: 2000, MonthlyContribution: -1},
+			err:  goal.ErrNegativeContribution,
+		},
+		{
+			name: "goal without deadline and monthly contribution",
+			goal: goal.Goal{Name: "emergency fund", TargetAmount: 10000},
+			err:  goal.ErrNoContribution,
+		},
+		{
+			name: "goal with deadline",
+			goal: goal.Goal{Name: "holiday", TargetAmount: 2000, Deadline: deadline},
+		},
+		{
+			name: "goal with monthly contribution",
+			goal: goal.Goal{Name: "emergency fund", TargetAmount: 10000, MonthlyContribution: 200},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.goal.Validate()
+
+			if tc.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.True(t, errors.Is(err, tc.err), "error", err)
+			}
+		})
+	}
+}
+
+func TestGoalRequiredMonthlyContribution(t *testing.T) {
+	january := interval.Month{Year: 2021, Month: time.January}
+	june := time.Date(2021, time.June, 30, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name     string
+		goal     goal.Goal
+		month    interval.Month
+		expected float64
+	}{
+		{
+			name:     "remaining amount is split across the months left",
+			goal:     goal.Goal{TargetAmount: 2000, SavedAmount: 200, Deadline: june, Status: goal.Active},
+			month:    january,
+			expected: 300,
+		},
+		{
+			name: "monthly contribution is used if higher",
+			goal: goal.Goal{
+				TargetAmount:        2000,
+				Deadline:            june,
+				MonthlyContribution: 500,
+				Status:              goal.Active,
+			},
+			month:    january,
+			expected: 500,
+		},
+		{
+			name:     "whole remaining amount is required after the deadline",
+			goal:     goal.Goal{TargetAmount: 2000, SavedAmount: 1500, Deadline: june, Status: goal.Active},
+			month:    interval.Month{Year: 2021, Month: time.August},
+			expected: 500,
+		},
+		{
+			name:     "goal without deadline requires the monthly contribution",
+			goal:     goal.Goal{TargetAmount: 10000, MonthlyContribution: 200, Status: goal.Active},
+			month:    january,
+			expected: 200,
+		},
+		{
+			name:     "contribution never exceeds the remaining amount",
+			goal:     goal.Goal{TargetAmount: 10000, SavedAmount: 9900, MonthlyContribution: 200, Status: goal.Active},
+			month:    january,
+			expected: 100,
+		},
+		{
+			name:     "achieved goal requires no contribution",
+			goal:     goal.Goal{TargetAmount: 10000, SavedAmount: 10000, MonthlyContribution: 200, Status: goal.Achieved},
+			month:    january,
+			expected: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.goal.RequiredMonthlyContribution(tc.month))
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package household
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+	"github.com/eventually-rs/eventually-go/command"
+)
+
+// AcceptInvitation is the Domain Command used by an invited Account
+// to join a Household.
+type AcceptInvitation struct {
+	HouseholdID aggregate.StringID
+	AccountID   string
+}
+
+// AcceptInvitationCommandHandler is the Command Handler for AcceptInvitation commands.
+type AcceptInvitationCommandHandler struct {
+	Repository *aggregate.Repository
+}
+
+// CommandType returns an AcceptInvitation instance to bind to this Handler.
+func (AcceptInvitationCommandHandler) CommandType() command.Command { return AcceptInvitation{} }
+
+// Handle makes the invited Account specified in the Command dispatched
+// a member of the Household.
+func (h AcceptInvitationCommandHandler) Handle(ctx context.Context, cmd eventually.Command) error {
+	command := cmd.Payload.(AcceptInvitation)
+
+	household, err := h.Repository.Get(ctx, command.HouseholdID)
+	if err != nil {
+		return fmt.Errorf("household.AcceptInvitationCommandHandler: failed to get household: %w", err)
+	}
+
+	if err := household.(*Household).AcceptInvitation(command.AccountID); err != nil {
+		return fmt.Errorf("household.AcceptInvitationCommandHandler: failed to accept invitation: %w", err)
+	}
+
+	if err := h.Repository.Add(ctx, household); err != nil {
+		return fmt.Errorf("household.AcceptInvitationCommandHandler: failed to save new household state: %w", err)
+	}
+
+	return nil
+}
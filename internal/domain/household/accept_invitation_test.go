@@ -0,0 +1,59 @@
+package household_test
+
+import (
+	"testing"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/household"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+	"github.com/eventually-rs/eventually-go/command"
+	"github.com/eventually-rs/eventually-go/eventstore"
+	"github.com/eventually-rs/eventually-go/scenario"
+)
+
+func TestAcceptInvitation(t *testing.T) {
+	given := []eventstore.Event{
+		householdEvent(1, household.WasCreated{HouseholdID: "test-household", Name: "Home", FoundedBy: "alice"}),
+		householdEvent(2, household.MemberWasInvited{AccountID: "bob", InvitedBy: "alice"}),
+	}
+
+	t.Run("invited account joins the household", func(t *testing.T) {
+		scenario.
+			CommandHandler().
+			Given(given...).
+			When(eventually.Command{
+				Payload: household.AcceptInvitation{HouseholdID: "test-household", AccountID: "bob"},
+			}).
+			Then(householdEvent(3, household.InvitationWasAccepted{AccountID: "bob"})).
+			Using(t, household.Type, func(r *aggregate.Repository) command.Handler {
+				return household.AcceptInvitationCommandHandler{Repository: r}
+			})
+	})
+
+	t.Run("invitations can only be accepted once", func(t *testing.T) {
+		scenario.
+			CommandHandler().
+			Given(append(given, householdEvent(3, household.InvitationWasAccepted{AccountID: "bob"}))...).
+			When(eventually.Command{
+				Payload: household.AcceptInvitation{HouseholdID: "test-household", AccountID: "bob"},
+			}).
+			ThenError(household.ErrNotInvited).
+			Using(t, household.Type, func(r *aggregate.Repository) command.Handler {
+				return household.AcceptInvitationCommandHandler{Repository: r}
+			})
+	})
+
+	t.Run("accounts without invitation cannot join", func(t *testing.T) {
+		scenario.
+			CommandHandler().
+			Given(given...).
+			When(eventually.Command{
+				Payload: household.AcceptInvitation{HouseholdID: "test-household", AccountID: "carol"},
+			}).
+			ThenError(household.ErrNotInvited).
+			Using(t, household.Type, func(r *aggregate.Repository) command.Handler {
+				return household.AcceptInvitationCommandHandler{Repository: r}
+			})
+	})
+}
@@ -0,0 +1,332 @@
+package household
+
+import (
+	"fmt"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/interval"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/saving"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+)
+
+var (
+	// ErrNoHouseholdID is returned when creating a new Household
+	// without specifying its identifier.
+	ErrNoHouseholdID = fmt.Errorf("household.Create: household id should be specified")
+
+	// ErrNoFounder is returned when creating a new Household
+	// without specifying the Account of its first member.
+	ErrNoFounder = fmt.Errorf("household.Create: founding account should be specified")
+
+	// ErrNotMember is returned when an Account that is not a member
+	// of the Household tries to act on it.
+	ErrNotMember = fmt.Errorf("household.Household: account is not a member")
+
+	// ErrAlreadyMember is returned when inviting an Account
+	// that is already a member of the Household.
+	ErrAlreadyMember = fmt.Errorf("household.Invite: account is already a member")
+
+	// ErrAlreadyInvited is returned when inviting an Account
+	// that has a pending invitation to the Household.
+	ErrAlreadyInvited = fmt.Errorf("household.Invite: account has already been invited")
+
+	// ErrNotInvited is returned when accepting an invitation
+	// that does not exist.
+	ErrNotInvited = fmt.Errorf("household.AcceptInvitation: account has not been invited")
+
+	// ErrAtLeastOneThreshold is returned when no thresholds have been specified
+	// in the Household's Saving Goal.
+	ErrAtLeastOneThreshold = fmt.Errorf("household.ChangeSavingGoal: at least one threshold should be specified")
+
+	// ErrGoalIsZero is returned when changing the Household's Saving Goal
+	// with a zero target amount.
+	ErrGoalIsZero = fmt.Errorf("household.ChangeSavingGoal: saving goal should be more than zero")
+)
+
+// Type defines the Household aggregate type.
+var Type = aggregate.NewType("household", func() aggregate.Root {
+	return new(Household)
+})
+
+// Household is an Aggregate type that groups the Accounts of people
+// saving together, e.g. a couple, towards a shared Saving Goal.
+//
+// The spending of all the members is tracked together every month,
+// and the thresholds reached are notified to all of them.
+type Household struct {
+	aggregate.BaseRoot
+
+	id          aggregate.StringID
+	members     map[string]struct{}
+	invitations map[string]struct{}
+}
+
+// AggregateID returns the identifier of the Household Aggregate.
+func (h Household) AggregateID() aggregate.ID { return h.id }
+
+// Members returns the identifiers of the member Accounts of the Household,
+// sorted alphabetically.
+func (h Household) Members() []string { return sortedKeys(h.members) }
+
+// WasCreated is the Domain Event triggered by the Aggregate
+// when a new Household has been created by one of its members.
+type WasCreated struct {
+	HouseholdID string
+	Name        string
+	FoundedBy   string
+}
+
+// MemberWasInvited is the Domain Event triggered by the Aggregate
+// when a member invites another Account to join the Household.
+type MemberWasInvited struct {
+	AccountID string
+	InvitedBy string
+}
+
+// InvitationWasAccepted is the Domain Event triggered by the Aggregate
+// when an invited Account joins the Household.
+type InvitationWasAccepted struct {
+	AccountID string
+}
+
+// MemberLeft is the Domain Event triggered by the Aggregate
+// when a member leaves the Household.
+type MemberLeft struct {
+	AccountID string
+}
+
+// SavingGoalWasChanged is the Domain Event triggered by the Aggregate
+// when the shared Saving Goal of the Household has been changed.
+type SavingGoalWasChanged struct {
+	SavingGoal saving.Goal
+}
+
+// MembersWereNotified is the Domain Event triggered by the Aggregate
+// when the combined spending of the Household reaches one of the
+// thresholds of its Saving Goal, listing the members to notify.
+type MembersWereNotified struct {
+	Month     interval.Month
+	Threshold saving.Threshold
+	Members   []string
+}
+
+// The Household Events refer to the Accounts of its members, which are
+// pseudonymized in the Event Store: the Accounts forgotten in the meantime
+// are read back with an empty identifier.
+
+// MapSubjects returns a copy of the Event with the founding Account mapped.
+func (e WasCreated) MapSubjects(f func(string) string) interface{} {
+	e.FoundedBy = f(e.FoundedBy)
+	return e
+}
+
+// MapSubjects returns a copy of the Event with the Accounts mapped.
+func (e MemberWasInvited) MapSubjects(f func(string) string) interface{} {
+	e.AccountID, e.InvitedBy = f(e.AccountID), f(e.InvitedBy)
+	return e
+}
+
+// MapSubjects returns a copy of the Event with the Account mapped.
+func (e InvitationWasAccepted) MapSubjects(f func(string) string) interface{} {
+	e.AccountID = f(e.AccountID)
+	return e
+}
+
+// MapSubjects returns a copy of the Event with the Account mapped.
+func (e MemberLeft) MapSubjects(f func(string) string) interface{} {
+	e.AccountID = f(e.AccountID)
+	return e
+}
+
+// MapSubjects returns a copy of the Event with the Accounts mapped.
+func (e MembersWereNotified) MapSubjects(f func(string) string) interface{} {
+	members := make([]string, 0, len(e.Members))
+	for _, member := range e.Members {
+		members = append(members, f(member))
+	}
+
+	e.Members = members
+
+	return e
+}
+
+// Apply applies the Domain Event received onto the Aggregate Root
+// by mutating the Root's state accordingly.
+func (h *Household) Apply(event eventually.Event) error {
+	switch evt := event.Payload.(type) {
+	case WasCreated:
+		h.id = aggregate.StringID(evt.HouseholdID)
+		h.members = map[string]struct{}{evt.FoundedBy: {}}
+		h.invitations = make(map[string]struct{})
+
+	case MemberWasInvited:
+		h.invitations[evt.AccountID] = struct{}{}
+
+	case InvitationWasAccepted:
+		delete(h.invitations, evt.AccountID)
+		h.members[evt.AccountID] = struct{}{}
+
+	case MemberLeft:
+		delete(h.members, evt.AccountID)
+
+	case SavingGoalWasChanged, MembersWereNotified:
+		// The Saving Goal is only used by the read models to start tracking
+		// the spending, while notifications do not change the Household state.
+
+	default:
+		return fmt.Errorf("household.Household: unsupported event received")
+	}
+
+	return nil
+}
+
+// Create creates a new Household, founded by the specified Account,
+// which becomes its first member.
+func Create(householdID, name, foundedBy string) (*Household, error) {
+	if householdID == "" {
+		return nil, ErrNoHouseholdID
+	}
+
+	if foundedBy == "" {
+		return nil, ErrNoFounder
+	}
+
+	var household Household
+
+	err := aggregate.RecordThat(&household, eventually.Event{
+		Payload: WasCreated{
+			HouseholdID: householdID,
+			Name:        name,
+			FoundedBy:   foundedBy,
+		},
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("household.Create: failed to record domain event: %w", err)
+	}
+
+	return &household, nil
+}
+
+// Invite invites the specified Account to join the Household,
+// on behalf of one of its members.
+//
+// An error is returned if the inviting Account is not a member, or if the
+// invited Account is already a member or has a pending invitation.
+func (h *Household) Invite(accountID, invitedBy string) error {
+	if _, ok := h.members[invitedBy]; !ok {
+		return fmt.Errorf("household.Invite: %w", ErrNotMember)
+	}
+
+	if _, ok := h.members[accountID]; ok {
+		return ErrAlreadyMember
+	}
+
+	if _, ok := h.invitations[accountID]; ok {
+		return ErrAlreadyInvited
+	}
+
+	err := aggregate.RecordThat(h, eventually.Event{
+		Payload: MemberWasInvited{AccountID: accountID, InvitedBy: invitedBy},
+	})
+
+	if err != nil {
+		return fmt.Errorf("household.Invite: failed to record domain event: %w", err)
+	}
+
+	return nil
+}
+
+// AcceptInvitation makes the invited Account a member of the Household.
+//
+// ErrNotInvited is returned if the Account has no pending invitation.
+func (h *Household) AcceptInvitation(accountID string) error {
+	if _, ok := h.invitations[accountID]; !ok {
+		return ErrNotInvited
+	}
+
+	err := aggregate.RecordThat(h, eventually.Event{
+		Payload: InvitationWasAccepted{AccountID: accountID},
+	})
+
+	if err != nil {
+		return fmt.Errorf("household.AcceptInvitation: failed to record domain event: %w", err)
+	}
+
+	return nil
+}
+
+// Leave removes the specified Account from the members of the Household.
+//
+// ErrNotMember is returned if the Account is not a member.
+func (h *Household) Leave(accountID string) error {
+	if _, ok := h.members[accountID]; !ok {
+		return fmt.Errorf("household.Leave: %w", ErrNotMember)
+	}
+
+	err := aggregate.RecordThat(h, eventually.Event{
+		Payload: MemberLeft{AccountID: accountID},
+	})
+
+	if err != nil {
+		return fmt.Errorf("household.Leave: failed to record domain event: %w", err)
+	}
+
+	return nil
+}
+
+// ChangeSavingGoal changes the shared Saving Goal of the Household.
+//
+// An error is returned if no thresholds have been specified, if any of the
+// thresholds is not valid, or if the Saving Goal target amount is zero.
+func (h *Household) ChangeSavingGoal(goal saving.Goal) error {
+	if len(goal.Thresholds) < 1 {
+		return ErrAtLeastOneThreshold
+	}
+
+	if goal.Amount == 0 {
+		return ErrGoalIsZero
+	}
+
+	for _, threshold := range goal.Thresholds {
+		if err := threshold.Validate(); err != nil {
+			return fmt.Errorf("household.ChangeSavingGoal: %w", err)
+		}
+	}
+
+	err := aggregate.RecordThat(h, eventually.Event{
+		Payload: SavingGoalWasChanged{SavingGoal: goal},
+	})
+
+	if err != nil {
+		return fmt.Errorf("household.ChangeSavingGoal: failed to record domain event: %w", err)
+	}
+
+	return nil
+}
+
+// NotifyMembers records the notification of the Threshold reached by the
+// combined spending of the month to all the current members of the Household.
+//
+// false is returned if the Household has no members left to notify.
+func (h *Household) NotifyMembers(month interval.Month, threshold saving.Threshold) (bool, error) {
+	members := sortedKeys(h.members)
+	if len(members) == 0 {
+		return false, nil
+	}
+
+	err := aggregate.RecordThat(h, eventually.Event{
+		Payload: MembersWereNotified{
+			Month:     month,
+			Threshold: threshold,
+			Members:   members,
+		},
+	})
+
+	if err != nil {
+		return false, fmt.Errorf("household.NotifyMembers: failed to record domain event: %w", err)
+	}
+
+	return true, nil
+}
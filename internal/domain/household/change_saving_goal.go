@@ -0,0 +1,48 @@
+package household
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/saving"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+	"github.com/eventually-rs/eventually-go/command"
+)
+
+// ChangeSavingGoal is the Domain Command used to change the shared
+// Saving Goal of a Household.
+type ChangeSavingGoal struct {
+	HouseholdID aggregate.StringID
+	SavingGoal  saving.Goal
+}
+
+// ChangeSavingGoalCommandHandler is the Command Handler for ChangeSavingGoal commands.
+type ChangeSavingGoalCommandHandler struct {
+	Repository *aggregate.Repository
+}
+
+// CommandType returns a ChangeSavingGoal instance to bind to this Handler.
+func (ChangeSavingGoalCommandHandler) CommandType() command.Command { return ChangeSavingGoal{} }
+
+// Handle changes the Saving Goal of a Household with the one specified in the
+// Command dispatched.
+func (h ChangeSavingGoalCommandHandler) Handle(ctx context.Context, cmd eventually.Command) error {
+	command := cmd.Payload.(ChangeSavingGoal)
+
+	household, err := h.Repository.Get(ctx, command.HouseholdID)
+	if err != nil {
+		return fmt.Errorf("household.ChangeSavingGoalCommandHandler: failed to get household: %w", err)
+	}
+
+	if err := household.(*Household).ChangeSavingGoal(command.SavingGoal); err != nil {
+		return fmt.Errorf("household.ChangeSavingGoalCommandHandler: failed to change saving goal: %w", err)
+	}
+
+	if err := h.Repository.Add(ctx, household); err != nil {
+		return fmt.Errorf("household.ChangeSavingGoalCommandHandler: failed to save new household state: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package household_test
+
+import (
+	"testing"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/household"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/saving"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+	"github.com/eventually-rs/eventually-go/command"
+	"github.com/eventually-rs/eventually-go/scenario"
+)
+
+func TestChangeSavingGoal(t *testing.T) {
+	created := householdEvent(1, household.WasCreated{HouseholdID: "test-household", Name: "Home", FoundedBy: "alice"})
+
+	testCases := []struct {
+		name string
+		goal saving.Goal
+		err  error
+	}{
+		{name: "goal needs thresholds", goal: saving.Goal{Amount: 1000}, err: household.ErrAtLeastOneThreshold},
+		{
+			name: "goal should not be zero",
+			goal: saving.Goal{Thresholds: []saving.Threshold{saving.Percentage(0.5)}},
+			err:  household.ErrGoalIsZero,
+		},
+		{
+			name: "thresholds should be valid",
+			goal: saving.Goal{Amount: 1000, Thresholds: []saving.Threshold{saving.Percentage(50)}},
+			err:  saving.ErrInvalidThreshold,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			scenario.
+				CommandHandler().
+				Given(created).
+				When(eventually.Command{
+					Payload: household.ChangeSavingGoal{HouseholdID: "test-household", SavingGoal: tc.goal},
+				}).
+				ThenError(tc.err).
+				Using(t, household.Type, func(r *aggregate.Repository) command.Handler {
+					return household.ChangeSavingGoalCommandHandler{Repository: r}
+				})
+		})
+	}
+
+	t.Run("shared saving goal is changed", func(t *testing.T) {
+		goal := saving.Goal{
+			Amount:     1000,
+			Thresholds: []saving.Threshold{saving.Percentage(0.5), saving.Percentage(1)},
+		}
+
+		scenario.
+			CommandHandler().
+			Given(created).
+			When(eventually.Command{
+				Payload: household.ChangeSavingGoal{HouseholdID: "test-household", SavingGoal: goal},
+			}).
+			Then(householdEvent(2, household.SavingGoalWasChanged{SavingGoal: goal})).
+			Using(t, household.Type, func(r *aggregate.Repository) command.Handler {
+				return household.ChangeSavingGoalCommandHandler{Repository: r}
+			})
+	})
+}
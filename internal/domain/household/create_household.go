@@ -0,0 +1,44 @@
+package household
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+	"github.com/eventually-rs/eventually-go/command"
+)
+
+var _ command.Handler = CreateCommandHandler{}
+
+// CreateCommand is the Domain Command to create new Households.
+type CreateCommand struct {
+	HouseholdID string
+	Name        string
+	FoundedBy   string
+}
+
+// CreateCommandHandler is the Command Handler for CreateCommand messages.
+type CreateCommandHandler struct {
+	Repository *aggregate.Repository
+}
+
+// CommandType returns a CreateCommand instance to bind to this Handler.
+func (CreateCommandHandler) CommandType() command.Command { return CreateCommand{} }
+
+// Handle handles a CreateCommand message, by trying to create a new Household
+// and saving it into the data store.
+func (h CreateCommandHandler) Handle(ctx context.Context, cmd eventually.Command) error {
+	command := cmd.Payload.(CreateCommand)
+
+	household, err := Create(command.HouseholdID, command.Name, command.FoundedBy)
+	if err != nil {
+		return fmt.Errorf("household.CreateCommandHandler: failed to create new household: %w", err)
+	}
+
+	if err := h.Repository.Add(ctx, household); err != nil {
+		return fmt.Errorf("household.CreateCommandHandler: failed to save household into repository: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,74 @@
+package household_test
+
+import (
+	"testing"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/household"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+	"github.com/eventually-rs/eventually-go/command"
+	"github.com/eventually-rs/eventually-go/eventstore"
+	"github.com/eventually-rs/eventually-go/scenario"
+)
+
+func TestCreateHousehold(t *testing.T) {
+	t.Run("household is created with its founder as member", func(t *testing.T) {
+		scenario.
+			CommandHandler().
+			When(eventually.Command{
+				Payload: household.CreateCommand{
+					HouseholdID: "test-household",
+					Name:        "Home",
+					FoundedBy:   "alice",
+				},
+			}).
+			Then(eventstore.Event{
+				StreamType: household.Type.Name(),
+				StreamName: "test-household",
+				Version:    1,
+				Event: eventually.Event{
+					Payload: household.WasCreated{
+						HouseholdID: "test-household",
+						Name:        "Home",
+						FoundedBy:   "alice",
+					},
+				},
+			}).
+			Using(t, household.Type, func(r *aggregate.Repository) command.Handler {
+				return household.CreateCommandHandler{Repository: r}
+			})
+	})
+
+	t.Run("creation fails without a founding account", func(t *testing.T) {
+		scenario.
+			CommandHandler().
+			When(eventually.Command{
+				Payload: household.CreateCommand{HouseholdID: "test-household", Name: "Home"},
+			}).
+			ThenError(household.ErrNoFounder).
+			Using(t, household.Type, func(r *aggregate.Repository) command.Handler {
+				return household.CreateCommandHandler{Repository: r}
+			})
+	})
+
+	t.Run("creation fails if the household already exists", func(t *testing.T) {
+		scenario.
+			CommandHandler().
+			Given(eventstore.Event{
+				StreamType: household.Type.Name(),
+				StreamName: "test-household",
+				Version:    1,
+				Event: eventually.Event{
+					Payload: household.WasCreated{HouseholdID: "test-household", FoundedBy: "alice"},
+				},
+			}).
+			When(eventually.Command{
+				Payload: household.CreateCommand{HouseholdID: "test-household", FoundedBy: "bob"},
+			}).
+			ThenFails().
+			Using(t, household.Type, func(r *aggregate.Repository) command.Handler {
+				return household.CreateCommandHandler{Repository: r}
+			})
+	})
+}
@@ -0,0 +1,100 @@
+package household
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/account"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/interval"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/monthly"
+	"go.uber.org/zap"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/command"
+	"github.com/eventually-rs/eventually-go/eventstore"
+	"github.com/eventually-rs/eventually-go/projection"
+)
+
+var _ projection.Applier = CreateSpendingStartOfTheMonthPolicy{}
+
+// CreateSpendingStartOfTheMonthPolicy starts tracking the combined spending
+// of every Household with a Saving Goal when a new month starts, using the
+// sum of the balances of its members as the starting balance.
+//
+// Members joining the Household during the month have their transactions
+// tracked from then on, while their balance is only included
+// from the following month.
+type CreateSpendingStartOfTheMonthPolicy struct {
+	CommandDispatcher command.Dispatcher
+	QueryDispatcher   monthly.QueryDispatcher
+	Logger            *zap.Logger
+}
+
+func (csp CreateSpendingStartOfTheMonthPolicy) Apply(ctx context.Context, event eventstore.Event) error {
+	if event, ok := event.Payload.(interval.MonthStarted); ok {
+		return csp.handleMonthStarted(ctx, event.Month)
+	}
+
+	return nil
+}
+
+func (csp CreateSpendingStartOfTheMonthPolicy) handleMonthStarted(ctx context.Context, month interval.Month) error {
+	answer, err := csp.QueryDispatcher.Dispatch(ctx, WithSavingGoalsQuery{})
+	if err != nil {
+		return fmt.Errorf("household.CreateSpendingStartOfTheMonthPolicy: failed to list households: %w", err)
+	}
+
+	for _, household := range answer.(WithSavingGoals) {
+		balance, err := csp.combinedBalance(ctx, household.Members)
+		if err != nil {
+			return err
+		}
+
+		if balance < household.SavingGoal.Amount {
+			continue
+		}
+
+		err = csp.CommandDispatcher.Dispatch(ctx, eventually.Command{
+			Payload: monthly.StartSpendingTracking{
+				HouseholdID:     household.HouseholdID,
+				Month:           month,
+				StartingBalance: balance,
+				SavingGoal:      household.SavingGoal,
+			},
+		})
+
+		if err != nil {
+			return fmt.Errorf("household.CreateSpendingStartOfTheMonthPolicy: failed to dispatch command: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// combinedBalance returns the sum of the current balances of the Accounts specified.
+//
+// Accounts that do not exist are skipped.
+func (csp CreateSpendingStartOfTheMonthPolicy) combinedBalance(ctx context.Context, accountIDs []string) (float64, error) {
+	var balance float64
+
+	for _, accountID := range accountIDs {
+		answer, err := csp.QueryDispatcher.Dispatch(ctx, account.ViewQuery{AccountID: accountID})
+
+		if errors.Is(err, account.ErrNotFound) {
+			csp.Logger.Warn("Household member account not found, skipping",
+				zap.String("accountId", accountID),
+			)
+
+			continue
+		}
+
+		if err != nil {
+			return 0, fmt.Errorf("household.CreateSpendingStartOfTheMonthPolicy: failed to get account: %w", err)
+		}
+
+		balance += answer.(account.View).Balance
+	}
+
+	return balance, nil
+}
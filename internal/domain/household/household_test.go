@@ -0,0 +1,19 @@
+package household_test
+
+import (
+	"github.com/eventually-rs/saving-goals-go/internal/domain/household"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/eventstore"
+)
+
+// householdEvent returns the event with the specified version
+// of the "test-household" Household stream.
+func householdEvent(version int64, payload interface{}) eventstore.Event {
+	return eventstore.Event{
+		StreamType: household.Type.Name(),
+		StreamName: "test-household",
+		Version:    version,
+		Event:      eventually.Event{Payload: payload},
+	}
+}
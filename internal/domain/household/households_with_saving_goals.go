@@ -0,0 +1,98 @@
+package household
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/saving"
+
+	"github.com/eventually-rs/eventually-go/eventstore"
+	"github.com/eventually-rs/eventually-go/projection"
+	"github.com/eventually-rs/eventually-go/query"
+)
+
+var _ projection.Projection = &WithSavingGoalsProjection{}
+
+// WithSavingGoalsQuery is the Domain Query used to fetch the Households
+// that have a shared Saving Goal set.
+//
+// If AccountID is specified, only the Households the Account
+// is a member of are returned.
+type WithSavingGoalsQuery struct {
+	AccountID string
+}
+
+// WithSavingGoals is the Domain Answer returned from a WithSavingGoalsQuery,
+// containing the Households sorted by identifier.
+type WithSavingGoals []WithSavingGoal
+
+// WithSavingGoal represents a single Household with a Saving Goal set,
+// alongside the Accounts of its current members.
+type WithSavingGoal struct {
+	HouseholdID string
+	Members     []string
+	SavingGoal  saving.Goal
+}
+
+// WithSavingGoalsProjection listens to Household Domain Events to build
+// the list of Households with a shared Saving Goal and their members.
+type WithSavingGoalsProjection struct {
+	mx         sync.RWMutex
+	households map[string]*entry
+}
+
+// NewWithSavingGoalsProjection returns a new instance of WithSavingGoalsProjection type.
+func NewWithSavingGoalsProjection() *WithSavingGoalsProjection {
+	return &WithSavingGoalsProjection{
+		households: make(map[string]*entry),
+	}
+}
+
+// QueryType binds the WithSavingGoalsQuery type to the projection.
+func (*WithSavingGoalsProjection) QueryType() query.Query { return WithSavingGoalsQuery{} }
+
+// Apply updates the state of the projection using the incoming event.
+func (p *WithSavingGoalsProjection) Apply(ctx context.Context, event eventstore.Event) error {
+	p.mx.Lock()
+	defer p.mx.Unlock()
+
+	applyEvent(p.households, event)
+
+	return nil
+}
+
+// Handle returns the Households with a Saving Goal set, optionally
+// filtered by the member Account specified in the query.
+func (p *WithSavingGoalsProjection) Handle(ctx context.Context, q query.Query) (query.Answer, error) {
+	p.mx.RLock()
+	defer p.mx.RUnlock()
+
+	accountID := q.(WithSavingGoalsQuery).AccountID
+	result := make(WithSavingGoals, 0)
+
+	for id, e := range p.households {
+		if e.savingGoal == nil {
+			continue
+		}
+
+		if _, ok := e.members[accountID]; accountID != "" && !ok {
+			continue
+		}
+
+		result = append(result, WithSavingGoal{
+			HouseholdID: id,
+			Members:     sortedKeys(e.members),
+			SavingGoal: saving.Goal{
+				Amount:     e.savingGoal.Amount,
+				Thresholds: append([]saving.Threshold{}, e.savingGoal.Thresholds...),
+			},
+		})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].HouseholdID < result[j].HouseholdID
+	})
+
+	return result, nil
+}
@@ -0,0 +1,48 @@
+package household_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/household"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/saving"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHouseholdsWithSavingGoals(t *testing.T) {
+	ctx := context.Background()
+	projection := household.NewWithSavingGoalsProjection()
+
+	goal := saving.Goal{Amount: 1000, Thresholds: []saving.Threshold{saving.Percentage(0.5)}}
+
+	events := []interface{}{
+		household.WasCreated{HouseholdID: "test-household", Name: "Home", FoundedBy: "alice"},
+		household.MemberWasInvited{AccountID: "bob", InvitedBy: "alice"},
+		household.MemberWasInvited{AccountID: "carol", InvitedBy: "alice"},
+		household.InvitationWasAccepted{AccountID: "carol"},
+		household.SavingGoalWasChanged{SavingGoal: goal},
+		household.MemberLeft{AccountID: "alice"},
+	}
+
+	for i, payload := range events {
+		assert.NoError(t, projection.Apply(ctx, householdEvent(int64(i)+1, payload)))
+	}
+
+	answer, err := projection.Handle(ctx, household.WithSavingGoalsQuery{})
+	assert.NoError(t, err)
+	assert.Equal(t, household.WithSavingGoals{
+		{HouseholdID: "test-household", Members: []string{"carol"}, SavingGoal: goal},
+	}, answer)
+
+	// Invited accounts and former members are not part of the Household.
+	for _, accountID := range []string{"alice", "bob"} {
+		answer, err = projection.Handle(ctx, household.WithSavingGoalsQuery{AccountID: accountID})
+		assert.NoError(t, err)
+		assert.Empty(t, answer)
+	}
+
+	answer, err = projection.Handle(ctx, household.WithSavingGoalsQuery{AccountID: "carol"})
+	assert.NoError(t, err)
+	assert.Len(t, answer, 1)
+}
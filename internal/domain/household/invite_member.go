@@ -0,0 +1,46 @@
+package household
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+	"github.com/eventually-rs/eventually-go/command"
+)
+
+// InviteMember is the Domain Command used by a member of a Household
+// to invite another Account to join it.
+type InviteMember struct {
+	HouseholdID aggregate.StringID
+	AccountID   string
+	InvitedBy   string
+}
+
+// InviteMemberCommandHandler is the Command Handler for InviteMember commands.
+type InviteMemberCommandHandler struct {
+	Repository *aggregate.Repository
+}
+
+// CommandType returns an InviteMember instance to bind to this Handler.
+func (InviteMemberCommandHandler) CommandType() command.Command { return InviteMember{} }
+
+// Handle invites the Account specified in the Command dispatched to join the Household.
+func (h InviteMemberCommandHandler) Handle(ctx context.Context, cmd eventually.Command) error {
+	command := cmd.Payload.(InviteMember)
+
+	household, err := h.Repository.Get(ctx, command.HouseholdID)
+	if err != nil {
+		return fmt.Errorf("household.InviteMemberCommandHandler: failed to get household: %w", err)
+	}
+
+	if err := household.(*Household).Invite(command.AccountID, command.InvitedBy); err != nil {
+		return fmt.Errorf("household.InviteMemberCommandHandler: failed to invite member: %w", err)
+	}
+
+	if err := h.Repository.Add(ctx, household); err != nil {
+		return fmt.Errorf("household.InviteMemberCommandHandler: failed to save new household state: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package household_test
+
+import (
+	"testing"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/household"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+	"github.com/eventually-rs/eventually-go/command"
+	"github.com/eventually-rs/eventually-go/eventstore"
+	"github.com/eventually-rs/eventually-go/scenario"
+)
+
+func TestInviteMember(t *testing.T) {
+	given := []eventstore.Event{
+		householdEvent(1, household.WasCreated{HouseholdID: "test-household", Name: "Home", FoundedBy: "alice"}),
+		householdEvent(2, household.MemberWasInvited{AccountID: "bob", InvitedBy: "alice"}),
+	}
+
+	testCases := []struct {
+		name      string
+		accountID string
+		invitedBy string
+		err       error
+	}{
+		{name: "inviting account should be a member", accountID: "carol", invitedBy: "bob", err: household.ErrNotMember},
+		{name: "members cannot be invited", accountID: "alice", invitedBy: "alice", err: household.ErrAlreadyMember},
+		{name: "accounts cannot be invited twice", accountID: "bob", invitedBy: "alice", err: household.ErrAlreadyInvited},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			scenario.
+				CommandHandler().
+				Given(given...).
+				When(eventually.Command{
+					Payload: household.InviteMember{
+						HouseholdID: "test-household",
+						AccountID:   tc.accountID,
+						InvitedBy:   tc.invitedBy,
+					},
+				}).
+				ThenError(tc.err).
+				Using(t, household.Type, func(r *aggregate.Repository) command.Handler {
+					return household.InviteMemberCommandHandler{Repository: r}
+				})
+		})
+	}
+
+	t.Run("members can invite other accounts", func(t *testing.T) {
+		scenario.
+			CommandHandler().
+			Given(given...).
+			When(eventually.Command{
+				Payload: household.InviteMember{
+					HouseholdID: "test-household",
+					AccountID:   "carol",
+					InvitedBy:   "alice",
+				},
+			}).
+			Then(householdEvent(3, household.MemberWasInvited{AccountID: "carol", InvitedBy: "alice"})).
+			Using(t, household.Type, func(r *aggregate.Repository) command.Handler {
+				return household.InviteMemberCommandHandler{Repository: r}
+			})
+	})
+}
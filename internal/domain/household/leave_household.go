@@ -0,0 +1,45 @@
+package household
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+	"github.com/eventually-rs/eventually-go/command"
+)
+
+// Leave is the Domain Command used by a member to leave a Household.
+type Leave struct {
+	HouseholdID aggregate.StringID
+	AccountID   string
+}
+
+// LeaveCommandHandler is the Command Handler for Leave commands.
+type LeaveCommandHandler struct {
+	Repository *aggregate.Repository
+}
+
+// CommandType returns a Leave instance to bind to this Handler.
+func (LeaveCommandHandler) CommandType() command.Command { return Leave{} }
+
+// Handle removes the Account specified in the Command dispatched
+// from the members of the Household.
+func (h LeaveCommandHandler) Handle(ctx context.Context, cmd eventually.Command) error {
+	command := cmd.Payload.(Leave)
+
+	household, err := h.Repository.Get(ctx, command.HouseholdID)
+	if err != nil {
+		return fmt.Errorf("household.LeaveCommandHandler: failed to get household: %w", err)
+	}
+
+	if err := household.(*Household).Leave(command.AccountID); err != nil {
+		return fmt.Errorf("household.LeaveCommandHandler: failed to leave household: %w", err)
+	}
+
+	if err := h.Repository.Add(ctx, household); err != nil {
+		return fmt.Errorf("household.LeaveCommandHandler: failed to save new household state: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,47 @@
+package household_test
+
+import (
+	"testing"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/household"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+	"github.com/eventually-rs/eventually-go/command"
+	"github.com/eventually-rs/eventually-go/eventstore"
+	"github.com/eventually-rs/eventually-go/scenario"
+)
+
+func TestLeave(t *testing.T) {
+	given := []eventstore.Event{
+		householdEvent(1, household.WasCreated{HouseholdID: "test-household", Name: "Home", FoundedBy: "alice"}),
+		householdEvent(2, household.MemberWasInvited{AccountID: "bob", InvitedBy: "alice"}),
+		householdEvent(3, household.InvitationWasAccepted{AccountID: "bob"}),
+	}
+
+	t.Run("members can leave the household", func(t *testing.T) {
+		scenario.
+			CommandHandler().
+			Given(given...).
+			When(eventually.Command{
+				Payload: household.Leave{HouseholdID: "test-household", AccountID: "alice"},
+			}).
+			Then(householdEvent(4, household.MemberLeft{AccountID: "alice"})).
+			Using(t, household.Type, func(r *aggregate.Repository) command.Handler {
+				return household.LeaveCommandHandler{Repository: r}
+			})
+	})
+
+	t.Run("accounts that are not members cannot leave", func(t *testing.T) {
+		scenario.
+			CommandHandler().
+			Given(given...).
+			When(eventually.Command{
+				Payload: household.Leave{HouseholdID: "test-household", AccountID: "carol"},
+			}).
+			ThenError(household.ErrNotMember).
+			Using(t, household.Type, func(r *aggregate.Repository) command.Handler {
+				return household.LeaveCommandHandler{Repository: r}
+			})
+	})
+}
@@ -0,0 +1,57 @@
+package household
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/interval"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/saving"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+	"github.com/eventually-rs/eventually-go/command"
+)
+
+// NotifyMembers is the Domain Command used to notify all the members
+// of a Household that their combined spending reached a Threshold.
+type NotifyMembers struct {
+	HouseholdID aggregate.StringID
+	Month       interval.Month
+	Threshold   saving.Threshold
+}
+
+// NotifyMembersCommandHandler is the Command Handler for NotifyMembers commands.
+type NotifyMembersCommandHandler struct {
+	Repository *aggregate.Repository
+}
+
+// CommandType returns a NotifyMembers instance to bind to this Handler.
+func (NotifyMembersCommandHandler) CommandType() command.Command { return NotifyMembers{} }
+
+// Handle notifies the Threshold specified in the Command to all the
+// current members of the Household.
+//
+// Nothing is recorded if the Household has no members left.
+func (h NotifyMembersCommandHandler) Handle(ctx context.Context, cmd eventually.Command) error {
+	command := cmd.Payload.(NotifyMembers)
+
+	household, err := h.Repository.Get(ctx, command.HouseholdID)
+	if err != nil {
+		return fmt.Errorf("household.NotifyMembersCommandHandler: failed to get household: %w", err)
+	}
+
+	notified, err := household.(*Household).NotifyMembers(command.Month, command.Threshold)
+	if err != nil {
+		return fmt.Errorf("household.NotifyMembersCommandHandler: failed to notify members: %w", err)
+	}
+
+	if !notified {
+		return nil
+	}
+
+	if err := h.Repository.Add(ctx, household); err != nil {
+		return fmt.Errorf("household.NotifyMembersCommandHandler: failed to save new household state: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package household
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/monthly"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+	"github.com/eventually-rs/eventually-go/command"
+	"github.com/eventually-rs/eventually-go/eventstore"
+	"github.com/eventually-rs/eventually-go/projection"
+)
+
+var _ projection.Applier = NotifyMembersPolicy{}
+
+// NotifyMembersPolicy notifies all the members of a Household every time
+// the combined monthly spending of the Household reaches one of the
+// thresholds of its Saving Goal.
+type NotifyMembersPolicy struct {
+	CommandDispatcher command.Dispatcher
+}
+
+func (nmp NotifyMembersPolicy) Apply(ctx context.Context, evt eventstore.Event) error {
+	event, ok := evt.Payload.(monthly.ThresholdWasReached)
+	if !ok {
+		return nil
+	}
+
+	id, err := monthly.ParseID(evt.StreamName)
+	if err != nil {
+		return fmt.Errorf("household.NotifyMembersPolicy: failed to parse spending id: %w", err)
+	}
+
+	if id.HouseholdID == "" {
+		// Thresholds reached by the spending of a single Account.
+		return nil
+	}
+
+	err = nmp.CommandDispatcher.Dispatch(ctx, eventually.Command{
+		Payload: NotifyMembers{
+			HouseholdID: aggregate.StringID(id.HouseholdID),
+			Month:       id.Month,
+			Threshold:   event.Threshold,
+		},
+	})
+
+	if err != nil {
+		return fmt.Errorf("household.NotifyMembersPolicy: failed to dispatch command: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,93 @@
+package household_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/household"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/interval"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/saving"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+	"github.com/eventually-rs/eventually-go/command"
+	"github.com/eventually-rs/eventually-go/eventstore"
+	"github.com/eventually-rs/eventually-go/scenario"
+)
+
+func TestNotifyMembers(t *testing.T) {
+	month := interval.Month{Year: 2021, Month: time.March}
+
+	t.Run("all the current members are notified", func(t *testing.T) {
+		scenario.
+			CommandHandler().
+			Given(
+				householdEvent(1, household.WasCreated{HouseholdID: "test-household", Name: "Home", FoundedBy: "bob"}),
+				householdEvent(2, household.MemberWasInvited{AccountID: "alice", InvitedBy: "bob"}),
+				householdEvent(3, household.InvitationWasAccepted{AccountID: "alice"}),
+				householdEvent(4, household.MemberWasInvited{AccountID: "carol", InvitedBy: "bob"}),
+			).
+			When(eventually.Command{
+				Payload: household.NotifyMembers{
+					HouseholdID: "test-household",
+					Month:       month,
+					Threshold:   saving.Percentage(0.8),
+				},
+			}).
+			Then(householdEvent(5, household.MembersWereNotified{
+				Month:     month,
+				Threshold: saving.Percentage(0.8),
+				Members:   []string{"alice", "bob"},
+			})).
+			Using(t, household.Type, func(r *aggregate.Repository) command.Handler {
+				return household.NotifyMembersCommandHandler{Repository: r}
+			})
+	})
+
+	t.Run("forgotten members are not notified", func(t *testing.T) {
+		scenario.
+			CommandHandler().
+			Given(
+				householdEvent(1, household.WasCreated{HouseholdID: "test-household", Name: "Home", FoundedBy: ""}),
+				householdEvent(2, household.MemberWasInvited{AccountID: "alice", InvitedBy: ""}),
+				householdEvent(3, household.InvitationWasAccepted{AccountID: "alice"}),
+			).
+			When(eventually.Command{
+				Payload: household.NotifyMembers{
+					HouseholdID: "test-household",
+					Month:       month,
+					Threshold:   saving.Percentage(0.8),
+				},
+			}).
+			Then(householdEvent(4, household.MembersWereNotified{
+				Month:     month,
+				Threshold: saving.Percentage(0.8),
+				Members:   []string{"alice"},
+			})).
+			Using(t, household.Type, func(r *aggregate.Repository) command.Handler {
+				return household.NotifyMembersCommandHandler{Repository: r}
+			})
+	})
+
+	t.Run("nothing is recorded when all the members left", func(t *testing.T) {
+		var then []eventstore.Event
+
+		scenario.
+			CommandHandler().
+			Given(
+				householdEvent(1, household.WasCreated{HouseholdID: "test-household", Name: "Home", FoundedBy: "bob"}),
+				householdEvent(2, household.MemberLeft{AccountID: "bob"}),
+			).
+			When(eventually.Command{
+				Payload: household.NotifyMembers{
+					HouseholdID: "test-household",
+					Month:       month,
+					Threshold:   saving.Percentage(0.8),
+				},
+			}).
+			Then(then...).
+			Using(t, household.Type, func(r *aggregate.Repository) command.Handler {
+				return household.NotifyMembersCommandHandler{Repository: r}
+			})
+	})
+}
@@ -0,0 +1,72 @@
+package household
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/account"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/interval"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/monthly"
+	"go.uber.org/zap"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+	"github.com/eventually-rs/eventually-go/command"
+	"github.com/eventually-rs/eventually-go/eventstore"
+	"github.com/eventually-rs/eventually-go/projection"
+)
+
+var _ projection.Applier = RecordMemberTransactionPolicy{}
+
+// RecordMemberTransactionPolicy records the transactions of the members
+// of a Household in the combined monthly spending of the Household,
+// alongside the spending of their own Account.
+type RecordMemberTransactionPolicy struct {
+	CommandDispatcher command.Dispatcher
+	QueryDispatcher   monthly.QueryDispatcher
+	Logger            *zap.Logger
+}
+
+func (rtp RecordMemberTransactionPolicy) Apply(ctx context.Context, evt eventstore.Event) error {
+	event, ok := evt.Payload.(account.TransactionWasRecorded)
+	if !ok {
+		return nil
+	}
+
+	answer, err := rtp.QueryDispatcher.Dispatch(ctx, WithSavingGoalsQuery{AccountID: evt.StreamName})
+	if err != nil {
+		return fmt.Errorf("household.RecordMemberTransactionPolicy: failed to list households: %w", err)
+	}
+
+	for _, household := range answer.(WithSavingGoals) {
+		err := rtp.CommandDispatcher.Dispatch(ctx, eventually.Command{
+			Payload: monthly.RecordTransaction{
+				ID: monthly.ID{
+					HouseholdID: household.HouseholdID,
+					Month:       interval.MonthFromTime(event.HappenedAt),
+				},
+				Amount:     event.Amount,
+				Kind:       event.Kind,
+				HappenedAt: event.HappenedAt,
+			},
+		})
+
+		if errors.Is(err, aggregate.ErrRootNotFound) {
+			// The Household spending for the month of the transaction is not being tracked,
+			// e.g. the Household had no Saving Goal at the start of the month.
+			rtp.Logger.Debug("Household spending not tracked for the transaction month, skipping",
+				zap.String("householdId", household.HouseholdID),
+				zap.String("accountId", evt.StreamName),
+			)
+
+			continue
+		}
+
+		if err != nil {
+			return fmt.Errorf("household.RecordMemberTransactionPolicy: failed to dispatch command: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,153 @@
+package household
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/saving"
+
+	"github.com/eventually-rs/eventually-go/eventstore"
+	"github.com/eventually-rs/eventually-go/projection"
+	"github.com/eventually-rs/eventually-go/query"
+)
+
+var _ projection.Projection = &ViewProjection{}
+
+// ErrNotFound is returned by ViewProjection when the Household requested
+// does not exist.
+var ErrNotFound = fmt.Errorf("household.View: household not found")
+
+// ViewQuery is the Domain Query used to fetch the current state
+// of a single Household.
+type ViewQuery struct {
+	HouseholdID string
+}
+
+// View is the Domain Answer returned from a ViewQuery, containing
+// the current state of the requested Household.
+//
+// Members and Invitations contain the identifiers of the Accounts,
+// sorted alphabetically.
+type View struct {
+	HouseholdID string
+	Name        string
+	Members     []string
+	Invitations []string
+	SavingGoal  *saving.Goal
+}
+
+// ViewProjection listens to Household Domain Events to build the
+// current state of all the Households.
+type ViewProjection struct {
+	mx         sync.RWMutex
+	households map[string]*entry
+}
+
+// NewViewProjection returns a new instance of ViewProjection type.
+func NewViewProjection() *ViewProjection {
+	return &ViewProjection{
+		households: make(map[string]*entry),
+	}
+}
+
+// QueryType binds the ViewQuery type to the projection.
+func (*ViewProjection) QueryType() query.Query { return ViewQuery{} }
+
+// Apply updates the state of the projection using the incoming event.
+func (p *ViewProjection) Apply(ctx context.Context, event eventstore.Event) error {
+	p.mx.Lock()
+	defer p.mx.Unlock()
+
+	applyEvent(p.households, event)
+
+	return nil
+}
+
+// Handle returns a copy of the current state of the Household requested.
+//
+// ErrNotFound is returned if the Household does not exist.
+func (p *ViewProjection) Handle(ctx context.Context, q query.Query) (query.Answer, error) {
+	p.mx.RLock()
+	defer p.mx.RUnlock()
+
+	householdID := q.(ViewQuery).HouseholdID
+
+	e, ok := p.households[householdID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, householdID)
+	}
+
+	view := View{
+		HouseholdID: householdID,
+		Name:        e.name,
+		Members:     sortedKeys(e.members),
+		Invitations: sortedKeys(e.invitations),
+	}
+
+	if e.savingGoal != nil {
+		goal := *e.savingGoal
+		goal.Thresholds = append([]saving.Threshold{}, e.savingGoal.Thresholds...)
+		view.SavingGoal = &goal
+	}
+
+	return view, nil
+}
+
+// entry is the state of a single Household kept by the projections.
+type entry struct {
+	name        string
+	members     map[string]struct{}
+	invitations map[string]struct{}
+	savingGoal  *saving.Goal
+}
+
+// applyEvent applies the Household Domain Event to the entries specified.
+func applyEvent(households map[string]*entry, event eventstore.Event) {
+	if evt, ok := event.Payload.(WasCreated); ok {
+		households[evt.HouseholdID] = &entry{
+			name:        evt.Name,
+			members:     map[string]struct{}{evt.FoundedBy: {}},
+			invitations: make(map[string]struct{}),
+		}
+
+		return
+	}
+
+	e, ok := households[event.StreamName]
+	if !ok {
+		return
+	}
+
+	switch evt := event.Payload.(type) {
+	case MemberWasInvited:
+		e.invitations[evt.AccountID] = struct{}{}
+
+	case InvitationWasAccepted:
+		delete(e.invitations, evt.AccountID)
+		e.members[evt.AccountID] = struct{}{}
+
+	case MemberLeft:
+		delete(e.members, evt.AccountID)
+
+	case SavingGoalWasChanged:
+		goal := evt.SavingGoal
+		goal.Thresholds = append([]saving.Threshold{}, evt.SavingGoal.Thresholds...)
+		e.savingGoal = &goal
+	}
+}
+
+func sortedKeys(set map[string]struct{}) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		// Forgotten Accounts have an empty identifier.
+		if key != "" {
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
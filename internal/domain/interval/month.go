@@ -24,3 +24,14 @@ func MonthFromTime(t time.Time) Month {
 func (m Month) String() string {
 	return fmt.Sprintf("%d-%02d", m.Year, m.Month)
 }
+
+// MonthsUntil returns the number of months between the Month and the
+// other Month specified, which is negative if the other Month comes before.
+func (m Month) MonthsUntil(other Month) int {
+	return (other.Year-m.Year)*12 + int(other.Month) - int(m.Month)
+}
+
+// Previous returns the Month before this one.
+func (m Month) Previous() Month {
+	return MonthFromTime(time.Date(m.Year, m.Month-1, 1, 0, 0, 0, 0, time.UTC))
+}
@@ -0,0 +1,59 @@
+package monthly
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/category"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/transaction"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+	"github.com/eventually-rs/eventually-go/command"
+)
+
+// CategorizeTransaction is the Domain Command used to move a transaction
+// of the month from its previous Category to a new one.
+type CategorizeTransaction struct {
+	ID
+	TransactionID    string
+	Category         category.Category
+	PreviousCategory category.Category
+	Amount           float64
+	Kind             transaction.Kind
+}
+
+type CategorizeTransactionCommandHandler struct {
+	Repository *aggregate.Repository
+}
+
+func (CategorizeTransactionCommandHandler) CommandType() command.Command {
+	return CategorizeTransaction{}
+}
+
+func (h CategorizeTransactionCommandHandler) Handle(ctx context.Context, cmd eventually.Command) error {
+	command := cmd.Payload.(CategorizeTransaction)
+
+	monthlySpending, err := h.Repository.Get(ctx, command.ID)
+	if err != nil {
+		return fmt.Errorf("monthly.CategorizeTransaction: failed to get spending aggregate from repository: %w", err)
+	}
+
+	err = monthlySpending.(*Spending).CategorizeTransaction(
+		command.TransactionID,
+		command.Category,
+		command.PreviousCategory,
+		command.Amount,
+		command.Kind,
+	)
+
+	if err != nil {
+		return fmt.Errorf("monthly.CategorizeTransaction: failed to categorize transaction in spending: %w", err)
+	}
+
+	if err := h.Repository.Add(ctx, monthlySpending); err != nil {
+		return fmt.Errorf("monthly.CategorizeTransaction: failed to save spending status to repository: %w", err)
+	}
+
+	return nil
+}
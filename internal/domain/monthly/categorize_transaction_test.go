@@ -0,0 +1,218 @@
+package monthly_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/interval"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/monthly"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/saving"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/transaction"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+	"github.com/eventually-rs/eventually-go/command"
+	"github.com/eventually-rs/eventually-go/eventstore"
+	"github.com/eventually-rs/eventually-go/scenario"
+)
+
+func TestCategorizeTransaction(t *testing.T) {
+	monthlySpendingID := monthly.ID{
+		AccountID: "test-account",
+		Month:     interval.MonthFromTime(time.Now()),
+	}
+
+	// Dining budget is 300, with thresholds at 50% and 100%.
+	trackingStarted := []interface{}{
+		monthly.SpendingTrackingStarted{
+			ID:              monthlySpendingID,
+			StartingBalance: 2000,
+			DesiredBalance:  1500,
+			Thresholds:      []saving.Threshold{saving.Percentage(0.5), saving.Percentage(1)},
+			Budgets: []saving.Budget{
+				{Category: "dining", Amount: 300, Thresholds: []saving.Threshold{saving.Percentage(0.5), saving.Percentage(1)}},
+				{Category: "groceries", Amount: 400, Thresholds: []saving.Threshold{saving.Percentage(0.8)}},
+			},
+		},
+		monthly.TransactionWasRecorded{Amount: -200, Kind: transaction.Expense},
+	}
+
+	testCases := []struct {
+		name  string
+		given []interface{}
+		when  monthly.CategorizeTransaction
+		then  []interface{}
+	}{
+		{
+			name:  "expense reaching a category threshold",
+			given: trackingStarted,
+			when: monthly.CategorizeTransaction{
+				TransactionID: "tx-1",
+				Category:      "dining",
+				Amount:        -200,
+				Kind:          transaction.Expense,
+			},
+			then: []interface{}{
+				monthly.TransactionWasCategorized{
+					TransactionID: "tx-1",
+					Category:      "dining",
+					Amount:        -200,
+					Kind:          transaction.Expense,
+				},
+				monthly.CategoryThresholdWasReached{Category: "dining", Threshold: saving.Percentage(0.5)},
+			},
+		},
+		{
+			name:  "expense in a category without budget is only recorded",
+			given: trackingStarted,
+			when: monthly.CategorizeTransaction{
+				TransactionID: "tx-1",
+				Category:      "entertainment",
+				Amount:        -200,
+				Kind:          transaction.Expense,
+			},
+			then: []interface{}{
+				monthly.TransactionWasCategorized{
+					TransactionID: "tx-1",
+					Category:      "entertainment",
+					Amount:        -200,
+					Kind:          transaction.Expense,
+				},
+			},
+		},
+		{
+			name: "expense moved to another category only counts in the new one",
+			given: append(trackingStarted[:len(trackingStarted):len(trackingStarted)],
+				monthly.TransactionWasCategorized{
+					TransactionID: "tx-1",
+					Category:      "dining",
+					Amount:        -200,
+					Kind:          transaction.Expense,
+				},
+				monthly.CategoryThresholdWasReached{Category: "dining", Threshold: saving.Percentage(0.5)},
+				monthly.TransactionWasRecorded{Amount: -150, Kind: transaction.Expense},
+			),
+			when: monthly.CategorizeTransaction{
+				TransactionID: "tx-2",
+				Category:      "dining",
+				Amount:        -150,
+				Kind:          transaction.Expense,
+			},
+			then: []interface{}{
+				monthly.TransactionWasCategorized{
+					TransactionID: "tx-2",
+					Category:      "dining",
+					Amount:        -150,
+					Kind:          transaction.Expense,
+				},
+				monthly.CategoryThresholdWasReached{Category: "dining", Threshold: saving.Percentage(1)},
+			},
+		},
+		{
+			name: "recategorized expense no longer counts in the previous category",
+			given: append(trackingStarted[:len(trackingStarted):len(trackingStarted)],
+				monthly.TransactionWasCategorized{
+					TransactionID: "tx-1",
+					Category:      "groceries",
+					Amount:        -200,
+					Kind:          transaction.Expense,
+				},
+				monthly.TransactionWasRecorded{Amount: -150, Kind: transaction.Expense},
+				monthly.TransactionWasCategorized{
+					TransactionID:    "tx-1",
+					Category:         "dining",
+					PreviousCategory: "groceries",
+					Amount:           -200,
+					Kind:             transaction.Expense,
+				},
+				monthly.CategoryThresholdWasReached{Category: "dining", Threshold: saving.Percentage(0.5)},
+			),
+			when: monthly.CategorizeTransaction{
+				TransactionID: "tx-2",
+				Category:      "groceries",
+				Amount:        -150,
+				Kind:          transaction.Expense,
+			},
+			then: []interface{}{
+				monthly.TransactionWasCategorized{
+					TransactionID: "tx-2",
+					Category:      "groceries",
+					Amount:        -150,
+					Kind:          transaction.Expense,
+				},
+			},
+		},
+		{
+			name: "refund reduces the amount spent in the category",
+			given: append(trackingStarted[:len(trackingStarted):len(trackingStarted)],
+				monthly.TransactionWasCategorized{
+					TransactionID: "tx-1",
+					Category:      "dining",
+					Amount:        -200,
+					Kind:          transaction.Expense,
+				},
+				monthly.CategoryThresholdWasReached{Category: "dining", Threshold: saving.Percentage(0.5)},
+				monthly.TransactionWasRecorded{Amount: 100, Kind: transaction.Refund},
+				monthly.TransactionWasCategorized{
+					TransactionID: "tx-2",
+					Category:      "dining",
+					Amount:        100,
+					Kind:          transaction.Refund,
+				},
+				monthly.TransactionWasRecorded{Amount: -150, Kind: transaction.Expense},
+			),
+			when: monthly.CategorizeTransaction{
+				TransactionID: "tx-3",
+				Category:      "dining",
+				Amount:        -150,
+				Kind:          transaction.Expense,
+			},
+			then: []interface{}{
+				monthly.TransactionWasCategorized{
+					TransactionID: "tx-3",
+					Category:      "dining",
+					Amount:        -150,
+					Kind:          transaction.Expense,
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			given := make([]eventstore.Event, 0, len(tc.given))
+			for i, payload := range tc.given {
+				given = append(given, eventstore.Event{
+					StreamType: monthly.Type.Name(),
+					StreamName: monthlySpendingID.String(),
+					Version:    int64(i) + 1,
+					Event:      eventually.Event{Payload: payload},
+				})
+			}
+
+			then := make([]eventstore.Event, 0, len(tc.then))
+			for i, payload := range tc.then {
+				then = append(then, eventstore.Event{
+					StreamType: monthly.Type.Name(),
+					StreamName: monthlySpendingID.String(),
+					Version:    int64(len(given) + i + 1),
+					Event:      eventually.Event{Payload: payload},
+				})
+			}
+
+			when := tc.when
+			when.ID = monthlySpendingID
+
+			scenario.
+				CommandHandler().
+				Given(given...).
+				When(eventually.Command{Payload: when}).
+				Then(then...).
+				Using(t, monthly.Type, func(r *aggregate.Repository) command.Handler {
+					return monthly.CategorizeTransactionCommandHandler{Repository: r}
+				})
+		})
+	}
+}
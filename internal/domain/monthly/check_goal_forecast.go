@@ -0,0 +1,54 @@
+package monthly
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/forecast"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+	"github.com/eventually-rs/eventually-go/command"
+)
+
+// CheckGoalForecast is the Domain Command used to check whether the Saving Goal
+// of the month is at risk, using the Account's transaction history.
+type CheckGoalForecast struct {
+	ID
+	History    []forecast.Transaction
+	Date       time.Time
+	Confidence float64
+}
+
+// CheckGoalForecastCommandHandler is the Command Handler for CheckGoalForecast commands.
+type CheckGoalForecastCommandHandler struct {
+	Repository *aggregate.Repository
+}
+
+func (CheckGoalForecastCommandHandler) CommandType() command.Command { return CheckGoalForecast{} }
+
+func (h CheckGoalForecastCommandHandler) Handle(ctx context.Context, cmd eventually.Command) error {
+	command := cmd.Payload.(CheckGoalForecast)
+
+	monthlySpending, err := h.Repository.Get(ctx, command.ID)
+	if err != nil {
+		return fmt.Errorf("monthly.CheckGoalForecast: failed to get spending aggregate from repository: %w", err)
+	}
+
+	changed, err := monthlySpending.(*Spending).CheckGoalForecast(command.History, command.Date, command.Confidence)
+	if err != nil {
+		return fmt.Errorf("monthly.CheckGoalForecast: failed to check forecast: %w", err)
+	}
+
+	if !changed {
+		// The Saving Goal status is the same, no need to save the spending.
+		return nil
+	}
+
+	if err := h.Repository.Add(ctx, monthlySpending); err != nil {
+		return fmt.Errorf("monthly.CheckGoalForecast: failed to save spending status to repository: %w", err)
+	}
+
+	return nil
+}
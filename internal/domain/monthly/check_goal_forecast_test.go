@@ -0,0 +1,124 @@
+package monthly_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/interval"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/monthly"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/saving"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/transaction"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+	"github.com/eventually-rs/eventually-go/command"
+	"github.com/eventually-rs/eventually-go/eventstore"
+	"github.com/eventually-rs/eventually-go/scenario"
+)
+
+func TestCheckGoalForecast(t *testing.T) {
+	monthlySpendingID := monthly.ID{
+		AccountID: "test-account",
+		Month:     interval.Month{Year: 2021, Month: time.March},
+	}
+
+	date := time.Date(2021, time.March, 15, 12, 0, 0, 0, time.UTC)
+
+	// Without any history, the balance is forecast to stay the same until the end of the month.
+	trackingStarted := monthly.SpendingTrackingStarted{
+		ID:              monthlySpendingID,
+		StartingBalance: 1000,
+		DesiredBalance:  3000,
+		Thresholds:      []saving.Threshold{saving.Percentage(1)},
+	}
+
+	goalAtRisk := monthly.GoalAtRisk{
+		Date:            date,
+		ExpectedBalance: 1000,
+		DesiredBalance:  3000,
+		Probability:     0,
+	}
+
+	testCases := []struct {
+		name  string
+		given []interface{}
+		date  time.Time
+		then  []interface{}
+	}{
+		{
+			name:  "goal is at risk when the forecast is below the confidence level",
+			given: []interface{}{trackingStarted},
+			date:  date,
+			then:  []interface{}{goalAtRisk},
+		},
+		{
+			name:  "goal already at risk is not reported again",
+			given: []interface{}{trackingStarted, goalAtRisk},
+			date:  date,
+		},
+		{
+			name: "goal is back on track when the forecast gets back to the confidence level",
+			given: []interface{}{
+				trackingStarted,
+				goalAtRisk,
+				monthly.TransactionWasRecorded{Amount: 2500, Kind: transaction.Income},
+			},
+			date: date,
+			then: []interface{}{
+				monthly.GoalBackOnTrack{
+					Date:            date,
+					ExpectedBalance: 3500,
+					DesiredBalance:  3000,
+					Probability:     1,
+				},
+			},
+		},
+		{
+			name:  "forecast outside the month tracked is ignored",
+			given: []interface{}{trackingStarted},
+			date:  time.Date(2021, time.April, 1, 12, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			given := make([]eventstore.Event, 0, len(tc.given))
+			for i, payload := range tc.given {
+				given = append(given, eventstore.Event{
+					StreamType: monthly.Type.Name(),
+					StreamName: monthlySpendingID.String(),
+					Version:    int64(i) + 1,
+					Event:      eventually.Event{Payload: payload},
+				})
+			}
+
+			// No events expected is represented by a nil slice in the scenario.
+			var then []eventstore.Event
+			for i, payload := range tc.then {
+				then = append(then, eventstore.Event{
+					StreamType: monthly.Type.Name(),
+					StreamName: monthlySpendingID.String(),
+					Version:    int64(len(given) + i + 1),
+					Event:      eventually.Event{Payload: payload},
+				})
+			}
+
+			scenario.
+				CommandHandler().
+				Given(given...).
+				When(eventually.Command{
+					Payload: monthly.CheckGoalForecast{
+						ID:         monthlySpendingID,
+						Date:       tc.date,
+						Confidence: 0.8,
+					},
+				}).
+				Then(then...).
+				Using(t, monthly.Type, func(r *aggregate.Repository) command.Handler {
+					return monthly.CheckGoalForecastCommandHandler{Repository: r}
+				})
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package monthly
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+	"github.com/eventually-rs/eventually-go/command"
+)
+
+// ErrSpendingClosed is returned when recording or categorizing transactions
+// in a spending that is not tracked anymore.
+var ErrSpendingClosed = fmt.Errorf("monthly.Spending: spending is closed")
+
+// SpendingWasClosed is the Domain Event triggered when the spending
+// stops being tracked before the end of the month, e.g. because
+// the Account has been closed.
+type SpendingWasClosed struct {
+	Reason   string
+	ClosedAt time.Time
+}
+
+// Close stops tracking the spending: no more transactions can be recorded,
+// and no more thresholds or warnings are triggered.
+//
+// false is returned if the spending is already closed, and nothing changed.
+func (s *Spending) Close(reason string, closedAt time.Time) (bool, error) {
+	if s.closed {
+		return false, nil
+	}
+
+	err := aggregate.RecordThat(s, eventually.Event{
+		Payload: SpendingWasClosed{Reason: reason, ClosedAt: closedAt},
+	})
+
+	if err != nil {
+		return false, fmt.Errorf("monthly.Close: failed to record domain event: %w", err)
+	}
+
+	return true, nil
+}
+
+// CloseSpending is the Domain Command used to stop tracking
+// the spending of the month before its end.
+type CloseSpending struct {
+	ID
+	Reason   string
+	ClosedAt time.Time
+}
+
+// CloseSpendingCommandHandler is the Command Handler for CloseSpending commands.
+type CloseSpendingCommandHandler struct {
+	Repository *aggregate.Repository
+}
+
+func (CloseSpendingCommandHandler) CommandType() command.Command { return CloseSpending{} }
+
+func (h CloseSpendingCommandHandler) Handle(ctx context.Context, cmd eventually.Command) error {
+	command := cmd.Payload.(CloseSpending)
+
+	monthlySpending, err := h.Repository.Get(ctx, command.ID)
+	if err != nil {
+		return fmt.Errorf("monthly.CloseSpending: failed to get spending aggregate from repository: %w", err)
+	}
+
+	closed, err := monthlySpending.(*Spending).Close(command.Reason, command.ClosedAt)
+	if err != nil {
+		return fmt.Errorf("monthly.CloseSpending: failed to close spending: %w", err)
+	}
+
+	if !closed {
+		// The spending was already closed, no need to save it.
+		return nil
+	}
+
+	if err := h.Repository.Add(ctx, monthlySpending); err != nil {
+		return fmt.Errorf("monthly.CloseSpending: failed to save spending status to repository: %w", err)
+	}
+
+	return nil
+}
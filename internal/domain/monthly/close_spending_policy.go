@@ -0,0 +1,60 @@
+package monthly
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/account"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/interval"
+	"go.uber.org/zap"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+	"github.com/eventually-rs/eventually-go/command"
+	"github.com/eventually-rs/eventually-go/eventstore"
+	"github.com/eventually-rs/eventually-go/projection"
+)
+
+var _ projection.Applier = CloseSpendingPolicy{}
+
+// CloseSpendingPolicy closes the spending of the month in progress
+// when an Account is closed.
+type CloseSpendingPolicy struct {
+	CommandDispatcher command.Dispatcher
+	Logger            *zap.Logger
+}
+
+func (csp CloseSpendingPolicy) Apply(ctx context.Context, evt eventstore.Event) error {
+	event, ok := evt.Payload.(account.WasClosed)
+	if !ok {
+		return nil
+	}
+
+	err := csp.CommandDispatcher.Dispatch(ctx, eventually.Command{
+		Payload: CloseSpending{
+			ID: ID{
+				AccountID: evt.StreamName,
+				Month:     interval.MonthFromTime(event.ClosedAt),
+			},
+			Reason:   event.Reason,
+			ClosedAt: event.ClosedAt,
+		},
+	})
+
+	if errors.Is(err, aggregate.ErrRootNotFound) {
+		// The spending for the month of the closing is not being tracked,
+		// e.g. the Account had no Saving Goal at the start of the month.
+		csp.Logger.Debug("Spending not tracked for the closing month, skipping",
+			zap.String("accountId", evt.StreamName),
+		)
+
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("monthly.CloseSpendingPolicy: failed to dispatch command: %w", err)
+	}
+
+	return nil
+}
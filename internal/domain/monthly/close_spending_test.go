@@ -0,0 +1,100 @@
+package monthly_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/interval"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/monthly"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/saving"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+	"github.com/eventually-rs/eventually-go/command"
+	"github.com/eventually-rs/eventually-go/eventstore"
+	"github.com/eventually-rs/eventually-go/scenario"
+)
+
+func TestCloseSpending(t *testing.T) {
+	closedAt := time.Date(2021, time.March, 15, 10, 0, 0, 0, time.UTC)
+	monthlySpendingID := monthly.ID{
+		AccountID: "test-account",
+		Month:     interval.MonthFromTime(closedAt),
+	}
+
+	started := eventstore.Event{
+		StreamType: monthly.Type.Name(),
+		StreamName: monthlySpendingID.String(),
+		Version:    1,
+		Event: eventually.Event{
+			Payload: monthly.SpendingTrackingStarted{
+				ID:              monthlySpendingID,
+				StartingBalance: 1000,
+				DesiredBalance:  1500,
+				Thresholds:      []saving.Threshold{saving.Percentage(1)},
+			},
+		},
+	}
+
+	closed := eventstore.Event{
+		StreamType: monthly.Type.Name(),
+		StreamName: monthlySpendingID.String(),
+		Version:    2,
+		Event: eventually.Event{
+			Payload: monthly.SpendingWasClosed{Reason: "account closed", ClosedAt: closedAt},
+		},
+	}
+
+	t.Run("spending in progress is closed", func(t *testing.T) {
+		scenario.
+			CommandHandler().
+			Given(started).
+			When(eventually.Command{
+				Payload: monthly.CloseSpending{
+					ID:       monthlySpendingID,
+					Reason:   "account closed",
+					ClosedAt: closedAt,
+				},
+			}).
+			Then(closed).
+			Using(t, monthly.Type, func(r *aggregate.Repository) command.Handler {
+				return monthly.CloseSpendingCommandHandler{Repository: r}
+			})
+	})
+
+	t.Run("closing a closed spending has no effect", func(t *testing.T) {
+		var then []eventstore.Event
+
+		scenario.
+			CommandHandler().
+			Given(started, closed).
+			When(eventually.Command{
+				Payload: monthly.CloseSpending{
+					ID:       monthlySpendingID,
+					Reason:   "account closed",
+					ClosedAt: closedAt,
+				},
+			}).
+			Then(then...).
+			Using(t, monthly.Type, func(r *aggregate.Repository) command.Handler {
+				return monthly.CloseSpendingCommandHandler{Repository: r}
+			})
+	})
+
+	t.Run("transactions cannot be recorded in a closed spending", func(t *testing.T) {
+		scenario.
+			CommandHandler().
+			Given(started, closed).
+			When(eventually.Command{
+				Payload: monthly.RecordTransaction{
+					ID:         monthlySpendingID,
+					Amount:     -100,
+					HappenedAt: closedAt.Add(time.Hour),
+				},
+			}).
+			ThenError(monthly.ErrSpendingClosed).
+			Using(t, monthly.Type, func(r *aggregate.Repository) command.Handler {
+				return monthly.RecordTransactionCommandHandler{Repository: r}
+			})
+	})
+}
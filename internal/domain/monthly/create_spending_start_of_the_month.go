@@ -41,7 +41,9 @@ func (csp CreateSpendingStartOfTheMonthPolicy) handleMonthStarted(ctx context.Co
 
 	accounts := answer.(account.WithSavingGoalsAnswer)
 	for account := range accounts {
-		if account.CurrentBalance < account.SavingGoal.Amount {
+		savingGoal := account.MonthlySavingGoal(month)
+
+		if account.CurrentBalance < savingGoal.Amount {
 			continue
 		}
 
@@ -50,7 +52,10 @@ func (csp CreateSpendingStartOfTheMonthPolicy) handleMonthStarted(ctx context.Co
 				Month:           month,
 				AccountID:       account.AccountID,
 				StartingBalance: account.CurrentBalance,
-				SavingGoal:      account.SavingGoal,
+				SavingGoal:      savingGoal,
+				Budgets:         account.Budgets,
+				Pacing:          account.Pacing,
+				Commitments:     commitmentsOf(account, month),
 			},
 		})
 
@@ -61,3 +66,18 @@ func (csp CreateSpendingStartOfTheMonthPolicy) handleMonthStarted(ctx context.Co
 
 	return nil
 }
+
+// commitmentsOf returns the Commitments of the month for the Account,
+// from its confirmed recurring outflows.
+func commitmentsOf(account account.WithSavingGoal, month interval.Month) []Commitment {
+	var commitments []Commitment
+
+	for _, series := range account.Commitments {
+		commitments = append(commitments, Commitment{
+			SeriesID: series.ID,
+			Amount:   series.ExpectedIn(month),
+		})
+	}
+
+	return commitments
+}
@@ -0,0 +1,77 @@
+package monthly
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/forecast"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/interval"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+)
+
+// GoalAtRisk is the Domain Event triggered when the probability of reaching
+// the desired balance at the end of the month, according to the forecast,
+// falls below the confidence level.
+type GoalAtRisk struct {
+	Date            time.Time
+	ExpectedBalance float64
+	DesiredBalance  float64
+	Probability     float64
+}
+
+// GoalBackOnTrack is the Domain Event triggered when the probability of reaching
+// the desired balance at the end of the month, according to the forecast,
+// gets back to the confidence level after the Saving Goal was at risk.
+type GoalBackOnTrack struct {
+	Date            time.Time
+	ExpectedBalance float64
+	DesiredBalance  float64
+	Probability     float64
+}
+
+// CheckGoalForecast forecasts the balance at the end of the month using
+// the Account's transaction history, and records whether the Saving Goal
+// is at risk, or back on track, when the probability of reaching the desired
+// balance crosses the confidence level specified.
+//
+// Forecasts for dates outside the month tracked, or for closed spending, are ignored.
+//
+// It returns true if the Saving Goal status has changed.
+func (s *Spending) CheckGoalForecast(history []forecast.Transaction, date time.Time, confidence float64) (bool, error) {
+	if s.closed || interval.MonthFromTime(date) != s.id.Month {
+		return false, nil
+	}
+
+	f := forecast.Compute(history, date, s.currentBalance, s.desiredBalance)
+	atRisk := f.Probability < confidence
+
+	if atRisk == s.goalAtRisk {
+		return false, nil
+	}
+
+	var event eventually.Event
+
+	if atRisk {
+		event.Payload = GoalAtRisk{
+			Date:            date,
+			ExpectedBalance: f.ExpectedBalance,
+			DesiredBalance:  f.DesiredBalance,
+			Probability:     f.Probability,
+		}
+	} else {
+		event.Payload = GoalBackOnTrack{
+			Date:            date,
+			ExpectedBalance: f.ExpectedBalance,
+			DesiredBalance:  f.DesiredBalance,
+			Probability:     f.Probability,
+		}
+	}
+
+	if err := aggregate.RecordThat(s, event); err != nil {
+		return false, fmt.Errorf("monthly.CheckGoalForecast: failed to record domain event: %w", err)
+	}
+
+	return true, nil
+}
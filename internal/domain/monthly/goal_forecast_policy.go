@@ -0,0 +1,74 @@
+package monthly
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/account"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/forecast"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/interval"
+	"go.uber.org/zap"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+	"github.com/eventually-rs/eventually-go/command"
+	"github.com/eventually-rs/eventually-go/eventstore"
+	"github.com/eventually-rs/eventually-go/projection"
+)
+
+var _ projection.Applier = GoalForecastPolicy{}
+
+// GoalForecastPolicy checks the forecast of the Saving Goal of the month
+// every time a new transaction is recorded in an Account.
+//
+// Confidence is the minimum probability of reaching the desired balance
+// for the Saving Goal not to be considered at risk.
+type GoalForecastPolicy struct {
+	CommandDispatcher command.Dispatcher
+	QueryDispatcher   QueryDispatcher
+	Confidence        float64
+	Logger            *zap.Logger
+}
+
+func (gfp GoalForecastPolicy) Apply(ctx context.Context, evt eventstore.Event) error {
+	event, ok := evt.Payload.(account.TransactionWasRecorded)
+	if !ok || event.HappenedAt.IsZero() {
+		return nil
+	}
+
+	answer, err := gfp.QueryDispatcher.Dispatch(ctx, account.HistoryQuery{
+		AccountID: evt.StreamName,
+		Since:     forecast.HistorySince(event.HappenedAt),
+	})
+
+	if err != nil {
+		return fmt.Errorf("monthly.GoalForecastPolicy: failed to get account history: %w", err)
+	}
+
+	err = gfp.CommandDispatcher.Dispatch(ctx, eventually.Command{
+		Payload: CheckGoalForecast{
+			ID: ID{
+				AccountID: evt.StreamName,
+				Month:     interval.MonthFromTime(event.HappenedAt),
+			},
+			History:    answer.(account.History),
+			Date:       event.HappenedAt,
+			Confidence: gfp.Confidence,
+		},
+	})
+
+	if errors.Is(err, aggregate.ErrRootNotFound) {
+		gfp.Logger.Debug("Spending not tracked for the transaction month, skipping forecast",
+			zap.String("accountId", evt.StreamName),
+		)
+
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("monthly.GoalForecastPolicy: failed to dispatch command: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,199 @@
+package monthly
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/account"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/category"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/forecast"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/interval"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/pacing"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/saving"
+
+	"github.com/eventually-rs/eventually-go/eventstore"
+	"github.com/eventually-rs/eventually-go/projection"
+	"github.com/eventually-rs/eventually-go/query"
+)
+
+var _ projection.Projection = &ProgressProjection{}
+
+// ErrProgressNotFound is returned by ProgressProjection when the spending
+// of the requested month is not being tracked for the Account.
+var ErrProgressNotFound = fmt.Errorf("monthly.Progress: spending not found")
+
+// ProgressQuery is the Domain Query used to fetch the spending progress
+// of an Account in a specific month, or of a Household when HouseholdID is specified.
+type ProgressQuery struct {
+	AccountID   string
+	HouseholdID string
+	Month       interval.Month
+}
+
+// Progress is the Domain Answer returned from a ProgressQuery, containing
+// the amount spent in the month compared to the spending limit,
+// and the amount spent in each Category compared to its Budget.
+type Progress struct {
+	AccountID         string
+	HouseholdID       string
+	Month             interval.Month
+	StartingBalance   float64
+	CurrentBalance    float64
+	DesiredBalance    float64
+	SpendingLimit     float64
+	Spent             float64
+	ReachedThresholds []saving.Threshold
+	Categories        []CategoryProgress
+	Pacing            pacing.Strategy
+	GoalAtRisk        bool
+	Committed         float64
+	Closed            bool
+}
+
+// Allowance returns the result of the pacing calculation for the specified date,
+// including how much can be spent on that day while still hitting the Saving Goal.
+func (p Progress) Allowance(date time.Time) pacing.Allowance {
+	return pacing.Compute(p.Pacing, date, p.SpendingLimit, p.Spent)
+}
+
+// Forecast returns the forecast of the balance at the end of the month,
+// as of the specified date, using the Account's transaction history.
+func (p Progress) Forecast(history []forecast.Transaction, date time.Time) forecast.Forecast {
+	return forecast.Compute(history, date, p.CurrentBalance, p.DesiredBalance)
+}
+
+// CategoryProgress contains the amount spent in a Category with a Budget.
+type CategoryProgress struct {
+	Category          category.Category
+	Budget            float64
+	Spent             float64
+	Remaining         float64
+	ReachedThresholds []saving.Threshold
+}
+
+// sorted returns the last thresholds reached for each kind, sorted by kind.
+func (t thresholdsByKind) sorted() []saving.Threshold {
+	result := make([]saving.Threshold, 0, len(t))
+	for _, threshold := range t {
+		result = append(result, threshold)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Kind < result[j].Kind
+	})
+
+	return result
+}
+
+// ProgressProjection listens to Spending Domain Events to build the
+// spending progress of all the Accounts, for each month tracked.
+type ProgressProjection struct {
+	mx        sync.RWMutex
+	spendings map[string]*Spending
+}
+
+// NewProgressProjection returns a new instance of ProgressProjection type.
+func NewProgressProjection() *ProgressProjection {
+	return &ProgressProjection{
+		spendings: make(map[string]*Spending),
+	}
+}
+
+// QueryType binds the ProgressQuery type to the projection.
+func (*ProgressProjection) QueryType() query.Query { return ProgressQuery{} }
+
+// Apply updates the state of the projection using the incoming event.
+//
+// The projection uses the same state transitions of the Spending aggregate,
+// so that the progress shown is consistent with the thresholds triggered.
+//
+// The spendings of an Account are erased when the Account is forgotten,
+// while the Events of other Stream types are ignored.
+func (p *ProgressProjection) Apply(ctx context.Context, event eventstore.Event) error {
+	p.mx.Lock()
+	defer p.mx.Unlock()
+
+	if evt, ok := event.Payload.(account.WasForgotten); ok {
+		for id, spending := range p.spendings {
+			if spending.id.AccountID == evt.AccountID {
+				delete(p.spendings, id)
+			}
+		}
+
+		return nil
+	}
+
+	if event.StreamType != Type.Name() {
+		return nil
+	}
+
+	spending, ok := p.spendings[event.StreamName]
+	if !ok {
+		spending = new(Spending)
+		p.spendings[event.StreamName] = spending
+	}
+
+	if err := spending.Apply(event.Event); err != nil {
+		return fmt.Errorf("monthly.ProgressProjection: failed to apply event: %w", err)
+	}
+
+	return nil
+}
+
+// Handle returns the spending progress of the Account in the month requested.
+//
+// ErrProgressNotFound is returned if the spending is not being tracked.
+func (p *ProgressProjection) Handle(ctx context.Context, q query.Query) (query.Answer, error) {
+	p.mx.RLock()
+	defer p.mx.RUnlock()
+
+	query := q.(ProgressQuery)
+	id := ID{AccountID: query.AccountID, HouseholdID: query.HouseholdID, Month: query.Month}
+
+	spending, ok := p.spendings[id.String()]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrProgressNotFound, id)
+	}
+
+	return spending.Progress(), nil
+}
+
+// Progress returns the spending progress of the month, the same returned
+// by the ProgressProjection for the same Events.
+func (ms *Spending) Progress() Progress {
+	progress := Progress{
+		AccountID:         ms.id.AccountID,
+		HouseholdID:       ms.id.HouseholdID,
+		Month:             ms.id.Month,
+		StartingBalance:   ms.startingBalance,
+		CurrentBalance:    ms.currentBalance,
+		DesiredBalance:    ms.desiredBalance,
+		SpendingLimit:     ms.spendingLimit,
+		Spent:             ms.spent,
+		ReachedThresholds: ms.lastReachedThresholds.sorted(),
+		Categories:        make([]CategoryProgress, 0, len(ms.categories)),
+		Pacing:            ms.pacing,
+		GoalAtRisk:        ms.goalAtRisk,
+		Committed:         ms.committed(),
+		Closed:            ms.closed,
+	}
+
+	for c, cs := range ms.categories {
+		progress.Categories = append(progress.Categories, CategoryProgress{
+			Category:          c,
+			Budget:            cs.budget.Amount,
+			Spent:             cs.spent,
+			Remaining:         cs.budget.Amount - cs.spent,
+			ReachedThresholds: cs.lastReachedThresholds.sorted(),
+		})
+	}
+
+	sort.Slice(progress.Categories, func(i, j int) bool {
+		return progress.Categories[i].Category < progress.Categories[j].Category
+	})
+
+	return progress
+}
@@ -0,0 +1,63 @@
+package monthly_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/account"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/interval"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/monthly"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/saving"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/eventstore"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProgressProjectionForgottenAccounts(t *testing.T) {
+	ctx := context.Background()
+	projection := monthly.NewProgressProjection()
+
+	march := interval.Month{Year: 2021, Month: time.March}
+	forgotten := monthly.ID{AccountID: "test-account", Month: march}
+	other := monthly.ID{AccountID: "other-account", Month: march}
+
+	for _, id := range []monthly.ID{forgotten, other} {
+		assert.NoError(t, projection.Apply(ctx, eventstore.Event{
+			StreamType: monthly.Type.Name(),
+			StreamName: id.String(),
+			Version:    1,
+			Event: eventually.Event{
+				Payload: monthly.SpendingTrackingStarted{
+					ID:              id,
+					StartingBalance: 1000,
+					DesiredBalance:  1500,
+					Thresholds:      []saving.Threshold{saving.Percentage(1)},
+				},
+			},
+		}))
+	}
+
+	// Other Account Events are ignored.
+	assert.NoError(t, projection.Apply(ctx, eventstore.Event{
+		StreamType: account.Type.Name(),
+		StreamName: "test-account",
+		Version:    1,
+		Event:      eventually.Event{Payload: account.WasCreated{AccountID: "test-account"}},
+	}))
+
+	assert.NoError(t, projection.Apply(ctx, eventstore.Event{
+		StreamType: account.Type.Name(),
+		StreamName: "pseudonym",
+		Version:    2,
+		Event:      eventually.Event{Payload: account.WasForgotten{AccountID: "test-account"}},
+	}))
+
+	_, err := projection.Handle(ctx, monthly.ProgressQuery{AccountID: "test-account", Month: march})
+	assert.True(t, errors.Is(err, monthly.ErrProgressNotFound))
+
+	_, err = projection.Handle(ctx, monthly.ProgressQuery{AccountID: "other-account", Month: march})
+	assert.NoError(t, err)
+}
@@ -2,6 +2,8 @@ package monthly
 
 import (
 	"context"
+	"errors"
+	"expvar"
 	"fmt"
 
 	"github.com/eventually-rs/saving-goals-go/internal/domain/account"
@@ -9,6 +11,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
 	"github.com/eventually-rs/eventually-go/command"
 	"github.com/eventually-rs/eventually-go/eventstore"
 	"github.com/eventually-rs/eventually-go/projection"
@@ -16,27 +19,99 @@ import (
 
 var _ projection.Applier = RecordTransactionPolicy{}
 
+// skippedTransactions counts the transactions not recorded in any monthly
+// spending, by reason, published with expvar.
+var skippedTransactions = expvar.NewMap("monthly_transactions_skipped")
+
+// Reasons of the transactions not recorded in any monthly spending.
+const (
+	skippedNotTracked = "spending_not_tracked"
+	skippedClosed     = "spending_closed"
+)
+
+// RecordTransactionPolicy records the Account's transactions, and their
+// categorization, in the monthly spending of the month they happened.
+//
+// Transactions happened in a month whose spending is not tracked, or has been
+// closed, are skipped: they are logged and counted by reason in the
+// monthly_transactions_skipped metric, while the categorizations are just skipped.
 type RecordTransactionPolicy struct {
 	CommandDispatcher command.Dispatcher
 	Logger            *zap.Logger
 }
 
 func (rtp RecordTransactionPolicy) Apply(ctx context.Context, evt eventstore.Event) error {
-	if event, ok := evt.Payload.(account.TransactionWasRecorded); ok {
-		err := rtp.CommandDispatcher.Dispatch(ctx, eventually.Command{
-			Payload: RecordTransaction{
-				ID: ID{
-					AccountID: evt.StreamName,
-					Month:     interval.MonthFromTime(event.HappenedAt),
-				},
-				Amount: event.Amount,
+	var cmd eventually.Command
+
+	switch event := evt.Payload.(type) {
+	case account.TransactionWasRecorded:
+		cmd.Payload = RecordTransaction{
+			ID: ID{
+				AccountID: evt.StreamName,
+				Month:     interval.MonthFromTime(event.HappenedAt),
 			},
-		})
+			Amount:     event.Amount,
+			Kind:       event.Kind,
+			HappenedAt: event.HappenedAt,
+			SeriesID:   event.SeriesID,
+		}
 
-		if err != nil {
-			return fmt.Errorf("monthly.RecordTransactionPolicy: failed to dispatch command: %w", err)
+	case account.TransactionWasCategorized:
+		cmd.Payload = CategorizeTransaction{
+			ID: ID{
+				AccountID: evt.StreamName,
+				Month:     interval.MonthFromTime(event.HappenedAt),
+			},
+			TransactionID:    event.TransactionID,
+			Category:         event.Category,
+			PreviousCategory: event.PreviousCategory,
+			Amount:           event.Amount,
+			Kind:             event.Kind,
 		}
+
+	default:
+		return nil
 	}
 
+	err := rtp.CommandDispatcher.Dispatch(ctx, cmd)
+
+	var reason string
+
+	switch {
+	case errors.Is(err, aggregate.ErrRootNotFound):
+		// The spending for the month of the transaction is not being tracked,
+		// e.g. the Account had no Saving Goal at the start of the month.
+		reason = skippedNotTracked
+
+	case errors.Is(err, ErrSpendingClosed):
+		// The Account was closed during the month and reopened afterwards:
+		// its spending is tracked again from the next month.
+		reason = skippedClosed
+
+	case err != nil:
+		return fmt.Errorf("monthly.RecordTransactionPolicy: failed to dispatch command: %w", err)
+
+	default:
+		return nil
+	}
+
+	record, ok := cmd.Payload.(RecordTransaction)
+	if !ok {
+		rtp.Logger.Debug("Spending not available for the categorized transaction month, skipping",
+			zap.String("accountId", evt.StreamName),
+			zap.String("reason", reason),
+		)
+
+		return nil
+	}
+
+	skippedTransactions.Add(reason, 1)
+
+	rtp.Logger.Info("Transaction not recorded in the monthly spending, skipping",
+		zap.String("accountId", evt.StreamName),
+		zap.String("month", record.ID.Month.String()),
+		zap.String("reason", reason),
+	)
+
 	return nil
 }
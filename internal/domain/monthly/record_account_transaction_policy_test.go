@@ -0,0 +1,128 @@
+package monthly_test
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/account"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/monthly"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/transaction"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+	"github.com/eventually-rs/eventually-go/eventstore"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// failingDispatcher fails all the Commands dispatched with the specified error.
+type failingDispatcher struct {
+	err        error
+	dispatched []eventually.Command
+}
+
+func (d *failingDispatcher) Dispatch(ctx context.Context, cmd eventually.Command) error {
+	d.dispatched = append(d.dispatched, cmd)
+	return d.err
+}
+
+func skippedTransactions(reason string) int64 {
+	if v, ok := expvar.Get("monthly_transactions_skipped").(*expvar.Map).Get(reason).(*expvar.Int); ok {
+		return v.Value()
+	}
+
+	return 0
+}
+
+func TestRecordTransactionPolicy(t *testing.T) {
+	ctx := context.Background()
+	happenedAt := time.Date(2021, time.March, 15, 12, 0, 0, 0, time.UTC)
+
+	recorded := eventstore.Event{
+		StreamType: account.Type.Name(),
+		StreamName: "test-account",
+		Version:    2,
+		Event: eventually.Event{
+			Payload: account.TransactionWasRecorded{
+				TransactionID: "tx-1",
+				Amount:        -45,
+				Kind:          transaction.Expense,
+				HappenedAt:    happenedAt,
+			},
+		},
+	}
+
+	categorized := eventstore.Event{
+		StreamType: account.Type.Name(),
+		StreamName: "test-account",
+		Version:    3,
+		Event: eventually.Event{
+			Payload: account.TransactionWasCategorized{
+				TransactionID: "tx-1",
+				Category:      "groceries",
+				Amount:        -45,
+				Kind:          transaction.Expense,
+				HappenedAt:    happenedAt,
+			},
+		},
+	}
+
+	t.Run("transactions of months not tracked are logged and counted", func(t *testing.T) {
+		core, logs := observer.New(zapcore.InfoLevel)
+		dispatcher := &failingDispatcher{err: fmt.Errorf("monthly.RecordTransactionHandler: %w", aggregate.ErrRootNotFound)}
+		policy := monthly.RecordTransactionPolicy{CommandDispatcher: dispatcher, Logger: zap.New(core)}
+
+		before := skippedTransactions("spending_not_tracked")
+
+		assert.NoError(t, policy.Apply(ctx, recorded))
+		assert.Equal(t, before+1, skippedTransactions("spending_not_tracked"))
+
+		if assert.Len(t, dispatcher.dispatched, 1) {
+			assert.IsType(t, monthly.RecordTransaction{}, dispatcher.dispatched[0].Payload)
+		}
+
+		if entries := logs.All(); assert.Len(t, entries, 1) {
+			assert.Equal(t, "2021-03", entries[0].ContextMap()["month"])
+			assert.Equal(t, "spending_not_tracked", entries[0].ContextMap()["reason"])
+		}
+	})
+
+	t.Run("transactions of closed months are logged and counted", func(t *testing.T) {
+		core, logs := observer.New(zapcore.InfoLevel)
+		dispatcher := &failingDispatcher{err: monthly.ErrSpendingClosed}
+		policy := monthly.RecordTransactionPolicy{CommandDispatcher: dispatcher, Logger: zap.New(core)}
+
+		before := skippedTransactions("spending_closed")
+
+		assert.NoError(t, policy.Apply(ctx, recorded))
+		assert.Equal(t, before+1, skippedTransactions("spending_closed"))
+		assert.Len(t, logs.All(), 1)
+	})
+
+	t.Run("categorizations of months not tracked are only skipped", func(t *testing.T) {
+		core, logs := observer.New(zapcore.InfoLevel)
+		dispatcher := &failingDispatcher{err: aggregate.ErrRootNotFound}
+		policy := monthly.RecordTransactionPolicy{CommandDispatcher: dispatcher, Logger: zap.New(core)}
+
+		before := skippedTransactions("spending_not_tracked")
+
+		assert.NoError(t, policy.Apply(ctx, categorized))
+		assert.Equal(t, before, skippedTransactions("spending_not_tracked"))
+		assert.Empty(t, logs.All())
+	})
+
+	t.Run("other failures are returned", func(t *testing.T) {
+		failure := errors.New("failed to append events")
+		dispatcher := &failingDispatcher{err: failure}
+		policy := monthly.RecordTransactionPolicy{CommandDispatcher: dispatcher, Logger: zap.NewNop()}
+
+		err := policy.Apply(ctx, recorded)
+		assert.True(t, errors.Is(err, failure))
+	})
+}
@@ -3,6 +3,9 @@ package monthly
 import (
 	"context"
 	"fmt"
+	"time"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/transaction"
 
 	"github.com/eventually-rs/eventually-go"
 	"github.com/eventually-rs/eventually-go/aggregate"
@@ -11,7 +14,10 @@ import (
 
 type RecordTransaction struct {
 	ID
-	Amount float64
+	Amount     float64
+	Kind       transaction.Kind
+	HappenedAt time.Time
+	SeriesID   string
 }
 
 type RecordTransactionCommandHandler struct {
@@ -28,7 +34,12 @@ func (h RecordTransactionCommandHandler) Handle(ctx context.Context, cmd eventua
 		return fmt.Errorf("monthly.RecordTransaction: failed to get spending aggregate from repository: %w", err)
 	}
 
-	if err := monthlySpending.(*Spending).RecordTransaction(command.Amount); err != nil {
+	if err := monthlySpending.(*Spending).RecordTransaction(
+		command.Amount,
+		command.Kind,
+		command.HappenedAt,
+		command.SeriesID,
+	); err != nil {
 		return fmt.Errorf("monthly.RecordTransaction: failed to record transaction in spending: %w", err)
 	}
 
@@ -0,0 +1,477 @@
+package monthly_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/interval"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/monthly"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/pacing"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/saving"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/transaction"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+	"github.com/eventually-rs/eventually-go/command"
+	"github.com/eventually-rs/eventually-go/eventstore"
+	"github.com/eventually-rs/eventually-go/scenario"
+)
+
+func TestRecordTransaction(t *testing.T) {
+	monthlySpendingID := monthly.ID{
+		AccountID: "test-account",
+		Month:     interval.MonthFromTime(time.Now()),
+	}
+
+	// Spending limit is 1000 after the salary, with thresholds at 50% and 100%.
+	trackingStarted := []interface{}{
+		monthly.SpendingTrackingStarted{
+			ID:              monthlySpendingID,
+			StartingBalance: 1000,
+			DesiredBalance:  1500,
+			Thresholds:      []saving.Threshold{saving.Percentage(0.5), saving.Percentage(1)},
+		},
+		monthly.TransactionWasRecorded{Amount: 1500, Kind: transaction.Income},
+		monthly.SpendingLimitWasUpdated{SpendingLimit: 1000},
+	}
+
+	// Saving goal of 300 on a starting balance of 2000, as started by monthly.NewSpending.
+	trackingStartedWithBalance := []interface{}{
+		monthly.SpendingTrackingStarted{
+			ID:              monthlySpendingID,
+			StartingBalance: 2000,
+			DesiredBalance:  2300,
+			Thresholds:      []saving.Threshold{saving.Percentage(0.5), saving.Percentage(1)},
+		},
+	}
+
+	testCases := []struct {
+		name   string
+		given  []interface{}
+		amount float64
+		kind   transaction.Kind
+		then   []interface{}
+	}{
+		{
+			name:   "income increases the spending limit",
+			given:  trackingStarted,
+			amount: 500,
+			kind:   transaction.Income,
+			then: []interface{}{
+				monthly.TransactionWasRecorded{Amount: 500, Kind: transaction.Income},
+				monthly.SpendingLimitWasUpdated{SpendingLimit: 1500},
+			},
+		},
+		{
+			name:   "unclassified positive amount is considered income",
+			given:  trackingStarted,
+			amount: 500,
+			then: []interface{}{
+				monthly.TransactionWasRecorded{Amount: 500, Kind: transaction.Income},
+				monthly.SpendingLimitWasUpdated{SpendingLimit: 1500},
+			},
+		},
+		{
+			name:   "expense below any threshold is only recorded",
+			given:  trackingStarted,
+			amount: -100,
+			kind:   transaction.Expense,
+			then: []interface{}{
+				monthly.TransactionWasRecorded{Amount: -100, Kind: transaction.Expense},
+			},
+		},
+		{
+			name:   "unclassified negative amount is considered an expense",
+			given:  trackingStarted,
+			amount: -600,
+			then: []interface{}{
+				monthly.TransactionWasRecorded{Amount: -600, Kind: transaction.Expense},
+				monthly.ThresholdWasReached{Threshold: saving.Percentage(0.5)},
+			},
+		},
+		{
+			name:   "expense reaching multiple thresholds only triggers the highest one",
+			given:  trackingStarted,
+			amount: -1200,
+			kind:   transaction.Expense,
+			then: []interface{}{
+				monthly.TransactionWasRecorded{Amount: -1200, Kind: transaction.Expense},
+				monthly.ThresholdWasReached{Threshold: saving.Percentage(1)},
+			},
+		},
+		{
+			name: "expense reaching thresholds of different kinds triggers one event per kind",
+			given: []interface{}{
+				monthly.SpendingTrackingStarted{
+					ID:              monthlySpendingID,
+					StartingBalance: 2000,
+					DesiredBalance:  1000,
+					Thresholds: []saving.Threshold{
+						saving.Percentage(0.5),
+						saving.SpentAbove(400),
+						saving.SpentAbove(600),
+						saving.RemainingBelow(300),
+					},
+				},
+			},
+			amount: -750,
+			kind:   transaction.Expense,
+			then: []interface{}{
+				monthly.TransactionWasRecorded{Amount: -750, Kind: transaction.Expense},
+				monthly.ThresholdWasReached{Threshold: saving.Percentage(0.5)},
+				monthly.ThresholdWasReached{Threshold: saving.SpentAbove(600)},
+				monthly.ThresholdWasReached{Threshold: saving.RemainingBelow(300)},
+			},
+		},
+		{
+			name: "thresholds already reached are not triggered again",
+			given: []interface{}{
+				monthly.SpendingTrackingStarted{
+					ID:              monthlySpendingID,
+					StartingBalance: 2000,
+					DesiredBalance:  1000,
+					Thresholds: []saving.Threshold{
+						saving.SpentAbove(400),
+						saving.RemainingBelow(300),
+						saving.RemainingBelow(100),
+					},
+				},
+				monthly.TransactionWasRecorded{Amount: -750, Kind: transaction.Expense},
+				monthly.ThresholdWasReached{Threshold: saving.SpentAbove(400)},
+				monthly.ThresholdWasReached{Threshold: saving.RemainingBelow(300)},
+			},
+			amount: -200,
+			kind:   transaction.Expense,
+			then: []interface{}{
+				monthly.TransactionWasRecorded{Amount: -200, Kind: transaction.Expense},
+				monthly.ThresholdWasReached{Threshold: saving.RemainingBelow(100)},
+			},
+		},
+		{
+			name:   "incoming internal transfer does not change the spending limit",
+			given:  trackingStarted,
+			amount: 2000,
+			kind:   transaction.InternalTransfer,
+			then: []interface{}{
+				monthly.TransactionWasRecorded{Amount: 2000, Kind: transaction.InternalTransfer},
+			},
+		},
+		{
+			name:   "outgoing internal transfer does not reach any threshold",
+			given:  trackingStarted,
+			amount: -2000,
+			kind:   transaction.InternalTransfer,
+			then: []interface{}{
+				monthly.TransactionWasRecorded{Amount: -2000, Kind: transaction.InternalTransfer},
+			},
+		},
+		{
+			name: "refund is not considered income",
+			given: append(trackingStarted[:len(trackingStarted):len(trackingStarted)],
+				monthly.TransactionWasRecorded{Amount: -400, Kind: transaction.Expense},
+			),
+			amount: 300,
+			kind:   transaction.Refund,
+			then: []interface{}{
+				monthly.TransactionWasRecorded{Amount: 300, Kind: transaction.Refund},
+			},
+		},
+		{
+			name: "refund reduces the amount spent",
+			given: append(trackingStarted[:len(trackingStarted):len(trackingStarted)],
+				monthly.TransactionWasRecorded{Amount: -400, Kind: transaction.Expense},
+				monthly.TransactionWasRecorded{Amount: 300, Kind: transaction.Refund},
+			),
+			amount: -300,
+			kind:   transaction.Expense,
+			then: []interface{}{
+				monthly.TransactionWasRecorded{Amount: -300, Kind: transaction.Expense},
+			},
+		},
+		{
+			name:   "income is reduced by the saving goal, but not by the starting balance",
+			given:  trackingStartedWithBalance,
+			amount: 1300,
+			kind:   transaction.Income,
+			then: []interface{}{
+				monthly.TransactionWasRecorded{Amount: 1300, Kind: transaction.Income},
+				monthly.SpendingLimitWasUpdated{SpendingLimit: 1000},
+			},
+		},
+		{
+			name: "expense is compared with the spending limit left after the saving goal",
+			given: append(trackingStartedWithBalance[:1:1],
+				monthly.TransactionWasRecorded{Amount: 1300, Kind: transaction.Income},
+				monthly.SpendingLimitWasUpdated{SpendingLimit: 1000},
+			),
+			amount: -500,
+			kind:   transaction.Expense,
+			then: []interface{}{
+				monthly.TransactionWasRecorded{Amount: -500, Kind: transaction.Expense},
+				monthly.ThresholdWasReached{Threshold: saving.Percentage(0.5)},
+			},
+		},
+		{
+			name:   "expense before any income oversteps all thresholds, whatever the starting balance",
+			given:  trackingStartedWithBalance,
+			amount: -10,
+			kind:   transaction.Expense,
+			then: []interface{}{
+				monthly.TransactionWasRecorded{Amount: -10, Kind: transaction.Expense},
+				monthly.ThresholdWasReached{Threshold: saving.Percentage(1)},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			given := make([]eventstore.Event, 0, len(tc.given))
+			for i, payload := range tc.given {
+				given = append(given, eventstore.Event{
+					StreamType: monthly.Type.Name(),
+					StreamName: monthlySpendingID.String(),
+					Version:    int64(i) + 1,
+					Event:      eventually.Event{Payload: payload},
+				})
+			}
+
+			then := make([]eventstore.Event, 0, len(tc.then))
+			for i, payload := range tc.then {
+				then = append(then, eventstore.Event{
+					StreamType: monthly.Type.Name(),
+					StreamName: monthlySpendingID.String(),
+					Version:    int64(len(given) + i + 1),
+					Event:      eventually.Event{Payload: payload},
+				})
+			}
+
+			scenario.
+				CommandHandler().
+				Given(given...).
+				When(eventually.Command{
+					Payload: monthly.RecordTransaction{
+						ID:     monthlySpendingID,
+						Amount: tc.amount,
+						Kind:   tc.kind,
+					},
+				}).
+				Then(then...).
+				Using(t, monthly.Type, func(r *aggregate.Repository) command.Handler {
+					return monthly.RecordTransactionCommandHandler{Repository: r}
+				})
+		})
+	}
+}
+
+func TestRecordTransactionPacing(t *testing.T) {
+	monthlySpendingID := monthly.ID{
+		AccountID: "test-account",
+		Month:     interval.Month{Year: 2021, Month: time.March},
+	}
+
+	march := func(day, hour int) time.Time {
+		return time.Date(2021, time.March, day, hour, 0, 0, 0, time.UTC)
+	}
+
+	// Spending limit is 1000, with thresholds at 50% and 100%.
+	trackingStarted := func(strategy pacing.Strategy) monthly.SpendingTrackingStarted {
+		return monthly.SpendingTrackingStarted{
+			ID:              monthlySpendingID,
+			StartingBalance: 2000,
+			DesiredBalance:  1000,
+			Thresholds:      []saving.Threshold{saving.Percentage(0.5), saving.Percentage(1)},
+			Pacing:          strategy,
+		}
+	}
+
+	testCases := []struct {
+		name       string
+		given      []interface{}
+		amount     float64
+		happenedAt time.Time
+		then       []interface{}
+	}{
+		{
+			name:       "expense on pace is only recorded",
+			given:      []interface{}{trackingStarted(pacing.Linear)},
+			amount:     -300,
+			happenedAt: march(10, 12),
+			then: []interface{}{
+				monthly.TransactionWasRecorded{Amount: -300, Kind: transaction.Expense, HappenedAt: march(10, 12)},
+			},
+		},
+		{
+			name:       "expense running ahead of pace triggers a warning",
+			given:      []interface{}{trackingStarted(pacing.Linear)},
+			amount:     -400,
+			happenedAt: march(10, 12),
+			then: []interface{}{
+				monthly.TransactionWasRecorded{Amount: -400, Kind: transaction.Expense, HappenedAt: march(10, 12)},
+				monthly.PaceWarning{
+					Date:          march(10, 12),
+					SpendingLimit: 1000,
+					Spent:         400,
+					Expected:      1000.0 * 10 / 31,
+				},
+			},
+		},
+		{
+			name:       "front-loaded strategy expects more spending in the first days",
+			given:      []interface{}{trackingStarted(pacing.FrontLoaded)},
+			amount:     -400,
+			happenedAt: march(5, 12),
+			then: []interface{}{
+				monthly.TransactionWasRecorded{Amount: -400, Kind: transaction.Expense, HappenedAt: march(5, 12)},
+			},
+		},
+		{
+			name: "warning is triggered at most once per day",
+			given: []interface{}{
+				trackingStarted(pacing.Linear),
+				monthly.TransactionWasRecorded{Amount: -400, Kind: transaction.Expense, HappenedAt: march(10, 12)},
+				monthly.PaceWarning{
+					Date:          march(10, 12),
+					SpendingLimit: 1000,
+					Spent:         400,
+					Expected:      1000.0 * 10 / 31,
+				},
+			},
+			amount:     -50,
+			happenedAt: march(10, 18),
+			then: []interface{}{
+				monthly.TransactionWasRecorded{Amount: -50, Kind: transaction.Expense, HappenedAt: march(10, 18)},
+			},
+		},
+		{
+			name:       "transaction outside the month tracked is not paced",
+			given:      []interface{}{trackingStarted(pacing.Linear)},
+			amount:     -400,
+			happenedAt: time.Date(2021, time.February, 28, 12, 0, 0, 0, time.UTC),
+			then: []interface{}{
+				monthly.TransactionWasRecorded{
+					Amount:     -400,
+					Kind:       transaction.Expense,
+					HappenedAt: time.Date(2021, time.February, 28, 12, 0, 0, 0, time.UTC),
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			given := make([]eventstore.Event, 0, len(tc.given))
+			for i, payload := range tc.given {
+				given = append(given, eventstore.Event{
+					StreamType: monthly.Type.Name(),
+					StreamName: monthlySpendingID.String(),
+					Version:    int64(i) + 1,
+					Event:      eventually.Event{Payload: payload},
+				})
+			}
+
+			then := make([]eventstore.Event, 0, len(tc.then))
+			for i, payload := range tc.then {
+				then = append(then, eventstore.Event{
+					StreamType: monthly.Type.Name(),
+					StreamName: monthlySpendingID.String(),
+					Version:    int64(len(given) + i + 1),
+					Event:      eventually.Event{Payload: payload},
+				})
+			}
+
+			scenario.
+				CommandHandler().
+				Given(given...).
+				When(eventually.Command{
+					Payload: monthly.RecordTransaction{
+						ID:         monthlySpendingID,
+						Amount:     tc.amount,
+						Kind:       transaction.Expense,
+						HappenedAt: tc.happenedAt,
+					},
+				}).
+				Then(then...).
+				Using(t, monthly.Type, func(r *aggregate.Repository) command.Handler {
+					return monthly.RecordTransactionCommandHandler{Repository: r}
+				})
+		})
+	}
+}
+
+func TestRecordTransactionCommitments(t *testing.T) {
+	monthlySpendingID := monthly.ID{
+		AccountID: "test-account",
+		Month:     interval.Month{Year: 2021, Month: time.March},
+	}
+
+	// Spending limit is 1000, of which 400 are committed to the rent.
+	trackingStarted := monthly.SpendingTrackingStarted{
+		ID:              monthlySpendingID,
+		StartingBalance: 2000,
+		DesiredBalance:  1000,
+		Thresholds:      []saving.Threshold{saving.Percentage(0.5)},
+		Commitments:     []monthly.Commitment{{SeriesID: "landlord-ltd", Amount: 400}},
+	}
+
+	testCases := []struct {
+		name     string
+		seriesID string
+		then     []interface{}
+	}{
+		{
+			name:     "committed expense does not reduce the amount left to spend",
+			seriesID: "landlord-ltd",
+			then: []interface{}{
+				monthly.TransactionWasRecorded{Amount: -400, Kind: transaction.Expense, SeriesID: "landlord-ltd"},
+			},
+		},
+		{
+			name: "uncommitted expense is counted against the reduced spending limit",
+			then: []interface{}{
+				monthly.TransactionWasRecorded{Amount: -400, Kind: transaction.Expense},
+				monthly.ThresholdWasReached{Threshold: saving.Percentage(0.5)},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			then := make([]eventstore.Event, 0, len(tc.then))
+			for i, payload := range tc.then {
+				then = append(then, eventstore.Event{
+					StreamType: monthly.Type.Name(),
+					StreamName: monthlySpendingID.String(),
+					Version:    int64(i) + 2,
+					Event:      eventually.Event{Payload: payload},
+				})
+			}
+
+			scenario.
+				CommandHandler().
+				Given(eventstore.Event{
+					StreamType: monthly.Type.Name(),
+					StreamName: monthlySpendingID.String(),
+					Version:    1,
+					Event:      eventually.Event{Payload: trackingStarted},
+				}).
+				When(eventually.Command{
+					Payload: monthly.RecordTransaction{
+						ID:       monthlySpendingID,
+						Amount:   -400,
+						Kind:     transaction.Expense,
+						SeriesID: tc.seriesID,
+					},
+				}).
+				Then(then...).
+				Using(t, monthly.Type, func(r *aggregate.Repository) command.Handler {
+					return monthly.RecordTransactionCommandHandler{Repository: r}
+				})
+		})
+	}
+}
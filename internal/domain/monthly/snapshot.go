@@ -0,0 +1,113 @@
+package monthly
+
+import (
+	"time"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/category"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/pacing"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/saving"
+)
+
+// SnapshotVersion is the schema version of the Spending Snapshot.
+//
+// Increase it whenever the Snapshot type or the meaning of its fields change:
+// Snapshots with a different version are discarded, and the Spending
+// is loaded by replaying all of its Domain Events.
+const SnapshotVersion = 1
+
+// Snapshot is the state of a Spending at a certain version, used to load it
+// without replaying all of its Domain Events.
+//
+// A Snapshot is applied to the Spending as any other Domain Event,
+// replacing its whole state.
+type Snapshot struct {
+	ID                    ID
+	StartingBalance       float64
+	CurrentBalance        float64
+	DesiredBalance        float64
+	SpendingLimit         float64
+	Spent                 float64
+	Thresholds            []saving.Threshold
+	LastReachedThresholds map[saving.ThresholdKind]saving.Threshold
+	Categories            map[category.Category]CategorySnapshot
+	Pacing                pacing.Strategy
+	LastPaceWarning       time.Time
+	GoalAtRisk            bool
+	Commitments           map[string]float64
+	Closed                bool
+}
+
+// CategorySnapshot is the spending state of a Category with a Budget,
+// part of the Spending Snapshot.
+type CategorySnapshot struct {
+	Budget                saving.Budget
+	Spent                 float64
+	LastReachedThresholds map[saving.ThresholdKind]saving.Threshold
+}
+
+// Snapshot returns the current state of the Spending.
+func (ms *Spending) Snapshot() interface{} {
+	categories := make(map[category.Category]CategorySnapshot, len(ms.categories))
+	for c, spending := range ms.categories {
+		categories[c] = CategorySnapshot{
+			Budget:                spending.budget,
+			Spent:                 spending.spent,
+			LastReachedThresholds: spending.lastReachedThresholds,
+		}
+	}
+
+	return Snapshot{
+		ID:                    ms.id,
+		StartingBalance:       ms.startingBalance,
+		CurrentBalance:        ms.currentBalance,
+		DesiredBalance:        ms.desiredBalance,
+		SpendingLimit:         ms.spendingLimit,
+		Spent:                 ms.spent,
+		Thresholds:            ms.thresholds,
+		LastReachedThresholds: ms.lastReachedThresholds,
+		Categories:            categories,
+		Pacing:                ms.pacing,
+		LastPaceWarning:       ms.lastPaceWarning,
+		GoalAtRisk:            ms.goalAtRisk,
+		Commitments:           ms.commitments,
+		Closed:                ms.closed,
+	}
+}
+
+func (ms *Spending) restore(snapshot Snapshot) {
+	ms.id = snapshot.ID
+	ms.startingBalance = snapshot.StartingBalance
+	ms.currentBalance = snapshot.CurrentBalance
+	ms.desiredBalance = snapshot.DesiredBalance
+	ms.spendingLimit = snapshot.SpendingLimit
+	ms.spent = snapshot.Spent
+	ms.thresholds = snapshot.Thresholds
+	ms.lastReachedThresholds = copyThresholds(snapshot.LastReachedThresholds)
+	ms.categories = make(map[category.Category]*categorySpending, len(snapshot.Categories))
+	ms.pacing = snapshot.Pacing
+	ms.lastPaceWarning = snapshot.LastPaceWarning
+	ms.goalAtRisk = snapshot.GoalAtRisk
+	ms.commitments = make(map[string]float64, len(snapshot.Commitments))
+	ms.closed = snapshot.Closed
+
+	for c, spending := range snapshot.Categories {
+		ms.categories[c] = &categorySpending{
+			budget:                spending.Budget,
+			spent:                 spending.Spent,
+			lastReachedThresholds: copyThresholds(spending.LastReachedThresholds),
+		}
+	}
+
+	for seriesID, amount := range snapshot.Commitments {
+		ms.commitments[seriesID] = amount
+	}
+}
+
+func copyThresholds(thresholds map[saving.ThresholdKind]saving.Threshold) thresholdsByKind {
+	copied := make(thresholdsByKind, len(thresholds))
+	for kind, threshold := range thresholds {
+		copied[kind] = threshold
+	}
+
+	return copied
+}
@@ -2,10 +2,16 @@ package monthly
 
 import (
 	"fmt"
-	"sort"
+	"math"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/eventually-rs/saving-goals-go/internal/domain/category"
 	"github.com/eventually-rs/saving-goals-go/internal/domain/interval"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/pacing"
 	"github.com/eventually-rs/saving-goals-go/internal/domain/saving"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/transaction"
 
 	"github.com/eventually-rs/eventually-go"
 	"github.com/eventually-rs/eventually-go/aggregate"
@@ -16,26 +22,98 @@ var Type = aggregate.NewType("monthly-spending", func() aggregate.Root {
 	return new(Spending)
 })
 
+// ErrInvalidID is returned by ParseID when the string specified
+// is not the identifier of a MonthlySpending.
+var ErrInvalidID = fmt.Errorf("monthly.ParseID: invalid spending id")
+
 // ID is the primary identifier type for a MonthlySpending Aggregate instance.
+//
+// The spending is either tracked for a single Account, or for a Household
+// combining the spending of all its members, when HouseholdID is specified.
 type ID struct {
-	AccountID string
-	Month     interval.Month
+	AccountID   string
+	HouseholdID string
+	Month       interval.Month
 }
 
 func (id ID) String() string {
+	if id.HouseholdID != "" {
+		return fmt.Sprintf("household:%s:month:%s", id.HouseholdID, id.Month.String())
+	}
+
 	return fmt.Sprintf("account:%s:month:%s", id.AccountID, id.Month.String())
 }
 
+// ParseID parses the identifier of a MonthlySpending from its string
+// representation, as returned by ID.String, e.g. the name of its event stream.
+func ParseID(s string) (ID, error) {
+	sep := strings.LastIndex(s, ":month:")
+	if sep < 0 {
+		return ID{}, fmt.Errorf("%w: %s", ErrInvalidID, s)
+	}
+
+	date := strings.SplitN(s[sep+len(":month:"):], "-", 2)
+	if len(date) != 2 {
+		return ID{}, fmt.Errorf("%w: %s", ErrInvalidID, s)
+	}
+
+	year, err := strconv.Atoi(date[0])
+	if err != nil {
+		return ID{}, fmt.Errorf("%w: %s", ErrInvalidID, s)
+	}
+
+	m, err := strconv.Atoi(date[1])
+	if err != nil || m < 1 || m > 12 {
+		return ID{}, fmt.Errorf("%w: %s", ErrInvalidID, s)
+	}
+
+	month := interval.Month{Year: year, Month: time.Month(m)}
+
+	switch owner := s[:sep]; {
+	case strings.HasPrefix(owner, "household:"):
+		return ID{HouseholdID: strings.TrimPrefix(owner, "household:"), Month: month}, nil
+	case strings.HasPrefix(owner, "account:"):
+		return ID{AccountID: strings.TrimPrefix(owner, "account:"), Month: month}, nil
+	default:
+		return ID{}, fmt.Errorf("%w: %s", ErrInvalidID, s)
+	}
+}
+
 type Spending struct {
 	aggregate.BaseRoot
 
-	id                     ID
-	startingBalance        float64
-	currentBalance         float64
-	desiredBalance         float64
-	spendingLimit          float64
-	thresholds             []float64
-	lastTriggeredThreshold float64
+	id                    ID
+	startingBalance       float64
+	currentBalance        float64
+	desiredBalance        float64
+	spendingLimit         float64
+	spent                 float64
+	thresholds            []saving.Threshold
+	lastReachedThresholds thresholdsByKind
+	categories            map[category.Category]*categorySpending
+	pacing                pacing.Strategy
+	lastPaceWarning       time.Time
+	goalAtRisk            bool
+	commitments           map[string]float64
+	closed                bool
+}
+
+// Commitment is an outflow expected in the month from a recurring series,
+// like rent or subscriptions, which is considered as already committed,
+// reducing the spending limit since the start of the month.
+type Commitment struct {
+	SeriesID string
+	Amount   float64
+}
+
+// thresholdsByKind keeps the last Threshold reached for each kind.
+type thresholdsByKind map[saving.ThresholdKind]saving.Threshold
+
+// categorySpending tracks the spending of a single Category with a Budget.
+type categorySpending struct {
+	budget                saving.Budget
+	spent                 float64
+	lastReachedThresholds thresholdsByKind
 }
 
 func (ms Spending) AggregateID() aggregate.ID { return ms.id }
@@ -44,19 +122,73 @@ type SpendingTrackingStarted struct {
 	ID              ID
 	StartingBalance float64
 	DesiredBalance  float64
-	Thresholds      []float64
+	Thresholds      []saving.Threshold
+	Budgets         []saving.Budget
+	Pacing          pacing.Strategy
+	Commitments     []Commitment
 }
 
+// TransactionWasRecorded is the Domain Event triggered when a new transaction
+// is recorded for the month.
+//
+// Kind is always specified since the second version of the Event:
+// the Events recorded before the classification was introduced
+// are classified with transaction.Classify when read.
+//
+// HappenedAt might be zero for transactions recorded before
+// the pacing was introduced.
+//
+// SeriesID is the identifier of the recurring series the transaction belongs to,
+// if any: expenses of a series with a Commitment are moved from the committed
+// amount to the amount spent.
 type TransactionWasRecorded struct {
-	Amount float64
+	Amount     float64
+	Kind       transaction.Kind
+	HappenedAt time.Time
+	SeriesID   string
 }
 
 type SpendingLimitWasUpdated struct {
 	SpendingLimit float64
 }
 
+// ThresholdWasReached is the Domain Event triggered when the amount spent
+// in the month reaches one of the thresholds of the Saving Goal.
+//
+// Events recorded before the Threshold kinds were introduced only contain
+// the percentage value, which is decoded as a percentage Threshold.
 type ThresholdWasReached struct {
-	Threshold float64
+	Threshold saving.Threshold
+}
+
+// TransactionWasCategorized is the Domain Event triggered when a transaction
+// of the month has been assigned to a different Category, moving its
+// contribution to the amount spent from the previous Category to the new one.
+type TransactionWasCategorized struct {
+	TransactionID    string
+	Category         category.Category
+	PreviousCategory category.Category
+	Amount           float64
+	Kind             transaction.Kind
+}
+
+// CategoryThresholdWasReached is the Domain Event triggered when the amount
+// spent in a Category reaches one of the thresholds of its Budget.
+type CategoryThresholdWasReached struct {
+	Category  category.Category
+	Threshold saving.Threshold
+}
+
+// PaceWarning is the Domain Event triggered when the amount spent in the month
+// runs ahead of the amount expected to be spent by the day of the transaction,
+// according to the pacing Strategy of the spending.
+//
+// The warning is triggered at most once per day.
+type PaceWarning struct {
+	Date          time.Time
+	SpendingLimit float64
+	Spent         float64
+	Expected      float64
 }
 
 func (ms *Spending) Apply(event eventually.Event) error {
@@ -66,16 +198,91 @@ func (ms *Spending) Apply(event eventually.Event) error {
 		ms.startingBalance = evt.StartingBalance
 		ms.currentBalance = evt.StartingBalance
 		ms.desiredBalance = evt.DesiredBalance
+		// The spending limit is the balance above the desired one, like when
+		// updated by the income: it starts from minus the saving goal, as the
+		// starting balance is already part of the desired balance, so that
+		// nothing can be spent until the income covers the saving goal.
+		ms.spendingLimit = evt.StartingBalance - evt.DesiredBalance
+		ms.commitments = make(map[string]float64, len(evt.Commitments))
+
+		for _, commitment := range evt.Commitments {
+			ms.commitments[commitment.SeriesID] += commitment.Amount
+			ms.spendingLimit -= commitment.Amount
+		}
+
 		ms.thresholds = evt.Thresholds
+		ms.lastReachedThresholds = make(thresholdsByKind)
+		ms.categories = make(map[category.Category]*categorySpending, len(evt.Budgets))
+		ms.pacing = evt.Pacing.OrDefault()
+
+		for _, budget := range evt.Budgets {
+			ms.categories[budget.Category] = &categorySpending{
+				budget:                budget,
+				lastReachedThresholds: make(thresholdsByKind),
+			}
+		}
 
 	case TransactionWasRecorded:
 		ms.currentBalance += evt.Amount
 
+		switch transaction.Classify(evt.Kind, evt.Amount) {
+		case transaction.Expense:
+			// Committed expenses were already subtracted from the spending limit,
+			// so they are given back before being counted as spent.
+			if remaining := ms.commitments[evt.SeriesID]; remaining > 0 {
+				released := math.Min(remaining, math.Abs(evt.Amount))
+				ms.commitments[evt.SeriesID] -= released
+				ms.spendingLimit += released
+			}
+
+			ms.spent += math.Abs(evt.Amount)
+		case transaction.Refund:
+			ms.spent = math.Max(0, ms.spent-math.Abs(evt.Amount))
+		}
+
 	case SpendingLimitWasUpdated:
 		ms.spendingLimit = evt.SpendingLimit
 
 	case ThresholdWasReached:
-		ms.lastTriggeredThreshold = evt.Threshold
+		ms.lastReachedThresholds[evt.Threshold.Kind] = evt.Threshold
+
+	case TransactionWasCategorized:
+		var contribution float64
+
+		switch transaction.Classify(evt.Kind, evt.Amount) {
+		case transaction.Expense:
+			contribution = math.Abs(evt.Amount)
+		case transaction.Refund:
+			contribution = -math.Abs(evt.Amount)
+		}
+
+		if previous, ok := ms.categories[evt.PreviousCategory]; ok {
+			previous.spent = math.Max(0, previous.spent-contribution)
+		}
+
+		if current, ok := ms.categories[evt.Category]; ok {
+			current.spent = math.Max(0, current.spent+contribution)
+		}
+
+	case CategoryThresholdWasReached:
+		if c, ok := ms.categories[evt.Category]; ok {
+			c.lastReachedThresholds[evt.Threshold.Kind] = evt.Threshold
+		}
+
+	case PaceWarning:
+		ms.lastPaceWarning = evt.Date
+
+	case GoalAtRisk:
+		ms.goalAtRisk = true
+
+	case GoalBackOnTrack:
+		ms.goalAtRisk = false
+
+	case SpendingWasClosed:
+		ms.closed = true
+
+	case Snapshot:
+		ms.restore(evt)
 
 	default:
 		return fmt.Errorf("spending: unsupported event received")
@@ -84,18 +291,32 @@ func (ms *Spending) Apply(event eventually.Event) error {
 	return nil
 }
 
-func NewSpending(accountID string, month interval.Month, balance float64, goal saving.Goal) (*Spending, error) {
+// NewSpending starts tracking the spending of the specified Account or Household for the month,
+// using the Saving Goal to compute the spending limit, and tracking the
+// spending of each Category with a Budget separately.
+//
+// The pacing Strategy is used to check whether the spending runs ahead
+// of the expected one during the month, while the Commitments reduce
+// the spending limit until the related expenses are recorded.
+func NewSpending(
+	id ID,
+	balance float64,
+	goal saving.Goal,
+	budgets []saving.Budget,
+	strategy pacing.Strategy,
+	commitments []Commitment,
+) (*Spending, error) {
 	var spending Spending
 
 	err := aggregate.RecordThat(&spending, eventually.Event{
 		Payload: SpendingTrackingStarted{
-			ID: ID{
-				AccountID: accountID,
-				Month:     month,
-			},
+			ID:              id,
 			StartingBalance: balance,
 			DesiredBalance:  balance + goal.Amount,
 			Thresholds:      goal.Thresholds,
+			Budgets:         budgets,
+			Pacing:          strategy.OrDefault(),
+			Commitments:     commitments,
 		},
 	})
 
@@ -106,65 +327,222 @@ func NewSpending(accountID string, month interval.Month, balance float64, goal s
 	return &spending, nil
 }
 
-func (s *Spending) RecordTransaction(amount float64) error {
-	newBalance := s.currentBalance + amount
+// RecordTransaction records a new transaction for the month, using its kind
+// to decide the effect on the monthly spending:
+//
+//   - income increases the spending limit,
+//   - expenses increase the amount spent, possibly reaching some of the thresholds,
+//     or running ahead of the pace expected on the day of the transaction,
+//   - refunds reduce the amount spent,
+//   - internal transfers only change the balance.
+//
+// If no kind is specified, the transaction is classified using the sign of the amount.
+//
+// Expenses of a recurring series with a Commitment for the month do not reduce
+// the amount that can still be spent, up to the committed amount.
+//
+// ErrSpendingClosed is returned if the spending has been closed.
+func (s *Spending) RecordTransaction(
+	amount float64,
+	kind transaction.Kind,
+	happenedAt time.Time,
+	seriesID string,
+) error {
+	if s.closed {
+		return fmt.Errorf("monthly.RecordTransaction: %w", ErrSpendingClosed)
+	}
+
+	kind = transaction.Classify(kind, amount)
 
 	err := aggregate.RecordThat(s, eventually.Event{
-		Payload: TransactionWasRecorded{Amount: amount},
+		Payload: TransactionWasRecorded{
+			Amount:     amount,
+			Kind:       kind,
+			HappenedAt: happenedAt,
+			SeriesID:   seriesID,
+		},
 	})
 
 	if err != nil {
 		return fmt.Errorf("monthly.RecordTransaction: failed to record domain event: %w", err)
 	}
 
-	if amount > 0 {
-		return s.updateSpendingLimit(newBalance)
+	switch kind {
+	case transaction.Income:
+		return s.updateSpendingLimit(s.spendingLimit + math.Abs(amount))
+	case transaction.Expense:
+		if err := s.triggerThresholdOverstepIfAny(); err != nil {
+			return err
+		}
+
+		return s.triggerPaceWarningIfAny(happenedAt)
+	default:
+		return nil
+	}
+}
+
+// CategorizeTransaction moves the contribution of a transaction to the amount
+// spent from its previous Category to the new one, possibly reaching some
+// of the thresholds of the new Category's Budget.
+//
+// ErrSpendingClosed is returned if the spending has been closed.
+func (s *Spending) CategorizeTransaction(
+	transactionID string,
+	c, previous category.Category,
+	amount float64,
+	kind transaction.Kind,
+) error {
+	if s.closed {
+		return fmt.Errorf("monthly.CategorizeTransaction: %w", ErrSpendingClosed)
+	}
+
+	err := aggregate.RecordThat(s, eventually.Event{
+		Payload: TransactionWasCategorized{
+			TransactionID:    transactionID,
+			Category:         c,
+			PreviousCategory: previous,
+			Amount:           amount,
+			Kind:             transaction.Classify(kind, amount),
+		},
+	})
+
+	if err != nil {
+		return fmt.Errorf("monthly.CategorizeTransaction: failed to record domain event: %w", err)
+	}
+
+	current, ok := s.categories[c]
+	if !ok {
+		// The new Category has no Budget, so there are no thresholds to check.
+		return nil
 	}
 
-	return s.triggerThresholdOverstepIfAny(newBalance)
+	thresholds := current.lastReachedThresholds.newlyReached(
+		current.budget.Thresholds,
+		current.spent,
+		current.budget.Amount,
+	)
+
+	for _, threshold := range thresholds {
+		err := aggregate.RecordThat(s, eventually.Event{
+			Payload: CategoryThresholdWasReached{Category: c, Threshold: threshold},
+		})
+
+		if err != nil {
+			return fmt.Errorf("monthly.CategorizeTransaction: failed to record domain event: %w", err)
+		}
+	}
+
+	return nil
 }
 
-func (s *Spending) triggerThresholdOverstepIfAny(newBalance float64) error {
-	currentPercentage := (newBalance - s.desiredBalance) / s.spendingLimit
+// newlyReached returns the thresholds reached by the amount spent, with respect to
+// the specified limit, that are more severe than the last ones reached.
+//
+// Only the most severe Threshold is returned for each kind, e.g. if both 50% and 80%
+// are reached with the same transaction, only 80% is returned. Thresholds are
+// returned following the order in which their kinds first appear in the list.
+func (last thresholdsByKind) newlyReached(thresholds []saving.Threshold, spent, limit float64) []saving.Threshold {
+	kinds := make([]saving.ThresholdKind, 0, len(thresholds))
+	reached := make(thresholdsByKind)
+
+	for _, threshold := range thresholds {
+		if !threshold.ReachedBy(spent, limit) {
+			continue
+		}
 
-	triggeredThresholds := make([]float64, 0, len(s.thresholds))
-	for _, threshold := range s.thresholds {
-		// We are only filtering for thresholds that fall between the last triggered one
-		// and the current percentage of the spending limit already spent.
-		//
-		// This will give us back those thresholds that the current spending
-		// percentage have been surpassed.
-		if threshold <= s.lastTriggeredThreshold || currentPercentage < threshold {
+		// We are only interested in thresholds more severe than the last reached one,
+		// which means they have been surpassed by the current spending.
+		if lastReached, ok := last[threshold.Kind]; ok && !threshold.MoreSevereThan(lastReached) {
 			continue
 		}
 
-		triggeredThresholds = append(triggeredThresholds, threshold)
+		mostSevere, ok := reached[threshold.Kind]
+		if !ok {
+			kinds = append(kinds, threshold.Kind)
+		}
+
+		if !ok || threshold.MoreSevereThan(mostSevere) {
+			reached[threshold.Kind] = threshold
+		}
+	}
+
+	result := make([]saving.Threshold, 0, len(kinds))
+	for _, kind := range kinds {
+		result = append(result, reached[kind])
 	}
 
-	if len(triggeredThresholds) == 0 {
-		// No triggered thresholds, no relevant Domain Events to register.
+	return result
+}
+
+func (s *Spending) triggerThresholdOverstepIfAny() error {
+	// No triggered thresholds means no relevant Domain Events to register.
+	for _, threshold := range s.lastReachedThresholds.newlyReached(s.thresholds, s.spent, s.spendingLimit) {
+		err := aggregate.RecordThat(s, eventually.Event{
+			Payload: ThresholdWasReached{Threshold: threshold},
+		})
+
+		if err != nil {
+			return fmt.Errorf("monthly.RecordTransaction.triggerThresholdOverstep: failed to record domain event: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// committed returns the amount of the Commitments whose expenses
+// have not been recorded yet.
+func (s *Spending) committed() float64 {
+	var total float64
+	for _, remaining := range s.commitments {
+		total += remaining
+	}
+
+	return total
+}
+
+// Allowance returns the result of the pacing calculation for the specified date,
+// using the current spending limit and amount spent.
+func (s *Spending) Allowance(date time.Time) pacing.Allowance {
+	return pacing.Compute(s.pacing, date, s.spendingLimit, s.spent)
+}
+
+func (s *Spending) triggerPaceWarningIfAny(happenedAt time.Time) error {
+	// Transactions outside the month tracked cannot be compared with the expected pace.
+	if happenedAt.IsZero() || interval.MonthFromTime(happenedAt) != s.id.Month {
 		return nil
 	}
 
-	// Let's take only the latest threshold surpassed, in case there are more than one,
-	// which should be the highest one, after sorting.
-	sort.Float64s(triggeredThresholds)
-	triggeredThreshold := triggeredThresholds[len(triggeredThresholds)-1]
+	allowance := s.Allowance(happenedAt)
+	if allowance.Status != pacing.AheadOfPace || sameDay(s.lastPaceWarning, happenedAt) {
+		return nil
+	}
 
 	err := aggregate.RecordThat(s, eventually.Event{
-		Payload: ThresholdWasReached{Threshold: triggeredThreshold},
+		Payload: PaceWarning{
+			Date:          happenedAt,
+			SpendingLimit: allowance.SpendingLimit,
+			Spent:         allowance.Spent,
+			Expected:      allowance.Expected,
+		},
 	})
 
 	if err != nil {
-		return fmt.Errorf("monthly.RecordTransaction.triggerThresholdOverstep: failed to record domain event: %w", err)
+		return fmt.Errorf("monthly.RecordTransaction.triggerPaceWarning: failed to record domain event: %w", err)
 	}
 
 	return nil
 }
 
-func (s *Spending) updateSpendingLimit(newBalance float64) error {
+func sameDay(a, b time.Time) bool {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+
+	return ay == by && am == bm && ad == bd
+}
+
+func (s *Spending) updateSpendingLimit(spendingLimit float64) error {
 	err := aggregate.RecordThat(s, eventually.Event{
-		Payload: SpendingLimitWasUpdated{SpendingLimit: newBalance - s.desiredBalance},
+		Payload: SpendingLimitWasUpdated{SpendingLimit: spendingLimit},
 	})
 
 	if err != nil {
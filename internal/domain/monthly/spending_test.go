@@ -0,0 +1,60 @@
+package monthly_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/interval"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/monthly"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseID(t *testing.T) {
+	month := interval.Month{Year: 2021, Month: time.March}
+
+	testCases := []struct {
+		name     string
+		id       string
+		expected monthly.ID
+		err      error
+	}{
+		{
+			name:     "account spending",
+			id:       monthly.ID{AccountID: "test-account", Month: month}.String(),
+			expected: monthly.ID{AccountID: "test-account", Month: month},
+		},
+		{
+			name:     "household spending",
+			id:       monthly.ID{HouseholdID: "test-household", Month: month}.String(),
+			expected: monthly.ID{HouseholdID: "test-household", Month: month},
+		},
+		{
+			name: "unknown owner",
+			id:   "goal:test-goal:month:2021-03",
+			err:  monthly.ErrInvalidID,
+		},
+		{
+			name: "invalid month",
+			id:   "account:test-account:month:2021-13",
+			err:  monthly.ErrInvalidID,
+		},
+		{
+			name: "not a spending",
+			id:   "test-account",
+			err:  monthly.ErrInvalidID,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			id, err := monthly.ParseID(tc.id)
+
+			assert.True(t, errors.Is(err, tc.err), "error", err)
+			assert.Equal(t, tc.expected, id)
+		})
+	}
+}
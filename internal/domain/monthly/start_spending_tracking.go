@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/eventually-rs/saving-goals-go/internal/domain/interval"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/pacing"
 	"github.com/eventually-rs/saving-goals-go/internal/domain/saving"
 
 	"github.com/eventually-rs/eventually-go"
@@ -12,11 +13,17 @@ import (
 	"github.com/eventually-rs/eventually-go/command"
 )
 
+// StartSpendingTracking is the Domain Command used to start tracking the spending
+// of an Account for the month, or of a Household when HouseholdID is specified.
 type StartSpendingTracking struct {
 	AccountID       string
+	HouseholdID     string
 	Month           interval.Month
 	StartingBalance float64
 	SavingGoal      saving.Goal
+	Budgets         []saving.Budget
+	Pacing          pacing.Strategy
+	Commitments     []Commitment
 }
 
 type StartSpendingTrackingCommandHandler struct {
@@ -30,7 +37,18 @@ func (StartSpendingTrackingCommandHandler) CommandType() command.Command {
 func (h StartSpendingTrackingCommandHandler) Handle(ctx context.Context, cmd eventually.Command) error {
 	command := cmd.Payload.(StartSpendingTracking)
 
-	monthlySpending, err := NewSpending(command.AccountID, command.Month, command.StartingBalance, command.SavingGoal)
+	monthlySpending, err := NewSpending(
+		ID{
+			AccountID:   command.AccountID,
+			HouseholdID: command.HouseholdID,
+			Month:       command.Month,
+		},
+		command.StartingBalance,
+		command.SavingGoal,
+		command.Budgets,
+		command.Pacing,
+		command.Commitments,
+	)
 	if err != nil {
 		return fmt.Errorf("monthly.StartSpendingTracking: failed to start new spending tracking: %w", err)
 	}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/eventually-rs/saving-goals-go/internal/domain/interval"
 	"github.com/eventually-rs/saving-goals-go/internal/domain/monthly"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/pacing"
 	"github.com/eventually-rs/saving-goals-go/internal/domain/saving"
 
 	"github.com/eventually-rs/eventually-go"
@@ -31,7 +32,7 @@ func TestStartSpendingTracking(t *testing.T) {
 					StartingBalance: 1000,
 					SavingGoal: saving.Goal{
 						Amount:     500,
-						Thresholds: []float64{0.25, 0.5},
+						Thresholds: []saving.Threshold{saving.Percentage(0.25), saving.Percentage(0.5)},
 					},
 				},
 			}).
@@ -44,7 +45,8 @@ func TestStartSpendingTracking(t *testing.T) {
 						ID:              monthlySpendingID,
 						StartingBalance: 1000,
 						DesiredBalance:  1500,
-						Thresholds:      []float64{0.25, 0.5},
+						Thresholds:      []saving.Threshold{saving.Percentage(0.25), saving.Percentage(0.5)},
+						Pacing:          pacing.Linear,
 					},
 				},
 			}).
@@ -70,7 +72,7 @@ func TestStartSpendingTracking(t *testing.T) {
 						ID:              monthlySpendingID,
 						StartingBalance: 1000,
 						DesiredBalance:  1500,
-						Thresholds:      []float64{0.25, 0.5},
+						Thresholds:      []saving.Threshold{saving.Percentage(0.25), saving.Percentage(0.5)},
 					},
 				},
 			}).
@@ -81,7 +83,7 @@ func TestStartSpendingTracking(t *testing.T) {
 					StartingBalance: 1000,
 					SavingGoal: saving.Goal{
 						Amount:     500,
-						Thresholds: []float64{0.25, 0.5},
+						Thresholds: []saving.Threshold{saving.Percentage(0.25), saving.Percentage(0.5)},
 					},
 				},
 			}).
@@ -90,4 +92,55 @@ func TestStartSpendingTracking(t *testing.T) {
 				return monthly.StartSpendingTrackingCommandHandler{Repository: r}
 			})
 	})
+
+	t.Run("household spending is tracked separately from the members' ones", func(t *testing.T) {
+		monthlySpendingID := monthly.ID{
+			HouseholdID: "test-household",
+			Month:       interval.MonthFromTime(time.Now()),
+		}
+
+		scenario.
+			CommandHandler().
+			Given(eventstore.Event{
+				StreamType: monthly.Type.Name(),
+				StreamName: monthly.ID{AccountID: "test-household", Month: monthlySpendingID.Month}.String(),
+				Version:    1,
+				Event: eventually.Event{
+					Payload: monthly.SpendingTrackingStarted{
+						ID:              monthly.ID{AccountID: "test-household", Month: monthlySpendingID.Month},
+						StartingBalance: 1000,
+						DesiredBalance:  1500,
+						Thresholds:      []saving.Threshold{saving.Percentage(0.5)},
+					},
+				},
+			}).
+			When(eventually.Command{
+				Payload: monthly.StartSpendingTracking{
+					HouseholdID:     monthlySpendingID.HouseholdID,
+					Month:           monthlySpendingID.Month,
+					StartingBalance: 3000,
+					SavingGoal: saving.Goal{
+						Amount:     1000,
+						Thresholds: []saving.Threshold{saving.Percentage(0.5), saving.Percentage(1)},
+					},
+				},
+			}).
+			Then(eventstore.Event{
+				StreamType: monthly.Type.Name(),
+				StreamName: monthlySpendingID.String(),
+				Version:    1,
+				Event: eventually.Event{
+					Payload: monthly.SpendingTrackingStarted{
+						ID:              monthlySpendingID,
+						StartingBalance: 3000,
+						DesiredBalance:  4000,
+						Thresholds:      []saving.Threshold{saving.Percentage(0.5), saving.Percentage(1)},
+						Pacing:          pacing.Linear,
+					},
+				},
+			}).
+			Using(t, monthly.Type, func(r *aggregate.Repository) command.Handler {
+				return monthly.StartSpendingTrackingCommandHandler{Repository: r}
+			})
+	})
 }
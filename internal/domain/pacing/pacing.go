@@ -0,0 +1,164 @@
+package pacing
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// ErrUnknownStrategy is returned when a pacing Strategy is not supported.
+var ErrUnknownStrategy = fmt.Errorf("pacing.Strategy: unknown strategy")
+
+// Strategy specifies how the spending limit of the month is expected
+// to be spent over the days of the month.
+type Strategy string
+
+const (
+	// Linear expects the same amount to be spent every day of the month.
+	Linear Strategy = "linear"
+
+	// WeekdayWeighted expects twice as much to be spent on weekends
+	// than on working days.
+	WeekdayWeighted Strategy = "weekday-weighted"
+
+	// FrontLoaded expects most of the spending to happen in the first days
+	// of the month, e.g. for Accounts paying rent and bills at the start of the month.
+	FrontLoaded Strategy = "front-loaded"
+)
+
+const (
+	weekendWeight = 2.0
+
+	frontLoadedDays   = 5
+	frontLoadedWeight = 4.0
+
+	// tolerance is the fraction of the spending limit the amount spent
+	// can differ from the expected one while still being on pace.
+	tolerance = 0.05
+)
+
+// Validate returns ErrUnknownStrategy if the Strategy is not supported.
+//
+// An empty Strategy is valid, and it is the same as Linear.
+func (s Strategy) Validate() error {
+	switch s {
+	case "", Linear, WeekdayWeighted, FrontLoaded:
+		return nil
+	default:
+		return fmt.Errorf("%w: %q", ErrUnknownStrategy, s)
+	}
+}
+
+// OrDefault returns the Strategy itself, or Linear if the Strategy is not specified.
+func (s Strategy) OrDefault() Strategy {
+	if s == "" {
+		return Linear
+	}
+
+	return s
+}
+
+// weight returns the relative amount expected to be spent on the specified day.
+func (s Strategy) weight(day time.Time) float64 {
+	switch s.OrDefault() {
+	case WeekdayWeighted:
+		if day.Weekday() == time.Saturday || day.Weekday() == time.Sunday {
+			return weekendWeight
+		}
+
+	case FrontLoaded:
+		if day.Day() <= frontLoadedDays {
+			return frontLoadedWeight
+		}
+	}
+
+	return 1
+}
+
+// weights returns the total weight of the days of the month of the specified date,
+// and the weight of the days of the month up to the date, included.
+func (s Strategy) weights(date time.Time) (total, upToDate float64) {
+	year, month, _ := date.Date()
+	days := time.Date(year, month+1, 0, 0, 0, 0, 0, date.Location()).Day()
+
+	for d := 1; d <= days; d++ {
+		w := s.weight(time.Date(year, month, d, 0, 0, 0, 0, date.Location()))
+
+		total += w
+		if d <= date.Day() {
+			upToDate += w
+		}
+	}
+
+	return total, upToDate
+}
+
+// ExpectedFraction returns the fraction of the spending limit expected
+// to be spent by the end of the specified date, within its month.
+func (s Strategy) ExpectedFraction(date time.Time) float64 {
+	total, upToDate := s.weights(date)
+	return upToDate / total
+}
+
+// Status indicates whether the amount spent is in line with the expected one.
+type Status string
+
+const (
+	// OnPace means the amount spent is close to the expected one.
+	OnPace Status = "on-pace"
+
+	// AheadOfPace means more than expected has been spent so far,
+	// putting the Saving Goal at risk.
+	AheadOfPace Status = "ahead"
+
+	// BehindPace means less than expected has been spent so far.
+	BehindPace Status = "behind"
+)
+
+// Allowance is the result of the pacing calculation for a specific day of the month.
+type Allowance struct {
+	Date             time.Time
+	Strategy         Strategy
+	SpendingLimit    float64
+	Spent            float64
+	Expected         float64
+	Remaining        float64
+	SafeToSpendToday float64
+	Status           Status
+}
+
+// Compute returns the Allowance for the specified date, given the spending limit
+// of the month and the amount spent so far.
+//
+// The amount safe to spend on the date is the remaining amount of the spending limit,
+// split over the days left in the month (date included) using the Strategy.
+func Compute(strategy Strategy, date time.Time, spendingLimit, spent float64) Allowance {
+	strategy = strategy.OrDefault()
+	total, upToDate := strategy.weights(date)
+
+	allowance := Allowance{
+		Date:          date,
+		Strategy:      strategy,
+		SpendingLimit: spendingLimit,
+		Spent:         spent,
+		Expected:      math.Max(0, spendingLimit) * upToDate / total,
+		Remaining:     spendingLimit - spent,
+		Status:        OnPace,
+	}
+
+	if allowance.Remaining > 0 {
+		weightLeft := total - upToDate + strategy.weight(date)
+		allowance.SafeToSpendToday = allowance.Remaining * strategy.weight(date) / weightLeft
+	}
+
+	margin := tolerance * math.Max(0, spendingLimit)
+
+	switch {
+	case spent > allowance.Expected+margin:
+		allowance.Status = AheadOfPace
+	case spent < allowance.Expected-margin:
+		allowance.Status = BehindPace
+	}
+
+	return allowance
+}
@@ -0,0 +1,144 @@
+package pacing_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/pacing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// March 2021 starts on a Monday and has 31 days, 8 of which on weekends.
+func march(day int) time.Time {
+	return time.Date(2021, time.March, day, 12, 0, 0, 0, time.UTC)
+}
+
+func TestStrategyValidate(t *testing.T) {
+	assert.NoError(t, pacing.Strategy("").Validate())
+	assert.NoError(t, pacing.Linear.Validate())
+	assert.NoError(t, pacing.WeekdayWeighted.Validate())
+	assert.NoError(t, pacing.FrontLoaded.Validate())
+
+	err := pacing.Strategy("back-loaded").Validate()
+	assert.True(t, errors.Is(err, pacing.ErrUnknownStrategy), "error", err)
+}
+
+func TestStrategyExpectedFraction(t *testing.T) {
+	testCases := []struct {
+		name     string
+		strategy pacing.Strategy
+		date     time.Time
+		expected float64
+	}{
+		{
+			name:     "linear strategy expects the same amount every day",
+			strategy: pacing.Linear,
+			date:     march(10),
+			expected: 10.0 / 31,
+		},
+		{
+			name:     "unspecified strategy is linear",
+			date:     march(10),
+			expected: 10.0 / 31,
+		},
+		{
+			name:     "weekday-weighted strategy expects more on weekends",
+			strategy: pacing.WeekdayWeighted,
+			date:     march(7),
+			expected: 9.0 / 39,
+		},
+		{
+			name:     "front-loaded strategy expects more in the first days",
+			strategy: pacing.FrontLoaded,
+			date:     march(5),
+			expected: 20.0 / 46,
+		},
+		{
+			name:     "whole spending limit is expected by the end of the month",
+			strategy: pacing.FrontLoaded,
+			date:     march(31),
+			expected: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			assert.InDelta(t, tc.expected, tc.strategy.ExpectedFraction(tc.date), 1e-9)
+		})
+	}
+}
+
+func TestCompute(t *testing.T) {
+	testCases := []struct {
+		name          string
+		strategy      pacing.Strategy
+		date          time.Time
+		spendingLimit float64
+		spent         float64
+		safeToSpend   float64
+		status        pacing.Status
+	}{
+		{
+			name:          "remaining amount is split over the days left",
+			strategy:      pacing.Linear,
+			date:          march(10),
+			spendingLimit: 1000,
+			spent:         300,
+			safeToSpend:   700.0 / 22,
+			status:        pacing.OnPace,
+		},
+		{
+			name:          "spending less than expected is behind pace",
+			strategy:      pacing.Linear,
+			date:          march(10),
+			spendingLimit: 1000,
+			spent:         100,
+			safeToSpend:   900.0 / 22,
+			status:        pacing.BehindPace,
+		},
+		{
+			name:          "spending more than expected is ahead of pace",
+			strategy:      pacing.Linear,
+			date:          march(10),
+			spendingLimit: 1000,
+			spent:         400,
+			safeToSpend:   600.0 / 22,
+			status:        pacing.AheadOfPace,
+		},
+		{
+			name:          "weekend days get a larger allowance",
+			strategy:      pacing.WeekdayWeighted,
+			date:          march(27),
+			spendingLimit: 1000,
+			spent:         900,
+			safeToSpend:   100.0 * 2 / 7,
+			status:        pacing.OnPace,
+		},
+		{
+			name:          "nothing is safe to spend after overstepping the spending limit",
+			strategy:      pacing.FrontLoaded,
+			date:          march(20),
+			spendingLimit: 1000,
+			spent:         1100,
+			safeToSpend:   0,
+			status:        pacing.AheadOfPace,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			allowance := pacing.Compute(tc.strategy, tc.date, tc.spendingLimit, tc.spent)
+
+			assert.Equal(t, tc.strategy, allowance.Strategy)
+			assert.Equal(t, tc.spendingLimit-tc.spent, allowance.Remaining)
+			assert.InDelta(t, tc.safeToSpend, allowance.SafeToSpendToday, 1e-9)
+			assert.Equal(t, tc.status, allowance.Status)
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package recurring
+
+import (
+	"math"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/interval"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/transaction"
+)
+
+const (
+	// minOccurrences is the number of most recent occurrences inspected
+	// to detect a recurring series.
+	minOccurrences = 3
+
+	// amountTolerance is the maximum relative difference between the amounts
+	// of the occurrences of the same series.
+	amountTolerance = 0.1
+)
+
+// Cadence is how often the transactions of a recurring series happen.
+type Cadence string
+
+const (
+	// Weekly series happen every 6 to 8 days.
+	Weekly Cadence = "weekly"
+
+	// Monthly series happen every 26 to 35 days.
+	Monthly Cadence = "monthly"
+)
+
+// Status is the status of a recurring series, as reviewed by the Account's Owner.
+type Status string
+
+const (
+	// Detected series have not been reviewed yet.
+	Detected Status = "detected"
+
+	// Confirmed series are expected to happen again, and their outflows are
+	// considered as already committed in the monthly spending.
+	Confirmed Status = "confirmed"
+
+	// Dismissed series are not considered as recurring anymore.
+	Dismissed Status = "dismissed"
+)
+
+// Series is a sequence of transactions of a similar amount, with the same
+// merchant, happening with a regular cadence, like rent, subscriptions or the salary.
+type Series struct {
+	ID         string
+	Name       string
+	Kind       transaction.Kind
+	Amount     float64
+	Cadence    Cadence
+	LastSeenAt time.Time
+	Status     Status
+}
+
+// Occurrence is a transaction that might be part of a recurring series.
+type Occurrence struct {
+	Amount     float64
+	HappenedAt time.Time
+}
+
+// SeriesID returns the identifier of the series a transaction with the
+// specified details would belong to, derived from its merchant, or its
+// description if no merchant is available.
+//
+// An empty string is returned if the details contain neither.
+func SeriesID(details transaction.Details) string {
+	name := details.Merchant
+	if name == "" {
+		name = details.Description
+	}
+
+	var b strings.Builder
+
+	dash := false
+	for _, r := range strings.ToLower(strings.TrimSpace(name)) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+			dash = false
+
+			continue
+		}
+
+		if !dash && b.Len() > 0 {
+			b.WriteRune('-')
+			dash = true
+		}
+	}
+
+	return strings.TrimSuffix(b.String(), "-")
+}
+
+// SeriesName returns the human-readable name of the series a transaction
+// with the specified details would belong to.
+func SeriesName(details transaction.Details) string {
+	if details.Merchant != "" {
+		return details.Merchant
+	}
+
+	return details.Description
+}
+
+// Detect returns the cadence of the series formed by the most recent occurrences,
+// sorted by the time they happened, if they have similar amounts and
+// a regular cadence.
+//
+// False is returned if there are not enough occurrences, or if they do not form a series.
+func Detect(occurrences []Occurrence) (Cadence, bool) {
+	if len(occurrences) < minOccurrences {
+		return "", false
+	}
+
+	recent := occurrences[len(occurrences)-minOccurrences:]
+	latest := recent[len(recent)-1]
+
+	var cadence Cadence
+
+	for i, occurrence := range recent {
+		if !Similar(latest.Amount, occurrence.Amount) {
+			return "", false
+		}
+
+		if i == 0 {
+			continue
+		}
+
+		c, ok := cadenceOf(recent[i-1].HappenedAt, occurrence.HappenedAt)
+		if !ok || (cadence != "" && c != cadence) {
+			return "", false
+		}
+
+		cadence = c
+	}
+
+	return cadence, true
+}
+
+// Similar returns true if the amounts are close enough to belong to the same series.
+func Similar(a, b float64) bool {
+	return math.Abs(a-b) <= amountTolerance*math.Abs(a)
+}
+
+func cadenceOf(previous, next time.Time) (Cadence, bool) {
+	days := next.Sub(previous).Hours() / 24
+
+	switch {
+	case days >= 6 && days <= 8:
+		return Weekly, true
+	case days >= 26 && days <= 35:
+		return Monthly, true
+	default:
+		return "", false
+	}
+}
+
+// ExpectedIn returns the total amount of the transactions of the series
+// expected in the specified month, as an absolute value.
+func (s Series) ExpectedIn(month interval.Month) float64 {
+	amount := math.Abs(s.Amount)
+
+	if s.Cadence != Weekly {
+		return amount
+	}
+
+	// Weekly series are expected on the same weekday of the last occurrence.
+	var count int
+
+	first := time.Date(month.Year, month.Month, 1, 0, 0, 0, 0, time.UTC)
+	for d := first; d.Month() == month.Month; d = d.AddDate(0, 0, 1) {
+		if d.Weekday() == s.LastSeenAt.Weekday() {
+			count++
+		}
+	}
+
+	return amount * float64(count)
+}
@@ -0,0 +1,120 @@
+package recurring_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/interval"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/recurring"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/transaction"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSeriesID(t *testing.T) {
+	assert.Equal(t, "acme-streaming-inc", recurring.SeriesID(transaction.Details{Merchant: " ACME Streaming, Inc. "}))
+	assert.Equal(t, "rent-flat-3b", recurring.SeriesID(transaction.Details{Description: "Rent - flat 3B"}))
+	assert.Equal(t, "", recurring.SeriesID(transaction.Details{}))
+}
+
+func TestDetect(t *testing.T) {
+	on := func(month time.Month, day int, amount float64) recurring.Occurrence {
+		return recurring.Occurrence{
+			Amount:     amount,
+			HappenedAt: time.Date(2021, month, day, 9, 0, 0, 0, time.UTC),
+		}
+	}
+
+	testCases := []struct {
+		name        string
+		occurrences []recurring.Occurrence
+		cadence     recurring.Cadence
+		detected    bool
+	}{
+		{
+			name: "monthly payments with similar amounts",
+			occurrences: []recurring.Occurrence{
+				on(time.January, 31, -12.99),
+				on(time.February, 28, -12.99),
+				on(time.March, 31, -13.99),
+			},
+			cadence:  recurring.Monthly,
+			detected: true,
+		},
+		{
+			name: "weekly payments",
+			occurrences: []recurring.Occurrence{
+				on(time.March, 1, -20),
+				on(time.March, 8, -20),
+				on(time.March, 15, -20),
+			},
+			cadence:  recurring.Weekly,
+			detected: true,
+		},
+		{
+			name: "only the most recent occurrences are inspected",
+			occurrences: []recurring.Occurrence{
+				on(time.January, 3, -5),
+				on(time.January, 20, -50),
+				on(time.February, 20, -50),
+				on(time.March, 20, -50),
+			},
+			cadence:  recurring.Monthly,
+			detected: true,
+		},
+		{
+			name: "not enough occurrences",
+			occurrences: []recurring.Occurrence{
+				on(time.January, 1, -800),
+				on(time.February, 1, -800),
+			},
+		},
+		{
+			name: "amounts too different",
+			occurrences: []recurring.Occurrence{
+				on(time.January, 10, -60),
+				on(time.February, 10, -100),
+				on(time.March, 10, -60),
+			},
+		},
+		{
+			name: "irregular cadence",
+			occurrences: []recurring.Occurrence{
+				on(time.January, 10, -30),
+				on(time.January, 17, -30),
+				on(time.February, 17, -30),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			cadence, ok := recurring.Detect(tc.occurrences)
+
+			assert.Equal(t, tc.detected, ok)
+			assert.Equal(t, tc.cadence, cadence)
+		})
+	}
+}
+
+func TestSeriesExpectedIn(t *testing.T) {
+	march := interval.Month{Year: 2021, Month: time.March}
+
+	monthly := recurring.Series{
+		Amount:     -800,
+		Cadence:    recurring.Monthly,
+		LastSeenAt: time.Date(2021, time.February, 1, 9, 0, 0, 0, time.UTC),
+	}
+
+	// March 2021 has five Mondays.
+	weekly := recurring.Series{
+		Amount:     -20,
+		Cadence:    recurring.Weekly,
+		LastSeenAt: time.Date(2021, time.February, 22, 9, 0, 0, 0, time.UTC),
+	}
+
+	assert.Equal(t, 800.0, monthly.ExpectedIn(march))
+	assert.Equal(t, 100.0, weekly.ExpectedIn(march))
+}
@@ -0,0 +1,11 @@
+package saving
+
+import "github.com/eventually-rs/saving-goals-go/internal/domain/category"
+
+// Budget is the maximum amount the Account's Owner is willing to spend
+// every month on a specific Category, e.g. 300 per month on dining.
+type Budget struct {
+	Category   category.Category
+	Amount     float64
+	Thresholds []Threshold
+}
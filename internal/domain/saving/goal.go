@@ -1,6 +1,16 @@
 package saving
 
+// Goal is the amount of money the Account's Owner wants to save every month,
+// with the thresholds used to warn them when the spending gets close
+// to the spending limit.
 type Goal struct {
 	Amount     float64
-	Thresholds []float64
+	Thresholds []Threshold
+}
+
+// DefaultThresholds returns the thresholds used when the Account's Owner
+// did not choose any, e.g. when saving only for named Goals:
+// at 50%, 80% and 100% of the spending limit.
+func DefaultThresholds() []Threshold {
+	return []Threshold{Percentage(0.5), Percentage(0.8), Percentage(1)}
 }
@@ -0,0 +1,194 @@
+package saving
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidThreshold is returned when a Threshold has an unknown kind,
+// or a value that is not valid for its kind.
+var ErrInvalidThreshold = fmt.Errorf("saving.Threshold: invalid threshold")
+
+// ThresholdKind specifies how the value of a Threshold is compared
+// against the amount spent in the month.
+type ThresholdKind string
+
+const (
+	// ThresholdPercentage is reached when the amount spent is at least
+	// the specified fraction of the spending limit, e.g. 0.5 for 50%.
+	ThresholdPercentage ThresholdKind = "percentage"
+
+	// ThresholdSpentAbove is reached when the amount spent is at least
+	// the specified absolute amount.
+	ThresholdSpentAbove ThresholdKind = "spent-above"
+
+	// ThresholdRemainingBelow is reached when the amount that can still be spent
+	// before reaching the spending limit falls below the specified absolute amount.
+	ThresholdRemainingBelow ThresholdKind = "remaining-below"
+)
+
+// Threshold is a notification point on the spending of the month,
+// used to warn the Account's Owner before the spending limit is reached.
+type Threshold struct {
+	Kind  ThresholdKind `json:"kind"`
+	Value float64       `json:"value"`
+}
+
+// Percentage returns a new ThresholdPercentage Threshold.
+func Percentage(value float64) Threshold {
+	return Threshold{Kind: ThresholdPercentage, Value: value}
+}
+
+// SpentAbove returns a new ThresholdSpentAbove Threshold.
+func SpentAbove(amount float64) Threshold {
+	return Threshold{Kind: ThresholdSpentAbove, Value: amount}
+}
+
+// RemainingBelow returns a new ThresholdRemainingBelow Threshold.
+func RemainingBelow(amount float64) Threshold {
+	return Threshold{Kind: ThresholdRemainingBelow, Value: amount}
+}
+
+// Validate returns ErrInvalidThreshold if the Threshold kind is unknown,
+// or if its value is not valid for the kind:
+//
+//   - percentages should be fractions of the spending limit, between 0 (excluded) and 1,
+//   - amounts spent should be more than zero,
+//   - remaining amounts should not be negative.
+func (t Threshold) Validate() error {
+	switch t.Kind {
+	case ThresholdPercentage:
+		if t.Value <= 0 || t.Value > 1 {
+			return fmt.Errorf("%w: percentage should be more than 0 and at most 1, got %v", ErrInvalidThreshold, t.Value)
+		}
+
+	case ThresholdSpentAbove:
+		if t.Value <= 0 {
+			return fmt.Errorf("%w: amount spent should be more than zero, got %v", ErrInvalidThreshold, t.Value)
+		}
+
+	case ThresholdRemainingBelow:
+		if t.Value < 0 {
+			return fmt.Errorf("%w: remaining amount should not be negative, got %v", ErrInvalidThreshold, t.Value)
+		}
+
+	default:
+		return fmt.Errorf("%w: unknown kind %q", ErrInvalidThreshold, t.Kind)
+	}
+
+	return nil
+}
+
+// ReachedBy returns true if the Threshold is reached by the amount spent,
+// given the spending limit.
+func (t Threshold) ReachedBy(spent, limit float64) bool {
+	switch t.Kind {
+	case ThresholdPercentage:
+		var currentPercentage float64
+
+		switch {
+		case limit > 0:
+			currentPercentage = spent / limit
+		case spent > 0:
+			// With no spending limit available, any expense oversteps all the thresholds.
+			currentPercentage = math.Inf(1)
+		}
+
+		return currentPercentage >= t.Value
+
+	case ThresholdSpentAbove:
+		return spent >= t.Value
+
+	case ThresholdRemainingBelow:
+		return limit-spent < t.Value
+
+	default:
+		return false
+	}
+}
+
+// MoreSevereThan returns true if the Threshold signals a spending closer
+// to the spending limit than the other Threshold of the same kind.
+//
+// Thresholds of different kinds are never more severe than each other.
+func (t Threshold) MoreSevereThan(other Threshold) bool {
+	if t.Kind != other.Kind {
+		return false
+	}
+
+	if t.Kind == ThresholdRemainingBelow {
+		return t.Value < other.Value
+	}
+
+	return t.Value > other.Value
+}
+
+// String returns the textual representation of the Threshold,
+// in the "<kind>:<value>" format accepted by ParseThreshold.
+func (t Threshold) String() string {
+	return fmt.Sprintf("%s:%v", t.Kind, t.Value)
+}
+
+// ParseThreshold parses a Threshold from its textual representation,
+// either in the "<kind>:<value>" format (e.g. "spent-above:300"), or as
+// a bare number, which is interpreted as a percentage (e.g. "0.5").
+//
+// The Threshold returned is not validated.
+func ParseThreshold(s string) (Threshold, error) {
+	kind, value := ThresholdPercentage, s
+
+	if i := strings.LastIndex(s, ":"); i >= 0 {
+		kind, value = ThresholdKind(s[:i]), s[i+1:]
+	}
+
+	v, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return Threshold{}, fmt.Errorf("%w: failed to parse value: %s", ErrInvalidThreshold, err)
+	}
+
+	return Threshold{Kind: kind, Value: v}, nil
+}
+
+// UnmarshalJSON decodes a Threshold from either its JSON object representation,
+// or from a bare number, which is interpreted as a percentage.
+//
+// Bare numbers are used by Domain Events recorded before the Threshold
+// kinds were introduced.
+func (t *Threshold) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	if len(data) > 0 && data[0] != '{' {
+		var value float64
+		if err := json.Unmarshal(data, &value); err != nil {
+			return fmt.Errorf("saving.Threshold: failed to decode legacy threshold: %w", err)
+		}
+
+		*t = Percentage(value)
+
+		return nil
+	}
+
+	// Use a different type to avoid recursive calls to UnmarshalJSON.
+	type threshold Threshold
+
+	var v threshold
+	if err := json.Unmarshal(data, &v); err != nil {
+		return fmt.Errorf("saving.Threshold: failed to decode threshold: %w", err)
+	}
+
+	if v.Kind == "" {
+		v.Kind = ThresholdPercentage
+	}
+
+	*t = Threshold(v)
+
+	return nil
+}
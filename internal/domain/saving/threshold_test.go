@@ -0,0 +1,150 @@
+package saving_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/saving"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestThresholdValidate(t *testing.T) {
+	testCases := []struct {
+		threshold saving.Threshold
+		valid     bool
+	}{
+		{threshold: saving.Percentage(0.5), valid: true},
+		{threshold: saving.Percentage(1), valid: true},
+		{threshold: saving.Percentage(0)},
+		{threshold: saving.Percentage(-1)},
+		{threshold: saving.Percentage(50)},
+		{threshold: saving.SpentAbove(200), valid: true},
+		{threshold: saving.SpentAbove(0)},
+		{threshold: saving.RemainingBelow(0), valid: true},
+		{threshold: saving.RemainingBelow(-10)},
+		{threshold: saving.Threshold{Kind: "unknown", Value: 1}},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.threshold.String(), func(t *testing.T) {
+			err := tc.threshold.Validate()
+
+			if tc.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.True(t, errors.Is(err, saving.ErrInvalidThreshold), "error", err)
+			}
+		})
+	}
+}
+
+func TestThresholdReachedBy(t *testing.T) {
+	testCases := []struct {
+		name      string
+		threshold saving.Threshold
+		spent     float64
+		limit     float64
+		reached   bool
+	}{
+		{name: "percentage below", threshold: saving.Percentage(0.5), spent: 400, limit: 1000},
+		{name: "percentage reached", threshold: saving.Percentage(0.5), spent: 500, limit: 1000, reached: true},
+		{name: "percentage without limit", threshold: saving.Percentage(0.5), spent: 1, reached: true},
+		{name: "spent below", threshold: saving.SpentAbove(200), spent: 199, limit: 1000},
+		{name: "spent reached", threshold: saving.SpentAbove(200), spent: 200, limit: 1000, reached: true},
+		{name: "remaining above", threshold: saving.RemainingBelow(100), spent: 900, limit: 1000},
+		{name: "remaining below", threshold: saving.RemainingBelow(100), spent: 901, limit: 1000, reached: true},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.reached, tc.threshold.ReachedBy(tc.spent, tc.limit))
+		})
+	}
+}
+
+func TestThresholdUnmarshalJSON(t *testing.T) {
+	t.Run("legacy saving goal with bare percentages", func(t *testing.T) {
+		var goal saving.Goal
+
+		err := json.Unmarshal([]byte(`{"Amount": 500, "Thresholds": [0.25, 0.5]}`), &goal)
+		assert.NoError(t, err)
+		assert.Equal(t, saving.Goal{
+			Amount:     500,
+			Thresholds: []saving.Threshold{saving.Percentage(0.25), saving.Percentage(0.5)},
+		}, goal)
+	})
+
+	t.Run("typed thresholds", func(t *testing.T) {
+		var thresholds []saving.Threshold
+
+		err := json.Unmarshal([]byte(`[
+			{"kind": "percentage", "value": 0.8},
+			{"kind": "spent-above", "value": 300},
+			{"kind": "remaining-below", "value": 50}
+		]`), &thresholds)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []saving.Threshold{
+			saving.Percentage(0.8),
+			saving.SpentAbove(300),
+			saving.RemainingBelow(50),
+		}, thresholds)
+	})
+
+	t.Run("encoded thresholds can be decoded", func(t *testing.T) {
+		expected := saving.SpentAbove(300)
+
+		data, err := json.Marshal(expected)
+		assert.NoError(t, err)
+
+		var threshold saving.Threshold
+		assert.NoError(t, json.Unmarshal(data, &threshold))
+		assert.Equal(t, expected, threshold)
+	})
+
+	t.Run("invalid payload", func(t *testing.T) {
+		var threshold saving.Threshold
+		assert.Error(t, json.Unmarshal([]byte(`"half"`), &threshold))
+	})
+}
+
+func TestParseThreshold(t *testing.T) {
+	testCases := []struct {
+		value     string
+		threshold saving.Threshold
+		fails     bool
+	}{
+		{value: "0.5", threshold: saving.Percentage(0.5)},
+		{value: "percentage:0.25", threshold: saving.Percentage(0.25)},
+		{value: "spent-above:300", threshold: saving.SpentAbove(300)},
+		{value: "remaining-below:50", threshold: saving.RemainingBelow(50)},
+		{value: "spent-above:lots", fails: true},
+		{value: "", fails: true},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.value, func(t *testing.T) {
+			threshold, err := saving.ParseThreshold(tc.value)
+
+			if tc.fails {
+				assert.True(t, errors.Is(err, saving.ErrInvalidThreshold), "error", err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.threshold, threshold)
+
+			parsed, err := saving.ParseThreshold(threshold.String())
+			assert.NoError(t, err)
+			assert.Equal(t, threshold, parsed)
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package savings
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+	"github.com/eventually-rs/eventually-go/command"
+)
+
+// AcknowledgeTransfer is the Domain Command used to record the outcome
+// of a Transfer, as received from the core banking system.
+type AcknowledgeTransfer struct {
+	TransferID aggregate.StringID
+	Confirmed  bool
+	Reason     string
+}
+
+// AcknowledgeTransferCommandHandler is the Command Handler for AcknowledgeTransfer commands.
+type AcknowledgeTransferCommandHandler struct {
+	Repository *aggregate.Repository
+}
+
+// CommandType returns an AcknowledgeTransfer instance to bind to this Handler.
+func (AcknowledgeTransferCommandHandler) CommandType() command.Command {
+	return AcknowledgeTransfer{}
+}
+
+// Handle records the outcome of the Transfer specified in the Command.
+//
+// Duplicated acknowledgements are ignored.
+func (h AcknowledgeTransferCommandHandler) Handle(ctx context.Context, cmd eventually.Command) error {
+	command := cmd.Payload.(AcknowledgeTransfer)
+
+	transfer, err := h.Repository.Get(ctx, command.TransferID)
+	if err != nil {
+		return fmt.Errorf("savings.AcknowledgeTransferCommandHandler: failed to get transfer: %w", err)
+	}
+
+	changed, err := transfer.(*Transfer).Acknowledge(command.Confirmed, command.Reason)
+	if err != nil {
+		return fmt.Errorf("savings.AcknowledgeTransferCommandHandler: failed to acknowledge transfer: %w", err)
+	}
+
+	if !changed {
+		return nil
+	}
+
+	if err := h.Repository.Add(ctx, transfer); err != nil {
+		return fmt.Errorf("savings.AcknowledgeTransferCommandHandler: failed to save new transfer state: %w", err)
+	}
+
+	return nil
+}
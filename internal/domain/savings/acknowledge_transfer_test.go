@@ -0,0 +1,116 @@
+package savings_test
+
+import (
+	"testing"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/savings"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/sweep"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+	"github.com/eventually-rs/eventually-go/command"
+	"github.com/eventually-rs/eventually-go/eventstore"
+	"github.com/eventually-rs/eventually-go/scenario"
+)
+
+func TestAcknowledgeTransfer(t *testing.T) {
+	transferID := savings.TransferID("test-account", "salary", "tx-2")
+
+	event := func(version int64, payload interface{}) eventstore.Event {
+		return eventstore.Event{
+			StreamType: savings.Type.Name(),
+			StreamName: transferID.String(),
+			Version:    version,
+			Event:      eventually.Event{Payload: payload},
+		}
+	}
+
+	requested := event(1, savings.TransferWasRequested{
+		TransferID: transferID.String(),
+		AccountID:  "test-account",
+		GoalID:     "emergency-fund",
+		RuleID:     "salary",
+		Kind:       sweep.IncomePercentage,
+		Amount:     250,
+	})
+
+	confirmed := savings.TransferWasConfirmed{
+		TransferID: transferID.String(),
+		AccountID:  "test-account",
+		GoalID:     "emergency-fund",
+		Amount:     250,
+	}
+
+	testCases := []struct {
+		name      string
+		given     []eventstore.Event
+		confirmed bool
+		then      []eventstore.Event
+		err       error
+	}{
+		{
+			name:      "requested transfer is confirmed",
+			given:     []eventstore.Event{requested},
+			confirmed: true,
+			then:      []eventstore.Event{event(2, confirmed)},
+		},
+		{
+			name:  "requested transfer is rejected",
+			given: []eventstore.Event{requested},
+			then: []eventstore.Event{event(2, savings.TransferWasRejected{
+				TransferID: transferID.String(),
+				Reason:     "insufficient funds",
+			})},
+		},
+		{
+			name:      "duplicated confirmation is ignored",
+			given:     []eventstore.Event{requested, event(2, confirmed)},
+			confirmed: true,
+		},
+		{
+			name:  "confirmed transfer cannot be rejected",
+			given: []eventstore.Event{requested, event(2, confirmed)},
+			err:   savings.ErrAlreadyAcknowledged,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			s := scenario.
+				CommandHandler().
+				Given(tc.given...).
+				When(eventually.Command{
+					Payload: savings.AcknowledgeTransfer{
+						TransferID: transferID,
+						Confirmed:  tc.confirmed,
+						Reason:     "insufficient funds",
+					},
+				})
+
+			handler := func(r *aggregate.Repository) command.Handler {
+				return savings.AcknowledgeTransferCommandHandler{Repository: r}
+			}
+
+			if tc.err != nil {
+				s.ThenError(tc.err).Using(t, savings.Type, handler)
+				return
+			}
+
+			s.Then(tc.then...).Using(t, savings.Type, handler)
+		})
+	}
+
+	t.Run("command fails when the transfer does not exist", func(t *testing.T) {
+		scenario.
+			CommandHandler().
+			When(eventually.Command{
+				Payload: savings.AcknowledgeTransfer{TransferID: transferID, Confirmed: true},
+			}).
+			ThenError(aggregate.ErrRootNotFound).
+			Using(t, savings.Type, func(r *aggregate.Repository) command.Handler {
+				return savings.AcknowledgeTransferCommandHandler{Repository: r}
+			})
+	})
+}
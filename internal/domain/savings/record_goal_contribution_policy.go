@@ -0,0 +1,59 @@
+package savings
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/account"
+	"go.uber.org/zap"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+	"github.com/eventually-rs/eventually-go/command"
+	"github.com/eventually-rs/eventually-go/eventstore"
+	"github.com/eventually-rs/eventually-go/projection"
+)
+
+var _ projection.Applier = RecordGoalContributionPolicy{}
+
+// RecordGoalContributionPolicy records the money moved by a Transfer
+// as a contribution to the Goal, once the Transfer has been confirmed
+// by the core banking system.
+type RecordGoalContributionPolicy struct {
+	CommandDispatcher command.Dispatcher
+	Logger            *zap.Logger
+}
+
+func (rgp RecordGoalContributionPolicy) Apply(ctx context.Context, evt eventstore.Event) error {
+	event, ok := evt.Payload.(TransferWasConfirmed)
+	if !ok {
+		return nil
+	}
+
+	err := rgp.CommandDispatcher.Dispatch(ctx, eventually.Command{
+		Payload: account.RecordGoalContribution{
+			AccountID: aggregate.StringID(event.AccountID),
+			GoalID:    event.GoalID,
+			Amount:    event.Amount,
+		},
+	})
+
+	if errors.Is(err, account.ErrGoalNotFound) {
+		// The Goal has been removed while the Transfer was being executed:
+		// the money is in the savings anyway, but there is no progress to update.
+		rgp.Logger.Warn("Goal of the confirmed transfer not found, skipping contribution",
+			zap.String("transferId", event.TransferID),
+			zap.String("accountId", event.AccountID),
+			zap.String("goalId", event.GoalID),
+		)
+
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("savings.RecordGoalContributionPolicy: failed to dispatch command: %w", err)
+	}
+
+	return nil
+}
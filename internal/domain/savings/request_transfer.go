@@ -0,0 +1,75 @@
+package savings
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/sweep"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+	"github.com/eventually-rs/eventually-go/command"
+)
+
+// RequestTransfer is the Domain Command used to request a new Transfer
+// from an Account to one of its Goals.
+//
+// Use TransferID to build the identifier of the Transfer.
+type RequestTransfer struct {
+	TransferID  aggregate.StringID
+	AccountID   string
+	GoalID      string
+	RuleID      string
+	Kind        sweep.Kind
+	Amount      float64
+	RequestedAt time.Time
+}
+
+// RequestTransferCommandHandler is the Command Handler for RequestTransfer commands.
+type RequestTransferCommandHandler struct {
+	Repository *aggregate.Repository
+}
+
+// CommandType returns a RequestTransfer instance to bind to this Handler.
+func (RequestTransferCommandHandler) CommandType() command.Command {
+	return RequestTransfer{}
+}
+
+// Handle requests the Transfer specified in the Command.
+//
+// The Command is idempotent: nothing happens if a Transfer with the same
+// identifier has already been requested.
+func (h RequestTransferCommandHandler) Handle(ctx context.Context, cmd eventually.Command) error {
+	command := cmd.Payload.(RequestTransfer)
+
+	_, err := h.Repository.Get(ctx, command.TransferID)
+	if err == nil {
+		return nil
+	}
+
+	if !errors.Is(err, aggregate.ErrRootNotFound) {
+		return fmt.Errorf("savings.RequestTransferCommandHandler: failed to get transfer: %w", err)
+	}
+
+	transfer, err := NewTransfer(
+		command.TransferID,
+		command.AccountID,
+		command.GoalID,
+		command.RuleID,
+		command.Kind,
+		command.Amount,
+		command.RequestedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("savings.RequestTransferCommandHandler: failed to request transfer: %w", err)
+	}
+
+	if err := h.Repository.Add(ctx, transfer); err != nil {
+		return fmt.Errorf("savings.RequestTransferCommandHandler: failed to save new transfer state: %w", err)
+	}
+
+	return nil
+}
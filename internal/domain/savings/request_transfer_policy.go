@@ -0,0 +1,138 @@
+package savings
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/account"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/interval"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/monthly"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/sweep"
+	"go.uber.org/zap"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/command"
+	"github.com/eventually-rs/eventually-go/eventstore"
+	"github.com/eventually-rs/eventually-go/projection"
+)
+
+var _ projection.Applier = RequestTransferPolicy{}
+
+// RequestTransferPolicy requests a new Transfer every time a sweep rule of
+// an Account is triggered by a new transaction, and when a month closes,
+// for the Leftover rules of the Accounts whose spending was tracked.
+type RequestTransferPolicy struct {
+	CommandDispatcher command.Dispatcher
+	QueryDispatcher   monthly.QueryDispatcher
+	Logger            *zap.Logger
+}
+
+func (rtp RequestTransferPolicy) Apply(ctx context.Context, evt eventstore.Event) error {
+	switch event := evt.Payload.(type) {
+	case account.SweepWasTriggered:
+		return rtp.dispatch(ctx, RequestTransfer{
+			TransferID:  TransferID(evt.StreamName, event.RuleID, event.TransactionID),
+			AccountID:   evt.StreamName,
+			GoalID:      event.GoalID,
+			RuleID:      event.RuleID,
+			Kind:        event.Kind,
+			Amount:      event.Amount,
+			RequestedAt: event.HappenedAt,
+		})
+
+	case interval.MonthStarted:
+		return rtp.handleMonthStarted(ctx, event.Month)
+	}
+
+	return nil
+}
+
+// handleMonthStarted sweeps the spending limit left unspent
+// in the month before the one started.
+func (rtp RequestTransferPolicy) handleMonthStarted(ctx context.Context, month interval.Month) error {
+	closed := month.Previous()
+	requestedAt := time.Date(month.Year, month.Month, 1, 0, 0, 0, 0, time.UTC)
+
+	answer, err := rtp.QueryDispatcher.Dispatch(ctx, account.WithSavingGoalsQuery{})
+	if err != nil {
+		return fmt.Errorf("savings.RequestTransferPolicy: failed to list accounts: %w", err)
+	}
+
+	for acc := range answer.(account.WithSavingGoalsAnswer) {
+		rules := leftoverRules(acc)
+		if len(rules) == 0 {
+			continue
+		}
+
+		answer, err := rtp.QueryDispatcher.Dispatch(ctx, monthly.ProgressQuery{
+			AccountID: acc.AccountID,
+			Month:     closed,
+		})
+
+		if errors.Is(err, monthly.ErrProgressNotFound) {
+			rtp.Logger.Debug("Spending not tracked for the closed month, skipping leftover sweep",
+				zap.String("accountId", acc.AccountID),
+			)
+
+			continue
+		}
+
+		if err != nil {
+			return fmt.Errorf("savings.RequestTransferPolicy: failed to get monthly progress: %w", err)
+		}
+
+		progress := answer.(monthly.Progress)
+		leftover := progress.SpendingLimit - progress.Spent
+
+		for _, rule := range rules {
+			amount := rule.LeftoverAmount(leftover)
+			if amount <= 0 {
+				continue
+			}
+
+			err := rtp.dispatch(ctx, RequestTransfer{
+				TransferID:  TransferID(acc.AccountID, rule.ID, closed.String()),
+				AccountID:   acc.AccountID,
+				GoalID:      rule.GoalID,
+				RuleID:      rule.ID,
+				Kind:        rule.Kind,
+				Amount:      amount,
+				RequestedAt: requestedAt,
+			})
+
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (rtp RequestTransferPolicy) dispatch(ctx context.Context, cmd RequestTransfer) error {
+	if err := rtp.CommandDispatcher.Dispatch(ctx, eventually.Command{Payload: cmd}); err != nil {
+		return fmt.Errorf("savings.RequestTransferPolicy: failed to dispatch command: %w", err)
+	}
+
+	return nil
+}
+
+// leftoverRules returns the Leftover rules of the Account saving for one of its active Goals.
+func leftoverRules(acc account.WithSavingGoal) []sweep.Rule {
+	active := make(map[string]bool, len(acc.Goals))
+	for _, g := range acc.Goals {
+		active[g.ID] = true
+	}
+
+	var rules []sweep.Rule
+
+	for _, rule := range acc.SweepRules {
+		if rule.Kind == sweep.Leftover && active[rule.GoalID] {
+			rules = append(rules, rule)
+		}
+	}
+
+	return rules
+}
@@ -0,0 +1,85 @@
+package savings_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/savings"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/sweep"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+	"github.com/eventually-rs/eventually-go/command"
+	"github.com/eventually-rs/eventually-go/eventstore"
+	"github.com/eventually-rs/eventually-go/scenario"
+)
+
+func TestRequestTransfer(t *testing.T) {
+	transferID := savings.TransferID("test-account", "round-up", "tx-1")
+	requestedAt := time.Date(2021, time.March, 12, 18, 30, 0, 0, time.UTC)
+
+	requested := savings.TransferWasRequested{
+		TransferID:  transferID.String(),
+		AccountID:   "test-account",
+		GoalID:      "holiday",
+		RuleID:      "round-up",
+		Kind:        sweep.RoundUp,
+		Amount:      0.7,
+		RequestedAt: requestedAt,
+	}
+
+	request := func(amount float64) savings.RequestTransfer {
+		return savings.RequestTransfer{
+			TransferID:  transferID,
+			AccountID:   "test-account",
+			GoalID:      "holiday",
+			RuleID:      "round-up",
+			Kind:        sweep.RoundUp,
+			Amount:      amount,
+			RequestedAt: requestedAt,
+		}
+	}
+
+	t.Run("command fails when the amount is not positive", func(t *testing.T) {
+		scenario.
+			CommandHandler().
+			When(eventually.Command{Payload: request(0)}).
+			ThenError(savings.ErrAmountNotPositive).
+			Using(t, savings.Type, func(r *aggregate.Repository) command.Handler {
+				return savings.RequestTransferCommandHandler{Repository: r}
+			})
+	})
+
+	t.Run("new transfer is requested", func(t *testing.T) {
+		scenario.
+			CommandHandler().
+			When(eventually.Command{Payload: request(0.7)}).
+			Then(eventstore.Event{
+				StreamType: savings.Type.Name(),
+				StreamName: transferID.String(),
+				Version:    1,
+				Event:      eventually.Event{Payload: requested},
+			}).
+			Using(t, savings.Type, func(r *aggregate.Repository) command.Handler {
+				return savings.RequestTransferCommandHandler{Repository: r}
+			})
+	})
+
+	t.Run("transfer already requested is not requested again", func(t *testing.T) {
+		var then []eventstore.Event
+
+		scenario.
+			CommandHandler().
+			Given(eventstore.Event{
+				StreamType: savings.Type.Name(),
+				StreamName: transferID.String(),
+				Version:    1,
+				Event:      eventually.Event{Payload: requested},
+			}).
+			When(eventually.Command{Payload: request(0.7)}).
+			Then(then...).
+			Using(t, savings.Type, func(r *aggregate.Repository) command.Handler {
+				return savings.RequestTransferCommandHandler{Repository: r}
+			})
+	})
+}
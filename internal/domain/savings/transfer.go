@@ -0,0 +1,219 @@
+package savings
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/sweep"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+)
+
+var (
+	// ErrAmountNotPositive is returned when requesting a Transfer
+	// with an amount that is not more than zero.
+	ErrAmountNotPositive = fmt.Errorf("savings.RequestTransfer: amount should be more than zero")
+
+	// ErrAlreadyAcknowledged is returned when acknowledging a Transfer
+	// with an outcome different from the one already received.
+	ErrAlreadyAcknowledged = fmt.Errorf("savings.AcknowledgeTransfer: transfer already acknowledged with a different outcome")
+)
+
+// Type defines the Transfer aggregate type.
+var Type = aggregate.NewType("savings-transfer", func() aggregate.Root {
+	return new(Transfer)
+})
+
+// TransferID returns the identifier of the Transfer requested by the sweep rule
+// of an Account for the specified reference, which is either the identifier of
+// the transaction that triggered the rule, or the month closed for Leftover rules.
+//
+// The identifier is deterministic, so that the same Transfer is never
+// requested twice, and it is sent to the core banking system to let it
+// discard duplicated requests as well.
+func TransferID(accountID, ruleID, reference string) aggregate.StringID {
+	return aggregate.StringID(fmt.Sprintf("account:%s:rule:%s:ref:%s", accountID, ruleID, reference))
+}
+
+// AccountOfTransfer returns the identifier of the Account
+// of the Transfer with the specified identifier, as built by TransferID.
+func AccountOfTransfer(transferID string) (string, bool) {
+	rest := strings.TrimPrefix(transferID, "account:")
+	if rest == transferID {
+		return "", false
+	}
+
+	sep := strings.Index(rest, ":rule:")
+	if sep <= 0 {
+		return "", false
+	}
+
+	return rest[:sep], true
+}
+
+// Status is the status of a Transfer.
+type Status string
+
+const (
+	// Requested transfers are waiting for the core banking system to execute them.
+	Requested Status = "requested"
+
+	// Confirmed transfers have been executed by the core banking system.
+	Confirmed Status = "confirmed"
+
+	// Rejected transfers have been refused by the core banking system,
+	// e.g. because of insufficient funds.
+	Rejected Status = "rejected"
+)
+
+// Transfer is an Aggregate type that represents a request to move money
+// from an Account to one of its Goals, triggered by a sweep rule and
+// executed by the core banking system.
+type Transfer struct {
+	aggregate.BaseRoot
+
+	id        aggregate.StringID
+	accountID string
+	goalID    string
+	amount    float64
+	status    Status
+}
+
+// AggregateID returns the identifier of the Transfer Aggregate.
+func (t Transfer) AggregateID() aggregate.ID { return t.id }
+
+// TransferWasRequested is the Domain Event triggered by the Aggregate
+// when a new Transfer has been requested to the core banking system.
+type TransferWasRequested struct {
+	TransferID  string
+	AccountID   string
+	GoalID      string
+	RuleID      string
+	Kind        sweep.Kind
+	Amount      float64
+	RequestedAt time.Time
+}
+
+// TransferWasConfirmed is the Domain Event triggered by the Aggregate
+// when the core banking system acknowledges the Transfer has been executed.
+//
+// The Event carries the Account, Goal and amount of the Transfer, so that
+// the Goal progress can be updated without looking up the Transfer.
+type TransferWasConfirmed struct {
+	TransferID string
+	AccountID  string
+	GoalID     string
+	Amount     float64
+}
+
+// TransferWasRejected is the Domain Event triggered by the Aggregate
+// when the core banking system refuses to execute the Transfer.
+type TransferWasRejected struct {
+	TransferID string
+	Reason     string
+}
+
+// Apply applies the Domain Event received onto the Aggregate Root
+// by mutating the Root's state accordingly.
+func (t *Transfer) Apply(event eventually.Event) error {
+	switch evt := event.Payload.(type) {
+	case TransferWasRequested:
+		t.id = aggregate.StringID(evt.TransferID)
+		t.accountID = evt.AccountID
+		t.goalID = evt.GoalID
+		t.amount = evt.Amount
+		t.status = Requested
+
+	case TransferWasConfirmed:
+		t.status = Confirmed
+
+	case TransferWasRejected:
+		t.status = Rejected
+
+	default:
+		return fmt.Errorf("savings.Transfer: unsupported event received")
+	}
+
+	return nil
+}
+
+// NewTransfer requests a new Transfer of the specified amount, from the Account
+// to one of its Goals, as triggered by the specified sweep rule.
+//
+// ErrAmountNotPositive is returned if the amount is not more than zero.
+func NewTransfer(
+	id aggregate.StringID,
+	accountID, goalID, ruleID string,
+	kind sweep.Kind,
+	amount float64,
+	requestedAt time.Time,
+) (*Transfer, error) {
+	if amount <= 0 {
+		return nil, ErrAmountNotPositive
+	}
+
+	var transfer Transfer
+
+	err := aggregate.RecordThat(&transfer, eventually.Event{
+		Payload: TransferWasRequested{
+			TransferID:  id.String(),
+			AccountID:   accountID,
+			GoalID:      goalID,
+			RuleID:      ruleID,
+			Kind:        kind,
+			Amount:      amount,
+			RequestedAt: requestedAt,
+		},
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("savings.NewTransfer: failed to record domain event: %w", err)
+	}
+
+	return &transfer, nil
+}
+
+// Acknowledge records the outcome of the Transfer received from
+// the core banking system.
+//
+// Acknowledgements are idempotent: false is returned if the Transfer
+// has already been acknowledged with the same outcome, and nothing changed.
+//
+// ErrAlreadyAcknowledged is returned if the Transfer has already been
+// acknowledged with a different outcome.
+func (t *Transfer) Acknowledge(confirmed bool, reason string) (bool, error) {
+	status := Rejected
+	if confirmed {
+		status = Confirmed
+	}
+
+	if t.status == status {
+		return false, nil
+	}
+
+	if t.status != Requested {
+		return false, fmt.Errorf("savings.Transfer.Acknowledge: %w", ErrAlreadyAcknowledged)
+	}
+
+	var payload interface{} = TransferWasRejected{
+		TransferID: t.id.String(),
+		Reason:     reason,
+	}
+
+	if confirmed {
+		payload = TransferWasConfirmed{
+			TransferID: t.id.String(),
+			AccountID:  t.accountID,
+			GoalID:     t.goalID,
+			Amount:     t.amount,
+		}
+	}
+
+	if err := aggregate.RecordThat(t, eventually.Event{Payload: payload}); err != nil {
+		return false, fmt.Errorf("savings.Transfer.Acknowledge: failed to record domain event: %w", err)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,74 @@
+package savings_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/account"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/savings"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/eventstore"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccountOfTransfer(t *testing.T) {
+	testCases := []struct {
+		name      string
+		id        string
+		accountID string
+		ok        bool
+	}{
+		{
+			name:      "transfer",
+			id:        savings.TransferID("test-account", "test-rule", "2021-03").String(),
+			accountID: "test-account",
+			ok:        true,
+		},
+		{name: "not a transfer", id: "test-account"},
+		{name: "no account", id: "account::rule:test-rule:ref:2021-03"},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			accountID, ok := savings.AccountOfTransfer(tc.id)
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.accountID, accountID)
+		})
+	}
+}
+
+func TestTransfersProjectionForgottenAccounts(t *testing.T) {
+	ctx := context.Background()
+	projection := savings.NewTransfersProjection()
+
+	for _, accountID := range []string{"test-account", "other-account"} {
+		transferID := savings.TransferID(accountID, "test-rule", "2021-03").String()
+
+		assert.NoError(t, projection.Apply(ctx, eventstore.Event{
+			StreamType: savings.Type.Name(),
+			StreamName: transferID,
+			Version:    1,
+			Event: eventually.Event{
+				Payload: savings.TransferWasRequested{TransferID: transferID, AccountID: accountID, Amount: 50},
+			},
+		}))
+	}
+
+	assert.NoError(t, projection.Apply(ctx, eventstore.Event{
+		StreamType: account.Type.Name(),
+		StreamName: "pseudonym",
+		Version:    2,
+		Event:      eventually.Event{Payload: account.WasForgotten{AccountID: "test-account"}},
+	}))
+
+	answer, err := projection.Handle(ctx, savings.TransfersQuery{AccountID: "test-account"})
+	assert.NoError(t, err)
+	assert.Empty(t, answer)
+
+	answer, err = projection.Handle(ctx, savings.TransfersQuery{AccountID: "other-account"})
+	assert.NoError(t, err)
+	assert.Len(t, answer, 1)
+}
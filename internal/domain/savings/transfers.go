@@ -0,0 +1,125 @@
+package savings
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/account"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/sweep"
+
+	"github.com/eventually-rs/eventually-go/eventstore"
+	"github.com/eventually-rs/eventually-go/projection"
+	"github.com/eventually-rs/eventually-go/query"
+)
+
+var _ projection.Projection = &TransfersProjection{}
+
+// TransfersQuery is the Domain Query used to fetch the Transfers
+// requested for an Account.
+type TransfersQuery struct {
+	AccountID string
+}
+
+// Transfers is the Domain Answer returned from a TransfersQuery,
+// containing the Transfers of the Account sorted by request time.
+type Transfers []View
+
+// View is the current state of a single Transfer.
+//
+// Reason is only set for Rejected transfers.
+type View struct {
+	ID          string
+	AccountID   string
+	GoalID      string
+	RuleID      string
+	Kind        sweep.Kind
+	Amount      float64
+	Status      Status
+	Reason      string
+	RequestedAt time.Time
+}
+
+// TransfersProjection listens to Transfer Domain Events to build
+// the list of the Transfers requested for each Account,
+// erased when the Account is forgotten.
+type TransfersProjection struct {
+	mx        sync.RWMutex
+	transfers map[string]View
+	accounts  map[string][]string
+}
+
+// NewTransfersProjection returns a new instance of TransfersProjection type.
+func NewTransfersProjection() *TransfersProjection {
+	return &TransfersProjection{
+		transfers: make(map[string]View),
+		accounts:  make(map[string][]string),
+	}
+}
+
+// QueryType binds the TransfersQuery type to the projection.
+func (*TransfersProjection) QueryType() query.Query { return TransfersQuery{} }
+
+// Apply updates the state of the projection using the incoming event.
+func (p *TransfersProjection) Apply(ctx context.Context, event eventstore.Event) error {
+	p.mx.Lock()
+	defer p.mx.Unlock()
+
+	switch evt := event.Payload.(type) {
+	case TransferWasRequested:
+		p.transfers[evt.TransferID] = View{
+			ID:          evt.TransferID,
+			AccountID:   evt.AccountID,
+			GoalID:      evt.GoalID,
+			RuleID:      evt.RuleID,
+			Kind:        evt.Kind,
+			Amount:      evt.Amount,
+			Status:      Requested,
+			RequestedAt: evt.RequestedAt,
+		}
+
+		p.accounts[evt.AccountID] = append(p.accounts[evt.AccountID], evt.TransferID)
+
+	case TransferWasConfirmed:
+		if view, ok := p.transfers[evt.TransferID]; ok {
+			view.Status = Confirmed
+			p.transfers[evt.TransferID] = view
+		}
+
+	case TransferWasRejected:
+		if view, ok := p.transfers[evt.TransferID]; ok {
+			view.Status = Rejected
+			view.Reason = evt.Reason
+			p.transfers[evt.TransferID] = view
+		}
+
+	case account.WasForgotten:
+		for _, id := range p.accounts[evt.AccountID] {
+			delete(p.transfers, id)
+		}
+
+		delete(p.accounts, evt.AccountID)
+	}
+
+	return nil
+}
+
+// Handle returns the Transfers requested for the Account, sorted by request time.
+func (p *TransfersProjection) Handle(ctx context.Context, q query.Query) (query.Answer, error) {
+	p.mx.RLock()
+	defer p.mx.RUnlock()
+
+	ids := p.accounts[q.(TransfersQuery).AccountID]
+	result := make(Transfers, 0, len(ids))
+
+	for _, id := range ids {
+		result = append(result, p.transfers[id])
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].RequestedAt.Before(result[j].RequestedAt)
+	})
+
+	return result, nil
+}
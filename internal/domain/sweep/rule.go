@@ -0,0 +1,119 @@
+package sweep
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/transaction"
+)
+
+var (
+	// ErrUnknownKind is returned when validating a Rule of an unsupported Kind.
+	ErrUnknownKind = fmt.Errorf("sweep.Rule: unknown kind")
+
+	// ErrNoGoal is returned when validating a Rule that does not specify
+	// the Goal the money should be saved for.
+	ErrNoGoal = fmt.Errorf("sweep.Rule: goal should be specified")
+
+	// ErrInvalidUnit is returned when validating a RoundUp Rule
+	// with a unit that is not positive.
+	ErrInvalidUnit = fmt.Errorf("sweep.Rule: round-up unit should be more than zero")
+
+	// ErrInvalidPercentage is returned when validating an IncomePercentage
+	// or Leftover Rule with a percentage not in the (0, 1] range.
+	ErrInvalidPercentage = fmt.Errorf("sweep.Rule: percentage should be more than 0 and at most 1")
+)
+
+// Kind is the kind of a sweep Rule, which specifies when the Rule
+// is triggered and how the amount to save is computed.
+type Kind string
+
+const (
+	// RoundUp rules round each expense up to the nearest Unit,
+	// saving the difference.
+	RoundUp Kind = "round-up"
+
+	// IncomePercentage rules save a Percentage of every income.
+	IncomePercentage Kind = "income-percentage"
+
+	// Leftover rules save a Percentage of the spending limit
+	// left unspent when the month closes.
+	Leftover Kind = "leftover"
+)
+
+// Rule is a user-defined rule to automatically move money from the Account
+// to one of its Goals.
+type Rule struct {
+	ID     string
+	Kind   Kind
+	GoalID string
+
+	// Unit is the amount expenses are rounded up to, used by RoundUp rules.
+	Unit float64
+
+	// Percentage is the fraction of the amount to save, between 0 and 1,
+	// used by IncomePercentage and Leftover rules.
+	Percentage float64
+}
+
+// Validate returns an error if the Rule is not valid.
+func (r Rule) Validate() error {
+	if r.GoalID == "" {
+		return ErrNoGoal
+	}
+
+	switch r.Kind {
+	case RoundUp:
+		if r.Unit <= 0 {
+			return ErrInvalidUnit
+		}
+
+	case IncomePercentage, Leftover:
+		if r.Percentage <= 0 || r.Percentage > 1 {
+			return ErrInvalidPercentage
+		}
+
+	default:
+		return fmt.Errorf("%w: %q", ErrUnknownKind, r.Kind)
+	}
+
+	return nil
+}
+
+// AmountFor returns the amount to save when a transaction of the specified
+// amount and kind is recorded, which is zero if the Rule does not apply
+// to the transaction.
+//
+// If no kind is specified, the transaction is classified using the sign of the amount.
+func (r Rule) AmountFor(amount float64, kind transaction.Kind) float64 {
+	kind = transaction.Classify(kind, amount)
+	amount = math.Abs(amount)
+
+	switch {
+	case r.Kind == RoundUp && kind == transaction.Expense:
+		return roundCents(math.Ceil(roundCents(amount/r.Unit))*r.Unit - amount)
+
+	case r.Kind == IncomePercentage && kind == transaction.Income:
+		return roundCents(amount * r.Percentage)
+
+	default:
+		return 0
+	}
+}
+
+// LeftoverAmount returns the amount to save when the month closes with
+// the specified spending limit left unspent, which is zero if the Rule
+// is not a Leftover rule or nothing is left.
+func (r Rule) LeftoverAmount(leftover float64) float64 {
+	if r.Kind != Leftover || leftover <= 0 {
+		return 0
+	}
+
+	return roundCents(leftover * r.Percentage)
+}
+
+// roundCents rounds the amount to the nearest cent, to avoid
+// floating point errors on the amounts to save.
+func roundCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
@@ -0,0 +1,90 @@
+package sweep_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/sweep"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/transaction"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRuleValidate(t *testing.T) {
+	testCases := []struct {
+		name string
+		rule sweep.Rule
+		err  error
+	}{
+		{
+			name: "valid round-up rule",
+			rule: sweep.Rule{Kind: sweep.RoundUp, GoalID: "holiday", Unit: 1},
+		},
+		{
+			name: "valid income percentage rule",
+			rule: sweep.Rule{Kind: sweep.IncomePercentage, GoalID: "holiday", Percentage: 0.1},
+		},
+		{
+			name: "valid leftover rule",
+			rule: sweep.Rule{Kind: sweep.Leftover, GoalID: "holiday", Percentage: 1},
+		},
+		{
+			name: "goal is required",
+			rule: sweep.Rule{Kind: sweep.RoundUp, Unit: 1},
+			err:  sweep.ErrNoGoal,
+		},
+		{
+			name: "round-up unit should be positive",
+			rule: sweep.Rule{Kind: sweep.RoundUp, GoalID: "holiday"},
+			err:  sweep.ErrInvalidUnit,
+		},
+		{
+			name: "percentage should not be more than 1",
+			rule: sweep.Rule{Kind: sweep.Leftover, GoalID: "holiday", Percentage: 1.5},
+			err:  sweep.ErrInvalidPercentage,
+		},
+		{
+			name: "unknown kind",
+			rule: sweep.Rule{Kind: "lottery", GoalID: "holiday"},
+			err:  sweep.ErrUnknownKind,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.rule.Validate()
+
+			if tc.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.True(t, errors.Is(err, tc.err), "error", err)
+			}
+		})
+	}
+}
+
+func TestRuleAmountFor(t *testing.T) {
+	roundUp := sweep.Rule{Kind: sweep.RoundUp, GoalID: "holiday", Unit: 1}
+	roundUpToFive := sweep.Rule{Kind: sweep.RoundUp, GoalID: "holiday", Unit: 5}
+	tenPercent := sweep.Rule{Kind: sweep.IncomePercentage, GoalID: "holiday", Percentage: 0.1}
+
+	assert.Equal(t, 0.7, roundUp.AmountFor(-12.3, transaction.Expense))
+	assert.Equal(t, 0.0, roundUp.AmountFor(-12, transaction.Expense))
+	assert.Equal(t, 2.7, roundUpToFive.AmountFor(-12.3, ""))
+	assert.Equal(t, 0.0, roundUp.AmountFor(1500, transaction.Income))
+	assert.Equal(t, 0.0, roundUp.AmountFor(-12.3, transaction.InternalTransfer))
+
+	assert.Equal(t, 150.0, tenPercent.AmountFor(1500, transaction.Income))
+	assert.Equal(t, 0.0, tenPercent.AmountFor(-12.3, transaction.Expense))
+	assert.Equal(t, 0.0, tenPercent.AmountFor(20, transaction.Refund))
+}
+
+func TestRuleLeftoverAmount(t *testing.T) {
+	half := sweep.Rule{Kind: sweep.Leftover, GoalID: "holiday", Percentage: 0.5}
+
+	assert.Equal(t, 60.13, half.LeftoverAmount(120.25))
+	assert.Equal(t, 0.0, half.LeftoverAmount(-30))
+	assert.Equal(t, 0.0, sweep.Rule{Kind: sweep.RoundUp, Unit: 1}.LeftoverAmount(100))
+}
@@ -0,0 +1,12 @@
+package transaction
+
+// Details contains the descriptive information of a transaction,
+// as provided by the bank, which can be used to categorize it.
+type Details struct {
+	Merchant    string
+	Description string
+
+	// MCC is the ISO 18245 Merchant Category Code, if the transaction
+	// was made with a card.
+	MCC string
+}
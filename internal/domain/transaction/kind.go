@@ -0,0 +1,47 @@
+package transaction
+
+// Kind classifies a transaction by the effect it should have
+// on the Account's monthly spending.
+type Kind string
+
+const (
+	// Unspecified is used when the origin of the transaction did not
+	// provide any classification. Use Classify to infer a Kind from the
+	// transaction amount in that case.
+	Unspecified Kind = ""
+
+	// Income is any real inflow of money (e.g. salary), which contributes
+	// to the monthly spending limit.
+	Income Kind = "income"
+
+	// Expense is any real outflow of money, which counts towards the
+	// amount spent in the month.
+	Expense Kind = "expense"
+
+	// InternalTransfer is a movement of money between accounts of the same
+	// owner (e.g. moving money to or from a savings account), which is
+	// considered neither as income nor as spending.
+	InternalTransfer Kind = "internal-transfer"
+
+	// Refund is money given back for a previous expense, which reduces
+	// the amount spent in the month.
+	Refund Kind = "refund"
+)
+
+// Classify returns the specified Kind, if any, or infers one from the sign
+// of the transaction amount: positive amounts are Income,
+// negative amounts are Expense.
+//
+// This is useful to deal with transactions recorded before the
+// classification was introduced.
+func Classify(kind Kind, amount float64) Kind {
+	if kind != Unspecified {
+		return kind
+	}
+
+	if amount > 0 {
+		return Income
+	}
+
+	return Expense
+}
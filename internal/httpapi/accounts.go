@@ -1,44 +1,118 @@
 package httpapi
 
 import (
-	"encoding/json"
-	"errors"
 	"net/http"
 
 	"github.com/eventually-rs/saving-goals-go/internal/domain/account"
 	"github.com/eventually-rs/saving-goals-go/internal/domain/saving"
+	"github.com/eventually-rs/saving-goals-go/internal/problem"
+	"github.com/eventually-rs/saving-goals-go/internal/timetravel"
+	v1 "github.com/eventually-rs/saving-goals-go/pkg/api/v1"
 
 	"github.com/eventually-rs/eventually-go"
 	"github.com/eventually-rs/eventually-go/aggregate"
 	"github.com/eventually-rs/eventually-go/command"
+	"github.com/eventually-rs/eventually-go/query"
 	"github.com/go-chi/chi"
 )
 
+func accountFromView(view account.View) v1.Account {
+	response := v1.Account{
+		AccountID: view.AccountID,
+		Balance:   view.Balance,
+		Goals:     goalsFromDomain(view.Goals),
+		Budgets:   make([]v1.Budget, 0, len(view.Budgets)),
+		Pacing:    string(view.Pacing.OrDefault()),
+		Status:    string(view.Status),
+
+		RecurringSeries: recurringSeriesFromDomain(view.RecurringSeries),
+		SweepRules:      sweepRulesFromDomain(view.SweepRules),
+	}
+
+	for _, budget := range view.Budgets {
+		response.Budgets = append(response.Budgets, budgetFromDomain(budget))
+	}
+
+	if view.SavingGoal != nil {
+		response.SavingGoal = savingGoalFromDomain(*view.SavingGoal)
+	}
+
+	return response
+}
+
+func getAccountHandler(queryBus QueryDispatcher) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		accountID := chi.URLParam(r, "accountId")
+
+		asOf, err := asOfFromURL(r)
+		if err != nil {
+			writeError(w, r, invalidParameter("asOf", err))
+			return
+		}
+
+		var q query.Query = account.ViewQuery{AccountID: accountID}
+		if !asOf.IsZero() {
+			q = timetravel.AccountQuery{AccountID: accountID, AsOf: asOf}
+		}
+
+		answer, err := queryBus.Dispatch(ctx, q)
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, accountFromView(answer.(account.View)))
+	}
+}
+
 func changeAccountSavingGoalHandler(commandBus command.Dispatcher) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
 		accountID := chi.URLParam(r, "accountId")
 
-		var savingGoal saving.Goal
-		if err := json.NewDecoder(r.Body).Decode(&savingGoal); err != nil {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+		var request v1.SavingGoal
+		if err := decodeJSON(r, &request); err != nil {
+			writeError(w, r, err)
 			return
 		}
 
 		err := commandBus.Dispatch(ctx, eventually.Command{
 			Payload: account.ChangeSavingGoal{
 				AccountID:  aggregate.StringID(accountID),
-				SavingGoal: savingGoal,
+				SavingGoal: savingGoalToDomain(request),
 			},
 		})
 
-		if errors.Is(err, account.ErrAtLeastOneThreshold) || errors.Is(err, account.ErrGoalIsZero) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+		if err != nil {
+			writeError(w, r, err)
 			return
 		}
 
+		w.WriteHeader(http.StatusAccepted)
+	}
+}
+
+func setNewAccountSavingGoalThresholdHandler(commandBus command.Dispatcher) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		accountID := chi.URLParam(r, "accountId")
+
+		var request v1.SetNewThresholdRequest
+		if err := decodeJSON(r, &request); err != nil {
+			writeError(w, r, err)
+			return
+		}
+
+		err := commandBus.Dispatch(ctx, eventually.Command{
+			Payload: account.SetNewThreshold{
+				AccountID: aggregate.StringID(accountID),
+				Value:     thresholdToDomain(request.Threshold),
+			},
+		})
+
 		if err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+			writeError(w, r, err)
 			return
 		}
 
@@ -46,35 +120,86 @@ func changeAccountSavingGoalHandler(commandBus command.Dispatcher) http.HandlerF
 	}
 }
 
-type SetNewThresholdRequest struct {
-	Threshold float64 `json:"threshold"`
+func listThresholdsHandler(queryBus QueryDispatcher) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		accountID := chi.URLParam(r, "accountId")
+
+		asOf, err := asOfFromURL(r)
+		if err != nil {
+			writeError(w, r, invalidParameter("asOf", err))
+			return
+		}
+
+		var q query.Query = account.ViewQuery{AccountID: accountID}
+		if !asOf.IsZero() {
+			q = timetravel.AccountQuery{AccountID: accountID, AsOf: asOf}
+		}
+
+		answer, err := queryBus.Dispatch(ctx, q)
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
+
+		view := answer.(account.View)
+		if view.SavingGoal == nil {
+			problem.Write(w, savingGoalNotFound)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, thresholdsFromDomain(view.SavingGoal.Thresholds))
+	}
 }
 
-func setNewAccountSavingGoalThresholdHandler(commandBus command.Dispatcher) http.HandlerFunc {
+func replaceThresholdsHandler(commandBus command.Dispatcher) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
 		accountID := chi.URLParam(r, "accountId")
 
-		var request SetNewThresholdRequest
-		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+		var request v1.ReplaceThresholdsRequest
+		if err := decodeJSON(r, &request); err != nil {
+			writeError(w, r, err)
 			return
 		}
 
 		err := commandBus.Dispatch(ctx, eventually.Command{
-			Payload: account.SetNewThreshold{
-				AccountID: aggregate.StringID(accountID),
-				Value:     request.Threshold,
+			Payload: account.ReplaceThresholds{
+				AccountID:  aggregate.StringID(accountID),
+				Thresholds: thresholdsToDomain(request.Thresholds),
 			},
 		})
 
-		if errors.Is(err, account.ErrNoSavingGoal) || errors.Is(err, account.ErrThresholdAlreadyExists) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusAccepted)
+	}
+}
+
+func removeThresholdHandler(commandBus command.Dispatcher) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		accountID := chi.URLParam(r, "accountId")
+
+		// Thresholds are specified as "<kind>:<value>", or as a bare percentage value.
+		threshold, err := saving.ParseThreshold(chi.URLParam(r, "value"))
+		if err != nil {
+			writeError(w, r, invalidParameter("value", err))
 			return
 		}
 
+		err = commandBus.Dispatch(ctx, eventually.Command{
+			Payload: account.RemoveThreshold{
+				AccountID: aggregate.StringID(accountID),
+				Threshold: threshold,
+			},
+		})
+
 		if err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+			writeError(w, r, err)
 			return
 		}
 
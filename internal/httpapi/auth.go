@@ -0,0 +1,113 @@
+package httpapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/eventually-rs/saving-goals-go/internal/commandbus"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/household"
+	"github.com/eventually-rs/saving-goals-go/internal/problem"
+
+	"github.com/go-chi/chi"
+	"go.uber.org/zap"
+)
+
+// Authenticator is the component used to identify the caller
+// of the HTTP API from its bearer token.
+type Authenticator interface {
+	Authenticate(ctx context.Context, token string) (commandbus.Caller, error)
+}
+
+// authenticate rejects the requests without a valid bearer token,
+// and carries the identity of the caller in the context of the others,
+// so that the Commands are dispatched on its behalf.
+func authenticate(authenticator Authenticator) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			header := r.Header.Get("Authorization")
+
+			if len(header) < len("Bearer ") || !strings.EqualFold(header[:len("Bearer ")], "Bearer ") {
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				problem.Write(w, unauthenticated.WithDetail("Bearer token not specified."))
+				return
+			}
+
+			caller, err := authenticator.Authenticate(r.Context(), strings.TrimSpace(header[len("Bearer "):]))
+			if err != nil {
+				loggerFrom(r.Context()).Debug("Invalid bearer token", zap.Error(err))
+
+				w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+				problem.Write(w, unauthenticated.WithDetail("Invalid bearer token."))
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(commandbus.WithCaller(r.Context(), caller)))
+		})
+	}
+}
+
+// requireRole allows only the callers granted the specified role.
+func requireRole(role string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if caller, _ := commandbus.CallerFrom(r.Context()); !caller.HasRole(role) {
+				problem.Write(w, forbidden.WithDetail("Role "+role+" required."))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// requireAccountAccess allows only the callers able to access
+// the Account identified by the specified URL parameter.
+func requireAccountAccess(param string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if caller, _ := commandbus.CallerFrom(r.Context()); !caller.CanAccess(chi.URLParam(r, param)) {
+				problem.Write(w, forbidden.WithDetail("Account not accessible."))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// requireHouseholdMembership allows only the callers able to access one of the member
+// Accounts of the Household identified by the specified URL parameter.
+//
+// Households not found are not accessible either, so that their existence
+// is not disclosed to the callers.
+func requireHouseholdMembership(queryBus QueryDispatcher, param string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+
+			answer, err := queryBus.Dispatch(ctx, household.ViewQuery{HouseholdID: chi.URLParam(r, param)})
+			if errors.Is(err, household.ErrNotFound) {
+				problem.Write(w, forbidden.WithDetail("Household not accessible."))
+				return
+			}
+
+			if err != nil {
+				writeError(w, r, err)
+				return
+			}
+
+			caller, _ := commandbus.CallerFrom(ctx)
+
+			for _, accountID := range answer.(household.View).Members {
+				if caller.CanAccess(accountID) {
+					next.ServeHTTP(w, r)
+					return
+				}
+			}
+
+			problem.Write(w, forbidden.WithDetail("Household not accessible."))
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package httpapi
+
+import (
+	"net/http"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/account"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/category"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/saving"
+	v1 "github.com/eventually-rs/saving-goals-go/pkg/api/v1"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+	"github.com/eventually-rs/eventually-go/command"
+	"github.com/go-chi/chi"
+)
+
+func budgetFromDomain(budget saving.Budget) v1.Budget {
+	return v1.Budget{
+		Category:   string(budget.Category),
+		Amount:     budget.Amount,
+		Thresholds: thresholdsFromDomain(budget.Thresholds),
+	}
+}
+
+func listBudgetsHandler(queryBus QueryDispatcher) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		accountID := chi.URLParam(r, "accountId")
+
+		answer, err := queryBus.Dispatch(ctx, account.ViewQuery{AccountID: accountID})
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
+
+		view := answer.(account.View)
+		budgets := make([]v1.Budget, 0, len(view.Budgets))
+
+		for _, budget := range view.Budgets {
+			budgets = append(budgets, budgetFromDomain(budget))
+		}
+
+		writeJSON(w, http.StatusOK, budgets)
+	}
+}
+
+func setBudgetHandler(commandBus command.Dispatcher) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		accountID := chi.URLParam(r, "accountId")
+
+		var request v1.SetBudgetRequest
+		if err := decodeJSON(r, &request); err != nil {
+			writeError(w, r, err)
+			return
+		}
+
+		budget := saving.Budget{
+			Category:   category.Category(chi.URLParam(r, "category")),
+			Amount:     request.Amount,
+			Thresholds: thresholdsToDomain(request.Thresholds),
+		}
+
+		err := commandBus.Dispatch(ctx, eventually.Command{
+			Payload: account.SetCategoryBudget{
+				AccountID: aggregate.StringID(accountID),
+				Budget:    budget,
+			},
+		})
+
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
+
+		writeJSON(w, http.StatusAccepted, budgetFromDomain(budget))
+	}
+}
+
+func removeBudgetHandler(commandBus command.Dispatcher) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		accountID := chi.URLParam(r, "accountId")
+
+		err := commandBus.Dispatch(ctx, eventually.Command{
+			Payload: account.RemoveCategoryBudget{
+				AccountID: aggregate.StringID(accountID),
+				Category:  category.Category(chi.URLParam(r, "category")),
+			},
+		})
+
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusAccepted)
+	}
+}
@@ -0,0 +1,146 @@
+package httpapi
+
+import (
+	"net/http"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/account"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/category"
+	v1 "github.com/eventually-rs/saving-goals-go/pkg/api/v1"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+	"github.com/eventually-rs/eventually-go/command"
+	"github.com/go-chi/chi"
+	"github.com/google/uuid"
+)
+
+func categorizationRuleFromDomain(rule category.Rule) v1.CategorizationRule {
+	return v1.CategorizationRule{
+		ID:                 rule.ID,
+		Category:           string(rule.Category),
+		Priority:           rule.Priority,
+		Merchant:           rule.Merchant,
+		DescriptionPattern: rule.DescriptionPattern,
+		MCC:                rule.MCC,
+		MinAmount:          rule.MinAmount,
+		MaxAmount:          rule.MaxAmount,
+	}
+}
+
+func categorizationRuleToDomain(r v1.CategorizationRule) category.Rule {
+	return category.Rule{
+		ID:                 r.ID,
+		Category:           category.Category(r.Category),
+		Priority:           r.Priority,
+		Merchant:           r.Merchant,
+		DescriptionPattern: r.DescriptionPattern,
+		MCC:                r.MCC,
+		MinAmount:          r.MinAmount,
+		MaxAmount:          r.MaxAmount,
+	}
+}
+
+func listCategorizationRulesHandler(queryBus QueryDispatcher) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		accountID := chi.URLParam(r, "accountId")
+
+		answer, err := queryBus.Dispatch(ctx, account.ViewQuery{AccountID: accountID})
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
+
+		view := answer.(account.View)
+		rules := make([]v1.CategorizationRule, 0, len(view.CategorizationRules))
+
+		for _, rule := range view.CategorizationRules {
+			rules = append(rules, categorizationRuleFromDomain(rule))
+		}
+
+		writeJSON(w, http.StatusOK, rules)
+	}
+}
+
+func addCategorizationRuleHandler(commandBus command.Dispatcher) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		accountID := chi.URLParam(r, "accountId")
+
+		var request v1.CategorizationRule
+		if err := decodeJSON(r, &request); err != nil {
+			writeError(w, r, err)
+			return
+		}
+
+		if request.ID == "" {
+			request.ID = uuid.New().String()
+		}
+
+		err := commandBus.Dispatch(ctx, eventually.Command{
+			Payload: account.AddCategorizationRule{
+				AccountID: aggregate.StringID(accountID),
+				Rule:      categorizationRuleToDomain(request),
+			},
+		})
+
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
+
+		writeJSON(w, http.StatusAccepted, request)
+	}
+}
+
+func removeCategorizationRuleHandler(commandBus command.Dispatcher) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		accountID := chi.URLParam(r, "accountId")
+		ruleID := chi.URLParam(r, "ruleId")
+
+		err := commandBus.Dispatch(ctx, eventually.Command{
+			Payload: account.RemoveCategorizationRule{
+				AccountID: aggregate.StringID(accountID),
+				RuleID:    ruleID,
+			},
+		})
+
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusAccepted)
+	}
+}
+
+func recategorizeTransactionsHandler(commandBus command.Dispatcher) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		accountID := chi.URLParam(r, "accountId")
+
+		// The request body is optional: all the transactions are recategorized if not specified.
+		var request v1.RecategorizeTransactionsRequest
+		if r.ContentLength != 0 {
+			if err := decodeJSON(r, &request); err != nil {
+				writeError(w, r, err)
+				return
+			}
+		}
+
+		err := commandBus.Dispatch(ctx, eventually.Command{
+			Payload: account.RecategorizeTransactions{
+				AccountID:     aggregate.StringID(accountID),
+				TransactionID: request.TransactionID,
+			},
+		})
+
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusAccepted)
+	}
+}
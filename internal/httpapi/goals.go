@@ -0,0 +1,232 @@
+package httpapi
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/account"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/goal"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/interval"
+	v1 "github.com/eventually-rs/saving-goals-go/pkg/api/v1"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+	"github.com/eventually-rs/eventually-go/command"
+	"github.com/go-chi/chi"
+	"github.com/google/uuid"
+)
+
+// deadlineLayout is the format used for Goal deadlines in requests and responses.
+const deadlineLayout = "2006-01-02"
+
+func goalFromDomain(g goal.Goal, month interval.Month) v1.Goal {
+	response := v1.Goal{
+		ID:                  g.ID,
+		Name:                g.Name,
+		TargetAmount:        g.TargetAmount,
+		MonthlyContribution: g.MonthlyContribution,
+		Priority:            g.Priority,
+		SavedAmount:         g.SavedAmount,
+		Status:              string(g.Status),
+		Progress:            g.Progress(),
+		RequiredThisMonth:   g.RequiredMonthlyContribution(month),
+	}
+
+	if !g.Deadline.IsZero() {
+		response.Deadline = g.Deadline.Format(deadlineLayout)
+	}
+
+	return response
+}
+
+func goalsFromDomain(goals []goal.Goal) []v1.Goal {
+	month := interval.MonthFromTime(time.Now())
+	result := make([]v1.Goal, 0, len(goals))
+
+	for _, g := range goals {
+		result = append(result, goalFromDomain(g, month))
+	}
+
+	return result
+}
+
+func goalFromRequest(r v1.GoalRequest) (goal.Goal, error) {
+	g := goal.Goal{
+		ID:                  r.ID,
+		Name:                r.Name,
+		TargetAmount:        r.TargetAmount,
+		MonthlyContribution: r.MonthlyContribution,
+		Priority:            r.Priority,
+	}
+
+	if r.Deadline != "" {
+		deadline, err := time.Parse(deadlineLayout, r.Deadline)
+		if err != nil {
+			return goal.Goal{}, &requestError{field: "deadline", reason: "should be formatted as YYYY-MM-DD", err: err}
+		}
+
+		g.Deadline = deadline
+	}
+
+	return g, nil
+}
+
+func listGoalsHandler(queryBus QueryDispatcher) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		accountID := chi.URLParam(r, "accountId")
+
+		answer, err := queryBus.Dispatch(ctx, account.ViewQuery{AccountID: accountID})
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, goalsFromDomain(answer.(account.View).Goals))
+	}
+}
+
+func getGoalHandler(queryBus QueryDispatcher) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		accountID := chi.URLParam(r, "accountId")
+		goalID := chi.URLParam(r, "goalId")
+
+		answer, err := queryBus.Dispatch(ctx, account.ViewQuery{AccountID: accountID})
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
+
+		for _, g := range answer.(account.View).Goals {
+			if g.ID == goalID {
+				writeJSON(w, http.StatusOK, goalFromDomain(g, interval.MonthFromTime(time.Now())))
+				return
+			}
+		}
+
+		writeError(w, r, account.ErrGoalNotFound)
+	}
+}
+
+func addGoalHandler(commandBus command.Dispatcher) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		accountID := chi.URLParam(r, "accountId")
+
+		var request v1.GoalRequest
+		if err := decodeJSON(r, &request); err != nil {
+			writeError(w, r, err)
+			return
+		}
+
+		if request.ID == "" {
+			request.ID = uuid.New().String()
+		}
+
+		g, err := goalFromRequest(request)
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
+
+		err = commandBus.Dispatch(ctx, eventually.Command{
+			Payload: account.AddGoal{
+				AccountID: aggregate.StringID(accountID),
+				Goal:      g,
+			},
+		})
+
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
+
+		g.Status = goal.Active
+		writeJSON(w, http.StatusAccepted, goalFromDomain(g, interval.MonthFromTime(time.Now())))
+	}
+}
+
+func updateGoalHandler(commandBus command.Dispatcher) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		accountID := chi.URLParam(r, "accountId")
+
+		var request v1.GoalRequest
+		if err := decodeJSON(r, &request); err != nil {
+			writeError(w, r, err)
+			return
+		}
+
+		request.ID = chi.URLParam(r, "goalId")
+
+		g, err := goalFromRequest(request)
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
+
+		err = commandBus.Dispatch(ctx, eventually.Command{
+			Payload: account.UpdateGoal{
+				AccountID: aggregate.StringID(accountID),
+				Goal:      g,
+			},
+		})
+
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusAccepted)
+	}
+}
+
+func removeGoalHandler(commandBus command.Dispatcher) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		accountID := chi.URLParam(r, "accountId")
+
+		err := commandBus.Dispatch(ctx, eventually.Command{
+			Payload: account.RemoveGoal{
+				AccountID: aggregate.StringID(accountID),
+				GoalID:    chi.URLParam(r, "goalId"),
+			},
+		})
+
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusAccepted)
+	}
+}
+
+func recordGoalContributionHandler(commandBus command.Dispatcher) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		accountID := chi.URLParam(r, "accountId")
+
+		var request v1.GoalContributionRequest
+		if err := decodeJSON(r, &request); err != nil {
+			writeError(w, r, err)
+			return
+		}
+
+		err := commandBus.Dispatch(ctx, eventually.Command{
+			Payload: account.RecordGoalContribution{
+				AccountID: aggregate.StringID(accountID),
+				GoalID:    chi.URLParam(r, "goalId"),
+				Amount:    request.Amount,
+			},
+		})
+
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusAccepted)
+	}
+}
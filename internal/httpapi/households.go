@@ -0,0 +1,183 @@
+package httpapi
+
+import (
+	"net/http"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/household"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/monthly"
+	"github.com/eventually-rs/saving-goals-go/internal/timetravel"
+	v1 "github.com/eventually-rs/saving-goals-go/pkg/api/v1"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+	"github.com/eventually-rs/eventually-go/command"
+	"github.com/eventually-rs/eventually-go/query"
+	"github.com/go-chi/chi"
+	"github.com/google/uuid"
+)
+
+func householdFromView(view household.View) v1.Household {
+	response := v1.Household{
+		HouseholdID: view.HouseholdID,
+		Name:        view.Name,
+		Members:     view.Members,
+		Invitations: view.Invitations,
+	}
+
+	if view.SavingGoal != nil {
+		response.SavingGoal = savingGoalFromDomain(*view.SavingGoal)
+	}
+
+	return response
+}
+
+func createHouseholdHandler(commandBus command.Dispatcher) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		var request v1.CreateHouseholdRequest
+		if err := decodeJSON(r, &request); err != nil {
+			writeError(w, r, err)
+			return
+		}
+
+		householdID := uuid.New().String()
+
+		err := commandBus.Dispatch(ctx, eventually.Command{
+			Payload: household.CreateCommand{
+				HouseholdID: householdID,
+				Name:        request.Name,
+				FoundedBy:   request.AccountID,
+			},
+		})
+
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
+
+		writeJSON(w, http.StatusAccepted, v1.CreateHouseholdResponse{HouseholdID: householdID})
+	}
+}
+
+func getHouseholdHandler(queryBus QueryDispatcher) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		householdID := chi.URLParam(r, "householdId")
+
+		answer, err := queryBus.Dispatch(ctx, household.ViewQuery{HouseholdID: householdID})
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, householdFromView(answer.(household.View)))
+	}
+}
+
+func changeHouseholdSavingGoalHandler(commandBus command.Dispatcher) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		householdID := chi.URLParam(r, "householdId")
+
+		var request v1.SavingGoal
+		if err := decodeJSON(r, &request); err != nil {
+			writeError(w, r, err)
+			return
+		}
+
+		err := commandBus.Dispatch(ctx, eventually.Command{
+			Payload: household.ChangeSavingGoal{
+				HouseholdID: aggregate.StringID(householdID),
+				SavingGoal:  savingGoalToDomain(request),
+			},
+		})
+
+		writeCommandResult(w, r, err)
+	}
+}
+
+func inviteHouseholdMemberHandler(commandBus command.Dispatcher) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		householdID := chi.URLParam(r, "householdId")
+
+		var request v1.InviteHouseholdMemberRequest
+		if err := decodeJSON(r, &request); err != nil {
+			writeError(w, r, err)
+			return
+		}
+
+		err := commandBus.Dispatch(ctx, eventually.Command{
+			Payload: household.InviteMember{
+				HouseholdID: aggregate.StringID(householdID),
+				AccountID:   request.AccountID,
+				InvitedBy:   request.InvitedBy,
+			},
+		})
+
+		writeCommandResult(w, r, err)
+	}
+}
+
+func acceptHouseholdInvitationHandler(commandBus command.Dispatcher) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		err := commandBus.Dispatch(ctx, eventually.Command{
+			Payload: household.AcceptInvitation{
+				HouseholdID: aggregate.StringID(chi.URLParam(r, "householdId")),
+				AccountID:   chi.URLParam(r, "accountId"),
+			},
+		})
+
+		writeCommandResult(w, r, err)
+	}
+}
+
+func leaveHouseholdHandler(commandBus command.Dispatcher) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		err := commandBus.Dispatch(ctx, eventually.Command{
+			Payload: household.Leave{
+				HouseholdID: aggregate.StringID(chi.URLParam(r, "householdId")),
+				AccountID:   chi.URLParam(r, "accountId"),
+			},
+		})
+
+		writeCommandResult(w, r, err)
+	}
+}
+
+func getHouseholdMonthProgressHandler(queryBus QueryDispatcher) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		householdID := chi.URLParam(r, "householdId")
+
+		month, err := monthFromURL(r)
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
+
+		asOf, err := asOfFromURL(r)
+		if err != nil {
+			writeError(w, r, invalidParameter("asOf", err))
+			return
+		}
+
+		var q query.Query = monthly.ProgressQuery{HouseholdID: householdID, Month: month}
+		if !asOf.IsZero() {
+			q = timetravel.ProgressQuery{HouseholdID: householdID, Month: month, AsOf: asOf}
+		}
+
+		answer, err := queryBus.Dispatch(ctx, q)
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, monthProgressFromDomain(answer.(monthly.Progress)))
+	}
+}
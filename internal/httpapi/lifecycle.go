@@ -0,0 +1,89 @@
+package httpapi
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/account"
+	v1 "github.com/eventually-rs/saving-goals-go/pkg/api/v1"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+	"github.com/eventually-rs/eventually-go/command"
+	"github.com/go-chi/chi"
+)
+
+func closeAccountHandler(commandBus command.Dispatcher) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		var request v1.AccountLifecycleRequest
+		if err := decodeJSON(r, &request); err != nil {
+			writeError(w, r, err)
+			return
+		}
+
+		err := commandBus.Dispatch(ctx, eventually.Command{
+			Payload: account.CloseAccount{
+				AccountID: aggregate.StringID(chi.URLParam(r, "accountId")),
+				Reason:    request.Reason,
+				ClosedAt:  time.Now(),
+			},
+		})
+
+		writeCommandResult(w, r, err)
+	}
+}
+
+func freezeAccountHandler(commandBus command.Dispatcher) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		var request v1.AccountLifecycleRequest
+		if err := decodeJSON(r, &request); err != nil {
+			writeError(w, r, err)
+			return
+		}
+
+		err := commandBus.Dispatch(ctx, eventually.Command{
+			Payload: account.FreezeAccount{
+				AccountID: aggregate.StringID(chi.URLParam(r, "accountId")),
+				Reason:    request.Reason,
+				FrozenAt:  time.Now(),
+			},
+		})
+
+		writeCommandResult(w, r, err)
+	}
+}
+
+func reopenAccountHandler(commandBus command.Dispatcher) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		err := commandBus.Dispatch(ctx, eventually.Command{
+			Payload: account.ReopenAccount{
+				AccountID:  aggregate.StringID(chi.URLParam(r, "accountId")),
+				ReopenedAt: time.Now(),
+			},
+		})
+
+		writeCommandResult(w, r, err)
+	}
+}
+
+// forgetAccountHandler erases all the data of the Account,
+// which is not found anymore once the request has been accepted.
+func forgetAccountHandler(commandBus command.Dispatcher) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		err := commandBus.Dispatch(ctx, eventually.Command{
+			Payload: account.ForgetAccount{
+				AccountID: aggregate.StringID(chi.URLParam(r, "accountId")),
+			},
+		})
+
+		writeCommandResult(w, r, err)
+	}
+}
@@ -1,14 +1,21 @@
 package httpapi
 
 import (
+	"fmt"
 	"net/http"
 	"strconv"
 	"time"
 
+	"github.com/eventually-rs/saving-goals-go/internal/domain/account"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/forecast"
 	"github.com/eventually-rs/saving-goals-go/internal/domain/interval"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/monthly"
+	"github.com/eventually-rs/saving-goals-go/internal/timetravel"
+	v1 "github.com/eventually-rs/saving-goals-go/pkg/api/v1"
 
 	"github.com/eventually-rs/eventually-go"
 	"github.com/eventually-rs/eventually-go/eventstore"
+	"github.com/eventually-rs/eventually-go/query"
 	"github.com/go-chi/chi"
 )
 
@@ -16,32 +23,133 @@ func forceMonthCreation(monthStore eventstore.Typed) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
 
-		year, err := strconv.Atoi(chi.URLParam(r, "year"))
+		month, err := monthFromURL(r)
 		if err != nil {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-			return
-		}
-
-		month, err := strconv.Atoi(chi.URLParam(r, "month"))
-		if err != nil {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+			writeError(w, r, err)
 			return
 		}
 
 		_, err = monthStore.
 			Instance("month").
 			Append(ctx, -1, eventually.Event{
-				Payload: interval.MonthStarted{
-					Month: interval.Month{
-						Year:  year,
-						Month: time.Month(month),
-					},
-				},
+				Payload: interval.MonthStarted{Month: month},
 			})
 
 		if err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+			writeError(w, r, err)
 			return
 		}
 	}
 }
+
+func forecastFromDomain(f forecast.Forecast) *v1.Forecast {
+	return &v1.Forecast{
+		Date:                 f.Date.Format(allowanceDateFormat),
+		DaysLeft:             f.DaysLeft,
+		AverageDailySpending: f.AverageDailySpending,
+		ExpectedSpending:     f.ExpectedSpending,
+		RecurringPayments:    f.RecurringPayments,
+		ExpectedBalance:      f.ExpectedBalance,
+		DesiredBalance:       f.DesiredBalance,
+		Probability:          f.Probability,
+	}
+}
+
+func monthProgressFromDomain(progress monthly.Progress) v1.MonthProgress {
+	response := v1.MonthProgress{
+		AccountID:         progress.AccountID,
+		HouseholdID:       progress.HouseholdID,
+		Month:             progress.Month.String(),
+		StartingBalance:   progress.StartingBalance,
+		CurrentBalance:    progress.CurrentBalance,
+		DesiredBalance:    progress.DesiredBalance,
+		SpendingLimit:     progress.SpendingLimit,
+		Spent:             progress.Spent,
+		ReachedThresholds: thresholdsFromDomain(progress.ReachedThresholds),
+		Categories:        make([]v1.CategoryProgress, 0, len(progress.Categories)),
+		GoalAtRisk:        progress.GoalAtRisk,
+		Committed:         progress.Committed,
+		Closed:            progress.Closed,
+	}
+
+	for _, c := range progress.Categories {
+		response.Categories = append(response.Categories, v1.CategoryProgress{
+			Category:          string(c.Category),
+			Budget:            c.Budget,
+			Spent:             c.Spent,
+			Remaining:         c.Remaining,
+			ReachedThresholds: thresholdsFromDomain(c.ReachedThresholds),
+		})
+	}
+
+	return response
+}
+
+func monthFromURL(r *http.Request) (interval.Month, error) {
+	year, err := strconv.Atoi(chi.URLParam(r, "year"))
+	if err != nil {
+		return interval.Month{}, invalidParameter("year", err)
+	}
+
+	month, err := strconv.Atoi(chi.URLParam(r, "month"))
+	if err != nil {
+		return interval.Month{}, invalidParameter("month", err)
+	}
+
+	if month < int(time.January) || month > int(time.December) {
+		return interval.Month{}, invalidParameter("month", fmt.Errorf("invalid month: %d", month))
+	}
+
+	return interval.Month{Year: year, Month: time.Month(month)}, nil
+}
+
+func getMonthProgressHandler(queryBus QueryDispatcher) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		accountID := chi.URLParam(r, "accountId")
+
+		month, err := monthFromURL(r)
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
+
+		asOf, err := asOfFromURL(r)
+		if err != nil {
+			writeError(w, r, invalidParameter("asOf", err))
+			return
+		}
+
+		var q query.Query = monthly.ProgressQuery{AccountID: accountID, Month: month}
+		if !asOf.IsZero() {
+			q = timetravel.ProgressQuery{AccountID: accountID, Month: month, AsOf: asOf}
+		}
+
+		answer, err := queryBus.Dispatch(ctx, q)
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
+
+		progress := answer.(monthly.Progress)
+		response := monthProgressFromDomain(progress)
+
+		// The forecast is only meaningful while the month is still in progress,
+		// and it is not available for the past states of the month.
+		if now := time.Now(); asOf.IsZero() && interval.MonthFromTime(now) == month {
+			answer, err := queryBus.Dispatch(ctx, account.HistoryQuery{
+				AccountID: accountID,
+				Since:     forecast.HistorySince(now),
+			})
+
+			if err != nil {
+				writeError(w, r, err)
+				return
+			}
+
+			response.Forecast = forecastFromDomain(progress.Forecast(answer.(account.History), now))
+		}
+
+		writeJSON(w, http.StatusOK, response)
+	}
+}
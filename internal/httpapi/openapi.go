@@ -0,0 +1,420 @@
+package httpapi
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/eventually-rs/saving-goals-go/internal/idempotency"
+	"github.com/eventually-rs/saving-goals-go/internal/problem"
+	v1 "github.com/eventually-rs/saving-goals-go/pkg/api/v1"
+)
+
+// openAPIDocument is the OpenAPI 3 document describing the HTTP API,
+// limited to the parts of the specification used by the API.
+type openAPIDocument struct {
+	OpenAPI    string                           `json:"openapi"`
+	Info       openAPIInfo                      `json:"info"`
+	Paths      map[string]map[string]*operation `json:"paths"`
+	Components openAPIComponents                `json:"components"`
+	Security   []map[string][]string            `json:"security"`
+}
+
+type openAPIInfo struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Version     string `json:"version"`
+}
+
+type openAPIComponents struct {
+	Schemas         map[string]*jsonSchema    `json:"schemas"`
+	SecuritySchemes map[string]securityScheme `json:"securitySchemes"`
+}
+
+type securityScheme struct {
+	Type         string `json:"type"`
+	Scheme       string `json:"scheme"`
+	BearerFormat string `json:"bearerFormat,omitempty"`
+}
+
+type operation struct {
+	OperationID string                `json:"operationId"`
+	Summary     string                `json:"summary"`
+	Tags        []string              `json:"tags"`
+	Parameters  []parameter           `json:"parameters,omitempty"`
+	RequestBody *requestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]response   `json:"responses"`
+	Security    []map[string][]string `json:"security,omitempty"`
+}
+
+type parameter struct {
+	Name        string      `json:"name"`
+	In          string      `json:"in"`
+	Description string      `json:"description,omitempty"`
+	Required    bool        `json:"required,omitempty"`
+	Schema      *jsonSchema `json:"schema"`
+}
+
+type requestBody struct {
+	Required bool                 `json:"required"`
+	Content  map[string]mediaType `json:"content"`
+}
+
+type response struct {
+	Description string               `json:"description"`
+	Content     map[string]mediaType `json:"content,omitempty"`
+}
+
+type mediaType struct {
+	Schema *jsonSchema `json:"schema"`
+}
+
+// jsonSchema is the subset of the OpenAPI Schema Object used to describe
+// the request and response bodies, also used to validate the requests.
+type jsonSchema struct {
+	Ref         string   `json:"$ref,omitempty"`
+	Type        string   `json:"type,omitempty"`
+	Format      string   `json:"format,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Nullable    bool     `json:"nullable,omitempty"`
+	Enum        []string `json:"enum,omitempty"`
+	Minimum     *float64 `json:"minimum,omitempty"`
+	Maximum     *float64 `json:"maximum,omitempty"`
+
+	Items                *jsonSchema            `json:"items,omitempty"`
+	Properties           map[string]*jsonSchema `json:"properties,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	AdditionalProperties interface{}            `json:"additionalProperties,omitempty"`
+
+	OneOf []*jsonSchema `json:"oneOf,omitempty"`
+	AllOf []*jsonSchema `json:"allOf,omitempty"`
+}
+
+const schemaRefPrefix = "#/components/schemas/"
+
+var (
+	timeType      = reflect.TypeOf(time.Time{})
+	thresholdType = reflect.TypeOf(v1.Threshold{})
+)
+
+// schemaGenerator generates the schemas of the Go types by reflection,
+// collecting the schemas of the structs as named components.
+type schemaGenerator struct {
+	components map[string]*jsonSchema
+}
+
+func (g schemaGenerator) schemaOf(t reflect.Type) *jsonSchema {
+	switch {
+	case t == timeType:
+		return &jsonSchema{Type: "string", Format: "date-time"}
+	case t.Kind() == reflect.Ptr:
+		s := g.schemaOf(t.Elem())
+		if s.Ref != "" {
+			return &jsonSchema{Nullable: true, AllOf: []*jsonSchema{s}}
+		}
+
+		s.Nullable = true
+
+		return s
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &jsonSchema{Type: "boolean"}
+	case reflect.String:
+		return &jsonSchema{Type: "string"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &jsonSchema{Type: "integer", Format: "int32"}
+	case reflect.Int64, reflect.Uint, reflect.Uint64:
+		return &jsonSchema{Type: "integer", Format: "int64"}
+	case reflect.Float32, reflect.Float64:
+		return &jsonSchema{Type: "number", Format: "double"}
+	case reflect.Slice, reflect.Array:
+		return &jsonSchema{Type: "array", Items: g.schemaOf(t.Elem())}
+	case reflect.Map:
+		return &jsonSchema{Type: "object", AdditionalProperties: true}
+	case reflect.Struct:
+		return g.structSchema(t)
+	default:
+		// Any JSON value, like the payloads of the Events.
+		return &jsonSchema{}
+	}
+}
+
+// structSchema returns a reference to the component describing the struct.
+func (g schemaGenerator) structSchema(t reflect.Type) *jsonSchema {
+	ref := &jsonSchema{Ref: schemaRefPrefix + t.Name()}
+	if _, ok := g.components[t.Name()]; ok {
+		return ref
+	}
+
+	s := &jsonSchema{
+		Type:                 "object",
+		Properties:           make(map[string]*jsonSchema),
+		AdditionalProperties: false,
+	}
+
+	// Registered before the fields, in case they refer to the struct itself.
+	g.components[t.Name()] = s
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "-" || field.PkgPath != "" {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		property := g.schemaOf(field.Type)
+		if constrain(property, field.Tag.Get("openapi")) {
+			s.Required = append(s.Required, name)
+		}
+
+		s.Properties[name] = property
+	}
+
+	// Thresholds can also be specified as bare percentages.
+	if t == thresholdType {
+		g.components[t.Name()] = &jsonSchema{
+			Description: "A threshold, or a bare number meaning a percentage of the spending limit.",
+			OneOf:       []*jsonSchema{s, {Type: "number", Minimum: s.Properties["value"].Minimum}},
+		}
+	}
+
+	return ref
+}
+
+// constrain applies the constraints of an openapi struct tag to the schema,
+// returning true if the field is required.
+func constrain(s *jsonSchema, tag string) (required bool) {
+	if tag == "" {
+		return false
+	}
+
+	for _, option := range strings.Split(tag, ",") {
+		key, value := option, ""
+		if i := strings.Index(option, "="); i >= 0 {
+			key, value = option[:i], option[i+1:]
+		}
+
+		switch key {
+		case "required":
+			required = true
+		case "minimum":
+			s.Minimum = mustParseFloat(value)
+		case "maximum":
+			s.Maximum = mustParseFloat(value)
+		case "enum":
+			s.Enum = strings.Split(value, "|")
+		case "format":
+			s.Format = value
+		default:
+			panic(fmt.Sprintf("httpapi: unknown openapi tag option %q", option))
+		}
+	}
+
+	return required
+}
+
+func mustParseFloat(s string) *float64 {
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		panic(fmt.Sprintf("httpapi: invalid number %q in openapi tag: %s", s, err))
+	}
+
+	return &v
+}
+
+var (
+	specOnce sync.Once
+	spec     *openAPIDocument
+)
+
+// openAPISpec returns the OpenAPI document of the HTTP API,
+// generated from the endpoints table the first time it is used.
+func openAPISpec() *openAPIDocument {
+	specOnce.Do(func() {
+		spec = newOpenAPIDocument(endpoints)
+	})
+
+	return spec
+}
+
+func newOpenAPIDocument(endpoints []endpoint) *openAPIDocument {
+	g := schemaGenerator{components: make(map[string]*jsonSchema)}
+	bearer := []map[string][]string{{"bearer": {}}}
+
+	doc := &openAPIDocument{
+		OpenAPI: "3.0.3",
+		Info: openAPIInfo{
+			Title: "Saving Goals API",
+			Description: "The errors are returned as application/problem+json documents, " +
+				"whose stable code tells them apart.",
+			Version: v1.Version,
+		},
+		Paths: make(map[string]map[string]*operation),
+		Components: openAPIComponents{
+			Schemas: g.components,
+			SecuritySchemes: map[string]securityScheme{
+				"bearer": {Type: "http", Scheme: "bearer", BearerFormat: "JWT"},
+			},
+		},
+		Security: bearer,
+	}
+
+	problemResponse := response{
+		Description: "The request failed.",
+		Content:     map[string]mediaType{problem.ContentType: {Schema: g.schemaOf(reflect.TypeOf(problem.Problem{}))}},
+	}
+
+	g.components["Problem"].Properties["code"].Enum = problemCodes()
+
+	for _, e := range endpoints {
+		op := &operation{
+			OperationID: e.id,
+			Summary:     e.summary,
+			Tags:        []string{e.tag},
+			Responses: map[string]response{
+				strconv.Itoa(e.status): e.successResponse(g),
+				"default":              problemResponse,
+			},
+		}
+
+		for _, name := range e.pathParameters() {
+			op.Parameters = append(op.Parameters, parameter{
+				Name:     name,
+				In:       "path",
+				Required: true,
+				Schema:   pathParameterSchema(name),
+			})
+		}
+
+		for _, q := range e.query {
+			op.Parameters = append(op.Parameters, parameter{
+				Name:        q.name,
+				In:          "query",
+				Description: q.description,
+				Schema:      q.schema,
+			})
+		}
+
+		if e.public {
+			op.Security = []map[string][]string{}
+		} else {
+			op.Responses[strconv.Itoa(http.StatusUnauthorized)] = problemResponse
+			op.Responses[strconv.Itoa(http.StatusForbidden)] = problemResponse
+
+			if e.method != http.MethodGet {
+				op.Parameters = append(op.Parameters, parameter{
+					Name:        idempotency.KeyHeader,
+					In:          "header",
+					Description: "Key to safely retry the request: the retries get the response of the first one.",
+					Schema:      &jsonSchema{Type: "string"},
+				})
+			}
+		}
+
+		if e.request != nil {
+			op.RequestBody = &requestBody{
+				Required: !e.optionalRequest,
+				Content:  map[string]mediaType{"application/json": {Schema: g.schemaOf(reflect.TypeOf(e.request))}},
+			}
+		}
+
+		if doc.Paths[e.path] == nil {
+			doc.Paths[e.path] = make(map[string]*operation)
+		}
+
+		doc.Paths[e.path][strings.ToLower(e.method)] = op
+	}
+
+	return doc
+}
+
+func (e endpoint) successResponse(g schemaGenerator) response {
+	r := response{Description: http.StatusText(e.status)}
+
+	var schema *jsonSchema
+
+	switch v := e.response.(type) {
+	case nil:
+		return r
+	case alternatives:
+		schema = &jsonSchema{}
+		for _, alternative := range v {
+			schema.OneOf = append(schema.OneOf, g.schemaOf(reflect.TypeOf(alternative)))
+		}
+	default:
+		schema = g.schemaOf(reflect.TypeOf(v))
+	}
+
+	r.Content = map[string]mediaType{"application/json": {Schema: schema}}
+
+	return r
+}
+
+// pathParameters returns the names of the path parameters of the endpoint.
+func (e endpoint) pathParameters() []string {
+	var names []string
+
+	for _, segment := range strings.Split(e.path, "/") {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			names = append(names, segment[1:len(segment)-1])
+		}
+	}
+
+	return names
+}
+
+func pathParameterSchema(name string) *jsonSchema {
+	switch name {
+	case "year":
+		return &jsonSchema{Type: "integer", Format: "int32"}
+	case "month":
+		return &jsonSchema{Type: "integer", Format: "int32", Minimum: mustParseFloat("1"), Maximum: mustParseFloat("12")}
+	default:
+		return &jsonSchema{Type: "string"}
+	}
+}
+
+// problemCodes returns the codes of all the Problems returned by the API.
+func problemCodes() []string {
+	problems := []problem.Problem{
+		malformedRequest, invalidRequest, invalidCommand, unauthenticated, forbidden,
+		notFound, savingGoalNotFound, concurrencyConflict, internalError,
+	}
+
+	for _, dp := range domainProblems {
+		problems = append(problems, dp.problem)
+	}
+
+	problems = append(problems, idempotency.Problems()...)
+
+	seen := make(map[string]bool)
+	codes := make([]string, 0, len(problems))
+
+	for _, p := range problems {
+		if !seen[p.Code] {
+			seen[p.Code] = true
+			codes = append(codes, p.Code)
+		}
+	}
+
+	sort.Strings(codes)
+
+	return codes
+}
+
+// openAPIHandler serves the OpenAPI document of the HTTP API.
+func openAPIHandler(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, openAPISpec())
+}
@@ -0,0 +1,159 @@
+package httpapi_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/eventually-rs/saving-goals-go/internal/problem"
+
+	"github.com/go-chi/chi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpenAPIDocument(t *testing.T) {
+	router := newRouter(nil, nil)
+
+	// The document is public.
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+
+	if !assert.Equal(t, http.StatusOK, w.Code) {
+		return
+	}
+
+	var doc struct {
+		OpenAPI string                                `json:"openapi"`
+		Paths   map[string]map[string]json.RawMessage `json:"paths"`
+	}
+
+	if !assert.NoError(t, json.NewDecoder(w.Body).Decode(&doc)) {
+		return
+	}
+
+	assert.Equal(t, "3.0.3", doc.OpenAPI)
+
+	t.Run("every route is documented, and every documented route exists", func(t *testing.T) {
+		var routes, documented []string
+
+		err := chi.Walk(router.(chi.Routes), func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+			if route != "/" {
+				route = strings.TrimSuffix(route, "/")
+			}
+
+			routes = append(routes, method+" "+route)
+			return nil
+		})
+
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		for path, operations := range doc.Paths {
+			for method := range operations {
+				documented = append(documented, strings.ToUpper(method)+" "+path)
+			}
+		}
+
+		assert.ElementsMatch(t, routes, documented)
+	})
+}
+
+func TestRequestValidation(t *testing.T) {
+	testcases := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		status int
+		errors []problem.FieldError
+	}{
+		{
+			name:   "valid requests are accepted",
+			method: http.MethodPost,
+			path:   "/accounts/test-account/change-saving-goal",
+			body:   `{"amount":500,"thresholds":[{"kind":"spent-above","value":300}]}`,
+			status: http.StatusAccepted,
+		},
+		{
+			name:   "thresholds can be bare percentages",
+			method: http.MethodPost,
+			path:   "/accounts/test-account/set-new-threshold",
+			body:   `{"threshold":0.5}`,
+			status: http.StatusAccepted,
+		},
+		{
+			name:   "unknown fields are rejected",
+			method: http.MethodPost,
+			path:   "/accounts/test-account/change-saving-goal",
+			body:   `{"amount":500,"currency":"EUR"}`,
+			status: http.StatusBadRequest,
+			errors: []problem.FieldError{{Field: "currency", Reason: "is not a known field"}},
+		},
+		{
+			name:   "negative amounts are rejected",
+			method: http.MethodPut,
+			path:   "/accounts/test-account/budgets/groceries",
+			body:   `{"amount":-100}`,
+			status: http.StatusBadRequest,
+			errors: []problem.FieldError{{Field: "amount", Reason: "should be at least 0"}},
+		},
+		{
+			name:   "required fields are reported",
+			method: http.MethodPost,
+			path:   "/accounts/test-account/goals",
+			body:   `{"deadline":"next year"}`,
+			status: http.StatusBadRequest,
+			errors: []problem.FieldError{
+				{Field: "deadline", Reason: "should be formatted as YYYY-MM-DD"},
+				{Field: "name", Reason: "should be specified"},
+				{Field: "targetAmount", Reason: "should be specified"},
+			},
+		},
+		{
+			name:   "nested fields are reported with their path",
+			method: http.MethodPut,
+			path:   "/accounts/test-account/thresholds",
+			body:   `{"thresholds":[0.5,{"kind":"spent-below","value":100},"80%"]}`,
+			status: http.StatusBadRequest,
+			errors: []problem.FieldError{
+				{Field: "thresholds[1].kind", Reason: "should be one of percentage, spent-above, remaining-below"},
+				{Field: "thresholds[2]", Reason: "should be an object or number"},
+			},
+		},
+		{
+			name:   "bodies of the wrong type are rejected",
+			method: http.MethodPut,
+			path:   "/accounts/test-account/pacing",
+			body:   `"linear"`,
+			status: http.StatusBadRequest,
+			errors: []problem.FieldError{{Field: "body", Reason: "should be an object"}},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			router := newRouter(nil, nil)
+
+			r := httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body))
+			r.Header.Set("Authorization", "Bearer test-token")
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, r)
+
+			if !assert.Equal(t, tc.status, w.Code, w.Body.String()) || tc.status < http.StatusBadRequest {
+				return
+			}
+
+			var p problem.Problem
+			if !assert.NoError(t, json.NewDecoder(w.Body).Decode(&p)) {
+				return
+			}
+
+			assert.Equal(t, "invalid_request", p.Code)
+			assert.Equal(t, tc.errors, p.Errors)
+		})
+	}
+}
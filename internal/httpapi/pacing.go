@@ -0,0 +1,95 @@
+package httpapi
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/account"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/interval"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/monthly"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/pacing"
+	v1 "github.com/eventually-rs/saving-goals-go/pkg/api/v1"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+	"github.com/eventually-rs/eventually-go/command"
+	"github.com/go-chi/chi"
+)
+
+// allowanceDateFormat is the format of the optional "date" query parameter
+// used to request the allowance of a specific day.
+const allowanceDateFormat = "2006-01-02"
+
+func allowanceFromDomain(accountID string, allowance pacing.Allowance) v1.Allowance {
+	return v1.Allowance{
+		AccountID:        accountID,
+		Date:             allowance.Date.Format(allowanceDateFormat),
+		Strategy:         string(allowance.Strategy),
+		SpendingLimit:    allowance.SpendingLimit,
+		Spent:            allowance.Spent,
+		Expected:         allowance.Expected,
+		Remaining:        allowance.Remaining,
+		SafeToSpendToday: allowance.SafeToSpendToday,
+		Pace:             string(allowance.Status),
+	}
+}
+
+func changePacingStrategyHandler(commandBus command.Dispatcher) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		accountID := chi.URLParam(r, "accountId")
+
+		var request v1.PacingStrategyRequest
+		if err := decodeJSON(r, &request); err != nil {
+			writeError(w, r, err)
+			return
+		}
+
+		err := commandBus.Dispatch(ctx, eventually.Command{
+			Payload: account.ChangePacingStrategy{
+				AccountID: aggregate.StringID(accountID),
+				Strategy:  pacing.Strategy(request.Strategy),
+			},
+		})
+
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusAccepted)
+	}
+}
+
+func getAllowanceHandler(queryBus QueryDispatcher) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		accountID := chi.URLParam(r, "accountId")
+
+		date := time.Now()
+
+		if value := r.URL.Query().Get("date"); value != "" {
+			d, err := time.Parse(allowanceDateFormat, value)
+			if err != nil {
+				writeError(w, r, invalidParameter("date", err))
+				return
+			}
+
+			date = d
+		}
+
+		answer, err := queryBus.Dispatch(ctx, monthly.ProgressQuery{
+			AccountID: accountID,
+			Month:     interval.MonthFromTime(date),
+		})
+
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
+
+		progress := answer.(monthly.Progress)
+
+		writeJSON(w, http.StatusOK, allowanceFromDomain(accountID, progress.Allowance(date)))
+	}
+}
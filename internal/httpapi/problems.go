@@ -0,0 +1,317 @@
+package httpapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/eventually-rs/saving-goals-go/internal/admin"
+	"github.com/eventually-rs/saving-goals-go/internal/commandbus"
+	"github.com/eventually-rs/saving-goals-go/internal/concurrency"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/account"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/category"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/goal"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/household"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/monthly"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/pacing"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/saving"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/sweep"
+	"github.com/eventually-rs/saving-goals-go/internal/inspector"
+	"github.com/eventually-rs/saving-goals-go/internal/problem"
+	"github.com/eventually-rs/saving-goals-go/internal/schema"
+	"github.com/eventually-rs/saving-goals-go/internal/timetravel"
+
+	"github.com/eventually-rs/eventually-go/aggregate"
+	"go.uber.org/zap"
+)
+
+// The problems not caused by a specific domain error.
+var (
+	malformedRequest    = problem.New(http.StatusBadRequest, "malformed_request", "Malformed request")
+	invalidRequest      = problem.New(http.StatusBadRequest, "invalid_request", "Request body not valid")
+	invalidCommand      = problem.New(http.StatusBadRequest, "invalid_command", "Required fields not specified")
+	unauthenticated     = problem.New(http.StatusUnauthorized, "unauthenticated", "Authentication required")
+	forbidden           = problem.New(http.StatusForbidden, "forbidden", "Not allowed")
+	notFound            = problem.New(http.StatusNotFound, "not_found", "Resource not found")
+	savingGoalNotFound  = problem.New(http.StatusNotFound, "saving_goal_not_found", "Saving goal not set")
+	concurrencyConflict = problem.New(http.StatusConflict, "concurrency_conflict",
+		"The resource has been changed by another request, retry")
+	internalError = problem.New(http.StatusInternalServerError, "internal_error", "Internal error")
+)
+
+// domainProblem is the Problem returned for a domain error,
+// with the request field causing it, when there is only one.
+type domainProblem struct {
+	err     error
+	field   string
+	problem problem.Problem
+}
+
+// domainProblems lists the domain errors returned to the clients: the codes
+// are part of the API, so they should not change once released.
+//
+// The first matching error wins, so the more specific errors come first.
+var domainProblems = []domainProblem{
+	// Validation of the requests.
+	{err: account.ErrAtLeastOneThreshold, field: "thresholds", problem: problem.New(http.StatusBadRequest,
+		"at_least_one_threshold", "At least one threshold should be specified")},
+	{err: household.ErrAtLeastOneThreshold, field: "thresholds", problem: problem.New(http.StatusBadRequest,
+		"at_least_one_threshold", "At least one threshold should be specified")},
+	{err: account.ErrGoalIsZero, field: "amount", problem: problem.New(http.StatusBadRequest,
+		"saving_goal_is_zero", "Saving goal amount should be more than zero")},
+	{err: household.ErrGoalIsZero, field: "amount", problem: problem.New(http.StatusBadRequest,
+		"saving_goal_is_zero", "Saving goal amount should be more than zero")},
+	{err: saving.ErrInvalidThreshold, problem: problem.New(http.StatusBadRequest,
+		"invalid_threshold", "Invalid threshold")},
+	{err: account.ErrNoSavingGoal, problem: problem.New(http.StatusBadRequest,
+		"no_saving_goal", "Saving goal not set")},
+	{err: account.ErrBudgetWithoutCategory, field: "category", problem: problem.New(http.StatusBadRequest,
+		"budget_without_category", "Budget category should be specified")},
+	{err: account.ErrBudgetIsZero, field: "amount", problem: problem.New(http.StatusBadRequest,
+		"budget_is_zero", "Budget amount should be more than zero")},
+	{err: account.ErrContributionIsZero, field: "amount", problem: problem.New(http.StatusBadRequest,
+		"contribution_is_zero", "Contribution amount should not be zero")},
+	{err: account.ErrNoGoalID, field: "id", problem: problem.New(http.StatusBadRequest,
+		"invalid_goal", "Goal id should be specified")},
+	{err: goal.ErrNoName, field: "name", problem: problem.New(http.StatusBadRequest,
+		"invalid_goal", "Goal name should be specified")},
+	{err: goal.ErrTargetIsZero, field: "targetAmount", problem: problem.New(http.StatusBadRequest,
+		"invalid_goal", "Goal target amount should be more than zero")},
+	{err: goal.ErrNegativeContribution, field: "monthlyContribution", problem: problem.New(http.StatusBadRequest,
+		"invalid_goal", "Goal monthly contribution should not be negative")},
+	{err: goal.ErrNoContribution, problem: problem.New(http.StatusBadRequest,
+		"invalid_goal", "Goal should have either a deadline or a monthly contribution")},
+	{err: account.ErrNoRuleID, field: "id", problem: problem.New(http.StatusBadRequest,
+		"invalid_categorization_rule", "Rule id should be specified")},
+	{err: category.ErrNoCategory, field: "category", problem: problem.New(http.StatusBadRequest,
+		"invalid_categorization_rule", "Rule category should be specified")},
+	{err: category.ErrNoCriteria, problem: problem.New(http.StatusBadRequest,
+		"invalid_categorization_rule", "At least one matching criteria should be specified")},
+	{err: category.ErrInvalidDescriptionPattern, field: "descriptionPattern", problem: problem.New(http.StatusBadRequest,
+		"invalid_categorization_rule", "Description pattern is not a valid regular expression")},
+	{err: category.ErrInvalidAmountRange, problem: problem.New(http.StatusBadRequest,
+		"invalid_categorization_rule", "Minimum amount should not be higher than maximum amount")},
+	{err: account.ErrNoSweepRuleID, field: "id", problem: problem.New(http.StatusBadRequest,
+		"invalid_sweep_rule", "Rule id should be specified")},
+	{err: sweep.ErrUnknownKind, field: "kind", problem: problem.New(http.StatusBadRequest,
+		"invalid_sweep_rule", "Unknown sweep rule kind")},
+	{err: sweep.ErrNoGoal, field: "goalId", problem: problem.New(http.StatusBadRequest,
+		"invalid_sweep_rule", "Goal should be specified")},
+	{err: sweep.ErrInvalidUnit, field: "unit", problem: problem.New(http.StatusBadRequest,
+		"invalid_sweep_rule", "Round-up unit should be more than zero")},
+	{err: sweep.ErrInvalidPercentage, field: "percentage", problem: problem.New(http.StatusBadRequest,
+		"invalid_sweep_rule", "Percentage should be more than 0 and at most 1")},
+	{err: pacing.ErrUnknownStrategy, field: "strategy", problem: problem.New(http.StatusBadRequest,
+		"unknown_pacing_strategy", "Unknown pacing strategy")},
+	{err: household.ErrNoFounder, field: "accountId", problem: problem.New(http.StatusBadRequest,
+		"no_founder", "Founding account should be specified")},
+	{err: timetravel.ErrInvalidPoint, problem: problem.New(http.StatusBadRequest,
+		"invalid_point_in_time", "Invalid point in time")},
+	{err: timetravel.ErrTimeNotRecorded, problem: problem.New(http.StatusBadRequest,
+		"point_in_time_not_recorded", "Point in time earlier than the recorded history")},
+	{err: schema.ErrUnknownEvent, field: "type", problem: problem.New(http.StatusBadRequest,
+		"unknown_event", "Unknown event type")},
+
+	// Resources not found.
+	{err: account.ErrNotFound, problem: problem.New(http.StatusNotFound,
+		"account_not_found", "Account not found")},
+	{err: account.ErrGoalNotFound, problem: problem.New(http.StatusNotFound,
+		"goal_not_found", "Goal not found")},
+	{err: account.ErrThresholdNotFound, problem: problem.New(http.StatusNotFound,
+		"threshold_not_found", "Threshold not found")},
+	{err: account.ErrBudgetNotFound, problem: problem.New(http.StatusNotFound,
+		"budget_not_found", "Budget not found")},
+	{err: account.ErrRuleNotFound, problem: problem.New(http.StatusNotFound,
+		"categorization_rule_not_found", "Categorization rule not found")},
+	{err: account.ErrTransactionNotFound, problem: problem.New(http.StatusNotFound,
+		"transaction_not_found", "Transaction not found")},
+	{err: account.ErrSeriesNotFound, problem: problem.New(http.StatusNotFound,
+		"recurring_series_not_found", "Recurring series not found")},
+	{err: account.ErrSweepRuleNotFound, problem: problem.New(http.StatusNotFound,
+		"sweep_rule_not_found", "Sweep rule not found")},
+	{err: household.ErrNotFound, problem: problem.New(http.StatusNotFound,
+		"household_not_found", "Household not found")},
+	{err: household.ErrNotInvited, problem: problem.New(http.StatusNotFound,
+		"invitation_not_found", "Account has not been invited")},
+	{err: monthly.ErrProgressNotFound, problem: problem.New(http.StatusNotFound,
+		"month_not_found", "Month not found")},
+	{err: inspector.ErrUnknownStreamType, problem: problem.New(http.StatusNotFound,
+		"unknown_stream_type", "Unknown stream type")},
+	{err: timetravel.ErrUnknownType, problem: problem.New(http.StatusNotFound,
+		"unknown_aggregate_type", "Unknown aggregate type")},
+	{err: admin.ErrUnknownSubscription, problem: problem.New(http.StatusNotFound,
+		"subscription_not_found", "Subscription not found")},
+	{err: aggregate.ErrRootNotFound, problem: notFound},
+
+	// Requests conflicting with the state of the resources.
+	{err: account.ErrAccountClosed, problem: problem.New(http.StatusConflict,
+		"account_closed", "Account is closed")},
+	{err: account.ErrAccountFrozen, problem: problem.New(http.StatusConflict,
+		"account_frozen", "Account is frozen")},
+	{err: account.ErrThresholdAlreadyExists, problem: problem.New(http.StatusConflict,
+		"threshold_already_exists", "Threshold already exists")},
+	{err: account.ErrGoalAlreadyExists, problem: problem.New(http.StatusConflict,
+		"goal_already_exists", "Goal already exists")},
+	{err: account.ErrRuleAlreadyExists, problem: problem.New(http.StatusConflict,
+		"categorization_rule_already_exists", "Categorization rule already exists")},
+	{err: account.ErrSweepRuleAlreadyExists, problem: problem.New(http.StatusConflict,
+		"sweep_rule_already_exists", "Sweep rule already exists")},
+	{err: household.ErrAlreadyMember, problem: problem.New(http.StatusConflict,
+		"already_member", "Account is already a member")},
+	{err: household.ErrAlreadyInvited, problem: problem.New(http.StatusConflict,
+		"already_invited", "Account has already been invited")},
+	{err: admin.ErrNotPolicy, problem: problem.New(http.StatusConflict,
+		"not_policy", "Subscription is not a policy")},
+	{err: admin.ErrNotReadModel, problem: problem.New(http.StatusConflict,
+		"not_read_model", "Subscription is not a read model")},
+	{err: admin.ErrRebuildInProgress, problem: problem.New(http.StatusConflict,
+		"rebuild_in_progress", "Rebuild already in progress")},
+
+	// Callers not allowed to perform the request.
+	{err: household.ErrNotMember, problem: problem.New(http.StatusForbidden,
+		"not_member", "Account is not a member of the household")},
+	{err: commandbus.ErrUnauthorized, problem: forbidden},
+}
+
+// requestError is returned when a parameter or the body
+// of the request can not be parsed.
+type requestError struct {
+	// field is empty when the whole body is malformed.
+	field  string
+	reason string
+	err    error
+}
+
+func (e *requestError) Error() string {
+	if e.field == "" {
+		return e.reason
+	}
+
+	return e.field + ": " + e.reason
+}
+
+func (e *requestError) Unwrap() error { return e.err }
+
+// invalidParameter returns the error of a path or query parameter
+// which can not be parsed.
+func invalidParameter(name string, err error) error {
+	return &requestError{field: name, reason: "invalid value", err: err}
+}
+
+// problemFor returns the Problem describing the error to the clients,
+// without any detail of the implementation.
+func problemFor(err error) problem.Problem {
+	var (
+		requestErr    *requestError
+		validationErr *commandbus.ValidationError
+		schemaErr     *schemaError
+	)
+
+	if errors.As(err, &schemaErr) {
+		return invalidRequest.WithErrors(schemaErr.errs...)
+	}
+
+	if errors.As(err, &validationErr) {
+		errs := make([]problem.FieldError, 0, len(validationErr.Fields))
+		for _, f := range validationErr.Fields {
+			errs = append(errs, problem.FieldError{Field: jsonFieldName(f.Field), Reason: f.Reason})
+		}
+
+		return invalidCommand.WithErrors(errs...)
+	}
+
+	for _, dp := range domainProblems {
+		if !errors.Is(err, dp.err) {
+			continue
+		}
+
+		p := dp.problem
+		if errors.As(err, &requestErr) && requestErr.field != "" {
+			return p.WithErrors(problem.FieldError{Field: requestErr.field, Reason: p.Title})
+		}
+
+		if dp.field != "" {
+			p = p.WithErrors(problem.FieldError{Field: dp.field, Reason: p.Title})
+		}
+
+		return p
+	}
+
+	switch {
+	case errors.As(err, &requestErr) && requestErr.field != "":
+		return malformedRequest.WithErrors(problem.FieldError{Field: requestErr.field, Reason: requestErr.reason})
+	case errors.As(err, &requestErr):
+		return malformedRequest.WithDetail(requestErr.reason)
+	case concurrency.IsConflict(err):
+		return concurrencyConflict
+	default:
+		return internalError
+	}
+}
+
+// writeError writes the Problem describing the error as the response,
+// logging the unexpected errors, whose details are not sent to the clients.
+func writeError(w http.ResponseWriter, r *http.Request, err error) {
+	p := problemFor(err)
+
+	if p.Status >= http.StatusInternalServerError {
+		loggerFrom(r.Context()).Error("Request failed",
+			zap.String("method", r.Method),
+			zap.String("path", r.URL.Path),
+			zap.Error(err))
+	}
+
+	problem.Write(w, p)
+}
+
+// jsonFieldName returns the name of a Command field in the requests,
+// like accountId for AccountID.
+func jsonFieldName(name string) string {
+	if name == "" {
+		return name
+	}
+
+	if strings.HasSuffix(name, "ID") {
+		name = strings.TrimSuffix(name, "ID") + "Id"
+	}
+
+	return strings.ToLower(name[:1]) + name[1:]
+}
+
+// jsonTypeName returns the name of the JSON type decoded in a Go type.
+func jsonTypeName(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Bool:
+		return "boolean"
+	case reflect.String:
+		return "string"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	case reflect.Map, reflect.Struct:
+		return "object"
+	default:
+		return "number"
+	}
+}
+
+type loggerKey struct{}
+
+// withLogger carries the logger in the context of the requests,
+// to log the errors not returned to the clients.
+func withLogger(logger *zap.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), loggerKey{}, logger)))
+		})
+	}
+}
+
+func loggerFrom(ctx context.Context) *zap.Logger {
+	if logger, ok := ctx.Value(loggerKey{}).(*zap.Logger); ok {
+		return logger
+	}
+
+	return zap.NewNop()
+}
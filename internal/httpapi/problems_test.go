@@ -0,0 +1,194 @@
+package httpapi_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/eventually-rs/saving-goals-go/internal/commandbus"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/account"
+	"github.com/eventually-rs/saving-goals-go/internal/httpapi"
+	"github.com/eventually-rs/saving-goals-go/internal/problem"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/query"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+type authenticator struct{}
+
+func (authenticator) Authenticate(ctx context.Context, token string) (commandbus.Caller, error) {
+	if token != "test-token" {
+		return commandbus.Caller{}, errors.New("auth.Authenticator: invalid token")
+	}
+
+	return commandbus.Caller{ID: "alice", Accounts: []string{"test-account"}}, nil
+}
+
+type queryBus struct{ err error }
+
+func (qb queryBus) Dispatch(context.Context, query.Query) (query.Answer, error) {
+	return nil, qb.err
+}
+
+func newRouter(commandErr, queryErr error) http.Handler {
+	commandBus := commandbus.DispatcherFunc(func(context.Context, eventually.Command) error {
+		return commandErr
+	})
+
+	noIdempotency := func(next http.Handler) http.Handler { return next }
+
+	return httpapi.NewRouter(commandBus, queryBus{err: queryErr}, nil, nil, authenticator{}, noIdempotency, zap.NewNop())
+}
+
+func TestErrorResponses(t *testing.T) {
+	testcases := []struct {
+		name       string
+		commandErr error
+		queryErr   error
+		method     string
+		path       string
+		token      string
+		body       string
+		status     int
+		code       string
+		errors     []problem.FieldError
+	}{
+		{
+			name:       "domain errors have a stable code",
+			commandErr: fmt.Errorf("account.ChangeSavingGoalCommandHandler: failed to apply: %w", account.ErrGoalIsZero),
+			method:     http.MethodPost,
+			path:       "/accounts/test-account/change-saving-goal",
+			body:       `{"amount":0}`,
+			status:     http.StatusBadRequest,
+			code:       "saving_goal_is_zero",
+			errors:     []problem.FieldError{{Field: "amount", Reason: "Saving goal amount should be more than zero"}},
+		},
+		{
+			name:       "saving goal not set",
+			commandErr: fmt.Errorf("account.SetNewThresholdCommandHandler: failed to apply: %w", account.ErrNoSavingGoal),
+			method:     http.MethodPost,
+			path:       "/accounts/test-account/set-new-threshold",
+			body:       `{"threshold":0.5}`,
+			status:     http.StatusBadRequest,
+			code:       "no_saving_goal",
+		},
+		{
+			name:     "resources not found",
+			queryErr: fmt.Errorf("account.ViewQueryHandler: failed to get account: %w", account.ErrNotFound),
+			method:   http.MethodGet,
+			path:     "/accounts/test-account",
+			status:   http.StatusNotFound,
+			code:     "account_not_found",
+		},
+		{
+			name: "invalid commands report the missing fields",
+			commandErr: &commandbus.ValidationError{
+				Command: account.AddGoal{},
+				Fields:  commandbus.FieldErrors{{Field: "AccountID", Reason: "should be specified"}},
+			},
+			method: http.MethodPost,
+			path:   "/accounts/test-account/goals",
+			body:   `{"name":"Holidays","targetAmount":1000,"monthlyContribution":100}`,
+			status: http.StatusBadRequest,
+			code:   "invalid_command",
+			errors: []problem.FieldError{{Field: "accountId", Reason: "should be specified"}},
+		},
+		{
+			name:       "concurrency conflicts",
+			commandErr: fmt.Errorf("account.RemoveGoalCommandHandler: failed to save account: %w", errors.New("inmemory: invalid version check")),
+			method:     http.MethodDelete,
+			path:       "/accounts/test-account/goals/test-goal",
+			status:     http.StatusConflict,
+			code:       "concurrency_conflict",
+		},
+		{
+			name:   "malformed bodies",
+			method: http.MethodPost,
+			path:   "/accounts/test-account/goals/test-goal/contributions",
+			body:   `{"amount":`,
+			status: http.StatusBadRequest,
+			code:   "malformed_request",
+		},
+		{
+			name:   "bodies not matching the schema",
+			method: http.MethodPost,
+			path:   "/accounts/test-account/goals/test-goal/contributions",
+			body:   `{"amount":"ten"}`,
+			status: http.StatusBadRequest,
+			code:   "invalid_request",
+			errors: []problem.FieldError{{Field: "amount", Reason: "should be a number"}},
+		},
+		{
+			name:   "invalid parameters",
+			method: http.MethodGet,
+			path:   "/accounts/test-account/months/2021/13",
+			status: http.StatusBadRequest,
+			code:   "malformed_request",
+			errors: []problem.FieldError{{Field: "month", Reason: "invalid value"}},
+		},
+		{
+			name:   "callers not authenticated",
+			method: http.MethodGet,
+			path:   "/accounts/test-account",
+			token:  "invalid-token",
+			status: http.StatusUnauthorized,
+			code:   "unauthenticated",
+		},
+		{
+			name:   "callers not allowed",
+			method: http.MethodGet,
+			path:   "/accounts/other-account",
+			status: http.StatusForbidden,
+			code:   "forbidden",
+		},
+		{
+			name:       "unexpected errors",
+			commandErr: errors.New("account.Repository: failed to connect to postgres"),
+			method:     http.MethodPost,
+			path:       "/accounts/test-account/freeze",
+			body:       `{}`,
+			status:     http.StatusInternalServerError,
+			code:       "internal_error",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			router := newRouter(tc.commandErr, tc.queryErr)
+
+			if tc.token == "" {
+				tc.token = "test-token"
+			}
+
+			r := httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body))
+			r.Header.Set("Authorization", "Bearer "+tc.token)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, tc.status, w.Code)
+			assert.Equal(t, problem.ContentType, w.Header().Get("Content-Type"))
+
+			// The internal error messages are not sent to the clients.
+			assert.NotContains(t, w.Body.String(), "account.")
+
+			var p problem.Problem
+			if !assert.NoError(t, json.NewDecoder(w.Body).Decode(&p)) {
+				return
+			}
+
+			assert.Equal(t, tc.status, p.Status)
+			assert.Equal(t, tc.code, p.Code)
+			assert.Equal(t, problem.TypePrefix+tc.code, p.Type)
+			assert.NotEmpty(t, p.Title)
+			assert.Equal(t, tc.errors, p.Errors)
+		})
+	}
+}
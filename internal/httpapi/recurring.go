@@ -0,0 +1,90 @@
+package httpapi
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/account"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/recurring"
+	v1 "github.com/eventually-rs/saving-goals-go/pkg/api/v1"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+	"github.com/eventually-rs/eventually-go/command"
+	"github.com/go-chi/chi"
+)
+
+func recurringSeriesFromDomain(series []recurring.Series) []v1.RecurringSeries {
+	response := make([]v1.RecurringSeries, 0, len(series))
+
+	for _, s := range series {
+		response = append(response, v1.RecurringSeries{
+			ID:         s.ID,
+			Name:       s.Name,
+			Kind:       string(s.Kind),
+			Amount:     s.Amount,
+			Cadence:    string(s.Cadence),
+			LastSeenAt: s.LastSeenAt.Format(time.RFC3339),
+			Status:     string(s.Status),
+		})
+	}
+
+	return response
+}
+
+func listRecurringSeriesHandler(queryBus QueryDispatcher) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		accountID := chi.URLParam(r, "accountId")
+
+		answer, err := queryBus.Dispatch(ctx, account.ViewQuery{AccountID: accountID})
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, recurringSeriesFromDomain(answer.(account.View).RecurringSeries))
+	}
+}
+
+func confirmRecurringSeriesHandler(commandBus command.Dispatcher) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		accountID := chi.URLParam(r, "accountId")
+
+		err := commandBus.Dispatch(ctx, eventually.Command{
+			Payload: account.ConfirmRecurringSeries{
+				AccountID: aggregate.StringID(accountID),
+				SeriesID:  chi.URLParam(r, "seriesId"),
+			},
+		})
+
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusAccepted)
+	}
+}
+
+func dismissRecurringSeriesHandler(commandBus command.Dispatcher) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		accountID := chi.URLParam(r, "accountId")
+
+		err := commandBus.Dispatch(ctx, eventually.Command{
+			Payload: account.DismissRecurringSeries{
+				AccountID: aggregate.StringID(accountID),
+				SeriesID:  chi.URLParam(r, "seriesId"),
+			},
+		})
+
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusAccepted)
+	}
+}
@@ -2,35 +2,162 @@ package httpapi
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
+	"expvar"
 	"io"
 	"net/http"
 
+	"github.com/eventually-rs/saving-goals-go/internal/auth"
 	"github.com/eventually-rs/saving-goals-go/pkg/zapchi"
 
 	"github.com/eventually-rs/eventually-go/command"
 	"github.com/eventually-rs/eventually-go/eventstore"
+	"github.com/eventually-rs/eventually-go/query"
 	"github.com/go-chi/chi"
 	"github.com/go-chi/chi/middleware"
 	"go.uber.org/zap"
 )
 
+// QueryDispatcher is the component used to dispatch Domain Queries
+// to serve the read endpoints of the HTTP API.
+type QueryDispatcher interface {
+	Dispatch(context.Context, query.Query) (query.Answer, error)
+}
+
 // NewRouter returns a new instance of the HTTP API router.
-func NewRouter(commandBus command.Dispatcher, monthStore eventstore.Typed, logger *zap.Logger) http.Handler {
+//
+// The idempotent middleware replays the responses of the write requests
+// retried with the same Idempotency-Key.
+func NewRouter(
+	commandBus command.Dispatcher,
+	queryBus QueryDispatcher,
+	monthStore eventstore.Typed,
+	subscriptions Subscriptions,
+	authenticator Authenticator,
+	idempotent func(http.Handler) http.Handler,
+	logger *zap.Logger,
+) http.Handler {
 	r := chi.NewRouter()
 
 	r.Use(middleware.RequestLogger(zapchi.UseLogger(logger)))
 	r.Use(middleware.Recoverer)
+	r.Use(withLogger(logger))
 
 	r.Get("/", func(w http.ResponseWriter, r *http.Request) {
 		io.Copy(w, bytes.NewBufferString("{\"message\": \"Hello world!\"}\n"))
 	})
 
-	r.Route("/accounts/{accountId}", func(r chi.Router) {
-		r.Post("/change-saving-goal", changeAccountSavingGoalHandler(commandBus))
-		r.Post("/set-new-threshold", setNewAccountSavingGoalThresholdHandler(commandBus))
-	})
+	// The OpenAPI document describing the endpoints is public.
+	r.Get("/openapi.json", openAPIHandler)
+
+	r.Group(func(r chi.Router) {
+		r.Use(authenticate(authenticator))
+
+		// Idempotency keys are scoped by caller, so they are checked after the authentication.
+		r.Use(idempotent)
+
+		r.Route("/accounts/{accountId}", func(r chi.Router) {
+			r.Use(requireAccountAccess("accountId"))
+
+			r.Get("/", getAccountHandler(queryBus))
+			r.Delete("/", forgetAccountHandler(commandBus))
+			r.Post("/close", closeAccountHandler(commandBus))
+			r.Post("/freeze", freezeAccountHandler(commandBus))
+			r.Post("/reopen", reopenAccountHandler(commandBus))
+
+			r.Post("/change-saving-goal", changeAccountSavingGoalHandler(commandBus))
+			r.Post("/set-new-threshold", setNewAccountSavingGoalThresholdHandler(commandBus))
+			r.Get("/thresholds", listThresholdsHandler(queryBus))
+			r.Put("/thresholds", replaceThresholdsHandler(commandBus))
+			r.Delete("/thresholds/{value}", removeThresholdHandler(commandBus))
+
+			r.Get("/goals", listGoalsHandler(queryBus))
+			r.Post("/goals", addGoalHandler(commandBus))
+			r.Get("/goals/{goalId}", getGoalHandler(queryBus))
+			r.Put("/goals/{goalId}", updateGoalHandler(commandBus))
+			r.Delete("/goals/{goalId}", removeGoalHandler(commandBus))
+			r.Post("/goals/{goalId}/contributions", recordGoalContributionHandler(commandBus))
+
+			r.Post("/recategorize-transactions", recategorizeTransactionsHandler(commandBus))
+
+			r.Get("/categorization-rules", listCategorizationRulesHandler(queryBus))
+			r.Post("/categorization-rules", addCategorizationRuleHandler(commandBus))
+			r.Delete("/categorization-rules/{ruleId}", removeCategorizationRuleHandler(commandBus))
+
+			r.Get("/budgets", listBudgetsHandler(queryBus))
+			r.Put("/budgets/{category}", setBudgetHandler(commandBus))
+			r.Delete("/budgets/{category}", removeBudgetHandler(commandBus))
+
+			r.Put("/pacing", changePacingStrategyHandler(commandBus))
+			r.Get("/allowance", getAllowanceHandler(queryBus))
+
+			r.Get("/recurring-series", listRecurringSeriesHandler(queryBus))
+			r.Post("/recurring-series/{seriesId}/confirm", confirmRecurringSeriesHandler(commandBus))
+			r.Post("/recurring-series/{seriesId}/dismiss", dismissRecurringSeriesHandler(commandBus))
+
+			r.Get("/sweep-rules", listSweepRulesHandler(queryBus))
+			r.Post("/sweep-rules", addSweepRuleHandler(commandBus))
+			r.Delete("/sweep-rules/{ruleId}", removeSweepRuleHandler(commandBus))
+			r.Get("/savings-transfers", listSavingsTransfersHandler(queryBus))
+
+			r.Get("/months/{year}/{month}", getMonthProgressHandler(queryBus))
+		})
 
-	r.Post("/internal/months/{year}/{month}/start", forceMonthCreation(monthStore))
+		r.Post("/households", createHouseholdHandler(commandBus))
+
+		r.Route("/households/{householdId}", func(r chi.Router) {
+			// The invitations and the members leaving are authorized by the Accounts they act upon.
+			r.Post("/invitations", inviteHouseholdMemberHandler(commandBus))
+			r.Post("/invitations/{accountId}/accept", acceptHouseholdInvitationHandler(commandBus))
+			r.Delete("/members/{accountId}", leaveHouseholdHandler(commandBus))
+
+			r.Group(func(r chi.Router) {
+				r.Use(requireHouseholdMembership(queryBus, "householdId"))
+
+				r.Get("/", getHouseholdHandler(queryBus))
+				r.Post("/change-saving-goal", changeHouseholdSavingGoalHandler(commandBus))
+				r.Get("/months/{year}/{month}", getHouseholdMonthProgressHandler(queryBus))
+			})
+		})
+
+		// The internal endpoints operate the whole application.
+		r.Route("/internal", func(r chi.Router) {
+			r.Use(requireRole(auth.RoleAdmin))
+
+			r.Post("/months/{year}/{month}/start", forceMonthCreation(monthStore))
+
+			// Metrics published with expvar, like the concurrency conflicts of the commands.
+			r.Get("/debug/vars", expvar.Handler().ServeHTTP)
+
+			r.Get("/aggregates/{type}/{id}/evolution", getAggregateEvolutionHandler(queryBus))
+			r.Get("/streams/{type}/{id}/events", listStreamEventsHandler(queryBus))
+
+			r.Get("/subscriptions", listSubscriptionsHandler(subscriptions))
+			r.Post("/subscriptions/{name}/reset", resetSubscriptionHandler(subscriptions))
+			r.Post("/subscriptions/{name}/rebuild", rebuildReadModelHandler(subscriptions))
+		})
+	})
 
 	return r
 }
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	// Errors here are caused by the client connection: since the status code
+	// has already been written, there is nothing else to do.
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeCommandResult writes the response of the endpoints dispatching
+// a Command, which is accepted unless it failed.
+func writeCommandResult(w http.ResponseWriter, r *http.Request, err error) {
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
@@ -0,0 +1,215 @@
+package httpapi
+
+import (
+	"net/http"
+
+	v1 "github.com/eventually-rs/saving-goals-go/pkg/api/v1"
+)
+
+// endpoint describes a route of the HTTP API in the OpenAPI document.
+//
+// Every route registered in NewRouter should have its endpoint here:
+// the tests fail when the routes and the document drift apart.
+type endpoint struct {
+	method  string
+	path    string
+	id      string
+	summary string
+	tag     string
+	query   []queryParameter
+
+	// request is a value of the type of the request body, if any.
+	request         interface{}
+	optionalRequest bool
+
+	status int
+
+	// response is a value of the type of the response body, if any.
+	response interface{}
+
+	// public endpoints do not require authentication.
+	public bool
+}
+
+type queryParameter struct {
+	name        string
+	description string
+	schema      *jsonSchema
+}
+
+// alternatives is the response of the endpoints answering
+// with one of different types of body.
+type alternatives []interface{}
+
+var asOfParameter = queryParameter{
+	name: "asOf",
+	description: "Returns the state at a point in time: a version number, an RFC 3339 timestamp, " +
+		"or a date in the YYYY-MM-DD format, meaning the end of that day in UTC. " +
+		"Events appended before their time was recorded are included at all the points in time " +
+		"after the first recorded one: earlier points in time are rejected.",
+	schema: &jsonSchema{Type: "string"},
+}
+
+var endpoints = []endpoint{
+	{method: http.MethodGet, path: "/", id: "hello", summary: "Checks that the API is up.", tag: "meta",
+		status: http.StatusOK, public: true},
+	{method: http.MethodGet, path: "/openapi.json", id: "getOpenAPIDocument", summary: "Returns this document.", tag: "meta",
+		status: http.StatusOK, response: map[string]interface{}{}, public: true},
+
+	// Accounts.
+	{method: http.MethodGet, path: "/accounts/{accountId}", id: "getAccount", summary: "Returns an Account.", tag: "accounts",
+		query: []queryParameter{asOfParameter}, status: http.StatusOK, response: v1.Account{}},
+	{method: http.MethodDelete, path: "/accounts/{accountId}", id: "forgetAccount", summary: "Erases all the data of an Account.", tag: "accounts",
+		status: http.StatusAccepted},
+	{method: http.MethodPost, path: "/accounts/{accountId}/close", id: "closeAccount", summary: "Closes an Account.", tag: "accounts",
+		request: v1.AccountLifecycleRequest{}, status: http.StatusAccepted},
+	{method: http.MethodPost, path: "/accounts/{accountId}/freeze", id: "freezeAccount", summary: "Freezes an Account.", tag: "accounts",
+		request: v1.AccountLifecycleRequest{}, status: http.StatusAccepted},
+	{method: http.MethodPost, path: "/accounts/{accountId}/reopen", id: "reopenAccount", summary: "Reopens a frozen Account.", tag: "accounts",
+		status: http.StatusAccepted},
+
+	// Saving goal and thresholds.
+	{method: http.MethodPost, path: "/accounts/{accountId}/change-saving-goal", id: "changeSavingGoal",
+		summary: "Changes the monthly saving goal of an Account.", tag: "thresholds",
+		request: v1.SavingGoal{}, status: http.StatusAccepted},
+	{method: http.MethodPost, path: "/accounts/{accountId}/set-new-threshold", id: "setNewThreshold",
+		summary: "Adds a threshold to the saving goal of an Account.", tag: "thresholds",
+		request: v1.SetNewThresholdRequest{}, status: http.StatusAccepted},
+	{method: http.MethodGet, path: "/accounts/{accountId}/thresholds", id: "listThresholds",
+		summary: "Lists the thresholds of the saving goal of an Account.", tag: "thresholds",
+		query: []queryParameter{asOfParameter}, status: http.StatusOK, response: []v1.Threshold{}},
+	{method: http.MethodPut, path: "/accounts/{accountId}/thresholds", id: "replaceThresholds",
+		summary: "Replaces the thresholds of the saving goal of an Account.", tag: "thresholds",
+		request: v1.ReplaceThresholdsRequest{}, status: http.StatusAccepted},
+	{method: http.MethodDelete, path: "/accounts/{accountId}/thresholds/{value}", id: "removeThreshold",
+		summary: `Removes a threshold, specified as "<kind>:<value>" or as a bare percentage.`, tag: "thresholds",
+		status: http.StatusAccepted},
+
+	// Goals.
+	{method: http.MethodGet, path: "/accounts/{accountId}/goals", id: "listGoals", summary: "Lists the Goals of an Account.", tag: "goals",
+		status: http.StatusOK, response: []v1.Goal{}},
+	{method: http.MethodPost, path: "/accounts/{accountId}/goals", id: "addGoal", summary: "Adds a Goal to an Account.", tag: "goals",
+		request: v1.GoalRequest{}, status: http.StatusAccepted, response: v1.Goal{}},
+	{method: http.MethodGet, path: "/accounts/{accountId}/goals/{goalId}", id: "getGoal", summary: "Returns a Goal.", tag: "goals",
+		status: http.StatusOK, response: v1.Goal{}},
+	{method: http.MethodPut, path: "/accounts/{accountId}/goals/{goalId}", id: "updateGoal", summary: "Updates a Goal.", tag: "goals",
+		request: v1.GoalRequest{}, status: http.StatusAccepted},
+	{method: http.MethodDelete, path: "/accounts/{accountId}/goals/{goalId}", id: "removeGoal", summary: "Removes a Goal.", tag: "goals",
+		status: http.StatusAccepted},
+	{method: http.MethodPost, path: "/accounts/{accountId}/goals/{goalId}/contributions", id: "recordGoalContribution",
+		summary: "Records an amount saved for, or withdrawn from, a Goal.", tag: "goals",
+		request: v1.GoalContributionRequest{}, status: http.StatusAccepted},
+
+	// Categorization.
+	{method: http.MethodPost, path: "/accounts/{accountId}/recategorize-transactions", id: "recategorizeTransactions",
+		summary: "Applies the categorization rules to one or all the transactions.", tag: "categorization",
+		request: v1.RecategorizeTransactionsRequest{}, optionalRequest: true, status: http.StatusAccepted},
+	{method: http.MethodGet, path: "/accounts/{accountId}/categorization-rules", id: "listCategorizationRules",
+		summary: "Lists the categorization rules of an Account.", tag: "categorization",
+		status: http.StatusOK, response: []v1.CategorizationRule{}},
+	{method: http.MethodPost, path: "/accounts/{accountId}/categorization-rules", id: "addCategorizationRule",
+		summary: "Adds a categorization rule to an Account.", tag: "categorization",
+		request: v1.CategorizationRule{}, status: http.StatusAccepted, response: v1.CategorizationRule{}},
+	{method: http.MethodDelete, path: "/accounts/{accountId}/categorization-rules/{ruleId}", id: "removeCategorizationRule",
+		summary: "Removes a categorization rule.", tag: "categorization",
+		status: http.StatusAccepted},
+
+	// Budgets and pacing.
+	{method: http.MethodGet, path: "/accounts/{accountId}/budgets", id: "listBudgets", summary: "Lists the budgets of an Account.", tag: "budgets",
+		status: http.StatusOK, response: []v1.Budget{}},
+	{method: http.MethodPut, path: "/accounts/{accountId}/budgets/{category}", id: "setBudget", summary: "Sets the budget of a category.", tag: "budgets",
+		request: v1.SetBudgetRequest{}, status: http.StatusAccepted, response: v1.Budget{}},
+	{method: http.MethodDelete, path: "/accounts/{accountId}/budgets/{category}", id: "removeBudget", summary: "Removes the budget of a category.", tag: "budgets",
+		status: http.StatusAccepted},
+	{method: http.MethodPut, path: "/accounts/{accountId}/pacing", id: "changePacingStrategy",
+		summary: "Changes how the monthly spending is paced.", tag: "budgets",
+		request: v1.PacingStrategyRequest{}, status: http.StatusAccepted},
+	{method: http.MethodGet, path: "/accounts/{accountId}/allowance", id: "getAllowance",
+		summary: "Returns how much can be spent on a day.", tag: "budgets",
+		query: []queryParameter{{name: "date", description: "The day of the allowance, today by default.",
+			schema: &jsonSchema{Type: "string", Format: "date"}}},
+		status: http.StatusOK, response: v1.Allowance{}},
+
+	// Recurring series.
+	{method: http.MethodGet, path: "/accounts/{accountId}/recurring-series", id: "listRecurringSeries",
+		summary: "Lists the recurring payments and incomes detected in an Account.", tag: "recurring",
+		status: http.StatusOK, response: []v1.RecurringSeries{}},
+	{method: http.MethodPost, path: "/accounts/{accountId}/recurring-series/{seriesId}/confirm", id: "confirmRecurringSeries",
+		summary: "Confirms a detected recurring series.", tag: "recurring",
+		status: http.StatusAccepted},
+	{method: http.MethodPost, path: "/accounts/{accountId}/recurring-series/{seriesId}/dismiss", id: "dismissRecurringSeries",
+		summary: "Dismisses a detected recurring series.", tag: "recurring",
+		status: http.StatusAccepted},
+
+	// Sweeps.
+	{method: http.MethodGet, path: "/accounts/{accountId}/sweep-rules", id: "listSweepRules",
+		summary: "Lists the rules moving money to the Goals.", tag: "sweeps",
+		status: http.StatusOK, response: []v1.SweepRule{}},
+	{method: http.MethodPost, path: "/accounts/{accountId}/sweep-rules", id: "addSweepRule",
+		summary: "Adds a rule moving money to a Goal.", tag: "sweeps",
+		request: v1.SweepRule{}, status: http.StatusAccepted, response: v1.SweepRule{}},
+	{method: http.MethodDelete, path: "/accounts/{accountId}/sweep-rules/{ruleId}", id: "removeSweepRule",
+		summary: "Removes a sweep rule.", tag: "sweeps",
+		status: http.StatusAccepted},
+	{method: http.MethodGet, path: "/accounts/{accountId}/savings-transfers", id: "listSavingsTransfers",
+		summary: "Lists the transfers requested by the sweep rules.", tag: "sweeps",
+		status: http.StatusOK, response: []v1.SavingsTransfer{}},
+
+	// Months.
+	{method: http.MethodGet, path: "/accounts/{accountId}/months/{year}/{month}", id: "getMonthProgress",
+		summary: "Returns the progress of an Account in a month.", tag: "months",
+		query: []queryParameter{asOfParameter}, status: http.StatusOK, response: v1.MonthProgress{}},
+
+	// Households.
+	{method: http.MethodPost, path: "/households", id: "createHousehold", summary: "Creates a Household.", tag: "households",
+		request: v1.CreateHouseholdRequest{}, status: http.StatusAccepted, response: v1.CreateHouseholdResponse{}},
+	{method: http.MethodGet, path: "/households/{householdId}", id: "getHousehold", summary: "Returns a Household.", tag: "households",
+		status: http.StatusOK, response: v1.Household{}},
+	{method: http.MethodPost, path: "/households/{householdId}/change-saving-goal", id: "changeHouseholdSavingGoal",
+		summary: "Changes the monthly saving goal of a Household.", tag: "households",
+		request: v1.SavingGoal{}, status: http.StatusAccepted},
+	{method: http.MethodPost, path: "/households/{householdId}/invitations", id: "inviteHouseholdMember",
+		summary: "Invites an Account to a Household.", tag: "households",
+		request: v1.InviteHouseholdMemberRequest{}, status: http.StatusAccepted},
+	{method: http.MethodPost, path: "/households/{householdId}/invitations/{accountId}/accept", id: "acceptHouseholdInvitation",
+		summary: "Accepts the invitation of an Account to a Household.", tag: "households",
+		status: http.StatusAccepted},
+	{method: http.MethodDelete, path: "/households/{householdId}/members/{accountId}", id: "leaveHousehold",
+		summary: "Removes an Account from a Household.", tag: "households",
+		status: http.StatusAccepted},
+	{method: http.MethodGet, path: "/households/{householdId}/months/{year}/{month}", id: "getHouseholdMonthProgress",
+		summary: "Returns the progress of a Household in a month.", tag: "households",
+		query: []queryParameter{asOfParameter}, status: http.StatusOK, response: v1.MonthProgress{}},
+
+	// Internal endpoints, requiring the admin role.
+	{method: http.MethodPost, path: "/internal/months/{year}/{month}/start", id: "startMonth",
+		summary: "Starts a month for all the Accounts.", tag: "admin",
+		status: http.StatusOK},
+	{method: http.MethodGet, path: "/internal/debug/vars", id: "getDebugVars",
+		summary: "Returns the metrics published with expvar.", tag: "admin",
+		status: http.StatusOK, response: map[string]interface{}{}},
+	{method: http.MethodGet, path: "/internal/aggregates/{type}/{id}/evolution", id: "getAggregateEvolution",
+		summary: "Returns the states of an aggregate after each of its Events.", tag: "admin",
+		query: []queryParameter{asOfParameter}, status: http.StatusOK, response: []v1.EvolutionStep{}},
+	{method: http.MethodGet, path: "/internal/streams/{type}/{id}/events", id: "listStreamEvents",
+		summary: "Lists the Events of an Event Stream.", tag: "admin",
+		query: []queryParameter{
+			{name: "type", description: "Only returns the Events with this name, can be repeated.",
+				schema: &jsonSchema{Type: "string"}},
+			{name: "from", description: "The version to start from.",
+				schema: &jsonSchema{Type: "integer", Format: "int64"}},
+			{name: "limit", description: "The maximum number of Events to return.",
+				schema: &jsonSchema{Type: "integer", Format: "int32"}},
+		},
+		status: http.StatusOK, response: v1.StreamPage{}},
+	{method: http.MethodGet, path: "/internal/subscriptions", id: "listSubscriptions",
+		summary: "Lists the policies and read models running in the application.", tag: "admin",
+		status: http.StatusOK, response: []v1.Subscription{}},
+	{method: http.MethodPost, path: "/internal/subscriptions/{name}/reset", id: "resetSubscription",
+		summary: "Moves the checkpoint of a policy, or replays it with dryRun.", tag: "admin",
+		request: v1.ResetSubscriptionRequest{}, status: http.StatusOK,
+		response: alternatives{v1.ResetSubscriptionResponse{}, v1.DryRunResponse{}}},
+	{method: http.MethodPost, path: "/internal/subscriptions/{name}/rebuild", id: "rebuildReadModel",
+		summary: "Rebuilds a read model from scratch.", tag: "admin",
+		status: http.StatusNoContent},
+}
@@ -0,0 +1,82 @@
+package httpapi
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/eventually-rs/saving-goals-go/internal/inspector"
+	v1 "github.com/eventually-rs/saving-goals-go/pkg/api/v1"
+
+	"github.com/go-chi/chi"
+)
+
+// listStreamEventsHandler returns the Events of a single Event Stream,
+// a page at a time: the from and limit query parameters select the page,
+// while the type parameter, which can be repeated, filters the Events by name.
+func listStreamEventsHandler(queryBus QueryDispatcher) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		params := r.URL.Query()
+
+		q := inspector.StreamQuery{
+			Type:  chi.URLParam(r, "type"),
+			ID:    chi.URLParam(r, "id"),
+			Names: params["type"],
+		}
+
+		if value := params.Get("from"); value != "" {
+			from, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				writeError(w, r, invalidParameter("from", err))
+				return
+			}
+
+			q.From = from
+		}
+
+		if value := params.Get("limit"); value != "" {
+			limit, err := strconv.Atoi(value)
+			if err != nil {
+				writeError(w, r, invalidParameter("limit", err))
+				return
+			}
+
+			q.Limit = limit
+		}
+
+		answer, err := queryBus.Dispatch(ctx, q)
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
+
+		page := answer.(inspector.Page)
+		response := v1.StreamPage{
+			Events:  make([]v1.StreamEvent, 0, len(page.Events)),
+			Next:    page.Next,
+			Version: page.Version,
+		}
+
+		for _, event := range page.Events {
+			e := v1.StreamEvent{
+				Name:           event.Name,
+				Version:        event.Version,
+				SequenceNumber: event.SequenceNumber,
+				EventID:        event.EventID,
+				CorrelationID:  event.CorrelationID,
+				CausationID:    event.CausationID,
+				Payload:        event.Payload,
+				Metadata:       event.Metadata,
+			}
+
+			if !event.RecordedAt.IsZero() {
+				recordedAt := event.RecordedAt
+				e.RecordedAt = &recordedAt
+			}
+
+			response.Events = append(response.Events, e)
+		}
+
+		writeJSON(w, http.StatusOK, response)
+	}
+}
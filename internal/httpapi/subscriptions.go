@@ -0,0 +1,114 @@
+package httpapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/eventually-rs/saving-goals-go/internal/admin"
+	v1 "github.com/eventually-rs/saving-goals-go/pkg/api/v1"
+
+	"github.com/go-chi/chi"
+)
+
+// Subscriptions is the component used to operate the policies and read models
+// running in the application, from the internal endpoints.
+type Subscriptions interface {
+	List(context.Context) ([]admin.Status, error)
+	Reset(ctx context.Context, name string, to admin.Position) (int64, error)
+	DryRun(ctx context.Context, name string, from admin.Position) (admin.DryRunResult, error)
+	Rebuild(ctx context.Context, name string) error
+}
+
+func listSubscriptionsHandler(subscriptions Subscriptions) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		statuses, err := subscriptions.List(r.Context())
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
+
+		response := make([]v1.Subscription, 0, len(statuses))
+		for _, status := range statuses {
+			response = append(response, v1.Subscription{
+				Name:           status.Name,
+				Kind:           string(status.Kind),
+				SequenceNumber: status.SequenceNumber,
+				Running:        status.Running,
+				Rebuilding:     status.Rebuilding,
+			})
+		}
+
+		writeJSON(w, http.StatusOK, response)
+	}
+}
+
+func resetSubscriptionHandler(subscriptions Subscriptions) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		name := chi.URLParam(r, "name")
+
+		var request v1.ResetSubscriptionRequest
+		if err := decodeJSON(r, &request); err != nil {
+			writeError(w, r, err)
+			return
+		}
+
+		if request.SequenceNumber < 0 {
+			writeError(w, r, &requestError{field: "sequenceNumber", reason: "should not be negative"})
+			return
+		}
+
+		position := admin.Position{SequenceNumber: request.SequenceNumber}
+		if request.Timestamp != nil {
+			position.Time = *request.Timestamp
+		}
+
+		if request.DryRun {
+			result, err := subscriptions.DryRun(ctx, name, position)
+			if err != nil {
+				writeError(w, r, err)
+				return
+			}
+
+			response := v1.DryRunResponse{
+				From:     result.From,
+				To:       result.To,
+				Events:   result.Events,
+				Commands: make([]v1.DryRunCommand, 0, len(result.Commands)),
+			}
+
+			for _, cmd := range result.Commands {
+				response.Commands = append(response.Commands, v1.DryRunCommand{
+					Type:    fmt.Sprintf("%T", cmd.Payload),
+					Payload: cmd.Payload,
+				})
+			}
+
+			writeJSON(w, http.StatusOK, response)
+
+			return
+		}
+
+		sequenceNumber, err := subscriptions.Reset(ctx, name, position)
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, v1.ResetSubscriptionResponse{SequenceNumber: sequenceNumber})
+	}
+}
+
+// rebuildReadModelHandler answers once the rebuilt read model
+// has replaced the previous one.
+func rebuildReadModelHandler(subscriptions Subscriptions) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := subscriptions.Rebuild(r.Context(), chi.URLParam(r, "name")); err != nil {
+			writeError(w, r, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,141 @@
+package httpapi
+
+import (
+	"net/http"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/account"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/savings"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/sweep"
+	v1 "github.com/eventually-rs/saving-goals-go/pkg/api/v1"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+	"github.com/eventually-rs/eventually-go/command"
+	"github.com/go-chi/chi"
+	"github.com/google/uuid"
+)
+
+func sweepRulesFromDomain(rules []sweep.Rule) []v1.SweepRule {
+	result := make([]v1.SweepRule, 0, len(rules))
+
+	for _, rule := range rules {
+		result = append(result, v1.SweepRule{
+			ID:         rule.ID,
+			Kind:       string(rule.Kind),
+			GoalID:     rule.GoalID,
+			Unit:       rule.Unit,
+			Percentage: rule.Percentage,
+		})
+	}
+
+	return result
+}
+
+func sweepRuleToDomain(r v1.SweepRule) sweep.Rule {
+	return sweep.Rule{
+		ID:         r.ID,
+		Kind:       sweep.Kind(r.Kind),
+		GoalID:     r.GoalID,
+		Unit:       r.Unit,
+		Percentage: r.Percentage,
+	}
+}
+
+func listSweepRulesHandler(queryBus QueryDispatcher) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		accountID := chi.URLParam(r, "accountId")
+
+		answer, err := queryBus.Dispatch(ctx, account.ViewQuery{AccountID: accountID})
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, sweepRulesFromDomain(answer.(account.View).SweepRules))
+	}
+}
+
+func addSweepRuleHandler(commandBus command.Dispatcher) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		accountID := chi.URLParam(r, "accountId")
+
+		var request v1.SweepRule
+		if err := decodeJSON(r, &request); err != nil {
+			writeError(w, r, err)
+			return
+		}
+
+		if request.ID == "" {
+			request.ID = uuid.New().String()
+		}
+
+		err := commandBus.Dispatch(ctx, eventually.Command{
+			Payload: account.AddSweepRule{
+				AccountID: aggregate.StringID(accountID),
+				Rule:      sweepRuleToDomain(request),
+			},
+		})
+
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
+
+		writeJSON(w, http.StatusAccepted, request)
+	}
+}
+
+func removeSweepRuleHandler(commandBus command.Dispatcher) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		accountID := chi.URLParam(r, "accountId")
+		ruleID := chi.URLParam(r, "ruleId")
+
+		err := commandBus.Dispatch(ctx, eventually.Command{
+			Payload: account.RemoveSweepRule{
+				AccountID: aggregate.StringID(accountID),
+				RuleID:    ruleID,
+			},
+		})
+
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusAccepted)
+	}
+}
+
+func listSavingsTransfersHandler(queryBus QueryDispatcher) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		accountID := chi.URLParam(r, "accountId")
+
+		answer, err := queryBus.Dispatch(ctx, savings.TransfersQuery{AccountID: accountID})
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
+
+		transfers := answer.(savings.Transfers)
+		response := make([]v1.SavingsTransfer, 0, len(transfers))
+
+		for _, transfer := range transfers {
+			response = append(response, v1.SavingsTransfer{
+				ID:          transfer.ID,
+				GoalID:      transfer.GoalID,
+				RuleID:      transfer.RuleID,
+				Kind:        string(transfer.Kind),
+				Amount:      transfer.Amount,
+				Status:      string(transfer.Status),
+				Reason:      transfer.Reason,
+				RequestedAt: transfer.RequestedAt,
+			})
+		}
+
+		writeJSON(w, http.StatusOK, response)
+	}
+}
@@ -0,0 +1,54 @@
+package httpapi
+
+import (
+	"github.com/eventually-rs/saving-goals-go/internal/domain/saving"
+	v1 "github.com/eventually-rs/saving-goals-go/pkg/api/v1"
+)
+
+func thresholdFromDomain(t saving.Threshold) v1.Threshold {
+	return v1.Threshold{Kind: string(t.Kind), Value: t.Value}
+}
+
+func thresholdToDomain(t v1.Threshold) saving.Threshold {
+	return saving.Threshold{Kind: saving.ThresholdKind(t.Kind), Value: t.Value}
+}
+
+func thresholdsFromDomain(thresholds []saving.Threshold) []v1.Threshold {
+	if thresholds == nil {
+		return nil
+	}
+
+	result := make([]v1.Threshold, 0, len(thresholds))
+	for _, t := range thresholds {
+		result = append(result, thresholdFromDomain(t))
+	}
+
+	return result
+}
+
+func thresholdsToDomain(thresholds []v1.Threshold) []saving.Threshold {
+	if thresholds == nil {
+		return nil
+	}
+
+	result := make([]saving.Threshold, 0, len(thresholds))
+	for _, t := range thresholds {
+		result = append(result, thresholdToDomain(t))
+	}
+
+	return result
+}
+
+func savingGoalFromDomain(goal saving.Goal) *v1.SavingGoal {
+	return &v1.SavingGoal{
+		Amount:     goal.Amount,
+		Thresholds: thresholdsFromDomain(goal.Thresholds),
+	}
+}
+
+func savingGoalToDomain(goal v1.SavingGoal) saving.Goal {
+	return saving.Goal{
+		Amount:     goal.Amount,
+		Thresholds: thresholdsToDomain(goal.Thresholds),
+	}
+}
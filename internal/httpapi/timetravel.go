@@ -0,0 +1,78 @@
+package httpapi
+
+import (
+	"net/http"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/account"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/monthly"
+	"github.com/eventually-rs/saving-goals-go/internal/timetravel"
+	v1 "github.com/eventually-rs/saving-goals-go/pkg/api/v1"
+
+	"github.com/go-chi/chi"
+)
+
+// asOfFromURL returns the point in time requested with the asOf query parameter,
+// which is the zero Point when not specified.
+func asOfFromURL(r *http.Request) (timetravel.Point, error) {
+	value := r.URL.Query().Get("asOf")
+	if value == "" {
+		return timetravel.Point{}, nil
+	}
+
+	return timetravel.ParsePoint(value)
+}
+
+func getAggregateEvolutionHandler(queryBus QueryDispatcher) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		asOf, err := asOfFromURL(r)
+		if err != nil {
+			writeError(w, r, invalidParameter("asOf", err))
+			return
+		}
+
+		answer, err := queryBus.Dispatch(ctx, timetravel.EvolutionQuery{
+			Type: chi.URLParam(r, "type"),
+			ID:   chi.URLParam(r, "id"),
+			AsOf: asOf,
+		})
+
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
+
+		evolution := answer.(timetravel.Evolution)
+		response := make([]v1.EvolutionStep, 0, len(evolution))
+
+		for _, step := range evolution {
+			sequenceNumber, _ := step.Event.GlobalSequenceNumber()
+
+			s := v1.EvolutionStep{
+				Version:        step.Event.Version,
+				SequenceNumber: sequenceNumber,
+				Event: v1.EvolutionEvent{
+					Type:    step.EventName,
+					Payload: step.Event.Payload,
+				},
+				State: step.State,
+			}
+
+			if recordedAt, ok := timetravel.AppendedAt(step.Event.Event); ok {
+				s.RecordedAt = &recordedAt
+			}
+
+			switch state := step.State.(type) {
+			case account.View:
+				s.State = accountFromView(state)
+			case monthly.Progress:
+				s.State = monthProgressFromDomain(state)
+			}
+
+			response = append(response, s)
+		}
+
+		writeJSON(w, http.StatusOK, response)
+	}
+}
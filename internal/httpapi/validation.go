@@ -0,0 +1,279 @@
+package httpapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/eventually-rs/saving-goals-go/internal/problem"
+)
+
+// schemaError is returned when the request body does not match its schema
+// in the OpenAPI document.
+type schemaError struct {
+	errs []problem.FieldError
+}
+
+func (e *schemaError) Error() string {
+	reasons := make([]string, 0, len(e.errs))
+	for _, err := range e.errs {
+		reasons = append(reasons, err.Field+": "+err.Reason)
+	}
+
+	return "httpapi: invalid request body: " + strings.Join(reasons, ", ")
+}
+
+// decodeJSON decodes the JSON body of the request in v, after validating it
+// against the schema of v in the OpenAPI document.
+//
+// It returns a *schemaError if the body does not match the schema,
+// or a *requestError if it is malformed.
+func decodeJSON(r *http.Request, v interface{}) error {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return &requestError{reason: "The request body could not be read.", err: err}
+	}
+
+	var value interface{}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	if err := decoder.Decode(&value); err != nil {
+		return decodeError(err)
+	}
+
+	ref := &jsonSchema{Ref: schemaRefPrefix + reflect.TypeOf(v).Elem().Name()}
+	if errs := openAPISpec().validate(value, ref, ""); len(errs) > 0 {
+		return &schemaError{errs: errs}
+	}
+
+	decoder = json.NewDecoder(bytes.NewReader(body))
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(v); err != nil {
+		return decodeError(err)
+	}
+
+	return nil
+}
+
+func decodeError(err error) error {
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		return &requestError{field: typeErr.Field, reason: "should be a " + jsonTypeName(typeErr.Type), err: err}
+	}
+
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return &requestError{reason: "The request body is not valid JSON.", err: err}
+	}
+
+	// Errors of the values decoding themselves, like the thresholds.
+	return &requestError{reason: "The request body is not valid.", err: err}
+}
+
+// validate returns the errors of a JSON value decoded using json.Number,
+// which does not match the schema, sorted by field.
+//
+// The schemas not found in the document accept any value.
+func (doc *openAPIDocument) validate(value interface{}, s *jsonSchema, path string) []problem.FieldError {
+	if s.Ref != "" {
+		resolved, ok := doc.Components.Schemas[strings.TrimPrefix(s.Ref, schemaRefPrefix)]
+		if !ok {
+			return nil
+		}
+
+		s = resolved
+	}
+
+	if value == nil {
+		if s.Nullable || (s.Type == "" && len(s.OneOf) == 0 && len(s.AllOf) == 0) {
+			return nil
+		}
+
+		return fieldErrors(path, "should not be null")
+	}
+
+	if len(s.OneOf) > 0 {
+		// The alternatives are told apart by their type.
+		for _, alternative := range s.OneOf {
+			if hasType(value, doc.typeOf(alternative)) {
+				return doc.validate(value, alternative, path)
+			}
+		}
+
+		types := make([]string, 0, len(s.OneOf))
+		for _, alternative := range s.OneOf {
+			types = append(types, doc.typeOf(alternative))
+		}
+
+		return fieldErrors(path, "should be "+article(types[0])+" "+strings.Join(types, " or "))
+	}
+
+	var errs []problem.FieldError
+	for _, schema := range s.AllOf {
+		errs = append(errs, doc.validate(value, schema, path)...)
+	}
+
+	if s.Type == "" {
+		return errs
+	}
+
+	if !hasType(value, s.Type) {
+		return fieldErrors(path, "should be "+article(s.Type)+" "+s.Type)
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		errs = append(errs, doc.validateObject(v, s, path)...)
+
+	case []interface{}:
+		for i, item := range v {
+			errs = append(errs, doc.validate(item, s.Items, path+"["+strconv.Itoa(i)+"]")...)
+		}
+
+	case json.Number:
+		n, _ := v.Float64()
+
+		switch {
+		case s.Minimum != nil && n < *s.Minimum:
+			return fieldErrors(path, fmt.Sprintf("should be at least %v", *s.Minimum))
+		case s.Maximum != nil && n > *s.Maximum:
+			return fieldErrors(path, fmt.Sprintf("should be at most %v", *s.Maximum))
+		}
+
+	case string:
+		return validateString(v, s, path)
+	}
+
+	return errs
+}
+
+func (doc *openAPIDocument) validateObject(object map[string]interface{}, s *jsonSchema, path string) []problem.FieldError {
+	var errs []problem.FieldError
+
+	for _, name := range s.Required {
+		if _, ok := object[name]; !ok {
+			errs = append(errs, problem.FieldError{Field: fieldPath(path, name), Reason: "should be specified"})
+		}
+	}
+
+	names := make([]string, 0, len(object))
+	for name := range object {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		property, ok := s.Properties[name]
+
+		switch {
+		case ok:
+			errs = append(errs, doc.validate(object[name], property, fieldPath(path, name))...)
+		case s.AdditionalProperties == false:
+			errs = append(errs, problem.FieldError{Field: fieldPath(path, name), Reason: "is not a known field"})
+		}
+	}
+
+	sort.SliceStable(errs, func(i, j int) bool { return errs[i].Field < errs[j].Field })
+
+	return errs
+}
+
+func validateString(value string, s *jsonSchema, path string) []problem.FieldError {
+	if len(s.Enum) > 0 {
+		for _, v := range s.Enum {
+			if v == value {
+				return nil
+			}
+		}
+
+		return fieldErrors(path, "should be one of "+strings.Join(s.Enum, ", "))
+	}
+
+	switch s.Format {
+	case "date":
+		if _, err := time.Parse("2006-01-02", value); err != nil {
+			return fieldErrors(path, "should be formatted as YYYY-MM-DD")
+		}
+
+	case "date-time":
+		if _, err := time.Parse(time.RFC3339, value); err != nil {
+			return fieldErrors(path, "should be an RFC 3339 timestamp")
+		}
+	}
+
+	return nil
+}
+
+// typeOf returns the JSON type of the values accepted by the schema.
+func (doc *openAPIDocument) typeOf(s *jsonSchema) string {
+	if s.Ref != "" {
+		if resolved, ok := doc.Components.Schemas[strings.TrimPrefix(s.Ref, schemaRefPrefix)]; ok {
+			return doc.typeOf(resolved)
+		}
+	}
+
+	return s.Type
+}
+
+// hasType returns true if the value decoded using json.Number
+// has the JSON type, where the integers are numbers too.
+func hasType(value interface{}, t string) bool {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return t == "object"
+	case []interface{}:
+		return t == "array"
+	case string:
+		return t == "string"
+	case bool:
+		return t == "boolean"
+	case json.Number:
+		if t == "integer" {
+			_, err := v.Int64()
+			return err == nil
+		}
+
+		return t == "number"
+	default:
+		return false
+	}
+}
+
+func article(word string) string {
+	if strings.ContainsAny(word[:1], "aeiou") {
+		return "an"
+	}
+
+	return "a"
+}
+
+// fieldPath returns the path of a field of the object at path,
+// like thresholds[0].value.
+func fieldPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+
+	return path + "." + name
+}
+
+func fieldErrors(path, reason string) []problem.FieldError {
+	if path == "" {
+		path = "body"
+	}
+
+	return []problem.FieldError{{Field: path, Reason: reason}}
+}
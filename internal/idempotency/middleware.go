@@ -0,0 +1,235 @@
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/eventually-rs/saving-goals-go/internal/commandbus"
+	"github.com/eventually-rs/saving-goals-go/internal/problem"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	// KeyHeader is the request header holding the Idempotency-Key.
+	KeyHeader = "Idempotency-Key"
+
+	// ReplayedHeader is set in the responses replayed for duplicated requests.
+	ReplayedHeader = "Idempotent-Replayed"
+
+	// maxKeyLength limits the size of the keys chosen by the clients.
+	maxKeyLength = 255
+
+	// storeTimeout limits the time spent recording the outcome of a request.
+	storeTimeout = 5 * time.Second
+)
+
+var (
+	// keyTooLong is the problem of the requests with a key longer than 255 characters.
+	keyTooLong = problem.New(http.StatusBadRequest, "idempotency_key_too_long", "Idempotency key too long")
+
+	// keyReused is the problem of the requests reusing the key of a different request.
+	keyReused = problem.New(http.StatusUnprocessableEntity, "idempotency_key_reused",
+		"Idempotency key already used for a different request")
+
+	// requestInProgress is the problem of the duplicates of a request still in progress.
+	requestInProgress = problem.New(http.StatusConflict, "idempotency_request_in_progress",
+		"A request with the same idempotency key is in progress")
+
+	unreadableBody = problem.New(http.StatusBadRequest, "malformed_request", "Malformed request")
+	internalError  = problem.New(http.StatusInternalServerError, "internal_error", "Internal error")
+)
+
+// Problems returns the Problems the Middleware responds with,
+// to document them with the rest of the API.
+func Problems() []problem.Problem {
+	return []problem.Problem{keyTooLong, keyReused, requestInProgress, unreadableBody, internalError}
+}
+
+// Middleware returns an HTTP middleware replaying the response of the first
+// request sent with an Idempotency-Key to the following ones with the same key,
+// for ttl since the first one completed.
+//
+// A key reused for a different request is rejected with 422, while
+// a duplicate of a request still in progress is rejected with 409,
+// for lease at most, so that the requests never completed can be retried:
+// the requests outliving their lease leave the key to the one which took it over.
+// The keys are scoped by caller, and the server errors are not recorded,
+// so that the request can be retried.
+//
+// The outcome of the requests is recorded even if their client went away.
+//
+// The requests without key, and the read requests, are not affected.
+func Middleware(
+	store Store,
+	ttl, lease time.Duration,
+	now func() time.Time,
+	logger *zap.Logger,
+) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			idempotencyKey := r.Header.Get(KeyHeader)
+
+			if idempotencyKey == "" || r.Method == http.MethodGet || r.Method == http.MethodHead {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if len(idempotencyKey) > maxKeyLength {
+				problem.Write(w, keyTooLong)
+				return
+			}
+
+			body, err := ioutil.ReadAll(r.Body)
+			if err != nil {
+				problem.Write(w, unreadableBody.WithDetail("The request body could not be read."))
+				return
+			}
+
+			r.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+			ctx := r.Context()
+			caller, _ := commandbus.CallerFrom(ctx)
+			key := caller.ID + ":" + idempotencyKey
+
+			// The token keeps a request outliving its lease from overwriting
+			// the record of the one which took its key over.
+			token := uuid.New().String()
+
+			record, started, err := store.Start(ctx, key, Record{
+				Fingerprint: fingerprint(r, body),
+				Token:       token,
+				ExpiresAt:   now().Add(lease),
+			})
+
+			if err != nil {
+				logger.Error("Failed to start idempotent request", zap.String("key", key), zap.Error(err))
+				problem.Write(w, internalError)
+				return
+			}
+
+			if !started {
+				replay(w, r, record, body)
+				return
+			}
+
+			recorder := &responseRecorder{ResponseWriter: w, statusCode: http.StatusOK}
+
+			completed := false
+
+			defer func() {
+				if completed {
+					return
+				}
+
+				// The request failed without a response to replay: the client can retry it.
+				detached, cancel := detach(ctx)
+				defer cancel()
+
+				if err := store.Release(detached, key, token); err != nil {
+					logger.Error("Failed to release idempotency key", zap.String("key", key), zap.Error(err))
+				}
+			}()
+
+			next.ServeHTTP(recorder, r)
+
+			if recorder.statusCode >= http.StatusInternalServerError {
+				return
+			}
+
+			detached, cancel := detach(ctx)
+			defer cancel()
+
+			err = store.Complete(detached, key, token, Response{
+				StatusCode:  recorder.statusCode,
+				ContentType: recorder.Header().Get("Content-Type"),
+				Body:        recorder.body.Bytes(),
+			}, now().Add(ttl))
+
+			if err != nil {
+				logger.Error("Failed to record idempotent response", zap.String("key", key), zap.Error(err))
+				return
+			}
+
+			completed = true
+		})
+	}
+}
+
+// detach returns a context carrying the values of the request context,
+// but not its cancellation, expiring after storeTimeout.
+func detach(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(detachedContext{Context: ctx}, storeTimeout)
+}
+
+// detachedContext is a context never canceled, carrying the values of its parent.
+type detachedContext struct{ context.Context }
+
+func (detachedContext) Deadline() (time.Time, bool) { return time.Time{}, false }
+func (detachedContext) Done() <-chan struct{}       { return nil }
+func (detachedContext) Err() error                  { return nil }
+
+// fingerprint identifies a request by its method, path and body.
+func fingerprint(r *http.Request, body []byte) string {
+	hash := sha256.New()
+
+	io.WriteString(hash, r.Method+" "+r.URL.Path+"\n")
+	hash.Write(body)
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func replay(w http.ResponseWriter, r *http.Request, record Record, body []byte) {
+	if record.Fingerprint != fingerprint(r, body) {
+		problem.Write(w, keyReused)
+		return
+	}
+
+	if record.Response == nil {
+		problem.Write(w, requestInProgress)
+		return
+	}
+
+	if record.Response.ContentType != "" {
+		w.Header().Set("Content-Type", record.Response.ContentType)
+	}
+
+	w.Header().Set(ReplayedHeader, "true")
+	w.WriteHeader(record.Response.StatusCode)
+
+	// Errors here are caused by the client connection: nothing else to do.
+	_, _ = w.Write(record.Response.Body)
+}
+
+// responseRecorder records the response written by the handler,
+// while sending it to the client.
+type responseRecorder struct {
+	http.ResponseWriter
+
+	statusCode  int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+func (rr *responseRecorder) WriteHeader(statusCode int) {
+	if !rr.wroteHeader {
+		rr.statusCode = statusCode
+		rr.wroteHeader = true
+	}
+
+	rr.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (rr *responseRecorder) Write(data []byte) (int, error) {
+	rr.wroteHeader = true
+	rr.body.Write(data)
+
+	return rr.ResponseWriter.Write(data)
+}
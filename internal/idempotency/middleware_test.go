@@ -0,0 +1,332 @@
+package idempotency_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/eventually-rs/saving-goals-go/internal/commandbus"
+	"github.com/eventually-rs/saving-goals-go/internal/idempotency"
+	"github.com/eventually-rs/saving-goals-go/internal/problem"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+type clock struct{ now time.Time }
+
+func (c *clock) Now() time.Time { return c.now }
+
+// thresholds answers with 202 for the first threshold set,
+// and with 400 for the following ones, like the API.
+type thresholds struct {
+	calls  int
+	status int
+}
+
+func (h *thresholds) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.calls++
+
+	if h.status != 0 {
+		http.Error(w, "server error", h.status)
+		return
+	}
+
+	if h.calls > 1 {
+		http.Error(w, "threshold already exists", http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	w.Write([]byte(`{"calls":1}`))
+}
+
+type request struct {
+	method string
+	key    string
+	caller string
+	body   string
+}
+
+func send(handler http.Handler, req request) *httptest.ResponseRecorder {
+	if req.method == "" {
+		req.method = http.MethodPost
+	}
+
+	r := httptest.NewRequest(req.method, "/accounts/test-account/set-new-threshold", strings.NewReader(req.body))
+	r.Header.Set(idempotency.KeyHeader, req.key)
+
+	if req.caller != "" {
+		r = r.WithContext(commandbus.WithCaller(r.Context(), commandbus.Caller{ID: req.caller}))
+	}
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+
+	return w
+}
+
+// cancelableStore fails like the stores backed by a database
+// when the context is canceled.
+type cancelableStore struct{ *idempotency.InMemoryStore }
+
+func (s cancelableStore) Complete(
+	ctx context.Context,
+	key, token string,
+	response idempotency.Response,
+	expiresAt time.Time,
+) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return s.InMemoryStore.Complete(ctx, key, token, response, expiresAt)
+}
+
+func (s cancelableStore) Release(ctx context.Context, key, token string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return s.InMemoryStore.Release(ctx, key, token)
+}
+
+func TestMiddleware(t *testing.T) {
+	const (
+		ttl   = time.Hour
+		lease = time.Minute
+	)
+
+	setup := func() (*thresholds, *clock, *idempotency.InMemoryStore, http.Handler) {
+		c := &clock{now: time.Date(2021, time.March, 1, 10, 0, 0, 0, time.UTC)}
+		store := idempotency.NewInMemoryStore(c.Now)
+		h := new(thresholds)
+
+		return h, c, store, idempotency.Middleware(store, ttl, lease, c.Now, zap.NewNop())(h)
+	}
+
+	t.Run("duplicated requests get the response of the first one", func(t *testing.T) {
+		h, _, _, handler := setup()
+		req := request{key: "key-1", caller: "alice", body: `{"value":0.5}`}
+
+		first := send(handler, req)
+		second := send(handler, req)
+
+		assert.Equal(t, 1, h.calls)
+		assert.Equal(t, http.StatusAccepted, second.Code)
+		assert.Equal(t, "application/json", second.Header().Get("Content-Type"))
+		assert.Equal(t, "true", second.Header().Get(idempotency.ReplayedHeader))
+		assert.Equal(t, first.Body.String(), second.Body.String())
+		assert.Empty(t, first.Header().Get(idempotency.ReplayedHeader))
+	})
+
+	t.Run("keys reused for a different request are rejected", func(t *testing.T) {
+		h, _, _, handler := setup()
+
+		send(handler, request{key: "key-1", caller: "alice", body: `{"value":0.5}`})
+		w := send(handler, request{key: "key-1", caller: "alice", body: `{"value":0.8}`})
+
+		assert.Equal(t, 1, h.calls)
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+		assert.Equal(t, problem.ContentType, w.Header().Get("Content-Type"))
+		assert.Contains(t, w.Body.String(), `"code":"idempotency_key_reused"`)
+	})
+
+	t.Run("duplicates of requests in progress are rejected", func(t *testing.T) {
+		h, c, store, handler := setup()
+
+		first := httptest.NewRequest(http.MethodPost, "/accounts/test-account/set-new-threshold", strings.NewReader(`{}`))
+
+		// Start the first request, without completing it.
+		inProgress := idempotency.Middleware(store, ttl, lease, c.Now, zap.NewNop())(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w2 := send(handler, request{key: "key-1", caller: "alice", body: `{}`})
+				assert.Equal(t, http.StatusConflict, w2.Code)
+				w.WriteHeader(http.StatusAccepted)
+			}),
+		)
+
+		first.Header.Set(idempotency.KeyHeader, "key-1")
+		first = first.WithContext(commandbus.WithCaller(context.Background(), commandbus.Caller{ID: "alice"}))
+		inProgress.ServeHTTP(httptest.NewRecorder(), first)
+
+		assert.Equal(t, 0, h.calls)
+	})
+
+	t.Run("requests in progress are rejected only for their lease", func(t *testing.T) {
+		h, c, store, handler := setup()
+
+		first := httptest.NewRequest(http.MethodPost, "/accounts/test-account/set-new-threshold", strings.NewReader(`{}`))
+
+		// The first request never completes in time, e.g. because the server stopped.
+		inProgress := idempotency.Middleware(store, ttl, lease, c.Now, zap.NewNop())(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				c.now = c.now.Add(lease + time.Second)
+
+				w2 := send(handler, request{key: "key-1", caller: "alice", body: `{}`})
+				assert.Equal(t, http.StatusAccepted, w2.Code)
+			}),
+		)
+
+		first.Header.Set(idempotency.KeyHeader, "key-1")
+		first = first.WithContext(commandbus.WithCaller(context.Background(), commandbus.Caller{ID: "alice"}))
+		inProgress.ServeHTTP(httptest.NewRecorder(), first)
+
+		assert.Equal(t, 1, h.calls)
+
+		// The responses are kept for the ttl instead.
+		c.now = c.now.Add(2 * lease)
+
+		w := send(handler, request{key: "key-1", caller: "alice", body: `{}`})
+		assert.Equal(t, "true", w.Header().Get(idempotency.ReplayedHeader))
+		assert.Equal(t, 1, h.calls)
+	})
+
+	t.Run("requests outliving their lease leave the key to the following one", func(t *testing.T) {
+		for name, status := range map[string]int{"completed": http.StatusOK, "failed": http.StatusServiceUnavailable} {
+			h, c, store, handler := setup()
+
+			first := httptest.NewRequest(http.MethodPost, "/accounts/test-account/set-new-threshold", strings.NewReader(`{}`))
+
+			// The first request is slower than its lease, and ends after the following one.
+			slow := idempotency.Middleware(store, ttl, lease, c.Now, zap.NewNop())(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					c.now = c.now.Add(lease + time.Second)
+
+					w2 := send(handler, request{key: "key-1", caller: "alice", body: `{}`})
+					assert.Equal(t, http.StatusAccepted, w2.Code, name)
+
+					w.WriteHeader(status)
+				}),
+			)
+
+			first.Header.Set(idempotency.KeyHeader, "key-1")
+			first = first.WithContext(commandbus.WithCaller(context.Background(), commandbus.Caller{ID: "alice"}))
+			slow.ServeHTTP(httptest.NewRecorder(), first)
+
+			w := send(handler, request{key: "key-1", caller: "alice", body: `{}`})
+			assert.Equal(t, "true", w.Header().Get(idempotency.ReplayedHeader), name)
+			assert.Equal(t, http.StatusAccepted, w.Code, name)
+			assert.Equal(t, 1, h.calls, name)
+		}
+	})
+
+	t.Run("the outcome is recorded after the client went away", func(t *testing.T) {
+		c := &clock{now: time.Date(2021, time.March, 1, 10, 0, 0, 0, time.UTC)}
+		store := cancelableStore{idempotency.NewInMemoryStore(c.Now)}
+
+		var (
+			calls  int
+			status int
+			cancel context.CancelFunc
+		)
+
+		handler := idempotency.Middleware(store, ttl, lease, c.Now, zap.NewNop())(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls++
+
+				// The client disconnects while the request is handled.
+				cancel()
+				w.WriteHeader(status)
+			}),
+		)
+
+		send := func() int {
+			var ctx context.Context
+			ctx, cancel = context.WithCancel(commandbus.WithCaller(context.Background(), commandbus.Caller{ID: "alice"}))
+
+			r := httptest.NewRequest(http.MethodPost, "/accounts/test-account/freeze", strings.NewReader(`{}`))
+			r.Header.Set(idempotency.KeyHeader, "key-1")
+
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r.WithContext(ctx))
+
+			return w.Code
+		}
+
+		// The failed requests are released, so they can be retried.
+		status = http.StatusServiceUnavailable
+		send()
+
+		status = http.StatusAccepted
+		assert.Equal(t, http.StatusAccepted, send())
+		assert.Equal(t, 2, calls)
+
+		// The completed ones are replayed.
+		assert.Equal(t, http.StatusAccepted, send())
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("keys are scoped by caller", func(t *testing.T) {
+		h, _, _, handler := setup()
+
+		send(handler, request{key: "key-1", caller: "alice", body: `{}`})
+		w := send(handler, request{key: "key-1", caller: "bob", body: `{}`})
+
+		assert.Equal(t, 2, h.calls)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("expired keys can be used again", func(t *testing.T) {
+		h, c, store, handler := setup()
+		req := request{key: "key-1", caller: "alice", body: `{}`}
+
+		send(handler, req)
+		c.now = c.now.Add(ttl + time.Second)
+
+		w := send(handler, req)
+		assert.Equal(t, 2, h.calls)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		c.now = c.now.Add(2 * ttl)
+
+		purged, err := store.Purge(context.Background(), c.now)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), purged)
+	})
+
+	t.Run("server errors are not replayed", func(t *testing.T) {
+		h, _, _, handler := setup()
+		req := request{key: "key-1", caller: "alice", body: `{}`}
+
+		h.status = http.StatusInternalServerError
+		send(handler, req)
+
+		h.status = 0
+		h.calls = 0
+
+		w := send(handler, req)
+		assert.Equal(t, 1, h.calls)
+		assert.Equal(t, http.StatusAccepted, w.Code)
+	})
+
+	t.Run("requests without key and read requests are not affected", func(t *testing.T) {
+		h, _, _, handler := setup()
+
+		send(handler, request{caller: "alice", body: `{}`})
+		send(handler, request{caller: "alice", body: `{}`})
+		send(handler, request{method: http.MethodGet, key: "key-1", caller: "alice"})
+
+		assert.Equal(t, 3, h.calls)
+	})
+
+	t.Run("the request body is still readable by the handler", func(t *testing.T) {
+		var body string
+
+		store := idempotency.NewInMemoryStore(time.Now)
+		handler := idempotency.Middleware(store, ttl, lease, time.Now, zap.NewNop())(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				data, _ := ioutil.ReadAll(r.Body)
+				body = string(data)
+			}),
+		)
+
+		send(handler, request{key: "key-1", caller: "alice", body: `{"value":0.5}`})
+		assert.Equal(t, `{"value":0.5}`, body)
+	})
+}
@@ -0,0 +1,207 @@
+package idempotency
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	_ "github.com/lib/pq" // postgres driver for database/sql
+)
+
+var _ Store = &PostgresStore{}
+
+// maxStartAttempts limits the attempts to start a request whose key
+// is released concurrently, between the insert and the read of its Record.
+const maxStartAttempts = 3
+
+// PostgresStore is a Store implementation backed by a Postgres table.
+type PostgresStore struct {
+	db  *sql.DB
+	now func() time.Time
+}
+
+// OpenPostgresStore opens a connection with the database,
+// creating the table of the idempotency keys if it does not exist,
+// and uses the specified clock to expire the keys.
+func OpenPostgresStore(ctx context.Context, dsn string, now func() time.Time) (*PostgresStore, error) {
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("idempotency.PostgresStore: failed to open connection with the db: %w", err)
+	}
+
+	_, err = db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS idempotency_keys (
+		"key"        TEXT PRIMARY KEY,
+		fingerprint  TEXT NOT NULL,
+		token        TEXT NOT NULL,
+		status_code  INTEGER,
+		content_type TEXT,
+		body         BYTEA,
+		expires_at   TIMESTAMPTZ NOT NULL
+	)`)
+
+	if err != nil {
+		return nil, fmt.Errorf("idempotency.PostgresStore: failed to create idempotency keys table: %w", err)
+	}
+
+	return &PostgresStore{db: db, now: now}, nil
+}
+
+// Close closes the connection with the database.
+func (s *PostgresStore) Close() error {
+	return s.db.Close()
+}
+
+// Start records a new request in progress with the key, unless the key
+// has already been used by a request not expired yet.
+//
+// Concurrent requests with the same key are serialized by the primary key:
+// only one of them starts, the others find its Record. The key is inserted again
+// if it has been released before its Record could be read, up to maxStartAttempts times.
+func (s *PostgresStore) Start(ctx context.Context, key string, record Record) (Record, bool, error) {
+	for attempt := 1; ; attempt++ {
+		started, err := s.insert(ctx, key, record)
+		if err != nil {
+			return Record{}, false, err
+		}
+
+		if started {
+			record.Response = nil
+			return record, true, nil
+		}
+
+		existing, err := s.read(ctx, key)
+		if errors.Is(err, sql.ErrNoRows) {
+			if attempt < maxStartAttempts {
+				continue
+			}
+
+			// The key is still taken and released by concurrent requests:
+			// the request is answered as a duplicate of one in progress.
+			record.Response = nil
+			return record, false, nil
+		}
+
+		if err != nil {
+			return Record{}, false, fmt.Errorf("idempotency.PostgresStore: failed to read request: %w", err)
+		}
+
+		return existing, false, nil
+	}
+}
+
+// insert records the request in progress with the key, returning false
+// if the key is used by a request not expired yet.
+func (s *PostgresStore) insert(ctx context.Context, key string, record Record) (bool, error) {
+	// The expired keys are taken over by the new request. They expire
+	// with the clock of the Store rather than the one of the database,
+	// which the expiration times are not computed with.
+	result, err := s.db.ExecContext(
+		ctx,
+		`INSERT INTO idempotency_keys ("key", fingerprint, token, expires_at)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT ("key") DO UPDATE SET
+				fingerprint = EXCLUDED.fingerprint,
+				token = EXCLUDED.token,
+				status_code = NULL,
+				content_type = NULL,
+				body = NULL,
+				expires_at = EXCLUDED.expires_at
+			WHERE idempotency_keys.expires_at <= $5`,
+		key,
+		record.Fingerprint,
+		record.Token,
+		record.ExpiresAt,
+		s.now(),
+	)
+
+	if err != nil {
+		return false, fmt.Errorf("idempotency.PostgresStore: failed to start request: %w", err)
+	}
+
+	started, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("idempotency.PostgresStore: failed to start request: %w", err)
+	}
+
+	return started > 0, nil
+}
+
+// read returns the Record of the key, or sql.ErrNoRows if there is none.
+func (s *PostgresStore) read(ctx context.Context, key string) (Record, error) {
+	var (
+		record      Record
+		statusCode  sql.NullInt64
+		contentType sql.NullString
+		body        []byte
+	)
+
+	err := s.db.QueryRowContext(
+		ctx,
+		`SELECT fingerprint, status_code, content_type, body, expires_at
+			FROM idempotency_keys WHERE "key" = $1`,
+		key,
+	).Scan(&record.Fingerprint, &statusCode, &contentType, &body, &record.ExpiresAt)
+
+	if err != nil {
+		return Record{}, err
+	}
+
+	if statusCode.Valid {
+		record.Response = &Response{
+			StatusCode:  int(statusCode.Int64),
+			ContentType: contentType.String,
+			Body:        body,
+		}
+	}
+
+	return record, nil
+}
+
+// Complete records the response of the request in progress with the key
+// and the token, keeping it until the specified expiration time.
+func (s *PostgresStore) Complete(ctx context.Context, key, token string, response Response, expiresAt time.Time) error {
+	_, err := s.db.ExecContext(
+		ctx,
+		`UPDATE idempotency_keys SET status_code = $3, content_type = $4, body = $5, expires_at = $6
+			WHERE "key" = $1 AND token = $2`,
+		key,
+		token,
+		response.StatusCode,
+		response.ContentType,
+		response.Body,
+		expiresAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("idempotency.PostgresStore: failed to record response: %w", err)
+	}
+
+	return nil
+}
+
+// Release forgets the key started with the token.
+func (s *PostgresStore) Release(ctx context.Context, key, token string) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM idempotency_keys WHERE "key" = $1 AND token = $2`, key, token)
+	if err != nil {
+		return fmt.Errorf("idempotency.PostgresStore: failed to release key: %w", err)
+	}
+
+	return nil
+}
+
+// Purge deletes the keys expired before the specified time.
+func (s *PostgresStore) Purge(ctx context.Context, before time.Time) (int64, error) {
+	result, err := s.db.ExecContext(ctx, "DELETE FROM idempotency_keys WHERE expires_at < $1", before)
+	if err != nil {
+		return 0, fmt.Errorf("idempotency.PostgresStore: failed to purge expired keys: %w", err)
+	}
+
+	purged, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("idempotency.PostgresStore: failed to purge expired keys: %w", err)
+	}
+
+	return purged, nil
+}
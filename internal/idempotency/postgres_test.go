@@ -0,0 +1,137 @@
+package idempotency_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/eventually-rs/saving-goals-go/internal/idempotency"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestPostgresStore runs against the Postgres database in DATABASE_TEST_DSN,
+// in a schema of its own, and is skipped if it is not set.
+func TestPostgresStore(t *testing.T) {
+	dsn := os.Getenv("DATABASE_TEST_DSN")
+	if dsn == "" {
+		t.Skip("DATABASE_TEST_DSN not set")
+	}
+
+	ctx := context.Background()
+
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer db.Close()
+
+	schema := fmt.Sprintf("idempotency_test_%d", time.Now().UnixNano())
+
+	if _, err := db.ExecContext(ctx, "CREATE SCHEMA "+schema); err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		if _, err := db.ExecContext(ctx, "DROP SCHEMA "+schema+" CASCADE"); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	u, err := url.Parse(dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	query := u.Query()
+	query.Set("search_path", schema)
+	u.RawQuery = query.Encode()
+
+	store, err := idempotency.OpenPostgresStore(ctx, u.String(), time.Now)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer store.Close()
+
+	record := idempotency.Record{Fingerprint: "test-fingerprint", Token: "test-token", ExpiresAt: time.Now().Add(time.Minute)}
+
+	t.Run("duplicates find the record of the request in progress", func(t *testing.T) {
+		_, started, err := store.Start(ctx, "alice:key-1", record)
+		assert.NoError(t, err)
+		assert.True(t, started)
+
+		existing, started, err := store.Start(ctx, "alice:key-1", record)
+		assert.NoError(t, err)
+		assert.False(t, started)
+		assert.Equal(t, "test-fingerprint", existing.Fingerprint)
+		assert.Nil(t, existing.Response)
+
+		response := idempotency.Response{StatusCode: 202, ContentType: "application/json", Body: []byte(`{}`)}
+		assert.NoError(t, store.Complete(ctx, "alice:key-1", record.Token, response, time.Now().Add(time.Hour)))
+
+		existing, _, err = store.Start(ctx, "alice:key-1", record)
+		assert.NoError(t, err)
+		assert.Equal(t, &response, existing.Response)
+	})
+
+	t.Run("keys released concurrently do not fail the duplicates", func(t *testing.T) {
+		var wg sync.WaitGroup
+
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				for j := 0; j < 50; j++ {
+					_, started, err := store.Start(ctx, "alice:key-2", record)
+					if !assert.NoError(t, err) {
+						return
+					}
+
+					if started {
+						assert.NoError(t, store.Release(ctx, "alice:key-2", record.Token))
+					}
+				}
+			}()
+		}
+
+		wg.Wait()
+	})
+
+	t.Run("requests outliving their lease leave the key to the following one", func(t *testing.T) {
+		slow := idempotency.Record{Fingerprint: "test-fingerprint", Token: "slow-token", ExpiresAt: time.Now().Add(-time.Second)}
+
+		_, started, err := store.Start(ctx, "alice:key-3", slow)
+		assert.NoError(t, err)
+		assert.True(t, started)
+
+		// The lease of the slow request has expired already.
+		_, started, err = store.Start(ctx, "alice:key-3", record)
+		assert.NoError(t, err)
+		assert.True(t, started)
+
+		response := idempotency.Response{StatusCode: 200}
+		assert.NoError(t, store.Complete(ctx, "alice:key-3", slow.Token, response, time.Now().Add(time.Hour)))
+		assert.NoError(t, store.Release(ctx, "alice:key-3", slow.Token))
+
+		existing, started, err := store.Start(ctx, "alice:key-3", record)
+		assert.NoError(t, err)
+		assert.False(t, started)
+		assert.Nil(t, existing.Response)
+
+		response = idempotency.Response{StatusCode: 202, ContentType: "application/json", Body: []byte(`{}`)}
+		assert.NoError(t, store.Complete(ctx, "alice:key-3", record.Token, response, time.Now().Add(time.Hour)))
+
+		existing, _, err = store.Start(ctx, "alice:key-3", record)
+		assert.NoError(t, err)
+		assert.Equal(t, &response, existing.Response)
+	})
+}
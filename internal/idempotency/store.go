@@ -0,0 +1,158 @@
+// Package idempotency implements the Idempotency-Key support of the HTTP API,
+// so that the clients can safely retry the write requests: the response
+// of the first request is replayed for its duplicates.
+package idempotency
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Response is the recorded response of a request.
+type Response struct {
+	StatusCode  int
+	ContentType string
+	Body        []byte
+}
+
+// Record is the state of a request with an Idempotency-Key.
+type Record struct {
+	// Fingerprint identifies the request sent with the key,
+	// to reject the reuse of a key for a different request.
+	Fingerprint string
+
+	// Token identifies the request in progress with the key: only that request
+	// completes or releases it, even after its lease expired and another request
+	// took the key over.
+	Token string
+
+	// Response is nil while the first request is still in progress.
+	Response *Response
+
+	ExpiresAt time.Time
+}
+
+// Store stores the requests with an Idempotency-Key, and their response.
+type Store interface {
+	// Start records a new request in progress with the key, returning true,
+	// unless the key has already been used by a request not expired yet,
+	// whose Record is returned instead.
+	Start(ctx context.Context, key string, record Record) (Record, bool, error)
+
+	// Complete records the response of the request in progress with the key
+	// and the token, keeping it until the specified expiration time.
+	// Nothing is done if the key has been taken over by another request.
+	Complete(ctx context.Context, key, token string, response Response, expiresAt time.Time) error
+
+	// Release forgets the key started with the token, so that the request
+	// can be sent again. Nothing is done if the key has been taken over
+	// by another request.
+	Release(ctx context.Context, key, token string) error
+
+	// Purge deletes the keys expired before the specified time.
+	Purge(ctx context.Context, before time.Time) (int64, error)
+}
+
+var _ Store = &InMemoryStore{}
+
+// InMemoryStore is an in-memory Store implementation,
+// useful for testing and local development.
+type InMemoryStore struct {
+	mx      sync.Mutex
+	now     func() time.Time
+	records map[string]Record
+}
+
+// NewInMemoryStore returns a new empty InMemoryStore instance,
+// using the specified clock to expire the keys.
+func NewInMemoryStore(now func() time.Time) *InMemoryStore {
+	return &InMemoryStore{
+		now:     now,
+		records: make(map[string]Record),
+	}
+}
+
+// Start records a new request in progress with the key, unless the key
+// has already been used by a request not expired yet.
+func (s *InMemoryStore) Start(ctx context.Context, key string, record Record) (Record, bool, error) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	if existing, ok := s.records[key]; ok && existing.ExpiresAt.After(s.now()) {
+		return existing, false, nil
+	}
+
+	record.Response = nil
+	s.records[key] = record
+
+	return record, true, nil
+}
+
+// Complete records the response of the request in progress with the key
+// and the token, keeping it until the specified expiration time.
+func (s *InMemoryStore) Complete(ctx context.Context, key, token string, response Response, expiresAt time.Time) error {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	if record, ok := s.records[key]; ok && record.Token == token {
+		record.Response = &response
+		record.ExpiresAt = expiresAt
+		s.records[key] = record
+	}
+
+	return nil
+}
+
+// Release forgets the key started with the token.
+func (s *InMemoryStore) Release(ctx context.Context, key, token string) error {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	if record, ok := s.records[key]; ok && record.Token == token {
+		delete(s.records, key)
+	}
+
+	return nil
+}
+
+// Purge deletes the keys expired before the specified time.
+func (s *InMemoryStore) Purge(ctx context.Context, before time.Time) (int64, error) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	var purged int64
+
+	for key, record := range s.records {
+		if record.ExpiresAt.Before(before) {
+			delete(s.records, key)
+			purged++
+		}
+	}
+
+	return purged, nil
+}
+
+// PurgePeriodically deletes the expired keys from the Store at every interval,
+// until the context is done.
+func PurgePeriodically(ctx context.Context, store Store, interval time.Duration, now func() time.Time, logger *zap.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			purged, err := store.Purge(ctx, now())
+			if err != nil {
+				logger.Error("Failed to purge expired idempotency keys", zap.Error(err))
+				continue
+			}
+
+			logger.Debug("Expired idempotency keys purged", zap.Int64("keys", purged))
+		}
+	}
+}
@@ -0,0 +1,198 @@
+// Package inspector exposes the Events recorded in the Event Store
+// as they are read by the application, for debugging and auditing.
+package inspector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/eventually-rs/saving-goals-go/internal/schema"
+	"github.com/eventually-rs/saving-goals-go/internal/shredding"
+	"github.com/eventually-rs/saving-goals-go/internal/timetravel"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/eventstore"
+	"github.com/eventually-rs/eventually-go/extension/correlation"
+	"github.com/eventually-rs/eventually-go/query"
+)
+
+const (
+	// DefaultLimit is the number of Events returned in a Page
+	// when no limit is specified.
+	DefaultLimit = 100
+
+	// MaxLimit is the maximum number of Events returned in a Page.
+	MaxLimit = 1000
+)
+
+// ErrUnknownStreamType is returned by StreamHandler when the Stream type requested
+// has not been registered in the Event Store.
+var ErrUnknownStreamType = errors.New("inspector.Stream: unknown stream type")
+
+var _ query.Handler = StreamHandler{}
+
+// StreamQuery is the Domain Query used to fetch a Page of the Events
+// of an Event Stream, starting from the version specified in From.
+//
+// When Names is specified, only the Events registered with one of those
+// versioned names are returned: since the Events are upcast when read,
+// only the names of the current versions match.
+type StreamQuery struct {
+	Type  string
+	ID    string
+	From  int64
+	Limit int
+	Names []string
+}
+
+// Page is the Domain Answer returned from a StreamQuery.
+type Page struct {
+	Events []Event
+
+	// Next is the version to request the following Page from,
+	// or zero if there are no more Events.
+	Next int64
+
+	// Version is the latest version of the Event Stream.
+	Version int64
+}
+
+// Event is an Event of the Event Stream, with its Metadata unpacked.
+//
+// RecordedAt is zero for the Events recorded before the recording time
+// was introduced, and Name is the name of the original payload
+// for the Events that cannot be decrypted anymore.
+type Event struct {
+	Name           string
+	Version        int64
+	SequenceNumber int64
+	RecordedAt     time.Time
+	EventID        string
+	CorrelationID  string
+	CausationID    string
+	Payload        interface{}
+	Metadata       eventually.Metadata
+}
+
+// StreamHandler answers to StreamQuery with the Events decoded
+// by the Event Store, in their current version.
+type StreamHandler struct {
+	EventStore eventstore.Store
+	Events     *schema.Registry
+}
+
+// QueryType binds the StreamQuery type to the handler.
+func (StreamHandler) QueryType() query.Query { return StreamQuery{} }
+
+// Handle returns the Page of the Event Stream requested.
+//
+// ErrUnknownStreamType is returned if the Stream type has not been registered,
+// and schema.ErrUnknownEvent if any of the names specified has not been registered.
+func (h StreamHandler) Handle(ctx context.Context, q query.Query) (query.Answer, error) {
+	query := q.(StreamQuery)
+
+	if err := h.validate(query); err != nil {
+		return nil, err
+	}
+
+	limit := query.Limit
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+
+	names := make(map[string]bool, len(query.Names))
+	for _, name := range query.Names {
+		names[name] = true
+	}
+
+	typed, err := h.EventStore.Type(ctx, query.Type)
+	if err != nil {
+		return nil, fmt.Errorf("inspector.StreamHandler: failed to access stream type: %w", err)
+	}
+
+	events, err := eventstore.StreamToSlice(ctx, func(ctx context.Context, es eventstore.EventStream) error {
+		return typed.Instance(query.ID).Stream(ctx, es, query.From)
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("inspector.StreamHandler: failed to stream events: %w", err)
+	}
+
+	page := Page{Events: make([]Event, 0, limit)}
+
+	for _, event := range events {
+		page.Version = event.Version
+
+		inspected := h.inspect(event)
+		if len(names) > 0 && !names[inspected.Name] {
+			continue
+		}
+
+		if len(page.Events) == limit {
+			if page.Next == 0 {
+				page.Next = event.Version
+			}
+
+			continue
+		}
+
+		page.Events = append(page.Events, inspected)
+	}
+
+	return page, nil
+}
+
+func (h StreamHandler) validate(query StreamQuery) error {
+	known := false
+
+	for _, streamType := range h.Events.StreamTypes() {
+		known = known || streamType == query.Type
+	}
+
+	if !known {
+		return fmt.Errorf("%w: '%s'", ErrUnknownStreamType, query.Type)
+	}
+
+	registered := make(map[string]bool)
+	for _, name := range h.Events.Names() {
+		registered[name] = true
+	}
+
+	for _, name := range query.Names {
+		if !registered[name] {
+			return fmt.Errorf("%w: '%s'", schema.ErrUnknownEvent, name)
+		}
+	}
+
+	return nil
+}
+
+func (h StreamHandler) inspect(event eventstore.Event) Event {
+	inspected := Event{
+		Version:  event.Version,
+		Payload:  event.Payload,
+		Metadata: event.Metadata,
+	}
+
+	if unreadable, ok := event.Payload.(shredding.Unreadable); ok {
+		inspected.Name = unreadable.EventName
+	} else if name, ok := h.Events.NameOf(event.Payload); ok {
+		inspected.Name = name
+	} else {
+		inspected.Name = fmt.Sprintf("%T", event.Payload)
+	}
+
+	inspected.SequenceNumber, _ = event.GlobalSequenceNumber()
+	inspected.RecordedAt, _ = timetravel.AppendedAt(event.Event)
+	inspected.EventID, _ = event.Metadata[correlation.EventIDKey].(string)
+	inspected.CorrelationID, _ = event.Metadata[correlation.CorrelationIDKey].(string)
+	inspected.CausationID, _ = event.Metadata[correlation.CausationIDKey].(string)
+
+	return inspected
+}
@@ -0,0 +1,154 @@
+package inspector_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/eventually-rs/saving-goals-go/internal/inspector"
+	"github.com/eventually-rs/saving-goals-go/internal/schema"
+	"github.com/eventually-rs/saving-goals-go/internal/timetravel"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/eventstore"
+	"github.com/eventually-rs/eventually-go/eventstore/inmemory"
+	"github.com/eventually-rs/eventually-go/extension/correlation"
+	"github.com/stretchr/testify/assert"
+)
+
+type goalSet struct{ Amount float64 }
+
+type goalReached struct{}
+
+var recordedAt = time.Date(2021, time.January, 12, 10, 0, 0, 0, time.UTC)
+
+// handler returns a StreamHandler on an Event Store with a goal Event Stream
+// alternating goalSet and goalReached Events.
+func handler(t *testing.T, events int) inspector.StreamHandler {
+	ctx := context.Background()
+
+	registry, err := schema.NewRegistry(map[string][]schema.EventType{
+		"goal": {
+			{Name: "goal_set", Event: goalSet{}},
+			{Name: "goal_reached", Event: goalReached{}},
+		},
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ids := 0
+	es := eventstore.Store(correlation.WrapEventStore(inmemory.NewEventStore(), func() string {
+		ids++
+		return fmt.Sprintf("id-%d", ids)
+	}))
+
+	es = timetravel.WrapEventStore(es, func() time.Time { return recordedAt })
+
+	if err := registry.Register(ctx, es); err != nil {
+		t.Fatal(err)
+	}
+
+	typed, err := es.Type(ctx, "goal")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < events; i++ {
+		var payload interface{} = goalSet{Amount: float64(i)}
+		if i%2 == 1 {
+			payload = goalReached{}
+		}
+
+		if _, err := typed.Instance("goal-1").Append(ctx, -1, eventually.Event{Payload: payload}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return inspector.StreamHandler{EventStore: es, Events: registry}
+}
+
+func versions(page inspector.Page) []int64 {
+	versions := make([]int64, 0, len(page.Events))
+	for _, event := range page.Events {
+		versions = append(versions, event.Version)
+	}
+
+	return versions
+}
+
+func TestStreamHandler(t *testing.T) {
+	ctx := context.Background()
+	h := handler(t, 5)
+
+	t.Run("events are returned with their metadata", func(t *testing.T) {
+		answer, err := h.Handle(ctx, inspector.StreamQuery{Type: "goal", ID: "goal-1", Limit: 1})
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		page := answer.(inspector.Page)
+		if !assert.Len(t, page.Events, 1) {
+			return
+		}
+
+		event := page.Events[0]
+		assert.Equal(t, "goal_set", event.Name)
+		assert.Equal(t, int64(1), event.Version)
+		assert.Equal(t, int64(1), event.SequenceNumber)
+		assert.Equal(t, recordedAt, event.RecordedAt)
+		assert.Equal(t, "id-2", event.EventID)
+		assert.Equal(t, "id-1", event.CorrelationID)
+		assert.Equal(t, "id-1", event.CausationID)
+		assert.Equal(t, goalSet{Amount: 0}, event.Payload)
+	})
+
+	t.Run("events are paged", func(t *testing.T) {
+		answer, err := h.Handle(ctx, inspector.StreamQuery{Type: "goal", ID: "goal-1", From: 2, Limit: 2})
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		page := answer.(inspector.Page)
+		assert.Equal(t, []int64{2, 3}, versions(page))
+		assert.Equal(t, int64(4), page.Next)
+		assert.Equal(t, int64(5), page.Version)
+
+		answer, err = h.Handle(ctx, inspector.StreamQuery{Type: "goal", ID: "goal-1", From: page.Next, Limit: 2})
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		page = answer.(inspector.Page)
+		assert.Equal(t, []int64{4, 5}, versions(page))
+		assert.Zero(t, page.Next)
+	})
+
+	t.Run("events are filtered by name", func(t *testing.T) {
+		answer, err := h.Handle(ctx, inspector.StreamQuery{
+			Type:  "goal",
+			ID:    "goal-1",
+			Limit: 1,
+			Names: []string{"goal_reached"},
+		})
+
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		page := answer.(inspector.Page)
+		assert.Equal(t, []int64{2}, versions(page))
+		assert.Equal(t, int64(4), page.Next)
+	})
+
+	t.Run("unknown stream types and names are rejected", func(t *testing.T) {
+		_, err := h.Handle(ctx, inspector.StreamQuery{Type: "account", ID: "account-1"})
+		assert.True(t, errors.Is(err, inspector.ErrUnknownStreamType))
+
+		_, err = h.Handle(ctx, inspector.StreamQuery{Type: "goal", ID: "goal-1", Names: []string{"goal_removed"}})
+		assert.True(t, errors.Is(err, schema.ErrUnknownEvent))
+	})
+}
@@ -0,0 +1,72 @@
+// Package problem implements the Problem Details for HTTP APIs (RFC 7807)
+// returned by the HTTP API for the failed requests.
+//
+// Every Problem carries a stable code, which the clients can rely on
+// to tell the errors apart, since the titles and details might change.
+package problem
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+const (
+	// ContentType is the media type of the Problem responses.
+	ContentType = "application/problem+json"
+
+	// TypePrefix is the prefix of the type URI of the Problems,
+	// followed by their code.
+	TypePrefix = "urn:saving-goals:problem:"
+)
+
+// FieldError describes why a field of the request is not valid.
+type FieldError struct {
+	// Field is the path of the field in the request, like "thresholds[0].value",
+	// or the name of the path or query parameter.
+	Field  string `json:"field"`
+	Reason string `json:"reason"`
+}
+
+// Problem is the body of the error responses of the HTTP API.
+type Problem struct {
+	Type   string       `json:"type"`
+	Title  string       `json:"title"`
+	Status int          `json:"status"`
+	Detail string       `json:"detail,omitempty"`
+	Code   string       `json:"code"`
+	Errors []FieldError `json:"errors,omitempty"`
+}
+
+// New returns a new Problem with the specified status, code and title.
+func New(status int, code, title string) Problem {
+	return Problem{
+		Type:   TypePrefix + code,
+		Title:  title,
+		Status: status,
+		Code:   code,
+	}
+}
+
+// WithDetail returns a copy of the Problem with the specified detail,
+// explaining this occurrence of the Problem.
+func (p Problem) WithDetail(detail string) Problem {
+	p.Detail = detail
+	return p
+}
+
+// WithErrors returns a copy of the Problem reporting the invalid fields.
+func (p Problem) WithErrors(errs ...FieldError) Problem {
+	p.Errors = append(p.Errors[:len(p.Errors):len(p.Errors)], errs...)
+	return p
+}
+
+// Write writes the Problem as the response.
+func Write(w http.ResponseWriter, p Problem) {
+	w.Header().Set("Content-Type", ContentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(p.Status)
+
+	// Errors here are caused by the client connection: since the status code
+	// has already been written, there is nothing else to do.
+	_ = json.NewEncoder(w).Encode(p)
+}
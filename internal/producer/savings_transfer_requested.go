@@ -0,0 +1,91 @@
+package producer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/savings"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/sweep"
+	"github.com/eventually-rs/saving-goals-go/resources/messages"
+
+	"github.com/eventually-rs/eventually-go/eventstore"
+	"github.com/eventually-rs/eventually-go/projection"
+	"github.com/segmentio/kafka-go"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var _ projection.Applier = SavingsTransferRequested{}
+
+// SavingsTransferRequested publishes a SavingsTransferRequested integration event
+// on Kafka for every Transfer requested, for the core banking system to execute it.
+//
+// Messages are published at least once: the core banking system should use
+// the transfer identifier to discard duplicated requests.
+type SavingsTransferRequested struct {
+	kafkaWriter *kafka.Writer
+	logger      *zap.Logger
+}
+
+func NewSavingsTransferRequested(kafkaURL string, logger *zap.Logger) SavingsTransferRequested {
+	kafkaWriter := kafka.NewWriter(kafka.WriterConfig{
+		Brokers: []string{kafkaURL},
+		Topic:   "savings-transfers",
+	})
+
+	return SavingsTransferRequested{
+		kafkaWriter: kafkaWriter,
+		logger:      logger,
+	}
+}
+
+func (p SavingsTransferRequested) Close() error { return p.kafkaWriter.Close() }
+
+func (p SavingsTransferRequested) Apply(ctx context.Context, evt eventstore.Event) error {
+	event, ok := evt.Payload.(savings.TransferWasRequested)
+	if !ok {
+		return nil
+	}
+
+	msg, err := proto.Marshal(&messages.SavingsTransferRequested{
+		TransferId:  event.TransferID,
+		AccountId:   event.AccountID,
+		GoalId:      event.GoalID,
+		Amount:      event.Amount,
+		Kind:        sweepKind(event.Kind),
+		RequestedAt: timestamppb.New(event.RequestedAt),
+	})
+
+	if err != nil {
+		return fmt.Errorf("producer.SavingsTransferRequested: failed to marshal message to protobuf: %w", err)
+	}
+
+	err = p.kafkaWriter.WriteMessages(ctx, kafka.Message{
+		Key:   []byte(event.AccountID),
+		Value: msg,
+	})
+
+	if err != nil {
+		return fmt.Errorf("producer.SavingsTransferRequested: failed to write message to kafka: %w", err)
+	}
+
+	p.logger.Debug("Savings transfer requested",
+		zap.String("transferId", event.TransferID),
+		zap.String("accountId", event.AccountID))
+
+	return nil
+}
+
+func sweepKind(kind sweep.Kind) messages.SweepKind {
+	switch kind {
+	case sweep.RoundUp:
+		return messages.SweepKind_SWEEP_KIND_ROUND_UP
+	case sweep.IncomePercentage:
+		return messages.SweepKind_SWEEP_KIND_INCOME_PERCENTAGE
+	case sweep.Leftover:
+		return messages.SweepKind_SWEEP_KIND_LEFTOVER
+	default:
+		return messages.SweepKind_SWEEP_KIND_UNSPECIFIED
+	}
+}
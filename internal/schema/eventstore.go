@@ -0,0 +1,132 @@
+package schema
+
+import (
+	"context"
+
+	"github.com/eventually-rs/eventually-go/eventstore"
+	"golang.org/x/sync/errgroup"
+)
+
+var _ eventstore.Store = EventStoreWrapper{}
+
+// EventStoreWrapper is an eventstore.Store decorator that upcasts the Events
+// read from the underlying Event Store to the current version
+// of their Event type.
+//
+// Use WrapEventStore to create a new instance.
+type EventStoreWrapper struct {
+	eventstore.Store
+	registry *Registry
+}
+
+// WrapEventStore wraps the provided eventstore.Store instance, upcasting
+// the Events read with the Upcasters in the Registry.
+func WrapEventStore(es eventstore.Store, registry *Registry) EventStoreWrapper {
+	return EventStoreWrapper{
+		Store:    es,
+		registry: registry,
+	}
+}
+
+// Type returns an eventstore.Typed instance for the specified Stream type,
+// upcasting its Events.
+func (es EventStoreWrapper) Type(ctx context.Context, typ string) (eventstore.Typed, error) {
+	ts, err := es.Store.Type(ctx, typ)
+	if err != nil {
+		return nil, err
+	}
+
+	return typedEventStoreWrapper{
+		Typed:    ts,
+		registry: es.registry,
+	}, nil
+}
+
+// Stream streams all the Events in the Event Store, upcasting them.
+func (es EventStoreWrapper) Stream(ctx context.Context, stream eventstore.EventStream, from int64) error {
+	return upcastStream(ctx, es.registry, stream, func(ctx context.Context, ch eventstore.EventStream) error {
+		return es.Store.Stream(ctx, ch, from)
+	})
+}
+
+// Subscribe subscribes to all the Events committed in the Event Store, upcasting them.
+func (es EventStoreWrapper) Subscribe(ctx context.Context, stream eventstore.EventStream) error {
+	return upcastStream(ctx, es.registry, stream, func(ctx context.Context, ch eventstore.EventStream) error {
+		return es.Store.Subscribe(ctx, ch)
+	})
+}
+
+type typedEventStoreWrapper struct {
+	eventstore.Typed
+	registry *Registry
+}
+
+func (ts typedEventStoreWrapper) Stream(ctx context.Context, stream eventstore.EventStream, from int64) error {
+	return upcastStream(ctx, ts.registry, stream, func(ctx context.Context, ch eventstore.EventStream) error {
+		return ts.Typed.Stream(ctx, ch, from)
+	})
+}
+
+func (ts typedEventStoreWrapper) Subscribe(ctx context.Context, stream eventstore.EventStream) error {
+	return upcastStream(ctx, ts.registry, stream, func(ctx context.Context, ch eventstore.EventStream) error {
+		return ts.Typed.Subscribe(ctx, ch)
+	})
+}
+
+func (ts typedEventStoreWrapper) Instance(id string) eventstore.Instanced {
+	return instancedEventStoreWrapper{
+		Instanced: ts.Typed.Instance(id),
+		registry:  ts.registry,
+	}
+}
+
+type instancedEventStoreWrapper struct {
+	eventstore.Instanced
+	registry *Registry
+}
+
+func (is instancedEventStoreWrapper) Stream(ctx context.Context, stream eventstore.EventStream, from int64) error {
+	return upcastStream(ctx, is.registry, stream, func(ctx context.Context, ch eventstore.EventStream) error {
+		return is.Instanced.Stream(ctx, ch, from)
+	})
+}
+
+// upcastStream upcasts the Events streamed by the specified function
+// into the provided EventStream, closing it when done.
+func upcastStream(
+	ctx context.Context,
+	registry *Registry,
+	stream eventstore.EventStream,
+	f func(context.Context, eventstore.EventStream) error,
+) error {
+	defer close(stream)
+
+	ch := make(chan eventstore.Event, 1)
+	group, ctx := errgroup.WithContext(ctx)
+
+	group.Go(func() error { return f(ctx, ch) })
+
+	group.Go(func() error {
+		var err error
+
+		// The source channel is always drained, even after a failure,
+		// since the source might not be listening to context cancellation.
+		for event := range ch {
+			if err != nil {
+				continue
+			}
+
+			event.Payload = registry.Upcast(event.Payload)
+
+			select {
+			case stream <- event:
+			case <-ctx.Done():
+				err = ctx.Err()
+			}
+		}
+
+		return err
+	})
+
+	return group.Wait()
+}
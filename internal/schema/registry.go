@@ -0,0 +1,207 @@
+// Package schema versions the Domain Events stored in the Event Store,
+// so that their payloads can change shape without breaking the Events
+// that have already been recorded.
+//
+// Every Event type is registered with a versioned name. Older versions stay
+// registered, to decode the Events recorded with them, together with
+// an Upcaster that transforms them into the following version:
+// Events are always read in their current version.
+package schema
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/eventually-rs/eventually-go/eventstore"
+)
+
+// ErrUnknownEvent is returned when decoding an Event with a name
+// that has not been registered.
+var ErrUnknownEvent = errors.New("schema.Registry: unknown event")
+
+// Name returns the versioned name of an Event type, used to register it
+// in the Event Store, e.g. "account_transaction_was_recorded.v2".
+//
+// The first version keeps the bare name, since the Events recorded
+// before versioning was introduced have been stored with it.
+func Name(name string, version int) string {
+	if version <= 1 {
+		return name
+	}
+
+	return fmt.Sprintf("%s.v%d", name, version)
+}
+
+// Upcaster transforms an Event payload into the following version
+// of its Event type.
+type Upcaster func(event interface{}) interface{}
+
+// EventType is a version of an Event type.
+type EventType struct {
+	Name    string
+	Version int
+
+	// Event is a zero value of the Event payload type for this version.
+	Event interface{}
+
+	// Upcast transforms the Event into the following version.
+	// It must be specified for all the versions but the current one.
+	Upcast Upcaster
+}
+
+// Registry contains all the versions of the Event types of each Stream type.
+//
+// Use NewRegistry to create a new instance.
+type Registry struct {
+	streamTypes map[string]map[string]interface{}
+	types       map[string]reflect.Type
+	names       map[reflect.Type]string
+	upcasters   map[reflect.Type]Upcaster
+}
+
+// NewRegistry returns a new Registry with the specified Event types,
+// by Stream type.
+//
+// An error is returned if the same versioned name, or the same payload type,
+// is registered twice, or if an older version has no Upcaster.
+func NewRegistry(eventTypes map[string][]EventType) (*Registry, error) {
+	r := &Registry{
+		streamTypes: make(map[string]map[string]interface{}, len(eventTypes)),
+		types:       make(map[string]reflect.Type),
+		names:       make(map[reflect.Type]string),
+		upcasters:   make(map[reflect.Type]Upcaster),
+	}
+
+	current := make(map[string]int)
+
+	for streamType, types := range eventTypes {
+		events := make(map[string]interface{}, len(types))
+
+		for _, eventType := range types {
+			name := Name(eventType.Name, eventType.Version)
+			typ := reflect.TypeOf(eventType.Event)
+
+			if _, ok := r.types[name]; ok {
+				return nil, fmt.Errorf("schema.NewRegistry: event '%s' already registered", name)
+			}
+
+			if other, ok := r.names[typ]; ok {
+				return nil, fmt.Errorf("schema.NewRegistry: type %s of event '%s' already registered by '%s'", typ, name, other)
+			}
+
+			r.types[name] = typ
+			r.names[typ] = name
+			events[name] = eventType.Event
+
+			if eventType.Upcast != nil {
+				r.upcasters[typ] = eventType.Upcast
+			}
+
+			if eventType.Version > current[eventType.Name] {
+				current[eventType.Name] = eventType.Version
+			}
+		}
+
+		r.streamTypes[streamType] = events
+	}
+
+	for _, types := range eventTypes {
+		for _, eventType := range types {
+			isCurrent := eventType.Version == current[eventType.Name]
+
+			if !isCurrent && eventType.Upcast == nil {
+				return nil, fmt.Errorf("schema.NewRegistry: event '%s' has no upcaster",
+					Name(eventType.Name, eventType.Version))
+			}
+
+			if isCurrent && eventType.Upcast != nil {
+				return nil, fmt.Errorf("schema.NewRegistry: event '%s' is the current version, but has an upcaster",
+					Name(eventType.Name, eventType.Version))
+			}
+		}
+	}
+
+	return r, nil
+}
+
+// Register registers all the versions of the Event types
+// in the specified Event Store.
+func (r *Registry) Register(ctx context.Context, es eventstore.Store) error {
+	for _, streamType := range r.StreamTypes() {
+		if err := es.Register(ctx, streamType, r.streamTypes[streamType]); err != nil {
+			return fmt.Errorf("schema.Registry: failed to register '%s' events: %w", streamType, err)
+		}
+	}
+
+	return nil
+}
+
+// Names returns the versioned names of all the registered Event types, sorted.
+func (r *Registry) Names() []string {
+	names := make([]string, 0, len(r.types))
+	for name := range r.types {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// StreamTypes returns the Stream types with registered Event types, sorted.
+func (r *Registry) StreamTypes() []string {
+	streamTypes := make([]string, 0, len(r.streamTypes))
+	for streamType := range r.streamTypes {
+		streamTypes = append(streamTypes, streamType)
+	}
+
+	sort.Strings(streamTypes)
+
+	return streamTypes
+}
+
+// NameOf returns the versioned name the type of the Event payload
+// has been registered with, if any.
+func (r *Registry) NameOf(event interface{}) (string, bool) {
+	name, ok := r.names[reflect.TypeOf(event)]
+	return name, ok
+}
+
+// Decode decodes the JSON payload of an Event registered with the specified
+// versioned name, and upcasts it to the current version of its Event type.
+func (r *Registry) Decode(name string, data []byte) (interface{}, error) {
+	typ, ok := r.types[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: '%s'", ErrUnknownEvent, name)
+	}
+
+	vp := reflect.New(typ)
+	if err := json.Unmarshal(data, vp.Interface()); err != nil {
+		return nil, fmt.Errorf("schema.Registry: failed to unmarshal event payload from json: %w", err)
+	}
+
+	return r.Upcast(vp.Elem().Interface()), nil
+}
+
+// Upcast transforms an Event payload into the current version of its Event type.
+//
+// Payloads already in their current version, or of unknown types,
+// are returned as they are.
+func (r *Registry) Upcast(event interface{}) interface{} {
+	// Each Upcaster should be applied at most once: bounding the iterations
+	// protects from Upcasters that do not return the following version.
+	for i := 0; i <= len(r.upcasters); i++ {
+		upcast, ok := r.upcasters[reflect.TypeOf(event)]
+		if !ok {
+			break
+		}
+
+		event = upcast(event)
+	}
+
+	return event
+}
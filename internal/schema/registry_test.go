@@ -0,0 +1,141 @@
+package schema_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/eventually-rs/saving-goals-go/internal/schema"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/eventstore"
+	"github.com/eventually-rs/eventually-go/eventstore/inmemory"
+	"github.com/stretchr/testify/assert"
+)
+
+type goalSetV1 struct{ Amount float64 }
+
+type goalSetV2 struct{ Amount int64 }
+
+type goalSet struct {
+	Amount   int64
+	Currency string
+}
+
+func goalSetTypes() []schema.EventType {
+	return []schema.EventType{
+		{Name: "goal_set", Version: 1, Event: goalSetV1{}, Upcast: func(event interface{}) interface{} {
+			return goalSetV2{Amount: int64(event.(goalSetV1).Amount * 100)}
+		}},
+		{Name: "goal_set", Version: 2, Event: goalSetV2{}, Upcast: func(event interface{}) interface{} {
+			return goalSet{Amount: event.(goalSetV2).Amount, Currency: "EUR"}
+		}},
+		{Name: "goal_set", Version: 3, Event: goalSet{}},
+	}
+}
+
+func TestNewRegistry(t *testing.T) {
+	t.Run("older versions must have an upcaster", func(t *testing.T) {
+		types := goalSetTypes()
+		types[1].Upcast = nil
+
+		_, err := schema.NewRegistry(map[string][]schema.EventType{"goal": types})
+		assert.Error(t, err)
+	})
+
+	t.Run("the current version must not have an upcaster", func(t *testing.T) {
+		types := goalSetTypes()[:2]
+
+		_, err := schema.NewRegistry(map[string][]schema.EventType{"goal": types})
+		assert.Error(t, err)
+	})
+
+	t.Run("payload types cannot be registered twice", func(t *testing.T) {
+		types := append(goalSetTypes(), schema.EventType{Name: "goal_reset", Version: 1, Event: goalSet{}})
+
+		_, err := schema.NewRegistry(map[string][]schema.EventType{"goal": types})
+		assert.Error(t, err)
+	})
+
+	t.Run("versioned names cannot be registered twice", func(t *testing.T) {
+		_, err := schema.NewRegistry(map[string][]schema.EventType{
+			"goal":  goalSetTypes(),
+			"other": {{Name: "goal_set", Version: 3, Event: struct{}{}}},
+		})
+
+		assert.Error(t, err)
+	})
+}
+
+func TestRegistry(t *testing.T) {
+	registry, err := schema.NewRegistry(map[string][]schema.EventType{"goal": goalSetTypes()})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, []string{"goal_set", "goal_set.v2", "goal_set.v3"}, registry.Names())
+
+	t.Run("decode upcasts every older version", func(t *testing.T) {
+		event, err := registry.Decode("goal_set", []byte(`{"Amount": 12.5}`))
+		assert.NoError(t, err)
+		assert.Equal(t, goalSet{Amount: 1250, Currency: "EUR"}, event)
+
+		event, err = registry.Decode("goal_set.v2", []byte(`{"Amount": 1250}`))
+		assert.NoError(t, err)
+		assert.Equal(t, goalSet{Amount: 1250, Currency: "EUR"}, event)
+
+		event, err = registry.Decode("goal_set.v3", []byte(`{"Amount": 1250, "Currency": "GBP"}`))
+		assert.NoError(t, err)
+		assert.Equal(t, goalSet{Amount: 1250, Currency: "GBP"}, event)
+	})
+
+	t.Run("names are found by payload type", func(t *testing.T) {
+		assert.Equal(t, []string{"goal"}, registry.StreamTypes())
+
+		name, ok := registry.NameOf(goalSetV2{})
+		assert.True(t, ok)
+		assert.Equal(t, "goal_set.v2", name)
+
+		_, ok = registry.NameOf(struct{}{})
+		assert.False(t, ok)
+	})
+
+	t.Run("decode fails with unknown events", func(t *testing.T) {
+		_, err := registry.Decode("goal_set.v4", []byte(`{}`))
+		assert.True(t, errors.Is(err, schema.ErrUnknownEvent))
+	})
+
+	t.Run("event store wrapper upcasts streamed events", func(t *testing.T) {
+		ctx := context.Background()
+		es := schema.WrapEventStore(inmemory.NewEventStore(), registry)
+
+		assert.NoError(t, registry.Register(ctx, es))
+
+		typed, err := es.Type(ctx, "goal")
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		_, err = typed.Instance("goal-1").Append(ctx, 0,
+			eventually.Event{Payload: goalSetV1{Amount: 10}},
+			eventually.Event{Payload: goalSetV2{Amount: 2000}},
+			eventually.Event{Payload: goalSet{Amount: 3000, Currency: "GBP"}},
+		)
+
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		events, err := eventstore.StreamToSlice(ctx, func(ctx context.Context, es eventstore.EventStream) error {
+			return typed.Instance("goal-1").Stream(ctx, es, 0)
+		})
+
+		if !assert.NoError(t, err) || !assert.Len(t, events, 3) {
+			return
+		}
+
+		assert.Equal(t, goalSet{Amount: 1000, Currency: "EUR"}, events[0].Payload)
+		assert.Equal(t, goalSet{Amount: 2000, Currency: "EUR"}, events[1].Payload)
+		assert.Equal(t, goalSet{Amount: 3000, Currency: "GBP"}, events[2].Payload)
+	})
+}
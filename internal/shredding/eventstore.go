@@ -0,0 +1,652 @@
+// Package shredding implements crypto-shredding of the personal data
+// stored in the Event Store.
+//
+// Events belonging to a personal data subject, e.g. an Account, are encrypted
+// with a key specific to that subject, and the subject identifier is replaced
+// by a pseudonym in the Event Stream names. Erasing the key makes the whole
+// history of the subject unrecoverable, without touching the Event Store.
+package shredding
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/eventstore"
+	"golang.org/x/sync/errgroup"
+)
+
+// EncryptedEventName is the name used to register Encrypted payloads
+// in the underlying Event Store.
+const EncryptedEventName = "encrypted_event"
+
+// SubjectFunc returns the identifier of the personal data subject
+// an Event Stream belongs to, if any.
+//
+// The subject identifier must be part of the Event Stream id,
+// as it gets replaced with the subject pseudonym.
+type SubjectFunc func(streamID string) (subjectID string, ok bool)
+
+// WholeStream is a SubjectFunc for the Event Streams whose id
+// is the subject identifier, e.g. the Account Event Streams.
+func WholeStream(streamID string) (string, bool) { return streamID, true }
+
+// Encrypted is the payload of the Events committed to the underlying
+// Event Store for Event Streams belonging to a personal data subject.
+type Encrypted struct {
+	// KeyID is the pseudonym of the subject whose key encrypted the Event.
+	KeyID string
+
+	// EventName is the name the original payload has been registered with.
+	EventName string
+
+	// Ciphertext is the JSON representation of the original payload,
+	// encrypted using AES-GCM and prefixed with the nonce used.
+	Ciphertext []byte
+}
+
+// Unreadable is the payload of the Events that cannot be decrypted anymore,
+// since the key of their subject has been forgotten.
+//
+// The Stream name of these Events is left pseudonymized.
+type Unreadable struct {
+	EventName string
+}
+
+// Tombstone is implemented by the Event payloads recording that a personal
+// data subject has been forgotten, so that the read models can erase
+// what they keep about the subject.
+//
+// Tombstones are not encrypted, as they must stay readable after the key
+// of the subject has been forgotten, and their Stream name is left
+// pseudonymized. Their subject is replaced with its pseudonym as in
+// Pseudonymized payloads, and it is restored only if the key of the subject
+// has been resolved before by the same EventStoreWrapper: the read models
+// fed by it can only keep data of the subjects whose Events they have read.
+type Tombstone interface {
+	Pseudonymized
+
+	// ForgottenSubject returns the identifier of the subject forgotten.
+	ForgottenSubject() string
+}
+
+// Pseudonymized is implemented by the Event payloads of Stream types not bound
+// to a single subject, which refer to personal data subjects, e.g. the members
+// of a group of Accounts.
+//
+// The subjects are replaced with their pseudonyms when the Events are appended,
+// and restored when the Events are read: the subjects forgotten in the meantime
+// are restored as empty identifiers.
+type Pseudonymized interface {
+	// MapSubjects returns a copy of the payload, with the identifiers
+	// of the subjects replaced by the result of the function.
+	MapSubjects(f func(subjectID string) string) interface{}
+}
+
+// pseudonymPrefix marks the pseudonyms in Pseudonymized payloads, so that they
+// are not mistaken for the identifiers recorded before the pseudonymization.
+const pseudonymPrefix = "pseudonym:"
+
+var _ eventstore.Store = &EventStoreWrapper{}
+
+// EventStoreWrapper is an eventstore.Store decorator that encrypts the Events
+// of the Event Streams belonging to a personal data subject, as returned
+// by the SubjectFunc registered for their Stream type.
+//
+// Events of other Stream types are left in clear, apart from the subjects
+// referred by Pseudonymized payloads.
+//
+// Use WrapEventStore to create a new instance.
+type EventStoreWrapper struct {
+	eventstore.Store
+
+	keys     KeyStore
+	subjects map[string]SubjectFunc
+
+	mx                  sync.RWMutex
+	eventNameToType     map[string]reflect.Type
+	eventTypeToName     map[reflect.Type]string
+	encryptedRegistered bool
+
+	// subjectsByPseudonym keeps the subjects of the keys resolved, in memory only,
+	// to restore the subject of the Tombstones read after its key has been erased.
+	subjectsByPseudonym map[string]string
+}
+
+// WrapEventStore wraps the provided eventstore.Store instance, encrypting
+// the Events of the Stream types in subjects with the keys in the KeyStore.
+func WrapEventStore(es eventstore.Store, keys KeyStore, subjects map[string]SubjectFunc) *EventStoreWrapper {
+	return &EventStoreWrapper{
+		Store:           es,
+		keys:            keys,
+		subjects:        subjects,
+		eventNameToType: make(map[string]reflect.Type),
+		eventTypeToName: make(map[reflect.Type]string),
+
+		subjectsByPseudonym: make(map[string]string),
+	}
+}
+
+// Register registers the Event types in the underlying Event Store,
+// keeping track of their names to encrypt and decrypt their payloads.
+func (es *EventStoreWrapper) Register(ctx context.Context, typ string, events map[string]interface{}) error {
+	es.mx.Lock()
+	defer es.mx.Unlock()
+
+	for eventName, event := range events {
+		eventType := reflect.TypeOf(event)
+		es.eventNameToType[eventName] = eventType
+		es.eventTypeToName[eventType] = eventName
+	}
+
+	if _, ok := es.subjects[typ]; ok && !es.encryptedRegistered {
+		withEncrypted := make(map[string]interface{}, len(events)+1)
+		for eventName, event := range events {
+			withEncrypted[eventName] = event
+		}
+
+		withEncrypted[EncryptedEventName] = Encrypted{}
+		events = withEncrypted
+	}
+
+	if err := es.Store.Register(ctx, typ, events); err != nil {
+		return err
+	}
+
+	if _, ok := es.subjects[typ]; ok {
+		es.encryptedRegistered = true
+	}
+
+	return nil
+}
+
+// Type returns an eventstore.Typed instance for the specified Stream type,
+// encrypting its Events if the Stream type has a SubjectFunc.
+func (es *EventStoreWrapper) Type(ctx context.Context, typ string) (eventstore.Typed, error) {
+	ts, err := es.Store.Type(ctx, typ)
+	if err != nil {
+		return nil, err
+	}
+
+	return typedEventStoreWrapper{
+		Typed:   ts,
+		parent:  es,
+		subject: es.subjects[typ],
+	}, nil
+}
+
+// Stream streams all the Events in the Event Store, decrypting them
+// if their key is still available.
+func (es *EventStoreWrapper) Stream(ctx context.Context, stream eventstore.EventStream, from int64) error {
+	return es.decryptStream(ctx, stream, func(ctx context.Context, ch eventstore.EventStream) error {
+		return es.Store.Stream(ctx, ch, from)
+	})
+}
+
+// Subscribe subscribes to all the Events committed in the Event Store,
+// decrypting them if their key is still available.
+func (es *EventStoreWrapper) Subscribe(ctx context.Context, stream eventstore.EventStream) error {
+	return es.decryptStream(ctx, stream, func(ctx context.Context, ch eventstore.EventStream) error {
+		return es.Store.Subscribe(ctx, ch)
+	})
+}
+
+type typedEventStoreWrapper struct {
+	eventstore.Typed
+
+	parent  *EventStoreWrapper
+	subject SubjectFunc
+}
+
+func (ts typedEventStoreWrapper) Stream(ctx context.Context, stream eventstore.EventStream, from int64) error {
+	return ts.parent.decryptStream(ctx, stream, func(ctx context.Context, ch eventstore.EventStream) error {
+		return ts.Typed.Stream(ctx, ch, from)
+	})
+}
+
+func (ts typedEventStoreWrapper) Subscribe(ctx context.Context, stream eventstore.EventStream) error {
+	return ts.parent.decryptStream(ctx, stream, func(ctx context.Context, ch eventstore.EventStream) error {
+		return ts.Typed.Subscribe(ctx, ch)
+	})
+}
+
+func (ts typedEventStoreWrapper) Instance(id string) eventstore.Instanced {
+	if ts.subject == nil {
+		return publicInstanceWrapper{Instanced: ts.Typed.Instance(id), parent: ts.parent}
+	}
+
+	subjectID, ok := ts.subject(id)
+	if !ok {
+		return publicInstanceWrapper{Instanced: ts.Typed.Instance(id), parent: ts.parent}
+	}
+
+	return instancedEventStoreWrapper{
+		typed:     ts.Typed,
+		parent:    ts.parent,
+		subject:   ts.subject,
+		streamID:  id,
+		subjectID: subjectID,
+	}
+}
+
+// publicInstanceWrapper appends the Events of the Event Streams
+// not belonging to a subject in clear, only pseudonymizing the subjects
+// of the Pseudonymized payloads.
+type publicInstanceWrapper struct {
+	eventstore.Instanced
+
+	parent *EventStoreWrapper
+}
+
+func (is publicInstanceWrapper) Stream(ctx context.Context, stream eventstore.EventStream, from int64) error {
+	return is.parent.decryptStream(ctx, stream, func(ctx context.Context, ch eventstore.EventStream) error {
+		return is.Instanced.Stream(ctx, ch, from)
+	})
+}
+
+func (is publicInstanceWrapper) Append(ctx context.Context, version int64, events ...eventually.Event) (int64, error) {
+	pseudonymized := make([]eventually.Event, 0, len(events))
+
+	for _, event := range events {
+		event, err := is.parent.pseudonymizeSubjects(ctx, event)
+		if err != nil {
+			return 0, err
+		}
+
+		pseudonymized = append(pseudonymized, event)
+	}
+
+	return is.Instanced.Append(ctx, version, pseudonymized...)
+}
+
+type instancedEventStoreWrapper struct {
+	typed     eventstore.Typed
+	parent    *EventStoreWrapper
+	subject   SubjectFunc
+	streamID  string
+	subjectID string
+}
+
+// Stream streams the Events of the pseudonymized Event Stream.
+//
+// Event Streams of a subject without a key are empty: either the subject
+// has never had any Event, or it has been forgotten.
+func (is instancedEventStoreWrapper) Stream(ctx context.Context, stream eventstore.EventStream, from int64) error {
+	key, err := is.parent.keys.Lookup(ctx, is.subjectID)
+	if errors.Is(err, ErrKeyNotFound) {
+		close(stream)
+		return nil
+	}
+
+	if err != nil {
+		close(stream)
+		return fmt.Errorf("shredding.EventStoreWrapper: failed to lookup key: %w", err)
+	}
+
+	instance := is.typed.Instance(pseudonymize(is.streamID, key, is.subject))
+
+	return is.parent.decryptStream(ctx, stream, func(ctx context.Context, ch eventstore.EventStream) error {
+		return instance.Stream(ctx, ch, from)
+	})
+}
+
+// Append encrypts the Events with the key of the subject, creating
+// a new one if necessary, and appends them to the pseudonymized Event Stream.
+//
+// Tombstone payloads are appended unencrypted, with their subject pseudonymized.
+// Appending to the Event Stream of a forgotten subject fails with ErrSubjectForgotten.
+func (is instancedEventStoreWrapper) Append(ctx context.Context, version int64, events ...eventually.Event) (int64, error) {
+	key, err := is.parent.keys.Key(ctx, is.subjectID)
+	if err != nil {
+		return 0, fmt.Errorf("shredding.EventStoreWrapper: failed to get key: %w", err)
+	}
+
+	encrypted := make([]eventually.Event, 0, len(events))
+
+	for _, event := range events {
+		if _, ok := event.Payload.(Tombstone); ok {
+			event, err := is.parent.pseudonymizeSubjects(ctx, event)
+			if err != nil {
+				return 0, err
+			}
+
+			encrypted = append(encrypted, event)
+
+			continue
+		}
+
+		event, err := is.parent.encrypt(key, event)
+		if err != nil {
+			return 0, err
+		}
+
+		encrypted = append(encrypted, event)
+	}
+
+	return is.typed.Instance(pseudonymize(is.streamID, key, is.subject)).Append(ctx, version, encrypted...)
+}
+
+func (es *EventStoreWrapper) encrypt(key Key, event eventually.Event) (eventually.Event, error) {
+	es.mx.RLock()
+	eventName, ok := es.eventTypeToName[reflect.TypeOf(event.Payload)]
+	es.mx.RUnlock()
+
+	if !ok {
+		return event, fmt.Errorf("shredding.EventStoreWrapper: event type not registered: %T", event.Payload)
+	}
+
+	plaintext, err := json.Marshal(event.Payload)
+	if err != nil {
+		return event, fmt.Errorf("shredding.EventStoreWrapper: failed to marshal event payload to json: %w", err)
+	}
+
+	aead, err := newAEAD(key)
+	if err != nil {
+		return event, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return event, fmt.Errorf("shredding.EventStoreWrapper: failed to generate nonce: %w", err)
+	}
+
+	// The event name is authenticated too, so that a ciphertext
+	// cannot be decoded into a different event type.
+	event.Payload = Encrypted{
+		KeyID:      key.Pseudonym,
+		EventName:  eventName,
+		Ciphertext: aead.Seal(nonce, nonce, plaintext, []byte(eventName)),
+	}
+
+	return event, nil
+}
+
+// pseudonymizeSubjects replaces the subjects of Pseudonymized payloads
+// with their pseudonyms, creating new keys if necessary.
+//
+// Subjects already forgotten are replaced with an empty identifier.
+func (es *EventStoreWrapper) pseudonymizeSubjects(ctx context.Context, event eventually.Event) (eventually.Event, error) {
+	payload, ok := event.Payload.(Pseudonymized)
+	if !ok {
+		return event, nil
+	}
+
+	var err error
+
+	event.Payload = payload.MapSubjects(func(subjectID string) string {
+		if err != nil || subjectID == "" {
+			return subjectID
+		}
+
+		key, keyErr := es.keys.Key(ctx, subjectID)
+		if errors.Is(keyErr, ErrSubjectForgotten) {
+			return ""
+		}
+
+		if keyErr != nil {
+			err = fmt.Errorf("shredding.EventStoreWrapper: failed to get key: %w", keyErr)
+			return subjectID
+		}
+
+		return pseudonymPrefix + key.Pseudonym
+	})
+
+	return event, err
+}
+
+// restoreSubjects replaces the pseudonyms of Pseudonymized payloads
+// with the identifiers of their subjects, or with an empty identifier
+// if the subject has been forgotten.
+func (es *EventStoreWrapper) restoreSubjects(
+	ctx context.Context,
+	keys streamKeys,
+	event eventstore.Event,
+) (eventstore.Event, error) {
+	payload, ok := event.Payload.(Pseudonymized)
+	if !ok {
+		return event, nil
+	}
+
+	var err error
+
+	event.Payload = payload.MapSubjects(func(pseudonym string) string {
+		if err != nil || !strings.HasPrefix(pseudonym, pseudonymPrefix) {
+			return pseudonym
+		}
+
+		key, keyErr := keys.byPseudonym(ctx, strings.TrimPrefix(pseudonym, pseudonymPrefix))
+		if errors.Is(keyErr, ErrKeyNotFound) {
+			return ""
+		}
+
+		if keyErr != nil {
+			err = keyErr
+			return pseudonym
+		}
+
+		return key.SubjectID
+	})
+
+	return event, err
+}
+
+// restoreTombstone replaces the pseudonym of the subject of a Tombstone
+// with the subject identifier, if its key has been resolved before,
+// and leaves it pseudonymized otherwise.
+//
+// The key of the subject is not kept for the rest of the stream.
+func (es *EventStoreWrapper) restoreTombstone(
+	ctx context.Context,
+	keys streamKeys,
+	event eventstore.Event,
+) (eventstore.Event, error) {
+	var err error
+
+	event.Payload = event.Payload.(Tombstone).MapSubjects(func(subject string) string {
+		if err != nil || !strings.HasPrefix(subject, pseudonymPrefix) {
+			return subject
+		}
+
+		pseudonym := strings.TrimPrefix(subject, pseudonymPrefix)
+
+		// The key is resolved if still available, as no other Event
+		// of the subject might have been read before.
+		if _, keyErr := keys.byPseudonym(ctx, pseudonym); keyErr != nil && !errors.Is(keyErr, ErrKeyNotFound) {
+			err = keyErr
+			return subject
+		}
+
+		delete(keys.resolved, pseudonym)
+
+		es.mx.RLock()
+		subjectID, ok := es.subjectsByPseudonym[pseudonym]
+		es.mx.RUnlock()
+
+		if !ok {
+			return subject
+		}
+
+		return subjectID
+	})
+
+	return event, err
+}
+
+func (es *EventStoreWrapper) decrypt(ctx context.Context, keys streamKeys, event eventstore.Event) (eventstore.Event, error) {
+	if _, ok := event.Payload.(Tombstone); ok {
+		return es.restoreTombstone(ctx, keys, event)
+	}
+
+	encrypted, ok := event.Payload.(Encrypted)
+	if !ok {
+		return es.restoreSubjects(ctx, keys, event)
+	}
+
+	key, err := keys.byPseudonym(ctx, encrypted.KeyID)
+	if errors.Is(err, ErrKeyNotFound) {
+		event.Payload = Unreadable{EventName: encrypted.EventName}
+		return event, nil
+	}
+
+	if err != nil {
+		return event, err
+	}
+
+	es.mx.RLock()
+	eventType, ok := es.eventNameToType[encrypted.EventName]
+	es.mx.RUnlock()
+
+	if !ok {
+		return event, fmt.Errorf("shredding.EventStoreWrapper: received unregistered event '%s'", encrypted.EventName)
+	}
+
+	aead, err := newAEAD(key)
+	if err != nil {
+		return event, err
+	}
+
+	if len(encrypted.Ciphertext) < aead.NonceSize() {
+		return event, fmt.Errorf("shredding.EventStoreWrapper: ciphertext is too short")
+	}
+
+	nonce, ciphertext := encrypted.Ciphertext[:aead.NonceSize()], encrypted.Ciphertext[aead.NonceSize():]
+
+	plaintext, err := aead.Open(nil, nonce, ciphertext, []byte(encrypted.EventName))
+	if err != nil {
+		return event, fmt.Errorf("shredding.EventStoreWrapper: failed to decrypt event payload: %w", err)
+	}
+
+	vp := reflect.New(eventType)
+	if err := json.Unmarshal(plaintext, vp.Interface()); err != nil {
+		return event, fmt.Errorf("shredding.EventStoreWrapper: failed to unmarshal event payload from json: %w", err)
+	}
+
+	event.Payload = vp.Elem().Interface()
+	event.StreamName = strings.Replace(event.StreamName, key.Pseudonym, key.SubjectID, 1)
+
+	return event, nil
+}
+
+// decryptStream decrypts the Events streamed by the specified function
+// into the provided EventStream, closing it when done.
+//
+// The key of each subject is looked up once per stream.
+func (es *EventStoreWrapper) decryptStream(
+	ctx context.Context,
+	stream eventstore.EventStream,
+	f func(context.Context, eventstore.EventStream) error,
+) error {
+	defer close(stream)
+
+	ch := make(chan eventstore.Event, 1)
+	keys := streamKeys{parent: es, resolved: make(map[string]resolvedKey)}
+	group, ctx := errgroup.WithContext(ctx)
+
+	group.Go(func() error { return f(ctx, ch) })
+
+	group.Go(func() error {
+		var err error
+
+		// The source channel is always drained, even after a failure,
+		// since the source might not be listening to context cancellation.
+		for event := range ch {
+			if err != nil {
+				continue
+			}
+
+			if event, err = es.decrypt(ctx, keys, event); err != nil {
+				continue
+			}
+
+			select {
+			case stream <- event:
+			case <-ctx.Done():
+				err = ctx.Err()
+			}
+		}
+
+		return err
+	})
+
+	return group.Wait()
+}
+
+// streamKeys resolves the keys of the Events of a single stream,
+// looking up each of them in the KeyStore only once.
+type streamKeys struct {
+	parent   *EventStoreWrapper
+	resolved map[string]resolvedKey
+}
+
+type resolvedKey struct {
+	key Key
+	err error
+}
+
+// byPseudonym returns the key with the specified pseudonym,
+// or ErrKeyNotFound if it has been forgotten.
+func (sk streamKeys) byPseudonym(ctx context.Context, pseudonym string) (Key, error) {
+	if resolved, ok := sk.resolved[pseudonym]; ok {
+		return resolved.key, resolved.err
+	}
+
+	key, err := sk.parent.keys.ByPseudonym(ctx, pseudonym)
+	if err != nil && !errors.Is(err, ErrKeyNotFound) {
+		return Key{}, fmt.Errorf("shredding.EventStoreWrapper: failed to lookup key: %w", err)
+	}
+
+	if err == nil {
+		sk.parent.mx.Lock()
+		sk.parent.subjectsByPseudonym[key.Pseudonym] = key.SubjectID
+		sk.parent.mx.Unlock()
+	}
+
+	sk.resolved[pseudonym] = resolvedKey{key: key, err: err}
+
+	return key, err
+}
+
+func newAEAD(key Key) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key.Secret)
+	if err != nil {
+		return nil, fmt.Errorf("shredding.newAEAD: invalid key: %w", err)
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("shredding.newAEAD: failed to create cipher: %w", err)
+	}
+
+	return aead, nil
+}
+
+// pseudonymize replaces the subject identifier in the Stream id with its
+// pseudonym, choosing the occurrence the SubjectFunc extracts the subject from,
+// as the identifier might appear in other parts of the Stream id too.
+func pseudonymize(streamID string, key Key, subject SubjectFunc) string {
+	for from := 0; from < len(streamID); {
+		i := strings.Index(streamID[from:], key.SubjectID)
+		if i < 0 {
+			break
+		}
+
+		i += from
+		pseudonymized := streamID[:i] + key.Pseudonym + streamID[i+len(key.SubjectID):]
+
+		if subjectID, ok := subject(pseudonymized); ok && subjectID == key.Pseudonym {
+			return pseudonymized
+		}
+
+		from = i + 1
+	}
+
+	return strings.Replace(streamID, key.SubjectID, key.Pseudonym, 1)
+}
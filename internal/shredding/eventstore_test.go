@@ -0,0 +1,357 @@
+package shredding_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/eventually-rs/saving-goals-go/internal/shredding"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/eventstore"
+	"github.com/eventually-rs/eventually-go/eventstore/inmemory"
+	"github.com/stretchr/testify/assert"
+)
+
+type personalEvent struct {
+	Name string
+}
+
+type publicEvent struct {
+	Value int
+}
+
+type forgottenEvent struct {
+	SubjectID string
+}
+
+func (e forgottenEvent) ForgottenSubject() string { return e.SubjectID }
+
+func (e forgottenEvent) MapSubjects(f func(string) string) interface{} {
+	return forgottenEvent{SubjectID: f(e.SubjectID)}
+}
+
+type groupEvent struct {
+	Members []string
+}
+
+func (e groupEvent) MapSubjects(f func(string) string) interface{} {
+	members := make([]string, 0, len(e.Members))
+	for _, member := range e.Members {
+		members = append(members, f(member))
+	}
+
+	return groupEvent{Members: members}
+}
+
+func accountOfMonth(streamID string) (string, bool) {
+	parts := strings.Split(streamID, ":")
+	if len(parts) != 4 || parts[0] != "account" {
+		return "", false
+	}
+
+	return parts[1], true
+}
+
+type countingKeyStore struct {
+	shredding.KeyStore
+
+	lookups int
+}
+
+func (s *countingKeyStore) ByPseudonym(ctx context.Context, pseudonym string) (shredding.Key, error) {
+	s.lookups++
+	return s.KeyStore.ByPseudonym(ctx, pseudonym)
+}
+
+func setup(t *testing.T) (*inmemory.EventStore, *shredding.InMemoryKeyStore, *shredding.EventStoreWrapper) {
+	inner := inmemory.NewEventStore()
+	keys := shredding.NewInMemoryKeyStore()
+
+	return inner, keys, wrap(t, inner, keys)
+}
+
+// wrap returns a new EventStoreWrapper of the Event Store, as created
+// by a new process using the same Event Store and keys.
+func wrap(t *testing.T, inner eventstore.Store, keys shredding.KeyStore) *shredding.EventStoreWrapper {
+	ctx := context.Background()
+
+	store := shredding.WrapEventStore(inner, keys, map[string]shredding.SubjectFunc{
+		"account": shredding.WholeStream,
+		"monthly": accountOfMonth,
+	})
+
+	assert.NoError(t, store.Register(ctx, "account", map[string]interface{}{
+		"personal_event":  personalEvent{},
+		"forgotten_event": forgottenEvent{},
+	}))
+
+	assert.NoError(t, store.Register(ctx, "monthly", map[string]interface{}{
+		"personal_monthly_event": publicEvent{},
+	}))
+
+	assert.NoError(t, store.Register(ctx, "public", map[string]interface{}{
+		"public_event": struct{}{},
+		"group_event":  groupEvent{},
+	}))
+
+	return store
+}
+
+func appendEvent(t *testing.T, store eventstore.Store, typ, id string, payload interface{}) {
+	ctx := context.Background()
+
+	typed, err := store.Type(ctx, typ)
+	assert.NoError(t, err)
+
+	_, err = typed.Instance(id).Append(ctx, -1, eventually.Event{Payload: payload})
+	assert.NoError(t, err)
+}
+
+func streamAll(t *testing.T, store eventstore.Streamer) []eventstore.Event {
+	events, err := eventstore.StreamToSlice(context.Background(), func(ctx context.Context, es eventstore.EventStream) error {
+		return store.Stream(ctx, es, 0)
+	})
+
+	assert.NoError(t, err)
+
+	return events
+}
+
+func TestEventStoreWrapper(t *testing.T) {
+	t.Run("personal events are encrypted in pseudonymized streams", func(t *testing.T) {
+		inner, keys, store := setup(t)
+		appendEvent(t, store, "account", "test-account", personalEvent{Name: "John"})
+		appendEvent(t, store, "monthly", "account:test-account:month:2021-03", publicEvent{Value: 42})
+
+		key, err := keys.Lookup(context.Background(), "test-account")
+		assert.NoError(t, err)
+
+		raw := streamAll(t, inner)
+		if !assert.Len(t, raw, 2) {
+			return
+		}
+
+		assert.Equal(t, key.Pseudonym, raw[0].StreamName)
+		assert.Equal(t, "account:"+key.Pseudonym+":month:2021-03", raw[1].StreamName)
+
+		for _, event := range raw {
+			encrypted, ok := event.Payload.(shredding.Encrypted)
+			if !assert.True(t, ok, "payload", event.Payload) {
+				continue
+			}
+			assert.Equal(t, key.Pseudonym, encrypted.KeyID)
+			assert.NotContains(t, string(encrypted.Ciphertext), "John")
+		}
+
+		decrypted := streamAll(t, store)
+		if !assert.Len(t, decrypted, 2) {
+			return
+		}
+
+		assert.Equal(t, "test-account", decrypted[0].StreamName)
+		assert.Equal(t, personalEvent{Name: "John"}, decrypted[0].Payload)
+		assert.Equal(t, "account:test-account:month:2021-03", decrypted[1].StreamName)
+		assert.Equal(t, publicEvent{Value: 42}, decrypted[1].Payload)
+
+		typed, err := store.Type(context.Background(), "account")
+		assert.NoError(t, err)
+
+		instance := streamAll(t, typed.Instance("test-account"))
+		if !assert.Len(t, instance, 1) {
+			return
+		}
+		assert.Equal(t, personalEvent{Name: "John"}, instance[0].Payload)
+	})
+
+	t.Run("keys are looked up once per stream", func(t *testing.T) {
+		inner, keys, store := setup(t)
+		appendEvent(t, store, "account", "test-account", personalEvent{Name: "John"})
+		appendEvent(t, store, "account", "test-account", personalEvent{Name: "Johnny"})
+		appendEvent(t, store, "monthly", "account:test-account:month:2021-03", publicEvent{Value: 42})
+		appendEvent(t, store, "public", "test-group", groupEvent{Members: []string{"test-account"}})
+
+		counting := &countingKeyStore{KeyStore: keys}
+
+		assert.Len(t, streamAll(t, wrap(t, inner, counting)), 4)
+		assert.Equal(t, 1, counting.lookups)
+	})
+
+	t.Run("the subject occurrence of the stream id is pseudonymized", func(t *testing.T) {
+		inner, keys, store := setup(t)
+		appendEvent(t, store, "monthly", "account:account:month:2021-03", publicEvent{Value: 42})
+
+		key, err := keys.Lookup(context.Background(), "account")
+		assert.NoError(t, err)
+
+		raw := streamAll(t, inner)
+		if assert.Len(t, raw, 1) {
+			assert.Equal(t, "account:"+key.Pseudonym+":month:2021-03", raw[0].StreamName)
+		}
+
+		typed, err := store.Type(context.Background(), "monthly")
+		assert.NoError(t, err)
+
+		instance := streamAll(t, typed.Instance("account:account:month:2021-03"))
+		if assert.Len(t, instance, 1) {
+			assert.Equal(t, "account:account:month:2021-03", instance[0].StreamName)
+		}
+	})
+
+	t.Run("subjects of pseudonymized payloads are replaced", func(t *testing.T) {
+		inner, keys, store := setup(t)
+		appendEvent(t, store, "public", "test-group", groupEvent{Members: []string{"test-account", "another-account"}})
+
+		key, err := keys.Lookup(context.Background(), "test-account")
+		assert.NoError(t, err)
+
+		raw := streamAll(t, inner)
+		if assert.Len(t, raw, 1) {
+			assert.Equal(t, "test-group", raw[0].StreamName)
+			assert.Contains(t, raw[0].Payload.(groupEvent).Members, "pseudonym:"+key.Pseudonym)
+			assert.NotContains(t, raw[0].Payload.(groupEvent).Members, "test-account")
+		}
+
+		events := streamAll(t, store)
+		if assert.Len(t, events, 1) {
+			assert.Equal(t, groupEvent{Members: []string{"test-account", "another-account"}}, events[0].Payload)
+		}
+
+		assert.NoError(t, keys.Forget(context.Background(), "test-account"))
+
+		typed, err := store.Type(context.Background(), "public")
+		assert.NoError(t, err)
+
+		instance := streamAll(t, typed.Instance("test-group"))
+		if assert.Len(t, instance, 1) {
+			assert.Equal(t, groupEvent{Members: []string{"", "another-account"}}, instance[0].Payload)
+		}
+
+		// Forgotten subjects are not pseudonymized again.
+		appendEvent(t, store, "public", "test-group", groupEvent{Members: []string{"test-account"}})
+
+		raw = streamAll(t, inner)
+		if assert.Len(t, raw, 2) {
+			assert.Equal(t, groupEvent{Members: []string{""}}, raw[1].Payload)
+		}
+	})
+
+	t.Run("other events are left untouched", func(t *testing.T) {
+		inner, _, store := setup(t)
+		appendEvent(t, store, "monthly", "household:test-household:month:2021-03", publicEvent{Value: 42})
+
+		raw := streamAll(t, inner)
+		if !assert.Len(t, raw, 1) {
+			return
+		}
+
+		assert.Equal(t, "household:test-household:month:2021-03", raw[0].StreamName)
+		assert.Equal(t, publicEvent{Value: 42}, raw[0].Payload)
+	})
+
+	t.Run("forgotten subjects are unreadable", func(t *testing.T) {
+		_, keys, store := setup(t)
+		appendEvent(t, store, "account", "test-account", personalEvent{Name: "John"})
+		appendEvent(t, store, "account", "another-account", personalEvent{Name: "Jane"})
+
+		key, err := keys.Lookup(context.Background(), "test-account")
+		assert.NoError(t, err)
+
+		assert.NoError(t, keys.Forget(context.Background(), "test-account"))
+
+		events := streamAll(t, store)
+		if !assert.Len(t, events, 2) {
+			return
+		}
+
+		assert.Equal(t, key.Pseudonym, events[0].StreamName)
+		assert.Equal(t, shredding.Unreadable{EventName: "personal_event"}, events[0].Payload)
+		assert.Equal(t, personalEvent{Name: "Jane"}, events[1].Payload)
+
+		typed, err := store.Type(context.Background(), "account")
+		assert.NoError(t, err)
+
+		assert.Empty(t, streamAll(t, typed.Instance("test-account")))
+	})
+
+	t.Run("forgotten subjects get no new key", func(t *testing.T) {
+		ctx := context.Background()
+		_, keys, store := setup(t)
+		appendEvent(t, store, "account", "test-account", personalEvent{Name: "John"})
+
+		assert.NoError(t, keys.Forget(ctx, "test-account"))
+
+		_, err := keys.Key(ctx, "test-account")
+		assert.True(t, errors.Is(err, shredding.ErrSubjectForgotten))
+
+		typed, err := store.Type(ctx, "account")
+		assert.NoError(t, err)
+
+		_, err = typed.Instance("test-account").Append(ctx, -1, eventually.Event{Payload: personalEvent{Name: "John"}})
+		assert.True(t, errors.Is(err, shredding.ErrSubjectForgotten))
+
+		// Subjects forgotten before having a key are refused as well.
+		assert.NoError(t, keys.Forget(ctx, "another-account"))
+
+		_, err = keys.Key(ctx, "another-account")
+		assert.True(t, errors.Is(err, shredding.ErrSubjectForgotten))
+	})
+
+	t.Run("tombstones stay readable after the subject is forgotten", func(t *testing.T) {
+		inner, keys, store := setup(t)
+		appendEvent(t, store, "account", "test-account", personalEvent{Name: "John"})
+
+		// The subject is read before it is forgotten, as the read models do.
+		assert.Len(t, streamAll(t, store), 1)
+
+		appendEvent(t, store, "account", "test-account", forgottenEvent{SubjectID: "test-account"})
+
+		key, err := keys.Lookup(context.Background(), "test-account")
+		assert.NoError(t, err)
+
+		assert.NoError(t, keys.Forget(context.Background(), "test-account"))
+
+		raw := streamAll(t, inner)
+		if assert.Len(t, raw, 2) {
+			assert.Equal(t, forgottenEvent{SubjectID: "pseudonym:" + key.Pseudonym}, raw[1].Payload)
+		}
+
+		events := streamAll(t, store)
+		if !assert.Len(t, events, 2) {
+			return
+		}
+
+		assert.Equal(t, shredding.Unreadable{EventName: "personal_event"}, events[0].Payload)
+		assert.Equal(t, key.Pseudonym, events[1].StreamName)
+		assert.Equal(t, forgottenEvent{SubjectID: "test-account"}, events[1].Payload)
+
+		// Processes that never read the subject keep nothing to erase.
+		events = streamAll(t, wrap(t, inner, keys))
+		if assert.Len(t, events, 2) {
+			assert.Equal(t, forgottenEvent{SubjectID: "pseudonym:" + key.Pseudonym}, events[1].Payload)
+		}
+	})
+}
+
+type recordingApplier struct {
+	applied []eventstore.Event
+}
+
+func (r *recordingApplier) Apply(ctx context.Context, event eventstore.Event) error {
+	r.applied = append(r.applied, event)
+	return nil
+}
+
+func TestProjectionWrapper(t *testing.T) {
+	applier := new(recordingApplier)
+	projection := shredding.WrapProjection(applier)
+
+	unreadable := eventstore.Event{Event: eventually.Event{Payload: shredding.Unreadable{EventName: "personal_event"}}}
+	readable := eventstore.Event{Event: eventually.Event{Payload: personalEvent{Name: "John"}}}
+
+	assert.NoError(t, projection.Apply(context.Background(), unreadable))
+	assert.NoError(t, projection.Apply(context.Background(), readable))
+
+	assert.Equal(t, []eventstore.Event{readable}, applier.applied)
+}
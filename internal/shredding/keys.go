@@ -0,0 +1,150 @@
+package shredding
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// ErrKeyNotFound is returned by the KeyStore when the subject has no key,
+// either because it has never been created or because it has been forgotten.
+var ErrKeyNotFound = fmt.Errorf("shredding.KeyStore: key not found")
+
+// ErrSubjectForgotten is returned by the KeyStore when a key is requested
+// for a subject that has been forgotten, so that no new data of the subject
+// is encrypted with a key that could be read again.
+var ErrSubjectForgotten = fmt.Errorf("shredding.KeyStore: subject has been forgotten")
+
+// keySize is the size of the secrets used to encrypt the Events, for AES-256.
+const keySize = 32
+
+// Key is the encryption key of a personal data subject, e.g. an Account.
+//
+// The Pseudonym is used in place of the subject identifier in the Event Streams
+// names, so that erasing the Key leaves nothing pointing back to the subject.
+type Key struct {
+	SubjectID string
+	Pseudonym string
+	Secret    []byte
+}
+
+// KeyStore stores the encryption keys of the personal data subjects.
+type KeyStore interface {
+	// Key returns the key of the subject, creating a new one if it has none,
+	// or ErrSubjectForgotten if the subject has been forgotten.
+	Key(ctx context.Context, subjectID string) (Key, error)
+
+	// Lookup returns the key of the subject, or ErrKeyNotFound if it has none.
+	Lookup(ctx context.Context, subjectID string) (Key, error)
+
+	// ByPseudonym returns the key with the specified pseudonym,
+	// or ErrKeyNotFound if it has been forgotten.
+	ByPseudonym(ctx context.Context, pseudonym string) (Key, error)
+
+	// Forget erases the key of the subject, making all the data
+	// encrypted with it unrecoverable, and refuses any new key for it.
+	Forget(ctx context.Context, subjectID string) error
+}
+
+func newKey(subjectID string) (Key, error) {
+	secret := make([]byte, keySize)
+	if _, err := rand.Read(secret); err != nil {
+		return Key{}, fmt.Errorf("shredding.newKey: failed to generate secret: %w", err)
+	}
+
+	return Key{
+		SubjectID: subjectID,
+		Pseudonym: uuid.New().String(),
+		Secret:    secret,
+	}, nil
+}
+
+var _ KeyStore = &InMemoryKeyStore{}
+
+// InMemoryKeyStore is an in-memory KeyStore implementation,
+// useful for testing and local development.
+type InMemoryKeyStore struct {
+	mx          sync.RWMutex
+	bySubject   map[string]Key
+	byPseudonym map[string]Key
+	forgotten   map[string]struct{}
+}
+
+// NewInMemoryKeyStore returns a new empty InMemoryKeyStore instance.
+func NewInMemoryKeyStore() *InMemoryKeyStore {
+	return &InMemoryKeyStore{
+		bySubject:   make(map[string]Key),
+		byPseudonym: make(map[string]Key),
+		forgotten:   make(map[string]struct{}),
+	}
+}
+
+// Key returns the key of the subject, creating a new one if it has none,
+// or ErrSubjectForgotten if the subject has been forgotten.
+func (s *InMemoryKeyStore) Key(ctx context.Context, subjectID string) (Key, error) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	if _, ok := s.forgotten[subjectID]; ok {
+		return Key{}, ErrSubjectForgotten
+	}
+
+	if key, ok := s.bySubject[subjectID]; ok {
+		return key, nil
+	}
+
+	key, err := newKey(subjectID)
+	if err != nil {
+		return Key{}, err
+	}
+
+	s.bySubject[subjectID] = key
+	s.byPseudonym[key.Pseudonym] = key
+
+	return key, nil
+}
+
+// Lookup returns the key of the subject, or ErrKeyNotFound if it has none.
+func (s *InMemoryKeyStore) Lookup(ctx context.Context, subjectID string) (Key, error) {
+	s.mx.RLock()
+	defer s.mx.RUnlock()
+
+	key, ok := s.bySubject[subjectID]
+	if !ok {
+		return Key{}, ErrKeyNotFound
+	}
+
+	return key, nil
+}
+
+// ByPseudonym returns the key with the specified pseudonym,
+// or ErrKeyNotFound if it has been forgotten.
+func (s *InMemoryKeyStore) ByPseudonym(ctx context.Context, pseudonym string) (Key, error) {
+	s.mx.RLock()
+	defer s.mx.RUnlock()
+
+	key, ok := s.byPseudonym[pseudonym]
+	if !ok {
+		return Key{}, ErrKeyNotFound
+	}
+
+	return key, nil
+}
+
+// Forget erases the key of the subject, if any, and refuses any new key for it.
+func (s *InMemoryKeyStore) Forget(ctx context.Context, subjectID string) error {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	s.forgotten[subjectID] = struct{}{}
+
+	if key, ok := s.bySubject[subjectID]; ok {
+		delete(s.byPseudonym, key.Pseudonym)
+		delete(s.bySubject, subjectID)
+	}
+
+	return nil
+}
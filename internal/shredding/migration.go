@@ -0,0 +1,276 @@
+package shredding
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/eventually-rs/eventually-go/eventstore"
+)
+
+// MigratePostgresEventStore encrypts in place the Events appended in clear
+// to a Postgres Event Store before the EventStoreWrapper was in use, so that
+// they are read from the pseudonymized Event Streams of their subjects,
+// and erased along with their keys.
+//
+// The Events keep their global sequence numbers and metadata, so that the
+// subscriptions do not receive them again. The Event Streams of the subjects
+// already forgotten are deleted, and the subjects of the Pseudonymized payloads
+// recorded in clear are replaced with their pseudonyms.
+//
+// The EventStoreWrapper must wrap the Postgres Event Store of the database,
+// with all the Event types registered. Concurrent migrations of the same
+// Event Store are safe, and nothing is done once all the Events are migrated.
+func MigratePostgresEventStore(ctx context.Context, dsn string, es *EventStoreWrapper) error {
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return fmt.Errorf("shredding.MigratePostgresEventStore: failed to open connection with the db: %w", err)
+	}
+
+	defer db.Close()
+
+	streams, pseudonymized, err := es.eventsInClear(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, stream := range streams {
+		if err := es.migrateStream(ctx, db, stream); err != nil {
+			return err
+		}
+	}
+
+	for _, event := range pseudonymized {
+		if err := es.migrateSubjects(ctx, db, event); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// streamInClear is an Event Stream of a subject appended in clear.
+type streamInClear struct {
+	streamType string
+	streamID   string
+	subject    SubjectFunc
+	events     []eventstore.Event
+}
+
+// eventsInClear returns the Event Streams of the subjects appended in clear,
+// and the Events with Pseudonymized payloads referring to subjects in clear.
+func (es *EventStoreWrapper) eventsInClear(ctx context.Context) ([]*streamInClear, []eventstore.Event, error) {
+	var (
+		streams       []*streamInClear
+		pseudonymized []eventstore.Event
+	)
+
+	byID := make(map[[2]string]*streamInClear)
+	ch := make(chan eventstore.Event, 1)
+	errs := make(chan error, 1)
+
+	go func() { errs <- es.Store.Stream(ctx, ch, 0) }()
+
+	for event := range ch {
+		switch event.Payload.(type) {
+		case Encrypted, Tombstone:
+			continue
+		}
+
+		if subject, ok := es.subjects[event.StreamType]; ok {
+			if _, ok := subject(event.StreamName); ok {
+				id := [2]string{event.StreamType, event.StreamName}
+
+				stream, ok := byID[id]
+				if !ok {
+					stream = &streamInClear{streamType: event.StreamType, streamID: event.StreamName, subject: subject}
+					byID[id] = stream
+					streams = append(streams, stream)
+				}
+
+				stream.events = append(stream.events, event)
+
+				continue
+			}
+		}
+
+		if payload, ok := event.Payload.(Pseudonymized); ok && subjectsInClear(payload) {
+			pseudonymized = append(pseudonymized, event)
+		}
+	}
+
+	if err := <-errs; err != nil {
+		return nil, nil, fmt.Errorf("shredding.MigratePostgresEventStore: failed to stream events: %w", err)
+	}
+
+	return streams, pseudonymized, nil
+}
+
+// migrateStream moves the Events of the Event Stream to the pseudonymized one,
+// encrypting them with the key of the subject, or deletes them if the subject
+// has been forgotten.
+func (es *EventStoreWrapper) migrateStream(ctx context.Context, db *sql.DB, stream *streamInClear) (err error) {
+	subjectID, _ := stream.subject(stream.streamID)
+
+	key, err := es.keys.Key(ctx, subjectID)
+	if errors.Is(err, ErrSubjectForgotten) {
+		_, err := db.ExecContext(
+			ctx,
+			"DELETE FROM streams WHERE stream_type = $1 AND id = $2",
+			stream.streamType,
+			stream.streamID,
+		)
+
+		if err != nil {
+			return fmt.Errorf("shredding.MigratePostgresEventStore: failed to delete stream of forgotten subject: %w", err)
+		}
+
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("shredding.MigratePostgresEventStore: failed to get key: %w", err)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("shredding.MigratePostgresEventStore: failed to open a transaction: %w", err)
+	}
+
+	// The migration error is returned, rather than the rollback one.
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	pseudonymized := pseudonymize(stream.streamID, key, stream.subject)
+	version := stream.events[len(stream.events)-1].Version
+
+	_, err = tx.ExecContext(
+		ctx,
+		`INSERT INTO streams (id, stream_type, "version") VALUES ($1, $2, $3)
+			ON CONFLICT (stream_type, id) DO NOTHING`,
+		pseudonymized,
+		stream.streamType,
+		version,
+	)
+
+	if err != nil {
+		return fmt.Errorf("shredding.MigratePostgresEventStore: failed to create pseudonymized stream: %w", err)
+	}
+
+	for _, event := range stream.events {
+		encrypted, err := es.encrypt(key, event.Event)
+		if err != nil {
+			return err
+		}
+
+		payload, err := json.Marshal(encrypted.Payload)
+		if err != nil {
+			return fmt.Errorf("shredding.MigratePostgresEventStore: failed to marshal event payload to json: %w", err)
+		}
+
+		result, err := tx.ExecContext(
+			ctx,
+			`UPDATE events SET stream_id = $1, event_type = $2, "event" = $3
+				WHERE stream_type = $4 AND stream_id = $5 AND "version" = $6`,
+			pseudonymized,
+			EncryptedEventName,
+			payload,
+			stream.streamType,
+			stream.streamID,
+			event.Version,
+		)
+
+		if err != nil {
+			return fmt.Errorf("shredding.MigratePostgresEventStore: failed to move event: %w", err)
+		}
+
+		moved, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("shredding.MigratePostgresEventStore: failed to move event: %w", err)
+		}
+
+		// The Event Stream has been migrated by another process in the meantime.
+		if moved == 0 {
+			return tx.Rollback()
+		}
+	}
+
+	// Events appended to the Event Stream in the meantime would be lost.
+	result, err := tx.ExecContext(
+		ctx,
+		`DELETE FROM streams WHERE stream_type = $1 AND id = $2 AND "version" = $3`,
+		stream.streamType,
+		stream.streamID,
+		version,
+	)
+
+	if err != nil {
+		return fmt.Errorf("shredding.MigratePostgresEventStore: failed to delete stream: %w", err)
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("shredding.MigratePostgresEventStore: failed to delete stream: %w", err)
+	}
+
+	if deleted == 0 {
+		return fmt.Errorf("shredding.MigratePostgresEventStore: stream '%s' changed during the migration", stream.streamID)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("shredding.MigratePostgresEventStore: failed to commit migration: %w", err)
+	}
+
+	return nil
+}
+
+// migrateSubjects replaces the subjects in clear of the Pseudonymized payload
+// of the Event with their pseudonyms.
+func (es *EventStoreWrapper) migrateSubjects(ctx context.Context, db *sql.DB, event eventstore.Event) error {
+	pseudonymized, err := es.pseudonymizeSubjects(ctx, event.Event)
+	if err != nil {
+		return err
+	}
+
+	payload, err := json.Marshal(pseudonymized.Payload)
+	if err != nil {
+		return fmt.Errorf("shredding.MigratePostgresEventStore: failed to marshal event payload to json: %w", err)
+	}
+
+	_, err = db.ExecContext(
+		ctx,
+		`UPDATE events SET "event" = $1 WHERE stream_type = $2 AND stream_id = $3 AND "version" = $4`,
+		payload,
+		event.StreamType,
+		event.StreamName,
+		event.Version,
+	)
+
+	if err != nil {
+		return fmt.Errorf("shredding.MigratePostgresEventStore: failed to update event: %w", err)
+	}
+
+	return nil
+}
+
+// subjectsInClear reports whether the payload refers to any subject
+// by its identifier rather than by its pseudonym.
+func subjectsInClear(payload Pseudonymized) bool {
+	var inClear bool
+
+	payload.MapSubjects(func(subjectID string) string {
+		if subjectID != "" && !strings.HasPrefix(subjectID, pseudonymPrefix) {
+			inClear = true
+		}
+
+		return subjectID
+	})
+
+	return inClear
+}
@@ -0,0 +1,141 @@
+package shredding_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/eventually-rs/saving-goals-go/internal/shredding"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/eventstore"
+	"github.com/eventually-rs/eventually-go/eventstore/postgres"
+	"github.com/stretchr/testify/assert"
+)
+
+func globalSequenceNumbers(events []eventstore.Event) []int64 {
+	numbers := make([]int64, 0, len(events))
+
+	for _, event := range events {
+		number, _ := event.GlobalSequenceNumber()
+		numbers = append(numbers, number)
+	}
+
+	return numbers
+}
+
+// TestMigratePostgresEventStore runs against the Postgres database in DATABASE_TEST_DSN,
+// in a schema of its own, and is skipped if it is not set.
+func TestMigratePostgresEventStore(t *testing.T) {
+	ctx := context.Background()
+	db, dsn := openTestSchema(t)
+
+	inner, err := postgres.OpenEventStore(dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer inner.Close()
+
+	keys, err := shredding.OpenPostgresKeyStore(ctx, dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer keys.Close()
+
+	store := wrap(t, inner, keys)
+
+	// The Events appended in clear before the Event Store was wrapped.
+	appendEvent(t, inner, "account", "test-account", personalEvent{Name: "John"})
+	appendEvent(t, inner, "monthly", "account:test-account:month:2021-03", publicEvent{Value: 42})
+	appendEvent(t, inner, "account", "forgotten-account", personalEvent{Name: "Jane"})
+	appendEvent(t, inner, "public", "test-group", groupEvent{Members: []string{"test-account", "forgotten-account"}})
+	appendEvent(t, inner, "account", "test-account", personalEvent{Name: "Johnny"})
+
+	assert.NoError(t, keys.Forget(ctx, "forgotten-account"))
+
+	before := streamAll(t, inner)
+
+	assert.NoError(t, shredding.MigratePostgresEventStore(ctx, dsn, store))
+
+	// Migrated Events are left alone.
+	assert.NoError(t, shredding.MigratePostgresEventStore(ctx, dsn, store))
+
+	accounts, err := store.Type(ctx, "account")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	t.Run("events are read from the pseudonymized streams", func(t *testing.T) {
+		events := streamAll(t, accounts.Instance("test-account"))
+		if assert.Len(t, events, 2) {
+			assert.Equal(t, personalEvent{Name: "John"}, events[0].Payload)
+			assert.Equal(t, personalEvent{Name: "Johnny"}, events[1].Payload)
+			assert.Equal(t, int64(2), events[1].Version)
+		}
+
+		events = streamAll(t, store)
+		if !assert.Len(t, events, 4) {
+			return
+		}
+
+		assert.Equal(t, []int64{
+			globalSequenceNumbers(before)[0],
+			globalSequenceNumbers(before)[1],
+			globalSequenceNumbers(before)[3],
+			globalSequenceNumbers(before)[4],
+		}, globalSequenceNumbers(events))
+
+		assert.Equal(t, "account:test-account:month:2021-03", events[1].StreamName)
+		assert.Equal(t, publicEvent{Value: 42}, events[1].Payload)
+		assert.Equal(t, groupEvent{Members: []string{"test-account", ""}}, events[2].Payload)
+	})
+
+	t.Run("new events follow the migrated ones", func(t *testing.T) {
+		_, err := accounts.Instance("test-account").Append(ctx, 2, eventually.Event{Payload: personalEvent{Name: "John"}})
+		assert.NoError(t, err)
+	})
+
+	t.Run("nothing stored refers to the subjects in clear", func(t *testing.T) {
+		rows, err := db.QueryContext(ctx, `SELECT stream_id, "event"::TEXT FROM events`)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		defer rows.Close()
+
+		for rows.Next() {
+			var streamID, payload string
+			if !assert.NoError(t, rows.Scan(&streamID, &payload)) {
+				return
+			}
+
+			for _, personal := range []string{"test-account", "forgotten-account", "John", "Jane"} {
+				assert.NotContains(t, streamID, personal)
+				assert.NotContains(t, payload, personal)
+			}
+		}
+
+		assert.NoError(t, rows.Err())
+
+		var streams int
+
+		err = db.QueryRowContext(ctx, `SELECT COUNT(*) FROM streams WHERE id LIKE '%-account%'`).Scan(&streams)
+		assert.NoError(t, err)
+		assert.Zero(t, streams)
+	})
+
+	t.Run("events are erased with the key of their subject", func(t *testing.T) {
+		assert.NoError(t, keys.Forget(ctx, "test-account"))
+		assert.Empty(t, streamAll(t, accounts.Instance("test-account")))
+
+		for _, event := range streamAll(t, store) {
+			if _, ok := event.Payload.(groupEvent); ok {
+				assert.Equal(t, groupEvent{Members: []string{"", ""}}, event.Payload)
+				continue
+			}
+
+			assert.IsType(t, shredding.Unreadable{}, event.Payload)
+		}
+	})
+}
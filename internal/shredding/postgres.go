@@ -0,0 +1,143 @@
+package shredding
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	_ "github.com/lib/pq" // postgres driver for database/sql
+)
+
+var _ KeyStore = &PostgresKeyStore{}
+
+// PostgresKeyStore is a KeyStore implementation backed by a Postgres table.
+//
+// The keys must be stored apart from the Events they encrypt: the table is
+// never replicated in the Event Store, and a deleted key is gone for good.
+type PostgresKeyStore struct {
+	db *sql.DB
+}
+
+// OpenPostgresKeyStore opens a connection with the database,
+// creating the table of the encryption keys if it does not exist.
+func OpenPostgresKeyStore(ctx context.Context, dsn string) (*PostgresKeyStore, error) {
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("shredding.PostgresKeyStore: failed to open connection with the db: %w", err)
+	}
+
+	// Forgotten subjects keep their row with the hash of their identifier only,
+	// so that no new key can be created for them.
+	_, err = db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS encryption_keys (
+		subject_hash BYTEA PRIMARY KEY,
+		subject_id   TEXT UNIQUE,
+		pseudonym    TEXT UNIQUE,
+		secret       BYTEA,
+		created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		forgotten_at TIMESTAMPTZ
+	)`)
+
+	if err != nil {
+		return nil, fmt.Errorf("shredding.PostgresKeyStore: failed to create keys table: %w", err)
+	}
+
+	return &PostgresKeyStore{db: db}, nil
+}
+
+// Close closes the connection with the database.
+func (s *PostgresKeyStore) Close() error {
+	return s.db.Close()
+}
+
+// Key returns the key of the subject, creating a new one if it has none,
+// or ErrSubjectForgotten if the subject has been forgotten.
+func (s *PostgresKeyStore) Key(ctx context.Context, subjectID string) (Key, error) {
+	key, err := newKey(subjectID)
+	if err != nil {
+		return Key{}, err
+	}
+
+	// Concurrent creations for the same subject keep the first key inserted.
+	_, err = s.db.ExecContext(
+		ctx,
+		`INSERT INTO encryption_keys (subject_hash, subject_id, pseudonym, secret)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (subject_hash) DO NOTHING`,
+		subjectHash(key.SubjectID),
+		key.SubjectID,
+		key.Pseudonym,
+		key.Secret,
+	)
+
+	if err != nil {
+		return Key{}, fmt.Errorf("shredding.PostgresKeyStore: failed to create key: %w", err)
+	}
+
+	// The subject row is kept by Forget, so the key is missing only
+	// when the subject has been forgotten.
+	key, err = s.Lookup(ctx, subjectID)
+	if errors.Is(err, ErrKeyNotFound) {
+		return Key{}, ErrSubjectForgotten
+	}
+
+	return key, err
+}
+
+// Lookup returns the key of the subject, or ErrKeyNotFound if it has none.
+func (s *PostgresKeyStore) Lookup(ctx context.Context, subjectID string) (Key, error) {
+	return s.queryKey(ctx, `SELECT subject_id, pseudonym, secret FROM encryption_keys
+		WHERE subject_hash = $1 AND forgotten_at IS NULL`, subjectHash(subjectID))
+}
+
+// ByPseudonym returns the key with the specified pseudonym,
+// or ErrKeyNotFound if it has been forgotten.
+func (s *PostgresKeyStore) ByPseudonym(ctx context.Context, pseudonym string) (Key, error) {
+	return s.queryKey(ctx, "SELECT subject_id, pseudonym, secret FROM encryption_keys WHERE pseudonym = $1", pseudonym)
+}
+
+// Forget erases the key of the subject, if any, and refuses any new key for it.
+//
+// Only the hash of the subject identifier is kept, so that nothing links
+// the subject to its pseudonym anymore.
+func (s *PostgresKeyStore) Forget(ctx context.Context, subjectID string) error {
+	_, err := s.db.ExecContext(
+		ctx,
+		`INSERT INTO encryption_keys (subject_hash, forgotten_at)
+			VALUES ($1, NOW())
+			ON CONFLICT (subject_hash) DO UPDATE SET
+				subject_id = NULL,
+				pseudonym = NULL,
+				secret = NULL,
+				forgotten_at = COALESCE(encryption_keys.forgotten_at, NOW())`,
+		subjectHash(subjectID),
+	)
+
+	if err != nil {
+		return fmt.Errorf("shredding.PostgresKeyStore: failed to forget key: %w", err)
+	}
+
+	return nil
+}
+
+func (s *PostgresKeyStore) queryKey(ctx context.Context, query string, arg interface{}) (Key, error) {
+	var key Key
+
+	err := s.db.QueryRowContext(ctx, query, arg).Scan(&key.SubjectID, &key.Pseudonym, &key.Secret)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Key{}, ErrKeyNotFound
+	}
+
+	if err != nil {
+		return Key{}, fmt.Errorf("shredding.PostgresKeyStore: failed to read key: %w", err)
+	}
+
+	return key, nil
+}
+
+// subjectHash returns the hash the subjects are stored with.
+func subjectHash(subjectID string) []byte {
+	hash := sha256.Sum256([]byte(subjectID))
+	return hash[:]
+}
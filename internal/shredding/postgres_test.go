@@ -0,0 +1,116 @@
+package shredding_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/eventually-rs/saving-goals-go/internal/shredding"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// openTestSchema creates a schema of its own for the test in the Postgres
+// database in DATABASE_TEST_DSN, returning a connection and the DSN using it,
+// and skips the test if it is not set.
+func openTestSchema(t *testing.T) (*sql.DB, string) {
+	dsn := os.Getenv("DATABASE_TEST_DSN")
+	if dsn == "" {
+		t.Skip("DATABASE_TEST_DSN not set")
+	}
+
+	ctx := context.Background()
+
+	conn, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	schema := fmt.Sprintf("shredding_test_%d", time.Now().UnixNano())
+
+	if _, err := conn.ExecContext(ctx, "CREATE SCHEMA "+schema); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		if _, err := conn.ExecContext(ctx, "DROP SCHEMA "+schema+" CASCADE"); err != nil {
+			t.Error(err)
+		}
+
+		conn.Close()
+	})
+
+	u, err := url.Parse(dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	query := u.Query()
+	query.Set("search_path", schema)
+	u.RawQuery = query.Encode()
+
+	scoped, err := sql.Open("postgres", u.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { scoped.Close() })
+
+	return scoped, u.String()
+}
+
+// TestPostgresKeyStore runs against the Postgres database in DATABASE_TEST_DSN,
+// in a schema of its own, and is skipped if it is not set.
+func TestPostgresKeyStore(t *testing.T) {
+	ctx := context.Background()
+	db, dsn := openTestSchema(t)
+
+	keys, err := shredding.OpenPostgresKeyStore(ctx, dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer keys.Close()
+
+	t.Run("keys are created once per subject", func(t *testing.T) {
+		key, err := keys.Key(ctx, "test-account")
+		assert.NoError(t, err)
+
+		again, err := keys.Key(ctx, "test-account")
+		assert.NoError(t, err)
+		assert.Equal(t, key, again)
+
+		byPseudonym, err := keys.ByPseudonym(ctx, key.Pseudonym)
+		assert.NoError(t, err)
+		assert.Equal(t, key, byPseudonym)
+	})
+
+	t.Run("forgotten subjects leave no trace of their identifier", func(t *testing.T) {
+		key, err := keys.Key(ctx, "forgotten-account")
+		assert.NoError(t, err)
+
+		assert.NoError(t, keys.Forget(ctx, "forgotten-account"))
+		assert.NoError(t, keys.Forget(ctx, "never-seen-account"))
+
+		var traces int
+
+		err = db.QueryRowContext(ctx, `SELECT COUNT(*) FROM encryption_keys
+			WHERE subject_id IN ('forgotten-account', 'never-seen-account') OR pseudonym = $1`, key.Pseudonym).Scan(&traces)
+
+		assert.NoError(t, err)
+		assert.Zero(t, traces)
+
+		_, err = keys.ByPseudonym(ctx, key.Pseudonym)
+		assert.True(t, errors.Is(err, shredding.ErrKeyNotFound))
+
+		for _, subjectID := range []string{"forgotten-account", "never-seen-account"} {
+			_, err = keys.Key(ctx, subjectID)
+			assert.True(t, errors.Is(err, shredding.ErrSubjectForgotten), subjectID)
+		}
+	})
+}
@@ -0,0 +1,35 @@
+package shredding
+
+import (
+	"context"
+
+	"github.com/eventually-rs/eventually-go/eventstore"
+	"github.com/eventually-rs/eventually-go/projection"
+)
+
+var _ projection.Applier = ProjectionWrapper{}
+
+// ProjectionWrapper is a projection.Applier decorator that skips
+// the Events whose payload is Unreadable, since their subject
+// has been forgotten.
+//
+// Use WrapProjection to create a new instance.
+type ProjectionWrapper struct {
+	applier projection.Applier
+}
+
+// WrapProjection wraps the specified projection.Applier instance
+// with a ProjectionWrapper.
+func WrapProjection(applier projection.Applier) ProjectionWrapper {
+	return ProjectionWrapper{applier: applier}
+}
+
+// Apply applies the provided Event to the wrapped projection.Applier,
+// unless its payload is Unreadable.
+func (pw ProjectionWrapper) Apply(ctx context.Context, event eventstore.Event) error {
+	if _, ok := event.Payload.(Unreadable); ok {
+		return nil
+	}
+
+	return pw.applier.Apply(ctx, event)
+}
@@ -0,0 +1,125 @@
+package shredding
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+
+	"github.com/eventually-rs/saving-goals-go/internal/snapshot"
+)
+
+var _ snapshot.Store = SnapshotStoreWrapper{}
+
+// SnapshotStoreWrapper is a snapshot.Store decorator that encrypts the state
+// of the Snapshots belonging to a personal data subject, and pseudonymizes
+// their Stream ids, like the EventStoreWrapper does for their Events.
+//
+// Snapshots of forgotten subjects are not found anymore.
+//
+// Use WrapSnapshotStore to create a new instance.
+type SnapshotStoreWrapper struct {
+	snapshot.Store
+
+	keys     KeyStore
+	subjects map[string]SubjectFunc
+}
+
+// WrapSnapshotStore wraps the provided snapshot.Store instance, encrypting
+// the Snapshots of the Stream types in subjects with the keys in the KeyStore.
+func WrapSnapshotStore(store snapshot.Store, keys KeyStore, subjects map[string]SubjectFunc) SnapshotStoreWrapper {
+	return SnapshotStoreWrapper{
+		Store:    store,
+		keys:     keys,
+		subjects: subjects,
+	}
+}
+
+// Load returns the decrypted latest Snapshot of the Event Stream,
+// or snapshot.ErrNotFound if none has been taken or its subject has been forgotten.
+func (s SnapshotStoreWrapper) Load(ctx context.Context, streamType, streamID string) (snapshot.Snapshot, error) {
+	subject, ok := s.subjects[streamType]
+	if !ok {
+		return s.Store.Load(ctx, streamType, streamID)
+	}
+
+	subjectID, ok := subject(streamID)
+	if !ok {
+		return s.Store.Load(ctx, streamType, streamID)
+	}
+
+	key, err := s.keys.Lookup(ctx, subjectID)
+	if errors.Is(err, ErrKeyNotFound) {
+		return snapshot.Snapshot{}, snapshot.ErrNotFound
+	}
+
+	if err != nil {
+		return snapshot.Snapshot{}, fmt.Errorf("shredding.SnapshotStoreWrapper: failed to lookup key: %w", err)
+	}
+
+	snap, err := s.Store.Load(ctx, streamType, pseudonymize(streamID, key, subject))
+	if err != nil {
+		return snapshot.Snapshot{}, err
+	}
+
+	aead, err := newAEAD(key)
+	if err != nil {
+		return snapshot.Snapshot{}, err
+	}
+
+	if len(snap.State) < aead.NonceSize() {
+		return snapshot.Snapshot{}, fmt.Errorf("shredding.SnapshotStoreWrapper: ciphertext is too short")
+	}
+
+	nonce, ciphertext := snap.State[:aead.NonceSize()], snap.State[aead.NonceSize():]
+
+	state, err := aead.Open(nil, nonce, ciphertext, []byte(streamType))
+	if err != nil {
+		return snapshot.Snapshot{}, fmt.Errorf("shredding.SnapshotStoreWrapper: failed to decrypt snapshot: %w", err)
+	}
+
+	snap.StreamID = streamID
+	snap.State = state
+
+	return snap, nil
+}
+
+// Save encrypts the Snapshot with the key of its subject, if any,
+// and saves it with the pseudonymized Stream id.
+func (s SnapshotStoreWrapper) Save(ctx context.Context, snap snapshot.Snapshot) error {
+	subject, ok := s.subjects[snap.StreamType]
+	if !ok {
+		return s.Store.Save(ctx, snap)
+	}
+
+	subjectID, ok := subject(snap.StreamID)
+	if !ok {
+		return s.Store.Save(ctx, snap)
+	}
+
+	// Snapshots are only taken after appending Events, so a missing key
+	// means the subject has been forgotten in the meantime.
+	key, err := s.keys.Lookup(ctx, subjectID)
+	if errors.Is(err, ErrKeyNotFound) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("shredding.SnapshotStoreWrapper: failed to lookup key: %w", err)
+	}
+
+	aead, err := newAEAD(key)
+	if err != nil {
+		return err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return fmt.Errorf("shredding.SnapshotStoreWrapper: failed to generate nonce: %w", err)
+	}
+
+	snap.StreamID = pseudonymize(snap.StreamID, key, subject)
+	snap.State = aead.Seal(nonce, nonce, snap.State, []byte(snap.StreamType))
+
+	return s.Store.Save(ctx, snap)
+}
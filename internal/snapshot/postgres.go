@@ -0,0 +1,101 @@
+package snapshot
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	_ "github.com/lib/pq" // postgres driver for database/sql
+)
+
+var _ Store = &PostgresStore{}
+
+// PostgresStore is a Store implementation backed by a Postgres table,
+// keeping only the latest Snapshot of each Event Stream.
+type PostgresStore struct {
+	db *sql.DB
+}
+
+// OpenPostgresStore opens a connection with the database,
+// creating the table of the Snapshots if it does not exist.
+func OpenPostgresStore(ctx context.Context, dsn string) (*PostgresStore, error) {
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("snapshot.PostgresStore: failed to open connection with the db: %w", err)
+	}
+
+	_, err = db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS snapshots (
+		stream_type    TEXT NOT NULL,
+		stream_id      TEXT NOT NULL,
+		"version"      INTEGER NOT NULL,
+		schema_version INTEGER NOT NULL,
+		state          BYTEA NOT NULL,
+		taken_at       TIMESTAMPTZ NOT NULL,
+		PRIMARY KEY (stream_type, stream_id)
+	)`)
+
+	if err != nil {
+		return nil, fmt.Errorf("snapshot.PostgresStore: failed to create snapshots table: %w", err)
+	}
+
+	return &PostgresStore{db: db}, nil
+}
+
+// Close closes the connection with the database.
+func (s *PostgresStore) Close() error {
+	return s.db.Close()
+}
+
+// Load returns the latest Snapshot of the Event Stream,
+// or ErrNotFound if none has been taken.
+func (s *PostgresStore) Load(ctx context.Context, streamType, streamID string) (Snapshot, error) {
+	snapshot := Snapshot{StreamType: streamType, StreamID: streamID}
+
+	err := s.db.QueryRowContext(
+		ctx,
+		`SELECT "version", schema_version, state, taken_at FROM snapshots
+			WHERE stream_type = $1 AND stream_id = $2`,
+		streamType,
+		streamID,
+	).Scan(&snapshot.Version, &snapshot.SchemaVersion, &snapshot.State, &snapshot.TakenAt)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return Snapshot{}, ErrNotFound
+	}
+
+	if err != nil {
+		return Snapshot{}, fmt.Errorf("snapshot.PostgresStore: failed to load snapshot: %w", err)
+	}
+
+	return snapshot, nil
+}
+
+// Save saves the Snapshot, unless a more recent one has been taken
+// with the same schema version.
+func (s *PostgresStore) Save(ctx context.Context, snapshot Snapshot) error {
+	_, err := s.db.ExecContext(
+		ctx,
+		`INSERT INTO snapshots (stream_type, stream_id, "version", schema_version, state, taken_at)
+			VALUES ($1, $2, $3, $4, $5, $6)
+			ON CONFLICT (stream_type, stream_id) DO UPDATE SET
+				"version" = EXCLUDED."version",
+				schema_version = EXCLUDED.schema_version,
+				state = EXCLUDED.state,
+				taken_at = EXCLUDED.taken_at
+			WHERE snapshots."version" < EXCLUDED."version"
+				OR snapshots.schema_version <> EXCLUDED.schema_version`,
+		snapshot.StreamType,
+		snapshot.StreamID,
+		snapshot.Version,
+		snapshot.SchemaVersion,
+		snapshot.State,
+		snapshot.TakenAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("snapshot.PostgresStore: failed to save snapshot: %w", err)
+	}
+
+	return nil
+}
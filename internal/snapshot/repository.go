@@ -0,0 +1,257 @@
+package snapshot
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+	"github.com/eventually-rs/eventually-go/eventstore"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+)
+
+// Root is an Aggregate Root whose state can be captured in a Snapshot.
+//
+// The value returned by Snapshot is applied back to a new Aggregate Root
+// instance as a Domain Event payload, which must restore the whole state.
+type Root interface {
+	aggregate.Applier
+
+	Snapshot() interface{}
+}
+
+// Options configures the snapshotting of an Aggregate type.
+type Options struct {
+	// SchemaVersion is the current version of the Aggregate state type:
+	// Snapshots with a different version are discarded.
+	SchemaVersion int
+
+	// Frequency is the number of Events after which a new Snapshot is taken.
+	// Zero disables taking new Snapshots.
+	Frequency int64
+
+	// Factory returns a new empty instance of the Aggregate Root.
+	Factory func() Root
+}
+
+// NewRepository returns a new aggregate.Repository for the specified Aggregate type,
+// which loads the latest Snapshot of an Aggregate Root and replays only the
+// Events committed afterwards, taking a new Snapshot every Options.Frequency Events.
+func NewRepository(
+	aggregateType aggregate.Type,
+	es eventstore.Typed,
+	snapshots Store,
+	options Options,
+	logger *zap.Logger,
+) *aggregate.Repository {
+	return aggregate.NewRepository(aggregateType, WrapEventStore(aggregateType.Name(), es, snapshots, options, logger))
+}
+
+// EventStoreWrapper is an eventstore.Typed decorator that prepends the
+// latest Snapshot of an Event Stream to the Events committed afterwards,
+// and takes new Snapshots while appending new Events.
+//
+// Use WrapEventStore to create a new instance.
+type EventStoreWrapper struct {
+	eventstore.Typed
+
+	streamType string
+	snapshots  Store
+	options    Options
+	stateType  reflect.Type
+	logger     *zap.Logger
+}
+
+// WrapEventStore wraps the eventstore.Typed instance of the specified Stream type.
+func WrapEventStore(
+	streamType string,
+	es eventstore.Typed,
+	snapshots Store,
+	options Options,
+	logger *zap.Logger,
+) EventStoreWrapper {
+	return EventStoreWrapper{
+		Typed:      es,
+		streamType: streamType,
+		snapshots:  snapshots,
+		options:    options,
+		stateType:  reflect.TypeOf(options.Factory().Snapshot()),
+		logger:     logger,
+	}
+}
+
+// Instance returns the snapshotted access to the specified Event Stream.
+func (es EventStoreWrapper) Instance(id string) eventstore.Instanced {
+	return instancedEventStoreWrapper{
+		Instanced: es.Typed.Instance(id),
+		parent:    es,
+		id:        id,
+	}
+}
+
+type instancedEventStoreWrapper struct {
+	eventstore.Instanced
+
+	parent EventStoreWrapper
+	id     string
+}
+
+// Stream streams the latest Snapshot of the Event Stream, as an Event with
+// the Snapshot version, followed by the Events committed afterwards.
+//
+// All the Events are streamed if no valid Snapshot is found,
+// or if the Stream does not start from the beginning.
+func (is instancedEventStoreWrapper) Stream(ctx context.Context, stream eventstore.EventStream, from int64) error {
+	if from > 1 {
+		return is.Instanced.Stream(ctx, stream, from)
+	}
+
+	snapshot, state, ok := is.parent.load(ctx, is.id)
+	if !ok {
+		return is.Instanced.Stream(ctx, stream, from)
+	}
+
+	defer close(stream)
+
+	select {
+	case stream <- eventstore.Event{
+		StreamType: is.parent.streamType,
+		StreamName: is.id,
+		Version:    snapshot.Version,
+		Event:      eventually.Event{Payload: state},
+	}:
+	case <-ctx.Done():
+		return fmt.Errorf("snapshot.EventStoreWrapper: context done: %w", ctx.Err())
+	}
+
+	ch := make(chan eventstore.Event, 1)
+	group, ctx := errgroup.WithContext(ctx)
+
+	group.Go(func() error { return is.Instanced.Stream(ctx, ch, snapshot.Version+1) })
+
+	group.Go(func() error {
+		var err error
+
+		// The source channel is always drained, even after a failure,
+		// since the source might not be listening to context cancellation.
+		for event := range ch {
+			if err != nil {
+				continue
+			}
+
+			select {
+			case stream <- event:
+			case <-ctx.Done():
+				err = ctx.Err()
+			}
+		}
+
+		return err
+	})
+
+	return group.Wait()
+}
+
+// Append appends the Events to the Event Stream, taking a new Snapshot
+// if the new version crosses a multiple of Options.Frequency.
+//
+// Failing to take a Snapshot does not fail the append, as the Events
+// have already been committed: the failure is only logged.
+func (is instancedEventStoreWrapper) Append(ctx context.Context, version int64, events ...eventually.Event) (int64, error) {
+	newVersion, err := is.Instanced.Append(ctx, version, events...)
+	if err != nil {
+		return newVersion, err
+	}
+
+	frequency := is.parent.options.Frequency
+	previousVersion := newVersion - int64(len(events))
+
+	if frequency > 0 && newVersion/frequency > previousVersion/frequency {
+		if err := is.take(ctx); err != nil {
+			is.parent.logger.Warn("Failed to take snapshot",
+				zap.String("streamType", is.parent.streamType),
+				zap.String("streamId", is.id),
+				zap.Int64("version", newVersion),
+				zap.Error(err))
+		}
+	}
+
+	return newVersion, nil
+}
+
+// take rehydrates the Aggregate Root from its latest Snapshot
+// and saves a new Snapshot of its current state.
+func (is instancedEventStoreWrapper) take(ctx context.Context) error {
+	root := is.parent.options.Factory()
+
+	events, err := eventstore.StreamToSlice(ctx, func(ctx context.Context, es eventstore.EventStream) error {
+		return is.Stream(ctx, es, 0)
+	})
+
+	if err != nil {
+		return fmt.Errorf("snapshot.EventStoreWrapper: failed to stream events: %w", err)
+	}
+
+	var version int64
+
+	for _, event := range events {
+		if err := root.Apply(event.Event); err != nil {
+			return fmt.Errorf("snapshot.EventStoreWrapper: failed to apply event: %w", err)
+		}
+
+		version = event.Version
+	}
+
+	state, err := json.Marshal(root.Snapshot())
+	if err != nil {
+		return fmt.Errorf("snapshot.EventStoreWrapper: failed to marshal state to json: %w", err)
+	}
+
+	return is.parent.snapshots.Save(ctx, Snapshot{
+		StreamType:    is.parent.streamType,
+		StreamID:      is.id,
+		Version:       version,
+		SchemaVersion: is.parent.options.SchemaVersion,
+		State:         state,
+		TakenAt:       time.Now(),
+	})
+}
+
+// load returns the latest Snapshot of the Event Stream and its decoded state,
+// if it exists and it has been taken with the current schema version.
+func (es EventStoreWrapper) load(ctx context.Context, id string) (Snapshot, interface{}, bool) {
+	snapshot, err := es.snapshots.Load(ctx, es.streamType, id)
+	if errors.Is(err, ErrNotFound) {
+		return Snapshot{}, nil, false
+	}
+
+	if err != nil {
+		es.logger.Warn("Failed to load snapshot, replaying all events",
+			zap.String("streamType", es.streamType),
+			zap.String("streamId", id),
+			zap.Error(err))
+
+		return Snapshot{}, nil, false
+	}
+
+	if snapshot.SchemaVersion != es.options.SchemaVersion {
+		return Snapshot{}, nil, false
+	}
+
+	state := reflect.New(es.stateType)
+	if err := json.Unmarshal(snapshot.State, state.Interface()); err != nil {
+		es.logger.Warn("Failed to decode snapshot, replaying all events",
+			zap.String("streamType", es.streamType),
+			zap.String("streamId", id),
+			zap.Error(err))
+
+		return Snapshot{}, nil, false
+	}
+
+	return snapshot, state.Elem().Interface(), true
+}
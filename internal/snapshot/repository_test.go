@@ -0,0 +1,234 @@
+package snapshot_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/account"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/transaction"
+	"github.com/eventually-rs/saving-goals-go/internal/snapshot"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+	"github.com/eventually-rs/eventually-go/eventstore"
+	"github.com/eventually-rs/eventually-go/eventstore/inmemory"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+const accountID = "test-account"
+
+func accountOptions(frequency int64) snapshot.Options {
+	return snapshot.Options{
+		SchemaVersion: account.SnapshotVersion,
+		Frequency:     frequency,
+		Factory:       func() snapshot.Root { return new(account.Account) },
+	}
+}
+
+// accountEventStore returns the Account Event Store, with an Account
+// that has recorded the specified number of transactions.
+func accountEventStore(tb testing.TB, snapshots snapshot.Store, frequency int64, transactions int) eventstore.Typed {
+	ctx := context.Background()
+	store := inmemory.NewEventStore()
+
+	if err := store.Register(ctx, account.Type.Name(), nil); err != nil {
+		tb.Fatal(err)
+	}
+
+	inner, err := store.Type(ctx, account.Type.Name())
+	if err != nil {
+		tb.Fatal(err)
+	}
+
+	typed := jsonEventStore{Typed: inner}
+
+	instance := snapshot.WrapEventStore(account.Type.Name(), typed, snapshots, accountOptions(frequency), zap.NewNop()).
+		Instance(accountID)
+
+	if _, err := instance.Append(ctx, -1, eventually.Event{Payload: account.WasCreated{AccountID: accountID}}); err != nil {
+		tb.Fatal(err)
+	}
+
+	happenedAt := time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	for i := 0; i < transactions; i++ {
+		_, err := instance.Append(ctx, -1, eventually.Event{
+			Payload: account.TransactionWasRecorded{
+				TransactionID: fmt.Sprintf("tx-%d", i),
+				Amount:        -10,
+				Kind:          transaction.Expense,
+				HappenedAt:    happenedAt.Add(time.Duration(i) * time.Hour),
+			},
+		})
+
+		if err != nil {
+			tb.Fatal(err)
+		}
+	}
+
+	return typed
+}
+
+// jsonEventStore stores Event payloads as JSON, like the Postgres Event Store
+// does, so that replaying an Event Stream pays the decoding cost of each Event.
+type jsonEventStore struct {
+	eventstore.Typed
+}
+
+type jsonPayload struct {
+	Type reflect.Type
+	Data []byte
+}
+
+func (es jsonEventStore) Instance(id string) eventstore.Instanced {
+	return jsonInstancedEventStore{Instanced: es.Typed.Instance(id)}
+}
+
+type jsonInstancedEventStore struct {
+	eventstore.Instanced
+}
+
+func (es jsonInstancedEventStore) Append(ctx context.Context, version int64, events ...eventually.Event) (int64, error) {
+	encoded := make([]eventually.Event, 0, len(events))
+
+	for _, event := range events {
+		data, err := json.Marshal(event.Payload)
+		if err != nil {
+			return 0, err
+		}
+
+		event.Payload = jsonPayload{Type: reflect.TypeOf(event.Payload), Data: data}
+		encoded = append(encoded, event)
+	}
+
+	return es.Instanced.Append(ctx, version, encoded...)
+}
+
+func (es jsonInstancedEventStore) Stream(ctx context.Context, stream eventstore.EventStream, from int64) error {
+	defer close(stream)
+
+	events, err := eventstore.StreamToSlice(ctx, func(ctx context.Context, inner eventstore.EventStream) error {
+		return es.Instanced.Stream(ctx, inner, from)
+	})
+
+	if err != nil {
+		return err
+	}
+
+	for _, event := range events {
+		payload := event.Payload.(jsonPayload)
+		value := reflect.New(payload.Type)
+
+		if err := json.Unmarshal(payload.Data, value.Interface()); err != nil {
+			return err
+		}
+
+		event.Payload = value.Elem().Interface()
+		stream <- event
+	}
+
+	return nil
+}
+
+func TestRepository(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("snapshots are taken every frequency events", func(t *testing.T) {
+		snapshots := snapshot.NewInMemoryStore()
+		accountEventStore(t, snapshots, 10, 25)
+
+		snap, err := snapshots.Load(ctx, account.Type.Name(), accountID)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(20), snap.Version)
+		assert.Equal(t, account.SnapshotVersion, snap.SchemaVersion)
+	})
+
+	t.Run("loaded state matches the full replay", func(t *testing.T) {
+		snapshots := snapshot.NewInMemoryStore()
+		typed := accountEventStore(t, snapshots, 10, 25)
+
+		expected, err := aggregate.NewRepository(account.Type, typed).Get(ctx, aggregate.StringID(accountID))
+		assert.NoError(t, err)
+
+		actual, err := snapshot.NewRepository(account.Type, typed, snapshots, accountOptions(10), zap.NewNop()).
+			Get(ctx, aggregate.StringID(accountID))
+
+		assert.NoError(t, err)
+		assert.Equal(t, expected.Version(), actual.Version())
+		assert.Equal(t, expected.(*account.Account).Snapshot(), actual.(*account.Account).Snapshot())
+	})
+
+	t.Run("latest snapshot is loaded before newer events", func(t *testing.T) {
+		snapshots := snapshot.NewInMemoryStore()
+		typed := accountEventStore(t, snapshots, 0, 25)
+
+		state, err := json.Marshal(account.Snapshot{AccountID: accountID, Balance: 1000})
+		assert.NoError(t, err)
+
+		assert.NoError(t, snapshots.Save(ctx, snapshot.Snapshot{
+			StreamType:    account.Type.Name(),
+			StreamID:      accountID,
+			Version:       21,
+			SchemaVersion: account.SnapshotVersion,
+			State:         state,
+		}))
+
+		root, err := snapshot.NewRepository(account.Type, typed, snapshots, accountOptions(0), zap.NewNop()).
+			Get(ctx, aggregate.StringID(accountID))
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(26), root.Version())
+		assert.Equal(t, float64(1000-5*10), root.(*account.Account).Snapshot().(account.Snapshot).Balance)
+	})
+
+	t.Run("snapshots of other schema versions are discarded", func(t *testing.T) {
+		snapshots := snapshot.NewInMemoryStore()
+		typed := accountEventStore(t, snapshots, 0, 25)
+
+		assert.NoError(t, snapshots.Save(ctx, snapshot.Snapshot{
+			StreamType:    account.Type.Name(),
+			StreamID:      accountID,
+			Version:       21,
+			SchemaVersion: account.SnapshotVersion - 1,
+			State:         []byte(`{"Balance": "not a number"}`),
+		}))
+
+		root, err := snapshot.NewRepository(account.Type, typed, snapshots, accountOptions(0), zap.NewNop()).
+			Get(ctx, aggregate.StringID(accountID))
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(26), root.Version())
+		assert.Equal(t, float64(-25*10), root.(*account.Account).Snapshot().(account.Snapshot).Balance)
+	})
+}
+
+func BenchmarkRepositoryGet(b *testing.B) {
+	ctx := context.Background()
+
+	for _, transactions := range []int{100, 1000, 10000} {
+		snapshots := snapshot.NewInMemoryStore()
+		typed := accountEventStore(b, snapshots, 100, transactions)
+
+		repositories := map[string]*aggregate.Repository{
+			"without snapshots": aggregate.NewRepository(account.Type, typed),
+			"with snapshots":    snapshot.NewRepository(account.Type, typed, snapshots, accountOptions(100), zap.NewNop()),
+		}
+
+		for _, name := range []string{"without snapshots", "with snapshots"} {
+			repository := repositories[name]
+
+			b.Run(fmt.Sprintf("%s/%d transactions", name, transactions), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					if _, err := repository.Get(ctx, aggregate.StringID(accountID)); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,90 @@
+// Package snapshot implements Aggregate snapshotting, to load long-lived
+// Aggregates without replaying all of their Domain Events.
+package snapshot
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrNotFound is returned by the Store when no Snapshot has been taken
+// for the specified Event Stream.
+var ErrNotFound = fmt.Errorf("snapshot.Store: snapshot not found")
+
+// Snapshot is the state of an Aggregate at a certain version
+// of its Event Stream.
+type Snapshot struct {
+	StreamType string
+	StreamID   string
+
+	// Version is the version of the last Event applied to the state.
+	Version int64
+
+	// SchemaVersion is the version of the state type, used to discard
+	// the Snapshots taken before a schema change.
+	SchemaVersion int
+
+	// State is the JSON representation of the Aggregate state.
+	State []byte
+
+	TakenAt time.Time
+}
+
+// Store stores the latest Snapshot of each Event Stream.
+type Store interface {
+	// Load returns the latest Snapshot of the Event Stream,
+	// or ErrNotFound if none has been taken.
+	Load(ctx context.Context, streamType, streamID string) (Snapshot, error)
+
+	// Save saves the Snapshot, unless a more recent one has been taken
+	// with the same schema version.
+	Save(ctx context.Context, snapshot Snapshot) error
+}
+
+var _ Store = &InMemoryStore{}
+
+// InMemoryStore is an in-memory Store implementation,
+// useful for testing and local development.
+type InMemoryStore struct {
+	mx        sync.RWMutex
+	snapshots map[string]Snapshot
+}
+
+// NewInMemoryStore returns a new empty InMemoryStore instance.
+func NewInMemoryStore() *InMemoryStore {
+	return &InMemoryStore{snapshots: make(map[string]Snapshot)}
+}
+
+// Load returns the latest Snapshot of the Event Stream,
+// or ErrNotFound if none has been taken.
+func (s *InMemoryStore) Load(ctx context.Context, streamType, streamID string) (Snapshot, error) {
+	s.mx.RLock()
+	defer s.mx.RUnlock()
+
+	snapshot, ok := s.snapshots[streamType+"/"+streamID]
+	if !ok {
+		return Snapshot{}, ErrNotFound
+	}
+
+	return snapshot, nil
+}
+
+// Save saves the Snapshot, unless a more recent one has been taken
+// with the same schema version.
+func (s *InMemoryStore) Save(ctx context.Context, snapshot Snapshot) error {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	key := snapshot.StreamType + "/" + snapshot.StreamID
+
+	latest, ok := s.snapshots[key]
+	if ok && latest.SchemaVersion == snapshot.SchemaVersion && latest.Version >= snapshot.Version {
+		return nil
+	}
+
+	s.snapshots[key] = snapshot
+
+	return nil
+}
@@ -0,0 +1,101 @@
+// Package timetravel rebuilds the state of the aggregates as it was
+// at a point in the past, either a version of their Event Stream or
+// the time their Events were recorded.
+//
+// The time the Events are appended is saved in their Metadata
+// by the EventStoreWrapper, using its own key: the Recorded-At Metadata
+// set by the consumers is the time of the original message instead.
+package timetravel
+
+import (
+	"context"
+	"time"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/eventstore"
+)
+
+// AppendedAtKey is the Metadata key holding the time an Event
+// has been appended to the Event Store.
+const AppendedAtKey = "Appended-At"
+
+// Clock returns the current time.
+type Clock func() time.Time
+
+var _ eventstore.Store = EventStoreWrapper{}
+
+// EventStoreWrapper is an eventstore.Store decorator that records
+// the time each Event is appended in its Metadata, using AppendedAtKey.
+//
+// Use WrapEventStore to create a new instance.
+type EventStoreWrapper struct {
+	eventstore.Store
+	now Clock
+}
+
+// WrapEventStore wraps the provided eventstore.Store instance, recording
+// the time the Events are appended as returned by the Clock.
+func WrapEventStore(es eventstore.Store, now Clock) EventStoreWrapper {
+	return EventStoreWrapper{Store: es, now: now}
+}
+
+// Type returns an eventstore.Typed instance for the specified Stream type,
+// recording the time its Events are appended.
+func (es EventStoreWrapper) Type(ctx context.Context, typ string) (eventstore.Typed, error) {
+	ts, err := es.Store.Type(ctx, typ)
+	if err != nil {
+		return nil, err
+	}
+
+	return typedEventStoreWrapper{Typed: ts, now: es.now}, nil
+}
+
+type typedEventStoreWrapper struct {
+	eventstore.Typed
+	now Clock
+}
+
+func (ts typedEventStoreWrapper) Instance(id string) eventstore.Instanced {
+	return instancedEventStoreWrapper{Instanced: ts.Typed.Instance(id), now: ts.now}
+}
+
+type instancedEventStoreWrapper struct {
+	eventstore.Instanced
+	now Clock
+}
+
+// Append appends copies of the Events with the current time in their Metadata,
+// leaving the Events and the Metadata of the caller untouched.
+func (is instancedEventStoreWrapper) Append(ctx context.Context, version int64, events ...eventually.Event) (int64, error) {
+	appendedAt := is.now().UTC()
+	stamped := make([]eventually.Event, 0, len(events))
+
+	for _, event := range events {
+		metadata := make(eventually.Metadata, len(event.Metadata)+1)
+		for key, value := range event.Metadata {
+			metadata[key] = value
+		}
+
+		event.Metadata = metadata.With(AppendedAtKey, appendedAt)
+		stamped = append(stamped, event)
+	}
+
+	return is.Instanced.Append(ctx, version, stamped...)
+}
+
+// AppendedAt returns the time the Event has been appended to the Event Store,
+// if it has been recorded.
+//
+// Events appended before the EventStoreWrapper was introduced have none.
+func AppendedAt(event eventually.Event) (time.Time, bool) {
+	switch v := event.Metadata[AppendedAtKey].(type) {
+	case time.Time:
+		return v, true
+	case string:
+		// The Postgres Event Store decodes the Metadata from JSON.
+		t, err := time.Parse(time.RFC3339Nano, v)
+		return t, err == nil
+	default:
+		return time.Time{}, false
+	}
+}
@@ -0,0 +1,111 @@
+package timetravel
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/eventually-rs/eventually-go/eventstore"
+)
+
+// ErrInvalidPoint is returned by ParsePoint when the string specified
+// is neither a version, a timestamp nor a date.
+var ErrInvalidPoint = errors.New("timetravel.ParsePoint: invalid point in time")
+
+// ErrTimeNotRecorded is returned when resolving a time the Events cannot tell:
+// earlier than all the Events with an appended time, while some Events have none.
+var ErrTimeNotRecorded = errors.New("timetravel: point in time not recorded")
+
+// Point is a point in the history of an aggregate.
+//
+// Either a version of its Event Stream, or a time: the state of the aggregate
+// includes all the Events recorded at or before it. The zero value is the
+// latest state of the aggregate.
+type Point struct {
+	Version int64
+	Time    time.Time
+}
+
+// ParsePoint parses a Point from a positive version number, an RFC 3339 timestamp,
+// or a date in the YYYY-MM-DD format, pointing to the end of that day in UTC.
+func ParsePoint(s string) (Point, error) {
+	if version, err := strconv.ParseInt(s, 10, 64); err == nil {
+		if version < 1 {
+			return Point{}, fmt.Errorf("%w: version should be positive: %s", ErrInvalidPoint, s)
+		}
+
+		return Point{Version: version}, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+		return Point{Time: t}, nil
+	}
+
+	if day, err := time.Parse("2006-01-02", s); err == nil {
+		return Point{Time: day.AddDate(0, 0, 1).Add(-time.Nanosecond)}, nil
+	}
+
+	return Point{}, fmt.Errorf("%w: %s", ErrInvalidPoint, s)
+}
+
+// IsZero returns true if the Point is the latest state of the aggregate.
+func (p Point) IsZero() bool {
+	return p.Version == 0 && p.Time.IsZero()
+}
+
+// Includes returns true if the Event is part of the state of the aggregate
+// at this Point.
+//
+// Events without an appended time precede all the recorded ones,
+// so they are included in all the Points in time: use resolves
+// to reject the Points in time earlier than the first recorded Event.
+func (p Point) Includes(event eventstore.Event) bool {
+	if p.Version > 0 && event.Version > p.Version {
+		return false
+	}
+
+	if p.Time.IsZero() {
+		return true
+	}
+
+	recordedAt, ok := AppendedAt(event.Event)
+
+	return !ok || !recordedAt.After(p.Time)
+}
+
+// resolves returns false if the Point is a time that cannot be told apart
+// in the Events, because it is earlier than all the Events with an appended time
+// while some of them have none, since they were appended before it was recorded.
+func (p Point) resolves(events []eventstore.Event) bool {
+	if p.Time.IsZero() {
+		return true
+	}
+
+	unrecorded := false
+
+	for _, event := range events {
+		recordedAt, ok := AppendedAt(event.Event)
+		if !ok {
+			unrecorded = true
+			continue
+		}
+
+		if !recordedAt.After(p.Time) {
+			return true
+		}
+	}
+
+	return !unrecorded
+}
+
+func (p Point) String() string {
+	switch {
+	case p.Version > 0:
+		return fmt.Sprintf("version %d", p.Version)
+	case !p.Time.IsZero():
+		return p.Time.Format(time.RFC3339Nano)
+	default:
+		return "latest"
+	}
+}
@@ -0,0 +1,172 @@
+package timetravel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/account"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/interval"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/monthly"
+	"github.com/eventually-rs/saving-goals-go/internal/schema"
+
+	"github.com/eventually-rs/eventually-go/aggregate"
+	"github.com/eventually-rs/eventually-go/eventstore"
+	"github.com/eventually-rs/eventually-go/query"
+)
+
+// ErrUnknownType is returned by EvolutionHandler when the aggregate type
+// requested does not support time travel.
+var ErrUnknownType = errors.New("timetravel.Evolution: unknown aggregate type")
+
+var (
+	_ query.Handler = AccountHandler{}
+	_ query.Handler = ProgressHandler{}
+	_ query.Handler = EvolutionHandler{}
+)
+
+// AccountQuery is the Domain Query used to fetch the state of a single
+// Account as it was at the specified Point.
+type AccountQuery struct {
+	AccountID string
+	AsOf      Point
+}
+
+// AccountHandler answers to AccountQuery with the account.View
+// of the Account rehydrated from its Event Stream.
+type AccountHandler struct {
+	EventStore eventstore.Typed
+}
+
+// QueryType binds the AccountQuery type to the handler.
+func (AccountHandler) QueryType() query.Query { return AccountQuery{} }
+
+// Handle returns the state of the Account requested at the Point specified.
+//
+// account.ErrNotFound is returned if the Account did not exist yet.
+func (h AccountHandler) Handle(ctx context.Context, q query.Query) (query.Answer, error) {
+	query := q.(AccountQuery)
+	acc := new(account.Account)
+
+	applied, err := replay(ctx, h.EventStore.Instance(query.AccountID), acc, query.AsOf, nil)
+	if err != nil {
+		return nil, fmt.Errorf("timetravel.AccountHandler: %w", err)
+	}
+
+	if applied == 0 {
+		return nil, fmt.Errorf("%w: %s as of %s", account.ErrNotFound, query.AccountID, query.AsOf)
+	}
+
+	return acc.View(), nil
+}
+
+// ProgressQuery is the Domain Query used to fetch the spending progress
+// of an Account or a Household in a specific month, as it was at the specified Point.
+type ProgressQuery struct {
+	AccountID   string
+	HouseholdID string
+	Month       interval.Month
+	AsOf        Point
+}
+
+// ProgressHandler answers to ProgressQuery with the monthly.Progress
+// of the Spending rehydrated from its Event Stream.
+type ProgressHandler struct {
+	EventStore eventstore.Typed
+}
+
+// QueryType binds the ProgressQuery type to the handler.
+func (ProgressHandler) QueryType() query.Query { return ProgressQuery{} }
+
+// Handle returns the spending progress requested at the Point specified.
+//
+// monthly.ErrProgressNotFound is returned if the spending was not being tracked yet.
+func (h ProgressHandler) Handle(ctx context.Context, q query.Query) (query.Answer, error) {
+	query := q.(ProgressQuery)
+	id := monthly.ID{AccountID: query.AccountID, HouseholdID: query.HouseholdID, Month: query.Month}
+	spending := new(monthly.Spending)
+
+	applied, err := replay(ctx, h.EventStore.Instance(id.String()), spending, query.AsOf, nil)
+	if err != nil {
+		return nil, fmt.Errorf("timetravel.ProgressHandler: %w", err)
+	}
+
+	if applied == 0 {
+		return nil, fmt.Errorf("%w: %s as of %s", monthly.ErrProgressNotFound, id, query.AsOf)
+	}
+
+	return spending.Progress(), nil
+}
+
+// EvolutionQuery is the Domain Query used to fetch the state of an aggregate
+// after each one of its Events, up to the specified Point.
+//
+// Type is either account.Type or monthly.Type name, and ID the name
+// of the Event Stream of the aggregate.
+type EvolutionQuery struct {
+	Type string
+	ID   string
+	AsOf Point
+}
+
+// Evolution is the Domain Answer returned from an EvolutionQuery, with a Step
+// for each Event applied: the State is an account.View for Accounts,
+// and a monthly.Progress for monthly Spendings.
+type Evolution []Step
+
+// EvolutionHandler answers to EvolutionQuery by rehydrating the aggregate
+// requested one Event at a time.
+type EvolutionHandler struct {
+	Accounts  eventstore.Typed
+	Spendings eventstore.Typed
+	Events    *schema.Registry
+}
+
+// QueryType binds the EvolutionQuery type to the handler.
+func (EvolutionHandler) QueryType() query.Query { return EvolutionQuery{} }
+
+// Handle returns the Evolution of the aggregate requested.
+//
+// ErrUnknownType is returned for aggregate types other than Accounts and monthly Spendings.
+func (h EvolutionHandler) Handle(ctx context.Context, q query.Query) (query.Answer, error) {
+	query := q.(EvolutionQuery)
+
+	var (
+		root       aggregate.Root
+		eventStore eventstore.Typed
+		state      func() interface{}
+	)
+
+	switch query.Type {
+	case account.Type.Name():
+		acc := new(account.Account)
+		root, eventStore, state = acc, h.Accounts, func() interface{} { return acc.View() }
+
+	case monthly.Type.Name():
+		spending := new(monthly.Spending)
+		root, eventStore, state = spending, h.Spendings, func() interface{} { return spending.Progress() }
+
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownType, query.Type)
+	}
+
+	evolution := Evolution{}
+
+	_, err := replay(ctx, eventStore.Instance(query.ID), root, query.AsOf, func(event eventstore.Event) {
+		evolution = append(evolution, Step{Event: event, EventName: h.nameOf(event.Payload), State: state()})
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("timetravel.EvolutionHandler: %w", err)
+	}
+
+	return evolution, nil
+}
+
+func (h EvolutionHandler) nameOf(payload interface{}) string {
+	if name, ok := h.Events.NameOf(payload); ok {
+		return name
+	}
+
+	return fmt.Sprintf("%T", payload)
+}
@@ -0,0 +1,70 @@
+package timetravel
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/eventually-rs/saving-goals-go/internal/shredding"
+
+	"github.com/eventually-rs/eventually-go/aggregate"
+	"github.com/eventually-rs/eventually-go/eventstore"
+)
+
+// Step is an Event applied to an aggregate, with the state of the aggregate
+// right after it.
+//
+// EventName is the versioned name the Event type has been registered with,
+// or its Go type if it has not been registered.
+type Step struct {
+	Event     eventstore.Event
+	EventName string
+	State     interface{}
+}
+
+// replay applies to the aggregate Root all the Events of its Event Stream
+// included in the Point, calling step after each one.
+//
+// The number of Events applied is returned: Unreadable Events are skipped,
+// since the subject the aggregate belongs to has been forgotten.
+//
+// ErrTimeNotRecorded is returned if the Point is a time the Event Stream
+// cannot tell.
+func replay(
+	ctx context.Context,
+	instance eventstore.Instanced,
+	root aggregate.Root,
+	point Point,
+	step func(eventstore.Event),
+) (int, error) {
+	events, err := eventstore.StreamToSlice(ctx, func(ctx context.Context, es eventstore.EventStream) error {
+		return instance.Stream(ctx, es, 0)
+	})
+
+	if err != nil {
+		return 0, fmt.Errorf("timetravel: failed to stream events: %w", err)
+	}
+
+	if !point.resolves(events) {
+		return 0, fmt.Errorf("%w: %s", ErrTimeNotRecorded, point)
+	}
+
+	applied := 0
+
+	for _, event := range events {
+		if _, ok := event.Payload.(shredding.Unreadable); ok || !point.Includes(event) {
+			continue
+		}
+
+		if err := root.Apply(event.Event); err != nil {
+			return 0, fmt.Errorf("timetravel: failed to apply event version %d: %w", event.Version, err)
+		}
+
+		applied++
+
+		if step != nil {
+			step(event)
+		}
+	}
+
+	return applied, nil
+}
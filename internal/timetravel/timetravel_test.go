@@ -0,0 +1,391 @@
+package timetravel_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/eventually-rs/saving-goals-go/internal/domain/account"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/interval"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/monthly"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/saving"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/transaction"
+	"github.com/eventually-rs/saving-goals-go/internal/schema"
+	"github.com/eventually-rs/saving-goals-go/internal/timetravel"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/eventstore"
+	"github.com/eventually-rs/eventually-go/eventstore/inmemory"
+	"github.com/stretchr/testify/assert"
+)
+
+const accountID = "test-account"
+
+var (
+	day1 = time.Date(2021, time.January, 12, 10, 0, 0, 0, time.UTC)
+	day2 = day1.AddDate(0, 0, 1)
+	day3 = day2.AddDate(0, 0, 1)
+)
+
+// clock returns the specified times, one per call.
+func clock(times ...time.Time) timetravel.Clock {
+	return func() time.Time {
+		t := times[0]
+		times = times[1:]
+
+		return t
+	}
+}
+
+// eventStore returns an Event Store with an Account created on day1, whose Saving Goal
+// was set on day2 and which recorded an expense on day3, and the spending of its month.
+func eventStore(t *testing.T) eventstore.Store {
+	ctx := context.Background()
+	es := timetravel.WrapEventStore(inmemory.NewEventStore(), clock(day1, day1, day2, day3, day3, day3))
+
+	for _, typ := range []string{account.Type.Name(), monthly.Type.Name()} {
+		if err := es.Register(ctx, typ, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	accounts, err := es.Type(ctx, account.Type.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	spendings, err := es.Type(ctx, monthly.Type.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	id := monthly.ID{AccountID: accountID, Month: interval.MonthFromTime(day1)}
+
+	appends := []struct {
+		instance eventstore.Instanced
+		payload  interface{}
+	}{
+		{accounts.Instance(accountID), account.WasCreated{AccountID: accountID}},
+		{spendings.Instance(id.String()), monthly.SpendingTrackingStarted{ID: id, StartingBalance: 1000, DesiredBalance: 500}},
+		{accounts.Instance(accountID), account.SavingGoalWasChanged{
+			SavingGoal: saving.Goal{Amount: 500, Thresholds: []saving.Threshold{saving.Percentage(50)}},
+		}},
+		{accounts.Instance(accountID), account.TransactionWasRecorded{
+			TransactionID: "tx-1",
+			Amount:        -100,
+			Kind:          transaction.Expense,
+			HappenedAt:    day3,
+		}},
+		{spendings.Instance(id.String()), monthly.TransactionWasRecorded{Amount: -100, Kind: transaction.Expense, HappenedAt: day3}},
+	}
+
+	for _, a := range appends {
+		if _, err := a.instance.Append(ctx, -1, eventually.Event{Payload: a.payload}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return es
+}
+
+func TestParsePoint(t *testing.T) {
+	testcases := []struct {
+		value    string
+		expected timetravel.Point
+		err      error
+	}{
+		{value: "3", expected: timetravel.Point{Version: 3}},
+		{value: "2021-01-12T10:00:00Z", expected: timetravel.Point{Time: day1}},
+		{value: "2021-01-12", expected: timetravel.Point{Time: time.Date(2021, time.January, 12, 23, 59, 59, 999999999, time.UTC)}},
+		{value: "0", err: timetravel.ErrInvalidPoint},
+		{value: "yesterday", err: timetravel.ErrInvalidPoint},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+
+		t.Run(tc.value, func(t *testing.T) {
+			point, err := timetravel.ParsePoint(tc.value)
+			assert.True(t, errors.Is(err, tc.err))
+			assert.True(t, tc.expected.Time.Equal(point.Time))
+			assert.Equal(t, tc.expected.Version, point.Version)
+		})
+	}
+}
+
+func TestEventStoreWrapper(t *testing.T) {
+	ctx := context.Background()
+	es := eventStore(t)
+
+	events, err := eventstore.StreamToSlice(ctx, func(ctx context.Context, stream eventstore.EventStream) error {
+		return es.Stream(ctx, stream, 0)
+	})
+
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	var recorded []time.Time
+
+	for _, event := range events {
+		recordedAt, ok := timetravel.AppendedAt(event.Event)
+		assert.True(t, ok)
+
+		recorded = append(recorded, recordedAt)
+	}
+
+	assert.Equal(t, []time.Time{day1, day1, day2, day3, day3}, recorded)
+
+	// The Postgres Event Store decodes the recording time as a string.
+	recordedAt, ok := timetravel.AppendedAt(eventually.Event{
+		Metadata: eventually.Metadata{timetravel.AppendedAtKey: day2.Format(time.RFC3339Nano)},
+	})
+
+	assert.True(t, ok)
+	assert.True(t, day2.Equal(recordedAt))
+
+	t.Run("the events appended are left untouched", func(t *testing.T) {
+		accounts, err := es.Type(ctx, account.Type.Name())
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		// The consumers record the time of the original message.
+		metadata := eventually.Metadata{"Recorded-At": day1}
+		events := []eventually.Event{{Payload: account.WasCreated{AccountID: "other-account"}, Metadata: metadata}}
+
+		_, err = accounts.Instance("other-account").Append(ctx, -1, events...)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		assert.Equal(t, eventually.Metadata{"Recorded-At": day1}, events[0].Metadata)
+
+		appended, err := eventstore.StreamToSlice(ctx, func(ctx context.Context, stream eventstore.EventStream) error {
+			return accounts.Instance("other-account").Stream(ctx, stream, 0)
+		})
+
+		if assert.NoError(t, err) && assert.Len(t, appended, 1) {
+			assert.Equal(t, day1, appended[0].Metadata["Recorded-At"])
+			assert.Equal(t, day3, appended[0].Metadata[timetravel.AppendedAtKey])
+		}
+	})
+}
+
+func TestUnrecordedEvents(t *testing.T) {
+	ctx := context.Background()
+	inner := inmemory.NewEventStore()
+
+	if !assert.NoError(t, inner.Register(ctx, account.Type.Name(), nil)) {
+		return
+	}
+
+	unrecorded, err := inner.Type(ctx, account.Type.Name())
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	// The Account was created before the appended time was recorded.
+	_, err = unrecorded.Instance(accountID).Append(ctx, -1, eventually.Event{Payload: account.WasCreated{AccountID: accountID}})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	handler := timetravel.AccountHandler{EventStore: unrecorded}
+
+	t.Run("points in time are rejected without recorded events", func(t *testing.T) {
+		_, err := handler.Handle(ctx, timetravel.AccountQuery{AccountID: accountID, AsOf: timetravel.Point{Time: day3}})
+		assert.True(t, errors.Is(err, timetravel.ErrTimeNotRecorded))
+
+		_, err = handler.Handle(ctx, timetravel.AccountQuery{AccountID: accountID, AsOf: timetravel.Point{Version: 1}})
+		assert.NoError(t, err)
+	})
+
+	accounts, err := timetravel.WrapEventStore(inner, clock(day2)).Type(ctx, account.Type.Name())
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	_, err = accounts.Instance(accountID).Append(ctx, -1, eventually.Event{
+		Payload: account.SavingGoalWasChanged{SavingGoal: saving.Goal{Amount: 500}},
+	})
+
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	t.Run("points in time before the first recorded event are rejected", func(t *testing.T) {
+		_, err := handler.Handle(ctx, timetravel.AccountQuery{AccountID: accountID, AsOf: timetravel.Point{Time: day1}})
+		assert.True(t, errors.Is(err, timetravel.ErrTimeNotRecorded))
+	})
+
+	t.Run("points in time after the first recorded event include the unrecorded ones", func(t *testing.T) {
+		answer, err := handler.Handle(ctx, timetravel.AccountQuery{AccountID: accountID, AsOf: timetravel.Point{Time: day2}})
+		if assert.NoError(t, err) {
+			assert.NotNil(t, answer.(account.View).SavingGoal)
+		}
+	})
+}
+
+func TestAccountHandler(t *testing.T) {
+	ctx := context.Background()
+	es := eventStore(t)
+
+	accounts, err := es.Type(ctx, account.Type.Name())
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	handler := timetravel.AccountHandler{EventStore: accounts}
+
+	t.Run("the latest state is the same of the view projection", func(t *testing.T) {
+		projection := account.NewViewProjection()
+
+		events, err := eventstore.StreamToSlice(ctx, func(ctx context.Context, stream eventstore.EventStream) error {
+			return accounts.Stream(ctx, stream, 0)
+		})
+
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		for _, event := range events {
+			assert.NoError(t, projection.Apply(ctx, event))
+		}
+
+		expected, err := projection.Handle(ctx, account.ViewQuery{AccountID: accountID})
+		assert.NoError(t, err)
+
+		answer, err := handler.Handle(ctx, timetravel.AccountQuery{AccountID: accountID})
+		assert.NoError(t, err)
+		assert.Equal(t, expected, answer)
+	})
+
+	t.Run("the state as of a version only includes the events up to it", func(t *testing.T) {
+		answer, err := handler.Handle(ctx, timetravel.AccountQuery{
+			AccountID: accountID,
+			AsOf:      timetravel.Point{Version: 2},
+		})
+
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		view := answer.(account.View)
+		assert.Equal(t, float64(0), view.Balance)
+		assert.NotNil(t, view.SavingGoal)
+	})
+
+	t.Run("the state as of a time only includes the events recorded before it", func(t *testing.T) {
+		answer, err := handler.Handle(ctx, timetravel.AccountQuery{
+			AccountID: accountID,
+			AsOf:      timetravel.Point{Time: day1.Add(time.Hour)},
+		})
+
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		view := answer.(account.View)
+		assert.Equal(t, accountID, view.AccountID)
+		assert.Nil(t, view.SavingGoal)
+	})
+
+	t.Run("accounts not created yet are not found", func(t *testing.T) {
+		_, err := handler.Handle(ctx, timetravel.AccountQuery{
+			AccountID: accountID,
+			AsOf:      timetravel.Point{Time: day1.Add(-time.Hour)},
+		})
+
+		assert.True(t, errors.Is(err, account.ErrNotFound))
+	})
+}
+
+func TestProgressHandler(t *testing.T) {
+	ctx := context.Background()
+	es := eventStore(t)
+
+	spendings, err := es.Type(ctx, monthly.Type.Name())
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	handler := timetravel.ProgressHandler{EventStore: spendings}
+	month := interval.MonthFromTime(day1)
+
+	answer, err := handler.Handle(ctx, timetravel.ProgressQuery{
+		AccountID: accountID,
+		Month:     month,
+		AsOf:      timetravel.Point{Time: day2},
+	})
+
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	progress := answer.(monthly.Progress)
+	assert.Equal(t, float64(0), progress.Spent)
+	assert.Equal(t, float64(500), progress.SpendingLimit)
+
+	answer, err = handler.Handle(ctx, timetravel.ProgressQuery{AccountID: accountID, Month: month})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, float64(100), answer.(monthly.Progress).Spent)
+
+	_, err = handler.Handle(ctx, timetravel.ProgressQuery{AccountID: accountID, Month: interval.MonthFromTime(day1.AddDate(0, 1, 0))})
+	assert.True(t, errors.Is(err, monthly.ErrProgressNotFound))
+}
+
+func TestEvolutionHandler(t *testing.T) {
+	ctx := context.Background()
+	es := eventStore(t)
+
+	accounts, err := es.Type(ctx, account.Type.Name())
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	spendings, err := es.Type(ctx, monthly.Type.Name())
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	events, err := schema.NewRegistry(map[string][]schema.EventType{
+		account.Type.Name(): {{Name: "account_was_created", Version: 1, Event: account.WasCreated{}}},
+	})
+
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	handler := timetravel.EvolutionHandler{Accounts: accounts, Spendings: spendings, Events: events}
+
+	answer, err := handler.Handle(ctx, timetravel.EvolutionQuery{
+		Type: account.Type.Name(),
+		ID:   accountID,
+		AsOf: timetravel.Point{Time: day3},
+	})
+
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	evolution := answer.(timetravel.Evolution)
+	if !assert.Len(t, evolution, 3) {
+		return
+	}
+
+	assert.Equal(t, schema.Name("account_was_created", 1), evolution[0].EventName)
+	assert.Equal(t, "account.SavingGoalWasChanged", evolution[1].EventName)
+
+	assert.Nil(t, evolution[0].State.(account.View).SavingGoal)
+	assert.NotNil(t, evolution[1].State.(account.View).SavingGoal)
+	assert.Equal(t, float64(0), evolution[1].State.(account.View).Balance)
+	assert.Equal(t, float64(-100), evolution[2].State.(account.View).Balance)
+
+	_, err = handler.Handle(ctx, timetravel.EvolutionQuery{Type: "household", ID: "test-household"})
+	assert.True(t, errors.Is(err, timetravel.ErrUnknownType))
+}
@@ -0,0 +1,29 @@
+package v1
+
+type SavingGoal struct {
+	Amount     float64     `json:"amount" openapi:"required,minimum=0"`
+	Thresholds []Threshold `json:"thresholds,omitempty"`
+}
+
+type Account struct {
+	AccountID  string      `json:"accountId"`
+	Balance    float64     `json:"balance"`
+	SavingGoal *SavingGoal `json:"savingGoal,omitempty"`
+	Goals      []Goal      `json:"goals"`
+	Budgets    []Budget    `json:"budgets"`
+	Pacing     string      `json:"pacing"`
+	Status     string      `json:"status"`
+
+	RecurringSeries []RecurringSeries `json:"recurringSeries"`
+	SweepRules      []SweepRule       `json:"sweepRules"`
+}
+
+// SetNewThresholdRequest contains the new threshold to set, either as
+// a {"kind", "value"} object or as a bare number, meaning a percentage.
+type SetNewThresholdRequest struct {
+	Threshold Threshold `json:"threshold" openapi:"required"`
+}
+
+type ReplaceThresholdsRequest struct {
+	Thresholds []Threshold `json:"thresholds" openapi:"required"`
+}
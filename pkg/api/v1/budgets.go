@@ -0,0 +1,12 @@
+package v1
+
+type Budget struct {
+	Category   string      `json:"category"`
+	Amount     float64     `json:"amount"`
+	Thresholds []Threshold `json:"thresholds"`
+}
+
+type SetBudgetRequest struct {
+	Amount     float64     `json:"amount" openapi:"required,minimum=0"`
+	Thresholds []Threshold `json:"thresholds,omitempty"`
+}
@@ -0,0 +1,16 @@
+package v1
+
+type CategorizationRule struct {
+	ID                 string   `json:"id"`
+	Category           string   `json:"category" openapi:"required"`
+	Priority           int      `json:"priority"`
+	Merchant           string   `json:"merchant,omitempty"`
+	DescriptionPattern string   `json:"descriptionPattern,omitempty"`
+	MCC                string   `json:"mcc,omitempty"`
+	MinAmount          *float64 `json:"minAmount,omitempty"`
+	MaxAmount          *float64 `json:"maxAmount,omitempty"`
+}
+
+type RecategorizeTransactionsRequest struct {
+	TransactionID string `json:"transactionId"`
+}
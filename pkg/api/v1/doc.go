@@ -0,0 +1,16 @@
+// Package v1 contains the request and response bodies of the version 1
+// of the HTTP API, shared by the server and its clients.
+//
+// The types are decoupled from the domain ones on purpose: changing the domain
+// should not change the API. The openapi tags document the constraints
+// of the request fields in the OpenAPI document served by the API,
+// which is also used to validate the requests:
+//
+//   - required: the field should always be specified,
+//   - minimum=<n> and maximum=<n>: limits of the numbers, included,
+//   - enum=<a>|<b>: the values accepted by a string field,
+//   - format=<format>: the format of a string field, like date.
+package v1
+
+// Version is the version of the HTTP API described by this package.
+const Version = "1.0.0"
@@ -0,0 +1,32 @@
+package v1
+
+type Goal struct {
+	ID                  string  `json:"id"`
+	Name                string  `json:"name"`
+	TargetAmount        float64 `json:"targetAmount"`
+	Deadline            string  `json:"deadline,omitempty" openapi:"format=date"`
+	MonthlyContribution float64 `json:"monthlyContribution,omitempty"`
+	Priority            int     `json:"priority"`
+	SavedAmount         float64 `json:"savedAmount"`
+	Status              string  `json:"status"`
+	Progress            float64 `json:"progress"`
+
+	// RequiredThisMonth is the amount to save in the current month
+	// to reach the Goal's target in time.
+	RequiredThisMonth float64 `json:"requiredThisMonth"`
+}
+
+type GoalRequest struct {
+	ID                  string  `json:"id"`
+	Name                string  `json:"name" openapi:"required"`
+	TargetAmount        float64 `json:"targetAmount" openapi:"required,minimum=0"`
+	Deadline            string  `json:"deadline,omitempty" openapi:"format=date"`
+	MonthlyContribution float64 `json:"monthlyContribution,omitempty" openapi:"minimum=0"`
+	Priority            int     `json:"priority"`
+}
+
+// GoalContributionRequest contains the amount saved for a Goal,
+// which is negative for the withdrawals.
+type GoalContributionRequest struct {
+	Amount float64 `json:"amount" openapi:"required"`
+}
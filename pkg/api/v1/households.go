@@ -0,0 +1,23 @@
+package v1
+
+type Household struct {
+	HouseholdID string      `json:"householdId"`
+	Name        string      `json:"name"`
+	Members     []string    `json:"members"`
+	Invitations []string    `json:"invitations"`
+	SavingGoal  *SavingGoal `json:"savingGoal,omitempty"`
+}
+
+type CreateHouseholdRequest struct {
+	Name      string `json:"name"`
+	AccountID string `json:"accountId" openapi:"required"`
+}
+
+type CreateHouseholdResponse struct {
+	HouseholdID string `json:"householdId"`
+}
+
+type InviteHouseholdMemberRequest struct {
+	AccountID string `json:"accountId" openapi:"required"`
+	InvitedBy string `json:"invitedBy" openapi:"required"`
+}
@@ -0,0 +1,5 @@
+package v1
+
+type AccountLifecycleRequest struct {
+	Reason string `json:"reason"`
+}
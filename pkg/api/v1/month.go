@@ -0,0 +1,37 @@
+package v1
+
+type CategoryProgress struct {
+	Category          string      `json:"category"`
+	Budget            float64     `json:"budget"`
+	Spent             float64     `json:"spent"`
+	Remaining         float64     `json:"remaining"`
+	ReachedThresholds []Threshold `json:"reachedThresholds"`
+}
+
+type Forecast struct {
+	Date                 string  `json:"date" openapi:"format=date"`
+	DaysLeft             int     `json:"daysLeft"`
+	AverageDailySpending float64 `json:"averageDailySpending"`
+	ExpectedSpending     float64 `json:"expectedSpending"`
+	RecurringPayments    float64 `json:"recurringPayments"`
+	ExpectedBalance      float64 `json:"expectedBalance"`
+	DesiredBalance       float64 `json:"desiredBalance"`
+	Probability          float64 `json:"probability"`
+}
+
+type MonthProgress struct {
+	AccountID         string             `json:"accountId,omitempty"`
+	HouseholdID       string             `json:"householdId,omitempty"`
+	Month             string             `json:"month"`
+	StartingBalance   float64            `json:"startingBalance"`
+	CurrentBalance    float64            `json:"currentBalance"`
+	DesiredBalance    float64            `json:"desiredBalance"`
+	SpendingLimit     float64            `json:"spendingLimit"`
+	Spent             float64            `json:"spent"`
+	ReachedThresholds []Threshold        `json:"reachedThresholds"`
+	Categories        []CategoryProgress `json:"categories"`
+	GoalAtRisk        bool               `json:"goalAtRisk"`
+	Committed         float64            `json:"committed"`
+	Closed            bool               `json:"closed"`
+	Forecast          *Forecast          `json:"forecast,omitempty"`
+}
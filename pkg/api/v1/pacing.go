@@ -0,0 +1,19 @@
+package v1
+
+// PacingStrategyRequest contains the pacing strategy to use for the
+// Account's monthly spending: "linear", "weekday-weighted" or "front-loaded".
+type PacingStrategyRequest struct {
+	Strategy string `json:"strategy" openapi:"required,enum=linear|weekday-weighted|front-loaded"`
+}
+
+type Allowance struct {
+	AccountID        string  `json:"accountId"`
+	Date             string  `json:"date" openapi:"format=date"`
+	Strategy         string  `json:"strategy"`
+	SpendingLimit    float64 `json:"spendingLimit"`
+	Spent            float64 `json:"spent"`
+	Expected         float64 `json:"expected"`
+	Remaining        float64 `json:"remaining"`
+	SafeToSpendToday float64 `json:"safeToSpendToday"`
+	Pace             string  `json:"pace"`
+}
@@ -0,0 +1,11 @@
+package v1
+
+type RecurringSeries struct {
+	ID         string  `json:"id"`
+	Name       string  `json:"name"`
+	Kind       string  `json:"kind"`
+	Amount     float64 `json:"amount"`
+	Cadence    string  `json:"cadence"`
+	LastSeenAt string  `json:"lastSeenAt" openapi:"format=date-time"`
+	Status     string  `json:"status"`
+}
@@ -0,0 +1,21 @@
+package v1
+
+import "time"
+
+type StreamEvent struct {
+	Name           string                 `json:"name"`
+	Version        int64                  `json:"version"`
+	SequenceNumber int64                  `json:"sequenceNumber"`
+	RecordedAt     *time.Time             `json:"recordedAt,omitempty"`
+	EventID        string                 `json:"eventId,omitempty"`
+	CorrelationID  string                 `json:"correlationId,omitempty"`
+	CausationID    string                 `json:"causationId,omitempty"`
+	Payload        interface{}            `json:"payload"`
+	Metadata       map[string]interface{} `json:"metadata"`
+}
+
+type StreamPage struct {
+	Events  []StreamEvent `json:"events"`
+	Next    int64         `json:"next,omitempty"`
+	Version int64         `json:"version"`
+}
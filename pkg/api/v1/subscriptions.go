@@ -0,0 +1,38 @@
+package v1
+
+import "time"
+
+type Subscription struct {
+	Name           string `json:"name"`
+	Kind           string `json:"kind"`
+	SequenceNumber int64  `json:"sequenceNumber"`
+	Running        bool   `json:"running"`
+	Rebuilding     bool   `json:"rebuilding"`
+}
+
+// ResetSubscriptionRequest moves the checkpoint of a policy either to
+// a global sequence number, or to the latest Event recorded before a time.
+//
+// With DryRun, the policy is replayed from there logging the commands
+// it would dispatch, without moving its checkpoint.
+type ResetSubscriptionRequest struct {
+	SequenceNumber int64      `json:"sequenceNumber" openapi:"minimum=0"`
+	Timestamp      *time.Time `json:"timestamp"`
+	DryRun         bool       `json:"dryRun"`
+}
+
+type ResetSubscriptionResponse struct {
+	SequenceNumber int64 `json:"sequenceNumber"`
+}
+
+type DryRunCommand struct {
+	Type    string      `json:"type"`
+	Payload interface{} `json:"payload"`
+}
+
+type DryRunResponse struct {
+	From     int64           `json:"from"`
+	To       int64           `json:"to"`
+	Events   int             `json:"events"`
+	Commands []DryRunCommand `json:"commands"`
+}
@@ -0,0 +1,22 @@
+package v1
+
+import "time"
+
+type SweepRule struct {
+	ID         string  `json:"id"`
+	Kind       string  `json:"kind" openapi:"required,enum=round-up|income-percentage|leftover"`
+	GoalID     string  `json:"goalId" openapi:"required"`
+	Unit       float64 `json:"unit,omitempty" openapi:"minimum=0"`
+	Percentage float64 `json:"percentage,omitempty" openapi:"minimum=0,maximum=1"`
+}
+
+type SavingsTransfer struct {
+	ID          string    `json:"id"`
+	GoalID      string    `json:"goalId"`
+	RuleID      string    `json:"ruleId"`
+	Kind        string    `json:"kind"`
+	Amount      float64   `json:"amount"`
+	Status      string    `json:"status"`
+	Reason      string    `json:"reason,omitempty"`
+	RequestedAt time.Time `json:"requestedAt"`
+}
@@ -0,0 +1,59 @@
+package v1
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// The kinds of Threshold.
+const (
+	ThresholdPercentage     = "percentage"
+	ThresholdSpentAbove     = "spent-above"
+	ThresholdRemainingBelow = "remaining-below"
+)
+
+// Threshold is a notification point on the spending of the month.
+//
+// In the requests, it can also be specified as a bare number,
+// meaning a percentage of the spending limit, like 0.5 for 50%.
+type Threshold struct {
+	Kind  string  `json:"kind,omitempty" openapi:"enum=percentage|spent-above|remaining-below"`
+	Value float64 `json:"value" openapi:"required,minimum=0"`
+}
+
+// UnmarshalJSON decodes a Threshold from either its JSON object representation,
+// or from a bare number, which is interpreted as a percentage.
+func (t *Threshold) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	if len(data) > 0 && data[0] != '{' {
+		var value float64
+		if err := json.Unmarshal(data, &value); err != nil {
+			return err
+		}
+
+		*t = Threshold{Kind: ThresholdPercentage, Value: value}
+
+		return nil
+	}
+
+	// Use a different type to avoid recursive calls to UnmarshalJSON.
+	type threshold Threshold
+
+	var v threshold
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	if v.Kind == "" {
+		v.Kind = ThresholdPercentage
+	}
+
+	*t = Threshold(v)
+
+	return nil
+}
@@ -0,0 +1,16 @@
+package v1
+
+import "time"
+
+type EvolutionEvent struct {
+	Type    string      `json:"type"`
+	Payload interface{} `json:"payload"`
+}
+
+type EvolutionStep struct {
+	Version        int64          `json:"version"`
+	SequenceNumber int64          `json:"sequenceNumber"`
+	RecordedAt     *time.Time     `json:"recordedAt,omitempty"`
+	Event          EvolutionEvent `json:"event"`
+	State          interface{}    `json:"state"`
+}
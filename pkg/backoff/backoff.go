@@ -0,0 +1,55 @@
+// Package backoff computes the delays between the retries of a failed operation.
+package backoff
+
+import (
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// Exponential is a randomized exponential backoff.
+type Exponential struct {
+	// Base is the delay before the first retry, doubled at each retry.
+	// Zero disables the delays.
+	Base time.Duration
+
+	// Max caps the delay between two retries. Zero leaves it uncapped.
+	Max time.Duration
+}
+
+// Delay returns the delay before the specified retry, starting from 1.
+//
+// The delay is randomized between zero and the exponential backoff,
+// so that the callers failed at the same time do not retry together again.
+func (e Exponential) Delay(retry int) time.Duration {
+	if e.Base <= 0 || retry < 1 {
+		return 0
+	}
+
+	delay := e.Base
+	for i := 1; i < retry && delay <= math.MaxInt64/2; i++ {
+		delay *= 2
+	}
+
+	if e.Max > 0 && delay > e.Max {
+		delay = e.Max
+	}
+
+	return time.Duration(jitter.int63n(int64(delay)))
+}
+
+var jitter = &lockedRand{rand: rand.New(rand.NewSource(time.Now().UnixNano()))}
+
+// lockedRand is a rand.Rand safe for concurrent use.
+type lockedRand struct {
+	mx   sync.Mutex
+	rand *rand.Rand
+}
+
+func (r *lockedRand) int63n(n int64) int64 {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	return r.rand.Int63n(n)
+}
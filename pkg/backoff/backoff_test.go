@@ -0,0 +1,50 @@
+package backoff_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/eventually-rs/saving-goals-go/pkg/backoff"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExponential(t *testing.T) {
+	t.Run("delays are doubled at each retry", func(t *testing.T) {
+		b := backoff.Exponential{Base: time.Millisecond}
+
+		for retry := 1; retry <= 10; retry++ {
+			delay := b.Delay(retry)
+			assert.True(t, delay >= 0 && delay < time.Millisecond<<uint(retry-1), delay)
+		}
+	})
+
+	t.Run("delays are capped by the max delay", func(t *testing.T) {
+		b := backoff.Exponential{Base: time.Millisecond, Max: 5 * time.Millisecond}
+
+		for retry := 1; retry <= 100; retry++ {
+			delay := b.Delay(retry)
+			assert.True(t, delay >= 0 && delay < 5*time.Millisecond, delay)
+		}
+	})
+
+	t.Run("delays without max are not capped", func(t *testing.T) {
+		b := backoff.Exponential{Base: time.Hour}
+
+		var longest time.Duration
+		for i := 0; i < 10; i++ {
+			if delay := b.Delay(10); delay > longest {
+				longest = delay
+			}
+		}
+
+		assert.True(t, longest > time.Hour, longest)
+		assert.True(t, b.Delay(1000) >= 0)
+	})
+
+	t.Run("delays are disabled without base delay", func(t *testing.T) {
+		b := backoff.Exponential{Max: time.Second}
+		assert.Zero(t, b.Delay(1))
+		assert.Zero(t, b.Delay(10))
+	})
+}
@@ -0,0 +1,46 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	v1 "github.com/eventually-rs/saving-goals-go/pkg/api/v1"
+)
+
+// GetAccount returns the Account, with its saving goal, Goals and budgets.
+func (c *Client) GetAccount(ctx context.Context, accountID string, options ...ReadOption) (v1.Account, error) {
+	var account v1.Account
+	err := c.do(ctx, http.MethodGet, pathf("/accounts/%s", accountID), readQuery(options), nil, &account)
+
+	return account, err
+}
+
+// ChangeSavingGoal changes the amount to save every month in the Account,
+// and the thresholds notified when approaching its spending limit.
+func (c *Client) ChangeSavingGoal(ctx context.Context, accountID string, savingGoal v1.SavingGoal) error {
+	return c.do(ctx, http.MethodPost, pathf("/accounts/%s/change-saving-goal", accountID), nil, savingGoal, nil)
+}
+
+// CloseAccount closes the Account, for the specified reason.
+func (c *Client) CloseAccount(ctx context.Context, accountID, reason string) error {
+	return c.do(ctx, http.MethodPost, pathf("/accounts/%s/close", accountID), nil,
+		v1.AccountLifecycleRequest{Reason: reason}, nil)
+}
+
+// FreezeAccount freezes the Account, for the specified reason,
+// until it is reopened.
+func (c *Client) FreezeAccount(ctx context.Context, accountID, reason string) error {
+	return c.do(ctx, http.MethodPost, pathf("/accounts/%s/freeze", accountID), nil,
+		v1.AccountLifecycleRequest{Reason: reason}, nil)
+}
+
+// ReopenAccount reopens a frozen Account.
+func (c *Client) ReopenAccount(ctx context.Context, accountID string) error {
+	return c.do(ctx, http.MethodPost, pathf("/accounts/%s/reopen", accountID), nil, nil, nil)
+}
+
+// ForgetAccount erases all the data of the Account,
+// which is not found anymore once the request has been accepted.
+func (c *Client) ForgetAccount(ctx context.Context, accountID string) error {
+	return c.do(ctx, http.MethodDelete, pathf("/accounts/%s", accountID), nil, nil, nil)
+}
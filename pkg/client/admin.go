@@ -0,0 +1,134 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	v1 "github.com/eventually-rs/saving-goals-go/pkg/api/v1"
+)
+
+// The internal endpoints operate the whole application,
+// and require a token with the admin role.
+
+// StartMonth starts the spending tracking of a month for all the Accounts,
+// without waiting for the month to begin.
+func (c *Client) StartMonth(ctx context.Context, year int, month time.Month) error {
+	return c.do(ctx, http.MethodPost, pathf("/internal/months/%d/%d/start", year, int(month)), nil, nil, nil)
+}
+
+// DebugVars returns the metrics published by the API with expvar.
+func (c *Client) DebugVars(ctx context.Context) (map[string]json.RawMessage, error) {
+	var vars map[string]json.RawMessage
+	err := c.do(ctx, http.MethodGet, "/internal/debug/vars", nil, nil, &vars)
+
+	return vars, err
+}
+
+// GetAggregateEvolution returns the states of an aggregate after each of its Events.
+func (c *Client) GetAggregateEvolution(
+	ctx context.Context,
+	aggregateType, aggregateID string,
+	options ...ReadOption,
+) ([]v1.EvolutionStep, error) {
+	var steps []v1.EvolutionStep
+	err := c.do(ctx, http.MethodGet, pathf("/internal/aggregates/%s/%s/evolution", aggregateType, aggregateID),
+		readQuery(options), nil, &steps)
+
+	return steps, err
+}
+
+// StreamEventsQuery selects the Events returned by ListStreamEvents.
+type StreamEventsQuery struct {
+	// Names only returns the Events with these names, all of them if empty.
+	Names []string
+
+	// From is the version to start from.
+	From int64
+
+	// Limit is the maximum number of Events to return, the API default if zero.
+	Limit int
+}
+
+// ListStreamEvents returns a page of the Events of an Event Stream.
+func (c *Client) ListStreamEvents(
+	ctx context.Context,
+	streamType, streamID string,
+	q StreamEventsQuery,
+) (v1.StreamPage, error) {
+	query := make(url.Values)
+
+	for _, name := range q.Names {
+		query.Add("type", name)
+	}
+
+	if q.From != 0 {
+		query.Set("from", strconv.FormatInt(q.From, 10))
+	}
+
+	if q.Limit != 0 {
+		query.Set("limit", strconv.Itoa(q.Limit))
+	}
+
+	var page v1.StreamPage
+	err := c.do(ctx, http.MethodGet, pathf("/internal/streams/%s/%s/events", streamType, streamID), query, nil, &page)
+
+	return page, err
+}
+
+// ListSubscriptions returns the policies and read models running in the application.
+func (c *Client) ListSubscriptions(ctx context.Context) ([]v1.Subscription, error) {
+	var subscriptions []v1.Subscription
+	err := c.do(ctx, http.MethodGet, "/internal/subscriptions", nil, nil, &subscriptions)
+
+	return subscriptions, err
+}
+
+// ResetSubscription moves the checkpoint of a policy, returning
+// the global sequence number it has been moved to.
+//
+// The DryRun field of the request is ignored: use DryRunSubscription.
+func (c *Client) ResetSubscription(
+	ctx context.Context,
+	name string,
+	request v1.ResetSubscriptionRequest,
+) (v1.ResetSubscriptionResponse, error) {
+	request.DryRun = false
+
+	var response v1.ResetSubscriptionResponse
+	err := c.do(ctx, http.MethodPost, pathf("/internal/subscriptions/%s/reset", name), nil, request, &response)
+
+	return response, err
+}
+
+// DryRunSubscription replays a policy from the position of the request,
+// returning the commands it would dispatch, without moving its checkpoint.
+func (c *Client) DryRunSubscription(
+	ctx context.Context,
+	name string,
+	request v1.ResetSubscriptionRequest,
+) (v1.DryRunResponse, error) {
+	request.DryRun = true
+
+	var response v1.DryRunResponse
+	err := c.do(ctx, http.MethodPost, pathf("/internal/subscriptions/%s/reset", name), nil, request, &response)
+
+	return response, err
+}
+
+// RebuildReadModel rebuilds a read model from scratch,
+// returning once it has replaced the previous one.
+func (c *Client) RebuildReadModel(ctx context.Context, name string) error {
+	return c.do(ctx, http.MethodPost, pathf("/internal/subscriptions/%s/rebuild", name), nil, nil, nil)
+}
+
+// OpenAPIDocument returns the OpenAPI document describing the API.
+func (c *Client) OpenAPIDocument(ctx context.Context) (json.RawMessage, error) {
+	var doc json.RawMessage
+	err := c.do(ctx, http.MethodGet, "/openapi.json", nil, nil, &doc)
+
+	return doc, err
+}
@@ -0,0 +1,52 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+
+	v1 "github.com/eventually-rs/saving-goals-go/pkg/api/v1"
+)
+
+// ListBudgets returns the budgets of the categories of the Account.
+func (c *Client) ListBudgets(ctx context.Context, accountID string) ([]v1.Budget, error) {
+	var budgets []v1.Budget
+	err := c.do(ctx, http.MethodGet, pathf("/accounts/%s/budgets", accountID), nil, nil, &budgets)
+
+	return budgets, err
+}
+
+// SetBudget sets the monthly budget of a category of the Account.
+func (c *Client) SetBudget(ctx context.Context, accountID, category string, budget v1.SetBudgetRequest) (v1.Budget, error) {
+	var set v1.Budget
+	err := c.do(ctx, http.MethodPut, pathf("/accounts/%s/budgets/%s", accountID, category), nil, budget, &set)
+
+	return set, err
+}
+
+// RemoveBudget removes the budget of a category of the Account.
+func (c *Client) RemoveBudget(ctx context.Context, accountID, category string) error {
+	return c.do(ctx, http.MethodDelete, pathf("/accounts/%s/budgets/%s", accountID, category), nil, nil, nil)
+}
+
+// ChangePacingStrategy changes how the monthly spending of the Account is paced:
+// "linear", "weekday-weighted" or "front-loaded".
+func (c *Client) ChangePacingStrategy(ctx context.Context, accountID, strategy string) error {
+	return c.do(ctx, http.MethodPut, pathf("/accounts/%s/pacing", accountID), nil,
+		v1.PacingStrategyRequest{Strategy: strategy}, nil)
+}
+
+// GetAllowance returns how much can be spent from the Account on a day,
+// or today if the date is zero.
+func (c *Client) GetAllowance(ctx context.Context, accountID string, date time.Time) (v1.Allowance, error) {
+	query := make(url.Values)
+	if !date.IsZero() {
+		query.Set("date", date.Format("2006-01-02"))
+	}
+
+	var allowance v1.Allowance
+	err := c.do(ctx, http.MethodGet, pathf("/accounts/%s/allowance", accountID), query, nil, &allowance)
+
+	return allowance, err
+}
@@ -0,0 +1,41 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	v1 "github.com/eventually-rs/saving-goals-go/pkg/api/v1"
+)
+
+// ListCategorizationRules returns the categorization rules of the Account.
+func (c *Client) ListCategorizationRules(ctx context.Context, accountID string) ([]v1.CategorizationRule, error) {
+	var rules []v1.CategorizationRule
+	err := c.do(ctx, http.MethodGet, pathf("/accounts/%s/categorization-rules", accountID), nil, nil, &rules)
+
+	return rules, err
+}
+
+// AddCategorizationRule adds a categorization rule to the Account, returning it
+// with its id, generated by the API if not specified.
+func (c *Client) AddCategorizationRule(
+	ctx context.Context,
+	accountID string,
+	rule v1.CategorizationRule,
+) (v1.CategorizationRule, error) {
+	var added v1.CategorizationRule
+	err := c.do(ctx, http.MethodPost, pathf("/accounts/%s/categorization-rules", accountID), nil, rule, &added)
+
+	return added, err
+}
+
+// RemoveCategorizationRule removes a categorization rule from the Account.
+func (c *Client) RemoveCategorizationRule(ctx context.Context, accountID, ruleID string) error {
+	return c.do(ctx, http.MethodDelete, pathf("/accounts/%s/categorization-rules/%s", accountID, ruleID), nil, nil, nil)
+}
+
+// RecategorizeTransactions applies the categorization rules to a transaction
+// of the Account, or to all of them if transactionID is empty.
+func (c *Client) RecategorizeTransactions(ctx context.Context, accountID, transactionID string) error {
+	return c.do(ctx, http.MethodPost, pathf("/accounts/%s/recategorize-transactions", accountID), nil,
+		v1.RecategorizeTransactionsRequest{TransactionID: transactionID}, nil)
+}
@@ -0,0 +1,253 @@
+// Package client is the Go client of the saving-goals HTTP API.
+//
+// The requests and responses use the types of the api/v1 package.
+// The errors answered by the API are returned as *Error, which can be
+// told apart by their Code:
+//
+//	if errors.Is(err, client.CodeAccountNotFound) {
+//		...
+//	}
+//
+// The write requests are sent with an Idempotency-Key, so that they
+// can be safely retried after a server error or a throttled request.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/eventually-rs/saving-goals-go/pkg/backoff"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// idempotencyKeyHeader is the header carrying the key of the write requests.
+	idempotencyKeyHeader = "Idempotency-Key"
+
+	problemContentType = "application/problem+json"
+)
+
+// Options configures the authentication of the Client,
+// and how many times, and how often, the failed requests are retried.
+type Options struct {
+	// Token is the bearer token authenticating the requests.
+	Token string
+
+	// HTTPClient sends the requests, http.DefaultClient if not specified.
+	HTTPClient *http.Client
+
+	// MaxAttempts is the maximum number of times a request is sent,
+	// including the first one. Values lower than one disable retries.
+	MaxAttempts int
+
+	// BaseDelay is the delay before the first retry, doubled at each retry.
+	BaseDelay time.Duration
+
+	// MaxDelay caps the delay between two retries, including the delay
+	// requested by the server with the Retry-After header. Zero leaves it uncapped.
+	MaxDelay time.Duration
+}
+
+// Client sends the requests to the saving-goals HTTP API.
+//
+// Use New to create a new instance.
+type Client struct {
+	baseURL    *url.URL
+	httpClient *http.Client
+	options    Options
+}
+
+// New returns a new Client for the API served at baseURL,
+// like "https://saving-goals.example.com".
+func New(baseURL string, options Options) (*Client, error) {
+	u, err := url.Parse(strings.TrimSuffix(baseURL, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("client.New: failed to parse base url: %w", err)
+	}
+
+	if u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("client.New: base url should be absolute, got %q", baseURL)
+	}
+
+	httpClient := options.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	return &Client{baseURL: u, httpClient: httpClient, options: options}, nil
+}
+
+type idempotencyKey struct{}
+
+// WithIdempotencyKey returns a context sending the write requests with
+// the specified Idempotency-Key, to retry them across different calls.
+//
+// Otherwise, a new key is generated for every call, and reused by its retries.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKey{}, key)
+}
+
+// ReadOption customizes a read request.
+type ReadOption func(url.Values)
+
+// AsOf returns the state at a point in time rather than the latest one:
+// either a version number, an RFC 3339 timestamp, or a date in the
+// YYYY-MM-DD format, meaning the end of that day in UTC.
+func AsOf(point string) ReadOption {
+	return func(query url.Values) { query.Set("asOf", point) }
+}
+
+func readQuery(options []ReadOption) url.Values {
+	query := make(url.Values)
+	for _, option := range options {
+		option(query)
+	}
+
+	return query
+}
+
+// pathf returns the path of an endpoint, escaping the string parameters,
+// like pathf("/accounts/%s", accountID).
+func pathf(format string, params ...interface{}) string {
+	escaped := make([]interface{}, 0, len(params))
+	for _, param := range params {
+		if s, ok := param.(string); ok {
+			param = url.PathEscape(s)
+		}
+
+		escaped = append(escaped, param)
+	}
+
+	return fmt.Sprintf(format, escaped...)
+}
+
+// do sends a request to the API, encoding in as its JSON body and decoding
+// the JSON response in out, when not nil.
+//
+// The requests failed with a server error or throttled by the server
+// are retried, with the same Idempotency-Key for the write requests.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, in, out interface{}) error {
+	var body []byte
+
+	if in != nil {
+		var err error
+		if body, err = json.Marshal(in); err != nil {
+			return fmt.Errorf("client: failed to encode %s %s request: %w", method, path, err)
+		}
+	}
+
+	u := c.baseURL.String() + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	header := make(http.Header)
+	header.Set("Accept", "application/json")
+
+	if in != nil {
+		header.Set("Content-Type", "application/json")
+	}
+
+	if c.options.Token != "" {
+		header.Set("Authorization", "Bearer "+c.options.Token)
+	}
+
+	if method != http.MethodGet {
+		key, ok := ctx.Value(idempotencyKey{}).(string)
+		if !ok {
+			key = uuid.New().String()
+		}
+
+		header.Set(idempotencyKeyHeader, key)
+	}
+
+	for attempt := 1; ; attempt++ {
+		retryAfter, err := c.send(ctx, method, u, header, body, out)
+		if err == nil || retryAfter < 0 || attempt >= c.options.MaxAttempts {
+			return err
+		}
+
+		delay := backoff.Exponential{Base: c.options.BaseDelay, Max: c.options.MaxDelay}.Delay(attempt)
+		if retryAfter > delay {
+			delay = retryAfter
+		}
+
+		if c.options.MaxDelay > 0 && delay > c.options.MaxDelay {
+			delay = c.options.MaxDelay
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("client: %s %s cancelled while retrying: %w", method, path, err)
+		case <-time.After(delay):
+		}
+	}
+}
+
+// send sends the request once, returning the delay requested by the server
+// before retrying it, or a negative delay if it should not be retried.
+func (c *Client) send(
+	ctx context.Context,
+	method, u string,
+	header http.Header,
+	body []byte,
+	out interface{},
+) (time.Duration, error) {
+	req, err := http.NewRequestWithContext(ctx, method, u, bytes.NewReader(body))
+	if err != nil {
+		return -1, fmt.Errorf("client: failed to create %s request: %w", method, err)
+	}
+
+	req.Header = header.Clone()
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		// Only the requests answered by the server are retried.
+		return -1, fmt.Errorf("client: failed to send %s request: %w", method, err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		err := errorFromResponse(resp)
+
+		if err.Temporary() {
+			return retryAfter(resp), err
+		}
+
+		return -1, err
+	}
+
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		// Drain the body to reuse the connection.
+		_, _ = io.Copy(ioutil.Discard, resp.Body)
+		return -1, nil
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return -1, fmt.Errorf("client: failed to decode %s response: %w", method, err)
+	}
+
+	return -1, nil
+}
+
+// retryAfter returns the delay requested with the Retry-After header,
+// in seconds, or zero if not specified.
+func retryAfter(resp *http.Response) time.Duration {
+	seconds, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+	if err != nil || seconds < 0 {
+		return 0
+	}
+
+	return time.Duration(seconds) * time.Second
+}
@@ -0,0 +1,553 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/eventually-rs/saving-goals-go/internal/app"
+	"github.com/eventually-rs/saving-goals-go/internal/auth"
+	"github.com/eventually-rs/saving-goals-go/internal/commandbus"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/account"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/household"
+	"github.com/eventually-rs/saving-goals-go/internal/domain/monthly"
+	"github.com/eventually-rs/saving-goals-go/internal/httpapi"
+	"github.com/eventually-rs/saving-goals-go/internal/idempotency"
+	"github.com/eventually-rs/saving-goals-go/internal/timetravel"
+	v1 "github.com/eventually-rs/saving-goals-go/pkg/api/v1"
+	"github.com/eventually-rs/saving-goals-go/pkg/client"
+
+	"github.com/eventually-rs/eventually-go"
+	"github.com/eventually-rs/eventually-go/aggregate"
+	"github.com/eventually-rs/eventually-go/command"
+	"github.com/eventually-rs/eventually-go/eventstore"
+	"github.com/eventually-rs/eventually-go/eventstore/inmemory"
+	"github.com/eventually-rs/eventually-go/query"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+const accountID = "test-account"
+
+type authenticator struct{}
+
+func (authenticator) Authenticate(ctx context.Context, token string) (commandbus.Caller, error) {
+	switch token {
+	case "alice-token":
+		return commandbus.Caller{ID: "alice", Accounts: []string{accountID}}, nil
+	case "bob-token":
+		return commandbus.Caller{ID: "bob", Accounts: []string{"other-account"}}, nil
+	case "admin-token":
+		return commandbus.Caller{ID: "admin", Roles: []string{auth.RoleAdmin}}, nil
+	default:
+		return commandbus.Caller{}, errors.New("auth.Authenticator: invalid token")
+	}
+}
+
+// accountViews answers to account.ViewQuery replaying the Event Stream
+// of the Account, so that the writes are read back right away.
+type accountViews struct{ timetravel.AccountHandler }
+
+func (accountViews) QueryType() query.Query { return account.ViewQuery{} }
+
+func (h accountViews) Handle(ctx context.Context, q query.Query) (query.Answer, error) {
+	return h.AccountHandler.Handle(ctx, timetravel.AccountQuery{AccountID: q.(account.ViewQuery).AccountID})
+}
+
+// monthlyProgress answers to monthly.ProgressQuery replaying the Event Stream
+// of the spending, like accountViews.
+type monthlyProgress struct{ timetravel.ProgressHandler }
+
+func (monthlyProgress) QueryType() query.Query { return monthly.ProgressQuery{} }
+
+func (h monthlyProgress) Handle(ctx context.Context, q query.Query) (query.Answer, error) {
+	progress := q.(monthly.ProgressQuery)
+
+	return h.ProgressHandler.Handle(ctx, timetravel.ProgressQuery{
+		AccountID:   progress.AccountID,
+		HouseholdID: progress.HouseholdID,
+		Month:       progress.Month,
+	})
+}
+
+// householdViews answers to household.ViewQuery projecting the Event Stream
+// of the Household, like accountViews.
+type householdViews struct{ eventstore.Typed }
+
+func (householdViews) QueryType() query.Query { return household.ViewQuery{} }
+
+func (h householdViews) Handle(ctx context.Context, q query.Query) (query.Answer, error) {
+	events, err := eventstore.StreamToSlice(ctx, func(ctx context.Context, es eventstore.EventStream) error {
+		return h.Instance(q.(household.ViewQuery).HouseholdID).Stream(ctx, es, 0)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	projection := household.NewViewProjection()
+
+	for _, event := range events {
+		if err := projection.Apply(ctx, event); err != nil {
+			return nil, err
+		}
+	}
+
+	return projection.Handle(ctx, q)
+}
+
+// newRouter returns the API router backed by an in-memory Event Store,
+// with the test-account already created.
+func newRouter(t *testing.T) http.Handler {
+	ctx := context.Background()
+	store := inmemory.NewEventStore()
+
+	for _, typ := range []string{"month", account.Type.Name(), monthly.Type.Name(), household.Type.Name()} {
+		if err := store.Register(ctx, typ, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	eventStore := timetravel.WrapEventStore(store, time.Now)
+
+	accounts, err := eventStore.Type(ctx, account.Type.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	spendings, err := eventStore.Type(ctx, monthly.Type.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	months, err := eventStore.Type(ctx, "month")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	households, err := eventStore.Type(ctx, household.Type.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	repository := aggregate.NewRepository(account.Type, accounts)
+
+	commandBus := command.NewSimpleBus()
+	commandBus.Register(account.CreateCommandHandler{Repository: repository})
+	commandBus.Register(account.ChangeSavingGoalCommandHandler{Repository: repository})
+	commandBus.Register(account.SetNewThresholdCommandHandler{Repository: repository})
+	commandBus.Register(account.RemoveThresholdCommandHandler{Repository: repository})
+	commandBus.Register(account.ReplaceThresholdsCommandHandler{Repository: repository})
+	commandBus.Register(account.AddGoalCommandHandler{Repository: repository})
+	commandBus.Register(account.UpdateGoalCommandHandler{Repository: repository})
+	commandBus.Register(account.RemoveGoalCommandHandler{Repository: repository})
+	commandBus.Register(account.RecordGoalContributionCommandHandler{Repository: repository})
+	commandBus.Register(account.SetCategoryBudgetCommandHandler{Repository: repository})
+	commandBus.Register(account.FreezeAccountCommandHandler{Repository: repository})
+	commandBus.Register(account.ReopenAccountCommandHandler{Repository: repository})
+
+	householdRepository := aggregate.NewRepository(household.Type, households)
+	commandBus.Register(household.CreateCommandHandler{Repository: householdRepository})
+	commandBus.Register(household.ChangeSavingGoalCommandHandler{Repository: householdRepository})
+
+	dispatcher := commandbus.Chain(commandBus, app.CommandMiddlewares(
+		app.Concurrency{MaxAttempts: 1},
+		householdRepository,
+		zap.NewNop(),
+	)...)
+
+	queryBus := query.NewSimpleBus()
+	queryBus.Register(accountViews{timetravel.AccountHandler{EventStore: accounts}})
+	queryBus.Register(monthlyProgress{timetravel.ProgressHandler{EventStore: spendings}})
+	queryBus.Register(timetravel.AccountHandler{EventStore: accounts})
+	queryBus.Register(timetravel.ProgressHandler{EventStore: spendings})
+	events, err := app.Events()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	queryBus.Register(timetravel.EvolutionHandler{Accounts: accounts, Spendings: spendings, Events: events})
+	queryBus.Register(householdViews{households})
+
+	err = dispatcher.Dispatch(commandbus.WithCaller(ctx, commandbus.System), eventually.Command{
+		Payload: account.CreateCommand{AccountID: accountID},
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	idempotent := idempotency.Middleware(idempotency.NewInMemoryStore(time.Now), time.Hour, time.Minute, time.Now, zap.NewNop())
+
+	return httpapi.NewRouter(dispatcher, queryBus, months, nil, authenticator{}, idempotent, zap.NewNop())
+}
+
+func newClient(t *testing.T, handler http.Handler, options client.Options) *client.Client {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	if options.Token == "" {
+		options.Token = "alice-token"
+	}
+
+	c, err := client.New(server.URL, options)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return c
+}
+
+func TestClient(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("saving goal and thresholds", func(t *testing.T) {
+		c := newClient(t, newRouter(t), client.Options{})
+
+		err := c.ChangeSavingGoal(ctx, accountID, v1.SavingGoal{
+			Amount:     500,
+			Thresholds: []v1.Threshold{{Kind: v1.ThresholdPercentage, Value: 0.5}},
+		})
+
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		assert.NoError(t, c.SetNewThreshold(ctx, accountID, v1.Threshold{Kind: v1.ThresholdSpentAbove, Value: 300}))
+		assert.NoError(t, c.RemoveThreshold(ctx, accountID, v1.Threshold{Value: 0.5}))
+
+		thresholds, err := c.ListThresholds(ctx, accountID)
+		assert.NoError(t, err)
+		assert.Equal(t, []v1.Threshold{{Kind: v1.ThresholdSpentAbove, Value: 300}}, thresholds)
+
+		acc, err := c.GetAccount(ctx, accountID)
+		assert.NoError(t, err)
+		assert.Equal(t, &v1.SavingGoal{Amount: 500, Thresholds: thresholds}, acc.SavingGoal)
+
+		// The past states are still available.
+		acc, err = c.GetAccount(ctx, accountID, client.AsOf("1"))
+		assert.NoError(t, err)
+		assert.Nil(t, acc.SavingGoal)
+	})
+
+	t.Run("goals", func(t *testing.T) {
+		c := newClient(t, newRouter(t), client.Options{})
+
+		goal, err := c.AddGoal(ctx, accountID, v1.GoalRequest{Name: "Holidays", TargetAmount: 1000, MonthlyContribution: 100})
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		assert.NotEmpty(t, goal.ID)
+		assert.NoError(t, c.RecordGoalContribution(ctx, accountID, goal.ID, 250))
+
+		saved, err := c.GetGoal(ctx, accountID, goal.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, "Holidays", saved.Name)
+		assert.Equal(t, 250.0, saved.SavedAmount)
+
+		assert.NoError(t, c.UpdateGoal(ctx, accountID, goal.ID, v1.GoalRequest{Name: "Summer holidays", TargetAmount: 1200, Deadline: "2030-07-01"}))
+		assert.NoError(t, c.RemoveGoal(ctx, accountID, goal.ID))
+
+		goals, err := c.ListGoals(ctx, accountID)
+		assert.NoError(t, err)
+		assert.Empty(t, goals)
+	})
+
+	t.Run("households", func(t *testing.T) {
+		router := newRouter(t)
+		c := newClient(t, router, client.Options{})
+
+		householdID, err := c.CreateHousehold(ctx, "Home", accountID)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		assert.NoError(t, c.ChangeHouseholdSavingGoal(ctx, householdID, v1.SavingGoal{
+			Amount:     1000,
+			Thresholds: []v1.Threshold{{Kind: v1.ThresholdPercentage, Value: 0.5}},
+		}))
+
+		home, err := c.GetHousehold(ctx, householdID)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{accountID}, home.Members)
+
+		// Only the members can access the Household.
+		bob := newClient(t, router, client.Options{Token: "bob-token"})
+
+		_, err = bob.GetHousehold(ctx, householdID)
+		assert.True(t, errors.Is(err, client.CodeForbidden))
+
+		err = bob.ChangeHouseholdSavingGoal(ctx, householdID, v1.SavingGoal{Amount: 1})
+		assert.True(t, errors.Is(err, client.CodeForbidden))
+
+		_, err = bob.GetHouseholdMonthProgress(ctx, householdID, 2021, time.March)
+		assert.True(t, errors.Is(err, client.CodeForbidden))
+
+		_, err = bob.GetHousehold(ctx, "unknown-household")
+		assert.True(t, errors.Is(err, client.CodeForbidden))
+	})
+
+	t.Run("admin endpoints", func(t *testing.T) {
+		router := newRouter(t)
+
+		steps, err := newClient(t, router, client.Options{Token: "admin-token"}).
+			GetAggregateEvolution(ctx, account.Type.Name(), accountID)
+
+		assert.NoError(t, err)
+		if assert.Len(t, steps, 1) {
+			assert.Equal(t, "account_was_created", steps[0].Event.Type)
+		}
+
+		_, err = newClient(t, router, client.Options{}).GetAggregateEvolution(ctx, account.Type.Name(), accountID)
+		assert.True(t, errors.Is(err, client.CodeForbidden))
+	})
+}
+
+func TestErrors(t *testing.T) {
+	ctx := context.Background()
+	c := newClient(t, newRouter(t), client.Options{})
+
+	t.Run("errors are typed by their code", func(t *testing.T) {
+		err := c.ChangeSavingGoal(ctx, accountID, v1.SavingGoal{Amount: 0, Thresholds: []v1.Threshold{{Value: 0.5}}})
+
+		var apiErr *client.Error
+		if !assert.True(t, errors.As(err, &apiErr)) {
+			return
+		}
+
+		assert.True(t, errors.Is(err, client.CodeSavingGoalIsZero))
+		assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+		assert.Equal(t, []client.FieldError{{Field: "amount", Reason: "Saving goal amount should be more than zero"}}, apiErr.Errors)
+		assert.False(t, apiErr.Temporary())
+	})
+
+	t.Run("invalid requests report the invalid fields", func(t *testing.T) {
+		_, err := c.SetBudget(ctx, accountID, "groceries", v1.SetBudgetRequest{Amount: -100})
+
+		var apiErr *client.Error
+		if !assert.True(t, errors.As(err, &apiErr)) {
+			return
+		}
+
+		assert.Equal(t, client.CodeInvalidRequest, apiErr.Code)
+		assert.Equal(t, []client.FieldError{{Field: "amount", Reason: "should be at least 0"}}, apiErr.Errors)
+	})
+
+	t.Run("resources not found", func(t *testing.T) {
+		_, err := c.GetMonthProgress(ctx, accountID, 2021, time.March)
+		assert.True(t, errors.Is(err, client.CodeMonthNotFound))
+	})
+
+	t.Run("callers not allowed", func(t *testing.T) {
+		_, err := c.GetAccount(ctx, "other-account")
+		assert.True(t, errors.Is(err, client.CodeForbidden))
+	})
+
+	t.Run("callers not authenticated", func(t *testing.T) {
+		_, err := newClient(t, newRouter(t), client.Options{Token: "invalid-token"}).GetAccount(ctx, accountID)
+		assert.True(t, errors.Is(err, client.CodeUnauthenticated))
+	})
+
+	t.Run("every code answered by the API is known", func(t *testing.T) {
+		doc, err := c.OpenAPIDocument(ctx)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		var spec struct {
+			Components struct {
+				Schemas struct {
+					Problem struct {
+						Properties struct {
+							Code struct {
+								Enum []client.Code `json:"enum"`
+							} `json:"code"`
+						} `json:"properties"`
+					} `json:"Problem"`
+				} `json:"schemas"`
+			} `json:"components"`
+		}
+
+		if !assert.NoError(t, json.Unmarshal(doc, &spec)) {
+			return
+		}
+
+		assert.ElementsMatch(t, codes, spec.Components.Schemas.Problem.Properties.Code.Enum)
+	})
+}
+
+// failing answers with an error status the first n requests,
+// then forwards them to the next handler, recording their idempotency keys.
+type failing struct {
+	mx     sync.Mutex
+	n      int
+	status int
+	keys   []string
+	next   http.Handler
+}
+
+func (f *failing) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mx.Lock()
+	f.keys = append(f.keys, r.Header.Get("Idempotency-Key"))
+	fail := len(f.keys) <= f.n
+	f.mx.Unlock()
+
+	if !fail {
+		f.next.ServeHTTP(w, r)
+		return
+	}
+
+	if f.status == http.StatusTooManyRequests {
+		w.Header().Set("Retry-After", "0")
+	}
+
+	http.Error(w, http.StatusText(f.status), f.status)
+}
+
+func TestRetries(t *testing.T) {
+	ctx := context.Background()
+	options := client.Options{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 10 * time.Millisecond}
+
+	for _, status := range []int{http.StatusServiceUnavailable, http.StatusTooManyRequests} {
+		t.Run(http.StatusText(status)+" answers are retried with the same idempotency key", func(t *testing.T) {
+			handler := &failing{n: 2, status: status, next: newRouter(t)}
+			c := newClient(t, handler, options)
+
+			err := c.SetNewThreshold(ctx, accountID, v1.Threshold{Kind: v1.ThresholdSpentAbove, Value: 300})
+			assert.True(t, errors.Is(err, client.CodeNoSavingGoal))
+
+			if assert.Len(t, handler.keys, 3) {
+				assert.NotEmpty(t, handler.keys[0])
+				assert.Equal(t, handler.keys[0], handler.keys[1])
+				assert.Equal(t, handler.keys[0], handler.keys[2])
+			}
+		})
+	}
+
+	t.Run("retries stop after the maximum number of attempts", func(t *testing.T) {
+		handler := &failing{n: 5, status: http.StatusBadGateway, next: newRouter(t)}
+		c := newClient(t, handler, options)
+
+		_, err := c.GetAccount(ctx, accountID)
+
+		var apiErr *client.Error
+		if !assert.True(t, errors.As(err, &apiErr)) {
+			return
+		}
+
+		assert.Equal(t, http.StatusBadGateway, apiErr.StatusCode)
+		assert.True(t, apiErr.Temporary())
+		assert.Len(t, handler.keys, 3)
+
+		// Read requests have no idempotency key.
+		assert.Empty(t, handler.keys[0])
+	})
+
+	t.Run("client errors are not retried", func(t *testing.T) {
+		handler := &failing{next: newRouter(t)}
+		c := newClient(t, handler, options)
+
+		_, err := c.GetAccount(ctx, "other-account")
+		assert.True(t, errors.Is(err, client.CodeForbidden))
+		assert.Len(t, handler.keys, 1)
+	})
+
+	t.Run("retries stop when the context is done", func(t *testing.T) {
+		handler := &failing{n: 5, status: http.StatusServiceUnavailable, next: newRouter(t)}
+		c := newClient(t, handler, client.Options{MaxAttempts: 5, BaseDelay: time.Hour, MaxDelay: time.Hour})
+
+		ctx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+
+		_, err := c.GetAccount(ctx, accountID)
+		assert.Error(t, err)
+		assert.Len(t, handler.keys, 1)
+	})
+}
+
+func TestIdempotencyKeys(t *testing.T) {
+	ctx := context.Background()
+	c := newClient(t, newRouter(t), client.Options{})
+
+	err := c.ChangeSavingGoal(ctx, accountID, v1.SavingGoal{Amount: 500, Thresholds: []v1.Threshold{{Value: 0.5}}})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	threshold := v1.Threshold{Kind: v1.ThresholdSpentAbove, Value: 300}
+
+	t.Run("calls retried with the same key get the response of the first one", func(t *testing.T) {
+		keyed := client.WithIdempotencyKey(ctx, "set-spent-above-300")
+
+		assert.NoError(t, c.SetNewThreshold(keyed, accountID, threshold))
+		assert.NoError(t, c.SetNewThreshold(keyed, accountID, threshold))
+	})
+
+	t.Run("every call has its own key otherwise", func(t *testing.T) {
+		err := c.SetNewThreshold(ctx, accountID, threshold)
+		assert.True(t, errors.Is(err, client.CodeThresholdAlreadyExists))
+	})
+}
+
+// codes are all the codes known by the client.
+var codes = []client.Code{
+	client.CodeMalformedRequest,
+	client.CodeInvalidRequest,
+	client.CodeInvalidCommand,
+	client.CodeInvalidPointInTime,
+	client.CodePointInTimeNotRecorded,
+	client.CodeUnauthenticated,
+	client.CodeForbidden,
+	client.CodeNotMember,
+	client.CodeAccountNotFound,
+	client.CodeAccountClosed,
+	client.CodeAccountFrozen,
+	client.CodeSavingGoalIsZero,
+	client.CodeSavingGoalNotFound,
+	client.CodeNoSavingGoal,
+	client.CodeAtLeastOneThreshold,
+	client.CodeInvalidThreshold,
+	client.CodeThresholdAlreadyExists,
+	client.CodeThresholdNotFound,
+	client.CodeTransactionNotFound,
+	client.CodeInvalidGoal,
+	client.CodeGoalAlreadyExists,
+	client.CodeGoalNotFound,
+	client.CodeContributionIsZero,
+	client.CodeInvalidCategorizationRule,
+	client.CodeCategorizationRuleAlreadyExists,
+	client.CodeCategorizationRuleNotFound,
+	client.CodeBudgetWithoutCategory,
+	client.CodeBudgetIsZero,
+	client.CodeBudgetNotFound,
+	client.CodeUnknownPacingStrategy,
+	client.CodeRecurringSeriesNotFound,
+	client.CodeInvalidSweepRule,
+	client.CodeSweepRuleAlreadyExists,
+	client.CodeSweepRuleNotFound,
+	client.CodeMonthNotFound,
+	client.CodeHouseholdNotFound,
+	client.CodeNoFounder,
+	client.CodeAlreadyInvited,
+	client.CodeAlreadyMember,
+	client.CodeInvitationNotFound,
+	client.CodeUnknownAggregateType,
+	client.CodeUnknownStreamType,
+	client.CodeUnknownEvent,
+	client.CodeSubscriptionNotFound,
+	client.CodeNotPolicy,
+	client.CodeNotReadModel,
+	client.CodeRebuildInProgress,
+	client.CodeIdempotencyKeyTooLong,
+	client.CodeIdempotencyKeyReused,
+	client.CodeIdempotencyRequestInProgress,
+	client.CodeNotFound,
+	client.CodeConcurrencyConflict,
+	client.CodeInternalError,
+}
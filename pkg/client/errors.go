@@ -0,0 +1,167 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// Code is the stable code of the errors answered by the API,
+// matching the code of their Problem Details.
+//
+// Codes are errors themselves, to be compared with errors.Is.
+type Code string
+
+// Error returns the code itself.
+func (c Code) Error() string { return string(c) }
+
+// The codes of the errors answered by the API.
+const (
+	// Requests not valid.
+	CodeMalformedRequest       Code = "malformed_request"
+	CodeInvalidRequest         Code = "invalid_request"
+	CodeInvalidCommand         Code = "invalid_command"
+	CodeInvalidPointInTime     Code = "invalid_point_in_time"
+	CodePointInTimeNotRecorded Code = "point_in_time_not_recorded"
+
+	// Authentication and authorization.
+	CodeUnauthenticated Code = "unauthenticated"
+	CodeForbidden       Code = "forbidden"
+	CodeNotMember       Code = "not_member"
+
+	// Accounts and saving goals.
+	CodeAccountNotFound        Code = "account_not_found"
+	CodeAccountClosed          Code = "account_closed"
+	CodeAccountFrozen          Code = "account_frozen"
+	CodeSavingGoalIsZero       Code = "saving_goal_is_zero"
+	CodeSavingGoalNotFound     Code = "saving_goal_not_found"
+	CodeNoSavingGoal           Code = "no_saving_goal"
+	CodeAtLeastOneThreshold    Code = "at_least_one_threshold"
+	CodeInvalidThreshold       Code = "invalid_threshold"
+	CodeThresholdAlreadyExists Code = "threshold_already_exists"
+	CodeThresholdNotFound      Code = "threshold_not_found"
+	CodeTransactionNotFound    Code = "transaction_not_found"
+
+	// Goals.
+	CodeInvalidGoal        Code = "invalid_goal"
+	CodeGoalAlreadyExists  Code = "goal_already_exists"
+	CodeGoalNotFound       Code = "goal_not_found"
+	CodeContributionIsZero Code = "contribution_is_zero"
+
+	// Categorization, budgets and pacing.
+	CodeInvalidCategorizationRule       Code = "invalid_categorization_rule"
+	CodeCategorizationRuleAlreadyExists Code = "categorization_rule_already_exists"
+	CodeCategorizationRuleNotFound      Code = "categorization_rule_not_found"
+	CodeBudgetWithoutCategory           Code = "budget_without_category"
+	CodeBudgetIsZero                    Code = "budget_is_zero"
+	CodeBudgetNotFound                  Code = "budget_not_found"
+	CodeUnknownPacingStrategy           Code = "unknown_pacing_strategy"
+	CodeRecurringSeriesNotFound         Code = "recurring_series_not_found"
+
+	// Sweeps.
+	CodeInvalidSweepRule       Code = "invalid_sweep_rule"
+	CodeSweepRuleAlreadyExists Code = "sweep_rule_already_exists"
+	CodeSweepRuleNotFound      Code = "sweep_rule_not_found"
+
+	// Months and households.
+	CodeMonthNotFound      Code = "month_not_found"
+	CodeHouseholdNotFound  Code = "household_not_found"
+	CodeNoFounder          Code = "no_founder"
+	CodeAlreadyInvited     Code = "already_invited"
+	CodeAlreadyMember      Code = "already_member"
+	CodeInvitationNotFound Code = "invitation_not_found"
+
+	// Internal endpoints.
+	CodeUnknownAggregateType Code = "unknown_aggregate_type"
+	CodeUnknownStreamType    Code = "unknown_stream_type"
+	CodeUnknownEvent         Code = "unknown_event"
+	CodeSubscriptionNotFound Code = "subscription_not_found"
+	CodeNotPolicy            Code = "not_policy"
+	CodeNotReadModel         Code = "not_read_model"
+	CodeRebuildInProgress    Code = "rebuild_in_progress"
+
+	// Idempotency keys.
+	CodeIdempotencyKeyTooLong        Code = "idempotency_key_too_long"
+	CodeIdempotencyKeyReused         Code = "idempotency_key_reused"
+	CodeIdempotencyRequestInProgress Code = "idempotency_request_in_progress"
+
+	// Generic errors.
+	CodeNotFound            Code = "not_found"
+	CodeConcurrencyConflict Code = "concurrency_conflict"
+	CodeInternalError       Code = "internal_error"
+)
+
+// FieldError describes why a field of the request is not valid.
+type FieldError struct {
+	// Field is the path of the field in the request, like "thresholds[0].value",
+	// or the name of the path or query parameter.
+	Field  string `json:"field"`
+	Reason string `json:"reason"`
+}
+
+// Error is returned when the API answers with an error status,
+// decoded from the Problem Details of the response.
+//
+// Errors not answered with Problem Details, like the ones of a proxy,
+// have the status text as Title and no Code.
+type Error struct {
+	StatusCode int          `json:"status"`
+	Code       Code         `json:"code"`
+	Type       string       `json:"type"`
+	Title      string       `json:"title"`
+	Detail     string       `json:"detail"`
+	Errors     []FieldError `json:"errors"`
+}
+
+// Error returns a one-line description of the error,
+// like "client: Invalid threshold (invalid_threshold)".
+func (e *Error) Error() string {
+	description := e.Title
+	if e.Detail != "" {
+		description += ": " + e.Detail
+	}
+
+	for _, fieldErr := range e.Errors {
+		description += fmt.Sprintf("; %s %s", fieldErr.Field, fieldErr.Reason)
+	}
+
+	if e.Code == "" {
+		return fmt.Sprintf("client: %s (status %d)", description, e.StatusCode)
+	}
+
+	return fmt.Sprintf("client: %s (%s)", description, e.Code)
+}
+
+// Is returns true if the target is the Code of the Error.
+func (e *Error) Is(target error) bool {
+	code, ok := target.(Code)
+	return ok && code == e.Code
+}
+
+// Temporary returns true if the request might succeed if retried later.
+func (e *Error) Temporary() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= http.StatusInternalServerError
+}
+
+// errorFromResponse returns the *Error answered by the API.
+func errorFromResponse(resp *http.Response) *Error {
+	body, _ := ioutil.ReadAll(resp.Body)
+
+	e := &Error{StatusCode: resp.StatusCode}
+
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if mediaType == problemContentType && json.Unmarshal(body, e) == nil {
+		// The status of the response wins over the one of the document.
+		e.StatusCode = resp.StatusCode
+		return e
+	}
+
+	e.Title = http.StatusText(resp.StatusCode)
+	e.Detail = strings.TrimSpace(string(bytes.ToValidUTF8(body, nil)))
+
+	return e
+}
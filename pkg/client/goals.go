@@ -0,0 +1,50 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	v1 "github.com/eventually-rs/saving-goals-go/pkg/api/v1"
+)
+
+// ListGoals returns the Goals of the Account.
+func (c *Client) ListGoals(ctx context.Context, accountID string) ([]v1.Goal, error) {
+	var goals []v1.Goal
+	err := c.do(ctx, http.MethodGet, pathf("/accounts/%s/goals", accountID), nil, nil, &goals)
+
+	return goals, err
+}
+
+// GetGoal returns a Goal of the Account.
+func (c *Client) GetGoal(ctx context.Context, accountID, goalID string) (v1.Goal, error) {
+	var goal v1.Goal
+	err := c.do(ctx, http.MethodGet, pathf("/accounts/%s/goals/%s", accountID, goalID), nil, nil, &goal)
+
+	return goal, err
+}
+
+// AddGoal adds a Goal to the Account, returning it with its id,
+// generated by the API if not specified.
+func (c *Client) AddGoal(ctx context.Context, accountID string, goal v1.GoalRequest) (v1.Goal, error) {
+	var added v1.Goal
+	err := c.do(ctx, http.MethodPost, pathf("/accounts/%s/goals", accountID), nil, goal, &added)
+
+	return added, err
+}
+
+// UpdateGoal updates a Goal of the Account.
+func (c *Client) UpdateGoal(ctx context.Context, accountID, goalID string, goal v1.GoalRequest) error {
+	return c.do(ctx, http.MethodPut, pathf("/accounts/%s/goals/%s", accountID, goalID), nil, goal, nil)
+}
+
+// RemoveGoal removes a Goal from the Account.
+func (c *Client) RemoveGoal(ctx context.Context, accountID, goalID string) error {
+	return c.do(ctx, http.MethodDelete, pathf("/accounts/%s/goals/%s", accountID, goalID), nil, nil, nil)
+}
+
+// RecordGoalContribution records an amount saved for a Goal,
+// or withdrawn from it when negative.
+func (c *Client) RecordGoalContribution(ctx context.Context, accountID, goalID string, amount float64) error {
+	return c.do(ctx, http.MethodPost, pathf("/accounts/%s/goals/%s/contributions", accountID, goalID), nil,
+		v1.GoalContributionRequest{Amount: amount}, nil)
+}
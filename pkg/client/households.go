@@ -0,0 +1,64 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	v1 "github.com/eventually-rs/saving-goals-go/pkg/api/v1"
+)
+
+// CreateHousehold creates a new Household founded by an Account,
+// returning the id of the Household.
+func (c *Client) CreateHousehold(ctx context.Context, name, accountID string) (string, error) {
+	var response v1.CreateHouseholdResponse
+	err := c.do(ctx, http.MethodPost, "/households", nil,
+		v1.CreateHouseholdRequest{Name: name, AccountID: accountID}, &response)
+
+	return response.HouseholdID, err
+}
+
+// GetHousehold returns the Household, with its members and saving goal.
+func (c *Client) GetHousehold(ctx context.Context, householdID string) (v1.Household, error) {
+	var household v1.Household
+	err := c.do(ctx, http.MethodGet, pathf("/households/%s", householdID), nil, nil, &household)
+
+	return household, err
+}
+
+// ChangeHouseholdSavingGoal changes the amount to save every month in the Household.
+func (c *Client) ChangeHouseholdSavingGoal(ctx context.Context, householdID string, savingGoal v1.SavingGoal) error {
+	return c.do(ctx, http.MethodPost, pathf("/households/%s/change-saving-goal", householdID), nil, savingGoal, nil)
+}
+
+// InviteHouseholdMember invites an Account to join the Household,
+// on behalf of one of its members.
+func (c *Client) InviteHouseholdMember(ctx context.Context, householdID, accountID, invitedBy string) error {
+	return c.do(ctx, http.MethodPost, pathf("/households/%s/invitations", householdID), nil,
+		v1.InviteHouseholdMemberRequest{AccountID: accountID, InvitedBy: invitedBy}, nil)
+}
+
+// AcceptHouseholdInvitation accepts the invitation of the Account to join the Household.
+func (c *Client) AcceptHouseholdInvitation(ctx context.Context, householdID, accountID string) error {
+	return c.do(ctx, http.MethodPost, pathf("/households/%s/invitations/%s/accept", householdID, accountID), nil, nil, nil)
+}
+
+// LeaveHousehold removes the Account from the members of the Household.
+func (c *Client) LeaveHousehold(ctx context.Context, householdID, accountID string) error {
+	return c.do(ctx, http.MethodDelete, pathf("/households/%s/members/%s", householdID, accountID), nil, nil, nil)
+}
+
+// GetHouseholdMonthProgress returns the spending progress of the Household in a month.
+func (c *Client) GetHouseholdMonthProgress(
+	ctx context.Context,
+	householdID string,
+	year int,
+	month time.Month,
+	options ...ReadOption,
+) (v1.MonthProgress, error) {
+	var progress v1.MonthProgress
+	err := c.do(ctx, http.MethodGet, pathf("/households/%s/months/%d/%d", householdID, year, int(month)),
+		readQuery(options), nil, &progress)
+
+	return progress, err
+}
@@ -0,0 +1,24 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	v1 "github.com/eventually-rs/saving-goals-go/pkg/api/v1"
+)
+
+// GetMonthProgress returns the spending progress of the Account in a month.
+func (c *Client) GetMonthProgress(
+	ctx context.Context,
+	accountID string,
+	year int,
+	month time.Month,
+	options ...ReadOption,
+) (v1.MonthProgress, error) {
+	var progress v1.MonthProgress
+	err := c.do(ctx, http.MethodGet, pathf("/accounts/%s/months/%d/%d", accountID, year, int(month)),
+		readQuery(options), nil, &progress)
+
+	return progress, err
+}
@@ -0,0 +1,28 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	v1 "github.com/eventually-rs/saving-goals-go/pkg/api/v1"
+)
+
+// ListRecurringSeries returns the recurring payments and incomes
+// detected in the transactions of the Account.
+func (c *Client) ListRecurringSeries(ctx context.Context, accountID string) ([]v1.RecurringSeries, error) {
+	var series []v1.RecurringSeries
+	err := c.do(ctx, http.MethodGet, pathf("/accounts/%s/recurring-series", accountID), nil, nil, &series)
+
+	return series, err
+}
+
+// ConfirmRecurringSeries confirms a detected recurring series,
+// which is then used to forecast the spending of the month.
+func (c *Client) ConfirmRecurringSeries(ctx context.Context, accountID, seriesID string) error {
+	return c.do(ctx, http.MethodPost, pathf("/accounts/%s/recurring-series/%s/confirm", accountID, seriesID), nil, nil, nil)
+}
+
+// DismissRecurringSeries dismisses a detected recurring series.
+func (c *Client) DismissRecurringSeries(ctx context.Context, accountID, seriesID string) error {
+	return c.do(ctx, http.MethodPost, pathf("/accounts/%s/recurring-series/%s/dismiss", accountID, seriesID), nil, nil, nil)
+}
@@ -0,0 +1,38 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	v1 "github.com/eventually-rs/saving-goals-go/pkg/api/v1"
+)
+
+// ListSweepRules returns the rules moving money from the Account to its Goals.
+func (c *Client) ListSweepRules(ctx context.Context, accountID string) ([]v1.SweepRule, error) {
+	var rules []v1.SweepRule
+	err := c.do(ctx, http.MethodGet, pathf("/accounts/%s/sweep-rules", accountID), nil, nil, &rules)
+
+	return rules, err
+}
+
+// AddSweepRule adds a rule moving money to a Goal of the Account, returning it
+// with its id, generated by the API if not specified.
+func (c *Client) AddSweepRule(ctx context.Context, accountID string, rule v1.SweepRule) (v1.SweepRule, error) {
+	var added v1.SweepRule
+	err := c.do(ctx, http.MethodPost, pathf("/accounts/%s/sweep-rules", accountID), nil, rule, &added)
+
+	return added, err
+}
+
+// RemoveSweepRule removes a sweep rule from the Account.
+func (c *Client) RemoveSweepRule(ctx context.Context, accountID, ruleID string) error {
+	return c.do(ctx, http.MethodDelete, pathf("/accounts/%s/sweep-rules/%s", accountID, ruleID), nil, nil, nil)
+}
+
+// ListSavingsTransfers returns the transfers to the Goals requested by the sweep rules.
+func (c *Client) ListSavingsTransfers(ctx context.Context, accountID string) ([]v1.SavingsTransfer, error) {
+	var transfers []v1.SavingsTransfer
+	err := c.do(ctx, http.MethodGet, pathf("/accounts/%s/savings-transfers", accountID), nil, nil, &transfers)
+
+	return transfers, err
+}
@@ -0,0 +1,41 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	v1 "github.com/eventually-rs/saving-goals-go/pkg/api/v1"
+)
+
+// SetNewThreshold adds a threshold to the saving goal of the Account.
+func (c *Client) SetNewThreshold(ctx context.Context, accountID string, threshold v1.Threshold) error {
+	return c.do(ctx, http.MethodPost, pathf("/accounts/%s/set-new-threshold", accountID), nil,
+		v1.SetNewThresholdRequest{Threshold: threshold}, nil)
+}
+
+// ListThresholds returns the thresholds of the saving goal of the Account.
+func (c *Client) ListThresholds(ctx context.Context, accountID string, options ...ReadOption) ([]v1.Threshold, error) {
+	var thresholds []v1.Threshold
+	err := c.do(ctx, http.MethodGet, pathf("/accounts/%s/thresholds", accountID), readQuery(options), nil, &thresholds)
+
+	return thresholds, err
+}
+
+// ReplaceThresholds replaces all the thresholds of the saving goal of the Account.
+func (c *Client) ReplaceThresholds(ctx context.Context, accountID string, thresholds []v1.Threshold) error {
+	return c.do(ctx, http.MethodPut, pathf("/accounts/%s/thresholds", accountID), nil,
+		v1.ReplaceThresholdsRequest{Thresholds: thresholds}, nil)
+}
+
+// RemoveThreshold removes a threshold from the saving goal of the Account.
+func (c *Client) RemoveThreshold(ctx context.Context, accountID string, threshold v1.Threshold) error {
+	kind := threshold.Kind
+	if kind == "" {
+		kind = v1.ThresholdPercentage
+	}
+
+	value := fmt.Sprintf("%s:%v", kind, threshold.Value)
+
+	return c.do(ctx, http.MethodDelete, pathf("/accounts/%s/thresholds/%s", accountID, value), nil, nil, nil)
+}
@@ -6,7 +6,7 @@ import (
 )
 
 func Gracefully() <-chan struct{} {
-	ch := make(chan os.Signal)
+	ch := make(chan os.Signal, 1)
 	notify := make(chan struct{})
 
 	signal.Notify(ch, os.Interrupt)
@@ -0,0 +1,427 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.25.0
+// 	protoc        v3.12.4
+// source: resources/messages/savings.proto
+
+package messages
+
+import (
+	proto "github.com/golang/protobuf/proto"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// This is a compile-time assertion that a sufficiently up-to-date version
+// of the legacy proto package is being used.
+const _ = proto.ProtoPackageIsVersion4
+
+type SweepKind int32
+
+const (
+	SweepKind_SWEEP_KIND_UNSPECIFIED       SweepKind = 0
+	SweepKind_SWEEP_KIND_ROUND_UP          SweepKind = 1
+	SweepKind_SWEEP_KIND_INCOME_PERCENTAGE SweepKind = 2
+	SweepKind_SWEEP_KIND_LEFTOVER          SweepKind = 3
+)
+
+// Enum value maps for SweepKind.
+var (
+	SweepKind_name = map[int32]string{
+		0: "SWEEP_KIND_UNSPECIFIED",
+		1: "SWEEP_KIND_ROUND_UP",
+		2: "SWEEP_KIND_INCOME_PERCENTAGE",
+		3: "SWEEP_KIND_LEFTOVER",
+	}
+	SweepKind_value = map[string]int32{
+		"SWEEP_KIND_UNSPECIFIED":       0,
+		"SWEEP_KIND_ROUND_UP":          1,
+		"SWEEP_KIND_INCOME_PERCENTAGE": 2,
+		"SWEEP_KIND_LEFTOVER":          3,
+	}
+)
+
+func (x SweepKind) Enum() *SweepKind {
+	p := new(SweepKind)
+	*p = x
+	return p
+}
+
+func (x SweepKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SweepKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_resources_messages_savings_proto_enumTypes[0].Descriptor()
+}
+
+func (SweepKind) Type() protoreflect.EnumType {
+	return &file_resources_messages_savings_proto_enumTypes[0]
+}
+
+func (x SweepKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SweepKind.Descriptor instead.
+func (SweepKind) EnumDescriptor() ([]byte, []int) {
+	return file_resources_messages_savings_proto_rawDescGZIP(), []int{0}
+}
+
+type SavingsTransferStatus int32
+
+const (
+	SavingsTransferStatus_SAVINGS_TRANSFER_STATUS_UNSPECIFIED SavingsTransferStatus = 0
+	SavingsTransferStatus_SAVINGS_TRANSFER_STATUS_CONFIRMED   SavingsTransferStatus = 1
+	SavingsTransferStatus_SAVINGS_TRANSFER_STATUS_REJECTED    SavingsTransferStatus = 2
+)
+
+// Enum value maps for SavingsTransferStatus.
+var (
+	SavingsTransferStatus_name = map[int32]string{
+		0: "SAVINGS_TRANSFER_STATUS_UNSPECIFIED",
+		1: "SAVINGS_TRANSFER_STATUS_CONFIRMED",
+		2: "SAVINGS_TRANSFER_STATUS_REJECTED",
+	}
+	SavingsTransferStatus_value = map[string]int32{
+		"SAVINGS_TRANSFER_STATUS_UNSPECIFIED": 0,
+		"SAVINGS_TRANSFER_STATUS_CONFIRMED":   1,
+		"SAVINGS_TRANSFER_STATUS_REJECTED":    2,
+	}
+)
+
+func (x SavingsTransferStatus) Enum() *SavingsTransferStatus {
+	p := new(SavingsTransferStatus)
+	*p = x
+	return p
+}
+
+func (x SavingsTransferStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SavingsTransferStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_resources_messages_savings_proto_enumTypes[1].Descriptor()
+}
+
+func (SavingsTransferStatus) Type() protoreflect.EnumType {
+	return &file_resources_messages_savings_proto_enumTypes[1]
+}
+
+func (x SavingsTransferStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SavingsTransferStatus.Descriptor instead.
+func (SavingsTransferStatus) EnumDescriptor() ([]byte, []int) {
+	return file_resources_messages_savings_proto_rawDescGZIP(), []int{1}
+}
+
+type SavingsTransferRequested struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TransferId  string               `protobuf:"bytes,1,opt,name=transfer_id,json=transferId,proto3" json:"transfer_id,omitempty"`
+	AccountId   string               `protobuf:"bytes,2,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	GoalId      string               `protobuf:"bytes,3,opt,name=goal_id,json=goalId,proto3" json:"goal_id,omitempty"`
+	Amount      float64              `protobuf:"fixed64,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	Kind        SweepKind            `protobuf:"varint,5,opt,name=kind,proto3,enum=messages.SweepKind" json:"kind,omitempty"`
+	RequestedAt *timestamp.Timestamp `protobuf:"bytes,6,opt,name=requested_at,json=requestedAt,proto3" json:"requested_at,omitempty"`
+}
+
+func (x *SavingsTransferRequested) Reset() {
+	*x = SavingsTransferRequested{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_resources_messages_savings_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SavingsTransferRequested) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SavingsTransferRequested) ProtoMessage() {}
+
+func (x *SavingsTransferRequested) ProtoReflect() protoreflect.Message {
+	mi := &file_resources_messages_savings_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SavingsTransferRequested.ProtoReflect.Descriptor instead.
+func (*SavingsTransferRequested) Descriptor() ([]byte, []int) {
+	return file_resources_messages_savings_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SavingsTransferRequested) GetTransferId() string {
+	if x != nil {
+		return x.TransferId
+	}
+	return ""
+}
+
+func (x *SavingsTransferRequested) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *SavingsTransferRequested) GetGoalId() string {
+	if x != nil {
+		return x.GoalId
+	}
+	return ""
+}
+
+func (x *SavingsTransferRequested) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *SavingsTransferRequested) GetKind() SweepKind {
+	if x != nil {
+		return x.Kind
+	}
+	return SweepKind_SWEEP_KIND_UNSPECIFIED
+}
+
+func (x *SavingsTransferRequested) GetRequestedAt() *timestamp.Timestamp {
+	if x != nil {
+		return x.RequestedAt
+	}
+	return nil
+}
+
+type SavingsTransferAcknowledged struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TransferId     string                `protobuf:"bytes,1,opt,name=transfer_id,json=transferId,proto3" json:"transfer_id,omitempty"`
+	Status         SavingsTransferStatus `protobuf:"varint,2,opt,name=status,proto3,enum=messages.SavingsTransferStatus" json:"status,omitempty"`
+	Reason         string                `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	AcknowledgedAt *timestamp.Timestamp  `protobuf:"bytes,4,opt,name=acknowledged_at,json=acknowledgedAt,proto3" json:"acknowledged_at,omitempty"`
+}
+
+func (x *SavingsTransferAcknowledged) Reset() {
+	*x = SavingsTransferAcknowledged{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_resources_messages_savings_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SavingsTransferAcknowledged) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SavingsTransferAcknowledged) ProtoMessage() {}
+
+func (x *SavingsTransferAcknowledged) ProtoReflect() protoreflect.Message {
+	mi := &file_resources_messages_savings_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SavingsTransferAcknowledged.ProtoReflect.Descriptor instead.
+func (*SavingsTransferAcknowledged) Descriptor() ([]byte, []int) {
+	return file_resources_messages_savings_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SavingsTransferAcknowledged) GetTransferId() string {
+	if x != nil {
+		return x.TransferId
+	}
+	return ""
+}
+
+func (x *SavingsTransferAcknowledged) GetStatus() SavingsTransferStatus {
+	if x != nil {
+		return x.Status
+	}
+	return SavingsTransferStatus_SAVINGS_TRANSFER_STATUS_UNSPECIFIED
+}
+
+func (x *SavingsTransferAcknowledged) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *SavingsTransferAcknowledged) GetAcknowledgedAt() *timestamp.Timestamp {
+	if x != nil {
+		return x.AcknowledgedAt
+	}
+	return nil
+}
+
+var File_resources_messages_savings_proto protoreflect.FileDescriptor
+
+var file_resources_messages_savings_proto_rawDesc = []byte{
+	0x0a, 0x20, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x2f, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x73, 0x2f, 0x73, 0x61, 0x76, 0x69, 0x6e, 0x67, 0x73, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x12, 0x08, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x1a, 0x1f, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xf3, 0x01,
+	0x0a, 0x18, 0x53, 0x61, 0x76, 0x69, 0x6e, 0x67, 0x73, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65,
+	0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x72,
+	0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0a, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x61,
+	0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x67, 0x6f,
+	0x61, 0x6c, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x67, 0x6f, 0x61,
+	0x6c, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x27, 0x0a, 0x04, 0x6b,
+	0x69, 0x6e, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x73, 0x2e, 0x53, 0x77, 0x65, 0x65, 0x70, 0x4b, 0x69, 0x6e, 0x64, 0x52, 0x04,
+	0x6b, 0x69, 0x6e, 0x64, 0x12, 0x3d, 0x0a, 0x0c, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65,
+	0x64, 0x5f, 0x61, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0b, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65,
+	0x64, 0x41, 0x74, 0x22, 0xd4, 0x01, 0x0a, 0x1b, 0x53, 0x61, 0x76, 0x69, 0x6e, 0x67, 0x73, 0x54,
+	0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x41, 0x63, 0x6b, 0x6e, 0x6f, 0x77, 0x6c, 0x65, 0x64,
+	0x67, 0x65, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66,
+	0x65, 0x72, 0x49, 0x64, 0x12, 0x37, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x1f, 0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2e,
+	0x53, 0x61, 0x76, 0x69, 0x6e, 0x67, 0x73, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x16, 0x0a,
+	0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72,
+	0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x43, 0x0a, 0x0f, 0x61, 0x63, 0x6b, 0x6e, 0x6f, 0x77, 0x6c,
+	0x65, 0x64, 0x67, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0e, 0x61, 0x63, 0x6b, 0x6e,
+	0x6f, 0x77, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x64, 0x41, 0x74, 0x2a, 0x7b, 0x0a, 0x09, 0x53, 0x77,
+	0x65, 0x65, 0x70, 0x4b, 0x69, 0x6e, 0x64, 0x12, 0x1a, 0x0a, 0x16, 0x53, 0x57, 0x45, 0x45, 0x50,
+	0x5f, 0x4b, 0x49, 0x4e, 0x44, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45,
+	0x44, 0x10, 0x00, 0x12, 0x17, 0x0a, 0x13, 0x53, 0x57, 0x45, 0x45, 0x50, 0x5f, 0x4b, 0x49, 0x4e,
+	0x44, 0x5f, 0x52, 0x4f, 0x55, 0x4e, 0x44, 0x5f, 0x55, 0x50, 0x10, 0x01, 0x12, 0x20, 0x0a, 0x1c,
+	0x53, 0x57, 0x45, 0x45, 0x50, 0x5f, 0x4b, 0x49, 0x4e, 0x44, 0x5f, 0x49, 0x4e, 0x43, 0x4f, 0x4d,
+	0x45, 0x5f, 0x50, 0x45, 0x52, 0x43, 0x45, 0x4e, 0x54, 0x41, 0x47, 0x45, 0x10, 0x02, 0x12, 0x17,
+	0x0a, 0x13, 0x53, 0x57, 0x45, 0x45, 0x50, 0x5f, 0x4b, 0x49, 0x4e, 0x44, 0x5f, 0x4c, 0x45, 0x46,
+	0x54, 0x4f, 0x56, 0x45, 0x52, 0x10, 0x03, 0x2a, 0x8d, 0x01, 0x0a, 0x15, 0x53, 0x61, 0x76, 0x69,
+	0x6e, 0x67, 0x73, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x12, 0x27, 0x0a, 0x23, 0x53, 0x41, 0x56, 0x49, 0x4e, 0x47, 0x53, 0x5f, 0x54, 0x52, 0x41,
+	0x4e, 0x53, 0x46, 0x45, 0x52, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x55, 0x4e, 0x53,
+	0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x25, 0x0a, 0x21, 0x53, 0x41,
+	0x56, 0x49, 0x4e, 0x47, 0x53, 0x5f, 0x54, 0x52, 0x41, 0x4e, 0x53, 0x46, 0x45, 0x52, 0x5f, 0x53,
+	0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x43, 0x4f, 0x4e, 0x46, 0x49, 0x52, 0x4d, 0x45, 0x44, 0x10,
+	0x01, 0x12, 0x24, 0x0a, 0x20, 0x53, 0x41, 0x56, 0x49, 0x4e, 0x47, 0x53, 0x5f, 0x54, 0x52, 0x41,
+	0x4e, 0x53, 0x46, 0x45, 0x52, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x52, 0x45, 0x4a,
+	0x45, 0x43, 0x54, 0x45, 0x44, 0x10, 0x02, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_resources_messages_savings_proto_rawDescOnce sync.Once
+	file_resources_messages_savings_proto_rawDescData = file_resources_messages_savings_proto_rawDesc
+)
+
+func file_resources_messages_savings_proto_rawDescGZIP() []byte {
+	file_resources_messages_savings_proto_rawDescOnce.Do(func() {
+		file_resources_messages_savings_proto_rawDescData = protoimpl.X.CompressGZIP(file_resources_messages_savings_proto_rawDescData)
+	})
+	return file_resources_messages_savings_proto_rawDescData
+}
+
+var file_resources_messages_savings_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_resources_messages_savings_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_resources_messages_savings_proto_goTypes = []interface{}{
+	(SweepKind)(0),                      // 0: messages.SweepKind
+	(SavingsTransferStatus)(0),          // 1: messages.SavingsTransferStatus
+	(*SavingsTransferRequested)(nil),    // 2: messages.SavingsTransferRequested
+	(*SavingsTransferAcknowledged)(nil), // 3: messages.SavingsTransferAcknowledged
+	(*timestamp.Timestamp)(nil),         // 4: google.protobuf.Timestamp
+}
+var file_resources_messages_savings_proto_depIdxs = []int32{
+	0, // 0: messages.SavingsTransferRequested.kind:type_name -> messages.SweepKind
+	4, // 1: messages.SavingsTransferRequested.requested_at:type_name -> google.protobuf.Timestamp
+	1, // 2: messages.SavingsTransferAcknowledged.status:type_name -> messages.SavingsTransferStatus
+	4, // 3: messages.SavingsTransferAcknowledged.acknowledged_at:type_name -> google.protobuf.Timestamp
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_resources_messages_savings_proto_init() }
+func file_resources_messages_savings_proto_init() {
+	if File_resources_messages_savings_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_resources_messages_savings_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SavingsTransferRequested); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_resources_messages_savings_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SavingsTransferAcknowledged); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_resources_messages_savings_proto_rawDesc,
+			NumEnums:      2,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_resources_messages_savings_proto_goTypes,
+		DependencyIndexes: file_resources_messages_savings_proto_depIdxs,
+		EnumInfos:         file_resources_messages_savings_proto_enumTypes,
+		MessageInfos:      file_resources_messages_savings_proto_msgTypes,
+	}.Build()
+	File_resources_messages_savings_proto = out.File
+	file_resources_messages_savings_proto_rawDesc = nil
+	file_resources_messages_savings_proto_goTypes = nil
+	file_resources_messages_savings_proto_depIdxs = nil
+}